@@ -0,0 +1,30 @@
+package api
+
+func NewAck(messageIds ...string) *Ack {
+	return &Ack{MessageIds: messageIds}
+}
+
+func (mb *Ack) Wrap() *Packet {
+	return &Packet{
+		Type: TypeAck,
+		Body: &Packet_Ack{Ack: mb},
+	}
+}
+
+// AckConv 累计ack，[from, sequence]内的消息都已收到；同一会话的范围需连续，合并为一个范围
+func (mb *Ack) AckConv(convId string, from, sequence int64) *Ack {
+	for _, c := range mb.Convs {
+		if c.ConvId == convId {
+			c.From = min(c.From, from)
+			c.Sequence = max(c.Sequence, sequence)
+			return mb
+		}
+	}
+	mb.Convs = append(mb.Convs, &ConvAck{ConvId: convId, From: from, Sequence: sequence})
+	return mb
+}
+
+// IsEmpty 没有需要ack的消息
+func (mb *Ack) IsEmpty() bool {
+	return len(mb.MessageIds) == 0 && len(mb.Convs) == 0
+}
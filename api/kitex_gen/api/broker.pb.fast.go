@@ -67,6 +67,51 @@ func (x *DeliverRequest) fastReadField3(buf []byte, _type int8) (offset int, err
 	return offset, nil
 }
 
+func (x *DeliverResult) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_DeliverResult[number], err)
+}
+
+func (x *DeliverResult) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Label, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *DeliverResult) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Code, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *DeliverResult) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Message, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
 func (x *DeliverReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
 	switch number {
 	case 1:
@@ -84,6 +129,11 @@ func (x *DeliverReply) FastRead(buf []byte, _type int8, number int32) (offset in
 		if err != nil {
 			goto ReadFieldError
 		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
 	default:
 		offset, err = fastpb.Skip(buf, _type, number)
 		if err != nil {
@@ -112,6 +162,16 @@ func (x *DeliverReply) fastReadField3(buf []byte, _type int8) (offset int, err e
 	return offset, err
 }
 
+func (x *DeliverReply) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	var v DeliverResult
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Results = append(x.Results, &v)
+	return offset, nil
+}
+
 func (x *DeliverRequest) FastWrite(buf []byte) (offset int) {
 	if x == nil {
 		return offset
@@ -148,6 +208,40 @@ func (x *DeliverRequest) fastWriteField3(buf []byte) (offset int) {
 	return offset
 }
 
+func (x *DeliverResult) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *DeliverResult) fastWriteField1(buf []byte) (offset int) {
+	if x.Label == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetLabel())
+	return offset
+}
+
+func (x *DeliverResult) fastWriteField2(buf []byte) (offset int) {
+	if x.Code == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 2, x.GetCode())
+	return offset
+}
+
+func (x *DeliverResult) fastWriteField3(buf []byte) (offset int) {
+	if x.Message == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetMessage())
+	return offset
+}
+
 func (x *DeliverReply) FastWrite(buf []byte) (offset int) {
 	if x == nil {
 		return offset
@@ -155,6 +249,7 @@ func (x *DeliverReply) FastWrite(buf []byte) (offset int) {
 	offset += x.fastWriteField1(buf[offset:])
 	offset += x.fastWriteField2(buf[offset:])
 	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
 	return offset
 }
 
@@ -182,6 +277,16 @@ func (x *DeliverReply) fastWriteField3(buf []byte) (offset int) {
 	return offset
 }
 
+func (x *DeliverReply) fastWriteField4(buf []byte) (offset int) {
+	if x.Results == nil {
+		return offset
+	}
+	for i := range x.GetResults() {
+		offset += fastpb.WriteMessage(buf[offset:], 4, x.GetResults()[i])
+	}
+	return offset
+}
+
 func (x *DeliverRequest) Size() (n int) {
 	if x == nil {
 		return n
@@ -218,6 +323,40 @@ func (x *DeliverRequest) sizeField3() (n int) {
 	return n
 }
 
+func (x *DeliverResult) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
+}
+
+func (x *DeliverResult) sizeField1() (n int) {
+	if x.Label == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetLabel())
+	return n
+}
+
+func (x *DeliverResult) sizeField2() (n int) {
+	if x.Code == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(2, x.GetCode())
+	return n
+}
+
+func (x *DeliverResult) sizeField3() (n int) {
+	if x.Message == "" {
+		return n
+	}
+	n += fastpb.SizeString(3, x.GetMessage())
+	return n
+}
+
 func (x *DeliverReply) Size() (n int) {
 	if x == nil {
 		return n
@@ -225,6 +364,7 @@ func (x *DeliverReply) Size() (n int) {
 	n += x.sizeField1()
 	n += x.sizeField2()
 	n += x.sizeField3()
+	n += x.sizeField4()
 	return n
 }
 
@@ -252,14 +392,31 @@ func (x *DeliverReply) sizeField3() (n int) {
 	return n
 }
 
+func (x *DeliverReply) sizeField4() (n int) {
+	if x.Results == nil {
+		return n
+	}
+	for i := range x.GetResults() {
+		n += fastpb.SizeMessage(4, x.GetResults()[i])
+	}
+	return n
+}
+
 var fieldIDToName_DeliverRequest = map[int32]string{
 	1: "MessageId",
 	2: "UserLabels",
 	3: "Message",
 }
 
+var fieldIDToName_DeliverResult = map[int32]string{
+	1: "Label",
+	2: "Code",
+	3: "Message",
+}
+
 var fieldIDToName_DeliverReply = map[int32]string{
 	1: "MessageId",
 	2: "Code",
 	3: "Message",
+	4: "Results",
 }
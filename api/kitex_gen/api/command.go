@@ -71,6 +71,39 @@ func (mb *Command) SetRequest(content proto.Message) {
 	case *LoginRequest:
 		mb.CommandType = CommandTypeUserLogin
 		mb.Request = &Command_LoginRequest{LoginRequest: c}
+	case *ReadRequest:
+		mb.CommandType = CommandTypeConvRead
+		mb.Request = &Command_ReadRequest{ReadRequest: c}
+	case *ReceiptRequest:
+		mb.CommandType = CommandTypeMessageReceipt
+		mb.Request = &Command_ReceiptRequest{ReceiptRequest: c}
+	case *KeyRegisterRequest:
+		mb.CommandType = CommandTypeKeyRegister
+		mb.Request = &Command_KeyRegisterRequest{KeyRegisterRequest: c}
+	case *KeyFetchRequest:
+		mb.CommandType = CommandTypeKeyFetch
+		mb.Request = &Command_KeyFetchRequest{KeyFetchRequest: c}
+	case *ReconnectRequest:
+		mb.CommandType = CommandTypeReconnect
+		mb.Request = &Command_ReconnectRequest{ReconnectRequest: c}
+	case *PushRegisterRequest:
+		mb.CommandType = CommandTypePushRegister
+		mb.Request = &Command_PushRegisterRequest{PushRegisterRequest: c}
+	case *NotifySettingRequest:
+		mb.CommandType = CommandTypeNotifySetting
+		mb.Request = &Command_NotifySettingRequest{NotifySettingRequest: c}
+	case *ScheduleRequest:
+		mb.CommandType = CommandTypeScheduleSend
+		mb.Request = &Command_ScheduleRequest{ScheduleRequest: c}
+	case *ScheduleListRequest:
+		mb.CommandType = CommandTypeScheduleList
+		mb.Request = &Command_ScheduleListRequest{ScheduleListRequest: c}
+	case *ScheduleCancelRequest:
+		mb.CommandType = CommandTypeScheduleCancel
+		mb.Request = &Command_ScheduleCancelRequest{ScheduleCancelRequest: c}
+	case *RecallRequest:
+		mb.CommandType = CommandTypeRecall
+		mb.Request = &Command_RecallRequest{RecallRequest: c}
 	default:
 	}
 }
@@ -85,6 +118,36 @@ func (mb *Command) SetReply(content proto.Message) {
 	case *LoginReply:
 		mb.CommandType = CommandTypeUserLogin
 		mb.Reply = &Command_LoginReply{LoginReply: c}
+	case *ReadReply:
+		mb.CommandType = CommandTypeConvRead
+		mb.Reply = &Command_ReadReply{ReadReply: c}
+	case *ReceiptReply:
+		mb.CommandType = CommandTypeMessageReceipt
+		mb.Reply = &Command_ReceiptReply{ReceiptReply: c}
+	case *KeyRegisterReply:
+		mb.CommandType = CommandTypeKeyRegister
+		mb.Reply = &Command_KeyRegisterReply{KeyRegisterReply: c}
+	case *KeyFetchReply:
+		mb.CommandType = CommandTypeKeyFetch
+		mb.Reply = &Command_KeyFetchReply{KeyFetchReply: c}
+	case *PushRegisterReply:
+		mb.CommandType = CommandTypePushRegister
+		mb.Reply = &Command_PushRegisterReply{PushRegisterReply: c}
+	case *NotifySettingReply:
+		mb.CommandType = CommandTypeNotifySetting
+		mb.Reply = &Command_NotifySettingReply{NotifySettingReply: c}
+	case *ScheduleReply:
+		mb.CommandType = CommandTypeScheduleSend
+		mb.Reply = &Command_ScheduleReply{ScheduleReply: c}
+	case *ScheduleListReply:
+		mb.CommandType = CommandTypeScheduleList
+		mb.Reply = &Command_ScheduleListReply{ScheduleListReply: c}
+	case *ScheduleCancelReply:
+		mb.CommandType = CommandTypeScheduleCancel
+		mb.Reply = &Command_ScheduleCancelReply{ScheduleCancelReply: c}
+	case *RecallReply:
+		mb.CommandType = CommandTypeRecall
+		mb.Reply = &Command_RecallReply{RecallReply: c}
 	default:
 	}
 }
@@ -11,6 +11,7 @@ const (
 	TypeCommand
 	TypeMessage
 	TypeEvent
+	TypeAck
 )
 
 // NeedAck
@@ -25,11 +26,44 @@ const (
 	CommandTypeFriendAdd             = "FRIEND_ADD"
 	CommandTypeFriendAddAgree        = "FRIEND_ADD_AGREE"
 	CommandTypeFriendReject          = "FRIEND_ADD_REJECT"
+	CommandTypeConvRead              = "CONV_READ"
+	CommandTypeMessageReceipt        = "MESSAGE_RECEIPT"
+	CommandTypeKeyRegister           = "KEY_REGISTER"
+	CommandTypeKeyFetch              = "KEY_FETCH"
+	CommandTypeReconnect             = "RECONNECT"
+	CommandTypePushRegister          = "PUSH_REGISTER"
+	CommandTypeNotifySetting         = "NOTIFY_SETTING"
+	CommandTypeScheduleSend          = "SCHEDULE_SEND"
+	CommandTypeScheduleList          = "SCHEDULE_LIST"
+	CommandTypeScheduleCancel        = "SCHEDULE_CANCEL"
+	CommandTypeRecall                = "RECALL"
 )
 
 const (
-	MessageTypeText  string = "TEXT"
-	MessageTypeImage string = "IMAGE"
-	MessageTypeAudio string = "AUDIO"
-	MessageTypeVideo string = "VIDEO"
+	MessageTypeText     string = "TEXT"
+	MessageTypeImage    string = "IMAGE"
+	MessageTypeAudio    string = "AUDIO"
+	MessageTypeVideo    string = "VIDEO"
+	MessageTypeReceipt  string = "RECEIPT"
+	MessageTypeFile     string = "FILE"
+	MessageTypeLocation string = "LOCATION"
+	MessageTypeCard     string = "CARD"
+	MessageTypeCustom   string = "CUSTOM"
+	MessageTypeMerged   string = "MERGED"
+	MessageTypeEnvelope string = "ENVELOPE"
+	MessageTypeStatus   string = "STATUS"
+	MessageTypeNotify   string = "NOTIFY_SETTINGS"
 )
+
+// Status.Status 消息的投递状态，只会向后推进：offline -> delivered
+const (
+	MessageStatusOffline   string = "offline"   //至少一个接收者需要从离线存储中获取
+	MessageStatusDelivered        = "delivered" //至少一个接收者的设备已收到
+	MessageStatusRejected         = "rejected"  //路由失败，消息没有发出
+)
+
+// MaxEnvelopeSize 端到端加密消息密文的最大字节数
+const MaxEnvelopeSize = 64 << 10
+
+// AtAll At.UserId 为该值时表示@所有人
+const AtAll int64 = -1
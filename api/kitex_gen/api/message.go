@@ -54,6 +54,7 @@ func (mb *Message) Success(content proto.Message) *Message {
 	ack := &Message{
 		MessageId:   mb.MessageId,
 		MessageType: mb.MessageType,
+		ClientMsgId: mb.ClientMsgId,
 		Flow:        FlowResponse,
 		NeedAck:     NO,
 		Code:        0,
@@ -73,6 +74,7 @@ func (mb *Message) Failure(e error) *Message {
 	ack := &Message{
 		MessageId:   mb.MessageId,
 		MessageType: mb.MessageType,
+		ClientMsgId: mb.ClientMsgId,
 		Flow:        FlowResponse,
 		NeedAck:     NO,
 	}
@@ -102,10 +104,88 @@ func (mb *Message) SetContent(content proto.Message) {
 	case *Video:
 		mb.MessageType = MessageTypeVideo
 		mb.Content = &Message_Video{Video: content}
+	case *Receipt:
+		mb.MessageType = MessageTypeReceipt
+		mb.Content = &Message_Receipt{Receipt: content}
+	case *File:
+		mb.MessageType = MessageTypeFile
+		mb.Content = &Message_File{File: content}
+	case *Location:
+		mb.MessageType = MessageTypeLocation
+		mb.Content = &Message_Location{Location: content}
+	case *Card:
+		mb.MessageType = MessageTypeCard
+		mb.Content = &Message_Card{Card: content}
+	case *Custom:
+		mb.MessageType = MessageTypeCustom
+		mb.Content = &Message_Custom{Custom: content}
+	case *Merged:
+		mb.MessageType = MessageTypeMerged
+		mb.Content = &Message_Merged{Merged: content}
+	case *Envelope:
+		mb.MessageType = MessageTypeEnvelope
+		mb.Content = &Message_Envelope{Envelope: content}
+	case *Status:
+		mb.MessageType = MessageTypeStatus
+		mb.Content = &Message_Status{Status: content}
+	case *NotifySettings:
+		mb.MessageType = MessageTypeNotify
+		mb.Content = &Message_NotifySettings{NotifySettings: content}
 	default:
 	}
 }
 
+// GetContentMessage 返回content中的具体消息
+func (mb *Message) GetContentMessage() proto.Message {
+	switch c := mb.Content.(type) {
+	case *Message_Text:
+		return c.Text
+	case *Message_Image:
+		return c.Image
+	case *Message_Audio:
+		return c.Audio
+	case *Message_Video:
+		return c.Video
+	case *Message_Receipt:
+		return c.Receipt
+	case *Message_File:
+		return c.File
+	case *Message_Location:
+		return c.Location
+	case *Message_Card:
+		return c.Card
+	case *Message_Custom:
+		return c.Custom
+	case *Message_Merged:
+		return c.Merged
+	case *Message_Envelope:
+		return c.Envelope
+	case *Message_Status:
+		return c.Status
+	case *Message_NotifySettings:
+		return c.NotifySettings
+	default:
+		return nil
+	}
+}
+
+// NewStatus 推送给发送者的投递状态
+func (mb *Message) NewStatus(status string, code int32) *Status {
+	return &Status{
+		MessageId: mb.MessageId,
+		ConvId:    mb.ConvId,
+		Sequence:  mb.Sequence,
+		Status:    status,
+		STime:     mb.STime,
+		Code:      code,
+	}
+}
+
+// IsSystem 服务端生成的回执、状态和设置同步消息，不再产生回执和状态
+func (mb *Message) IsSystem() bool {
+	return mb.MessageType == MessageTypeReceipt || mb.MessageType == MessageTypeStatus || mb.MessageType == MessageTypeNotify
+}
+
 func (mb *Message) IsToGroup() bool {
 	return mb.GroupId > 0
 }
@@ -123,3 +203,65 @@ func (mb *Message) IsResponse() bool {
 	}
 	return false
 }
+
+// IsAtAll 是否@所有人
+func (mb *Message) IsAtAll() bool {
+	for _, at := range mb.At {
+		if at.UserId == AtAll {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAt 是否@了userId，@所有人时对全部成员成立
+func (mb *Message) IsAt(userId int64) bool {
+	for _, at := range mb.At {
+		if at.UserId == AtAll || at.UserId == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// SetContent 设置引用内容，不支持的类型忽略
+func (r *Refer) SetContent(content proto.Message) {
+
+	if content == nil {
+		return
+	}
+
+	switch content := content.(type) {
+	case *Text:
+		r.CType = MessageTypeText
+		r.Content = &Refer_Text{Text: content}
+	case *Image:
+		r.CType = MessageTypeImage
+		r.Content = &Refer_Image{Image: content}
+	case *Audio:
+		r.CType = MessageTypeAudio
+		r.Content = &Refer_Audio{Audio: content}
+	case *Video:
+		r.CType = MessageTypeVideo
+		r.Content = &Refer_Video{Video: content}
+	case *File:
+		r.CType = MessageTypeFile
+		r.Content = &Refer_File{File: content}
+	case *Location:
+		r.CType = MessageTypeLocation
+		r.Content = &Refer_Location{Location: content}
+	case *Card:
+		r.CType = MessageTypeCard
+		r.Content = &Refer_Card{Card: content}
+	case *Custom:
+		r.CType = MessageTypeCustom
+		r.Content = &Refer_Custom{Custom: content}
+	case *Merged:
+		r.CType = MessageTypeMerged
+		r.Content = &Refer_Merged{Merged: content}
+	case *Envelope:
+		// 密文只有接收设备能解开，引用时只保留类型
+		r.CType = MessageTypeEnvelope
+	default:
+	}
+}
@@ -1,6 +1,15 @@
 package api
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+)
+
+// MaxMergedItems 合并转发最多包含的消息数
+const MaxMergedItems = 100
+
+// MaxClientMsgIdLength 客户端消息id的最大长度
+const MaxClientMsgIdLength = 64
 
 var (
 	InvalidMessage     = errors.New("message is nil")
@@ -10,9 +19,26 @@ var (
 	InvalidFlow        = errors.New("flow is zero")
 	InvalidUserId      = errors.New("userId is zero")
 	InvalidConvId      = errors.New("convId is empty")
-	InvalidSequence    = errors.New("sequence is zero")
 	InvalidCTime       = errors.New("cTime is zero")
 	InvalidToGroupId   = errors.New("both to and groupId are zero")
+	InvalidClientMsgId = errors.New("clientMsgId is too long")
+
+	InvalidContent         = errors.New("content does not match message type")
+	InvalidText            = errors.New("text is empty")
+	InvalidUrl             = errors.New("url is empty")
+	InvalidFileName        = errors.New("file name is empty")
+	InvalidFileSize        = errors.New("file size is not positive")
+	InvalidLocation        = errors.New("lat/lng out of range")
+	InvalidCardUserId      = errors.New("card userId is zero")
+	InvalidCustomType      = errors.New("custom type is empty")
+	InvalidCustomPayload   = errors.New("custom payload is not valid json")
+	InvalidMergedItems     = errors.New("merged items is empty or too many")
+	InvalidMergedItem      = errors.New("merged item messageId is empty")
+	InvalidSystemMessage   = errors.New("system message can not be sent by client")
+	InvalidCiphertext      = errors.New("ciphertext is empty or too large")
+	InvalidKeyId           = errors.New("keyId is empty")
+	InvalidDeviceId        = errors.New("deviceId is empty")
+	InvalidUnsupportedType = errors.New("unsupported message type")
 )
 
 func (mb *Message) Validate() error {
@@ -44,10 +70,6 @@ func (mb *Message) Validate() error {
 		return InvalidConvId
 	}
 
-	if mb.Sequence == 0 {
-		return InvalidSequence
-	}
-
 	if mb.CTime == 0 {
 		return InvalidCTime
 	}
@@ -56,5 +78,113 @@ func (mb *Message) Validate() error {
 		return InvalidToGroupId
 	}
 
+	if len(mb.ClientMsgId) > MaxClientMsgIdLength {
+		return InvalidClientMsgId
+	}
+
+	// 回执、状态和设置同步只由服务端生成
+	if mb.IsSystem() {
+		return InvalidSystemMessage
+	}
+
+	return mb.validateContent()
+}
+
+// validateContent 校验content与messageType一致，并按类型校验必填字段
+func (mb *Message) validateContent() error {
+	switch mb.MessageType {
+	case MessageTypeText:
+		c := mb.GetText()
+		if c == nil {
+			return InvalidContent
+		}
+		if c.Text == "" {
+			return InvalidText
+		}
+	case MessageTypeImage:
+		if mb.GetImage() == nil {
+			return InvalidContent
+		}
+	case MessageTypeAudio:
+		if mb.GetAudio() == nil {
+			return InvalidContent
+		}
+	case MessageTypeVideo:
+		if mb.GetVideo() == nil {
+			return InvalidContent
+		}
+	case MessageTypeFile:
+		c := mb.GetFile()
+		if c == nil {
+			return InvalidContent
+		}
+		if c.Url == "" {
+			return InvalidUrl
+		}
+		if c.Name == "" {
+			return InvalidFileName
+		}
+		if c.FileSize <= 0 {
+			return InvalidFileSize
+		}
+	case MessageTypeLocation:
+		c := mb.GetLocation()
+		if c == nil {
+			return InvalidContent
+		}
+		if c.Lat < -90 || c.Lat > 90 || c.Lng < -180 || c.Lng > 180 {
+			return InvalidLocation
+		}
+	case MessageTypeCard:
+		c := mb.GetCard()
+		if c == nil {
+			return InvalidContent
+		}
+		if c.UserId == 0 {
+			return InvalidCardUserId
+		}
+	case MessageTypeCustom:
+		c := mb.GetCustom()
+		if c == nil {
+			return InvalidContent
+		}
+		if c.Type == "" {
+			return InvalidCustomType
+		}
+		if c.Payload != "" && !json.Valid([]byte(c.Payload)) {
+			return InvalidCustomPayload
+		}
+	case MessageTypeMerged:
+		c := mb.GetMerged()
+		if c == nil {
+			return InvalidContent
+		}
+		if len(c.Items) == 0 || len(c.Items) > MaxMergedItems {
+			return InvalidMergedItems
+		}
+		for _, item := range c.Items {
+			if item.GetMessageId() == "" {
+				return InvalidMergedItem
+			}
+		}
+	case MessageTypeEnvelope:
+		// 只校验信封，不解析密文
+		c := mb.GetEnvelope()
+		if c == nil {
+			return InvalidContent
+		}
+		if len(c.Ciphertext) == 0 || len(c.Ciphertext) > MaxEnvelopeSize {
+			return InvalidCiphertext
+		}
+		if c.KeyId == "" {
+			return InvalidKeyId
+		}
+		if c.DeviceId == "" {
+			return InvalidDeviceId
+		}
+	default:
+		return InvalidUnsupportedType
+	}
+
 	return nil
 }
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+func TestValidateContent(t *testing.T) {
+	cases := []struct {
+		content proto.Message
+		err     error
+	}{
+		{&Text{Text: "hi"}, nil},
+		{&Text{}, InvalidText},
+		{&Image{}, nil},
+		{&File{Url: "http://f", Name: "a.pdf", FileSize: 10, Mime: "application/pdf"}, nil},
+		{&File{Url: "http://f", Name: "a.pdf"}, InvalidFileSize},
+		{&Location{Lat: 31.2, Lng: 121.5, Title: "Shanghai"}, nil},
+		{&Location{Lat: 91}, InvalidLocation},
+		{&Card{UserId: 1, Name: "bob"}, nil},
+		{&Card{}, InvalidCardUserId},
+		{&Custom{Type: "vote", Payload: `{"options":["a","b"]}`}, nil},
+		{&Custom{Type: "vote", Payload: `{`}, InvalidCustomPayload},
+		{&Custom{Payload: `{}`}, InvalidCustomType},
+		{&Merged{Title: "history", Items: []*MergedItem{{MessageId: "1", Summary: "hi"}}}, nil},
+		{&Merged{Title: "history"}, InvalidMergedItems},
+		{&Merged{Items: []*MergedItem{{Summary: "hi"}}}, InvalidMergedItem},
+		{&Envelope{Ciphertext: []byte{1, 2, 3}, KeyId: "k1", DeviceId: "d1"}, nil},
+		{&Envelope{KeyId: "k1", DeviceId: "d1"}, InvalidCiphertext},
+		{&Envelope{Ciphertext: make([]byte, MaxEnvelopeSize+1), KeyId: "k1", DeviceId: "d1"}, InvalidCiphertext},
+		{&Envelope{Ciphertext: []byte{1}, DeviceId: "d1"}, InvalidKeyId},
+		{&Envelope{Ciphertext: []byte{1}, KeyId: "k1"}, InvalidDeviceId},
+		{&Receipt{MessageId: "1"}, InvalidSystemMessage},
+		{&Status{MessageId: "1", Status: MessageStatusDelivered}, InvalidSystemMessage},
+		{&NotifySettings{Version: 1}, InvalidSystemMessage},
+	}
+
+	for _, c := range cases {
+		m := NewMessage(1, 2, 0, 1, "app", "conv", c.content)
+		assert.Equal(t, c.err, m.Validate(), "%T %v", c.content, c.content)
+	}
+}
+
+func TestValidateContentMismatch(t *testing.T) {
+	m := NewMessage(1, 2, 0, 1, "app", "conv", &Text{Text: "hi"})
+	m.MessageType = MessageTypeFile
+	assert.Equal(t, InvalidContent, m.Validate())
+
+	m.MessageType = "UNKNOWN"
+	assert.Equal(t, InvalidUnsupportedType, m.Validate())
+}
+
+func TestValidateClientMsgId(t *testing.T) {
+	m := NewMessage(1, 2, 0, 1, "app", "conv", &Text{Text: "hi"})
+	m.ClientMsgId = strings.Repeat("a", MaxClientMsgIdLength)
+	assert.NoError(t, m.Validate())
+
+	m.ClientMsgId += "a"
+	assert.Equal(t, InvalidClientMsgId, m.Validate())
+}
+
+func TestSetContent(t *testing.T) {
+	m := NewMessage(1, 2, 0, 1, "app", "conv", &Location{Lat: 1, Lng: 2})
+	assert.Equal(t, MessageTypeLocation, m.MessageType)
+	assert.True(t, proto.Equal(&Location{Lat: 1, Lng: 2}, m.GetContentMessage()))
+
+	r := &Refer{}
+	r.SetContent(&Merged{Title: "t"})
+	assert.Equal(t, MessageTypeMerged, r.CType)
+	assert.Equal(t, "t", r.GetMerged().Title)
+}
@@ -13,6 +13,8 @@ func (p *Packet) GetPacketId() string {
 		return p.GetMessage().GetMessageId()
 	case TypeHeartbeat:
 		return strconv.Itoa(int(p.GetHeartbeat().GetValue()))
+	case TypeAck:
+		return "ack"
 	default:
 		return ""
 	}
@@ -30,6 +32,10 @@ func (p *Packet) IsMessage() bool {
 	return p.Type == TypeMessage
 }
 
+func (p *Packet) IsAck() bool {
+	return p.Type == TypeAck
+}
+
 func (p *Packet) Failure(e error) *Packet {
 	switch p.Type {
 	case TypeHeartbeat:
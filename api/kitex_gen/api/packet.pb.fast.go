@@ -34,6 +34,11 @@ func (x *Packet) FastRead(buf []byte, _type int8, number int32) (offset int, err
 		if err != nil {
 			goto ReadFieldError
 		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
 	default:
 		offset, err = fastpb.Skip(buf, _type, number)
 		if err != nil {
@@ -88,6 +93,18 @@ func (x *Packet) fastReadField4(buf []byte, _type int8) (offset int, err error)
 	return offset, nil
 }
 
+func (x *Packet) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	var ov Packet_Ack
+	x.Body = &ov
+	var v Ack
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Ack = &v
+	return offset, nil
+}
+
 func (x *Heartbeat) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
 	switch number {
 	case 1:
@@ -113,7 +130,7 @@ func (x *Heartbeat) fastReadField1(buf []byte, _type int8) (offset int, err erro
 	return offset, err
 }
 
-func (x *Command) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+func (x *Ack) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
 	switch number {
 	case 1:
 		offset, err = x.fastReadField1(buf, _type)
@@ -125,33 +142,53 @@ func (x *Command) FastRead(buf []byte, _type int8, number int32) (offset int, er
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 3:
-		offset, err = x.fastReadField3(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 4:
-		offset, err = x.fastReadField4(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 5:
-		offset, err = x.fastReadField5(buf, _type)
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
 		if err != nil {
-			goto ReadFieldError
+			goto SkipFieldError
 		}
-	case 6:
-		offset, err = x.fastReadField6(buf, _type)
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Ack[number], err)
+}
+
+func (x *Ack) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	var v string
+	v, offset, err = fastpb.ReadString(buf, _type)
+	if err != nil {
+		return offset, err
+	}
+	x.MessageIds = append(x.MessageIds, v)
+	return offset, err
+}
+
+func (x *Ack) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	var v ConvAck
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Convs = append(x.Convs, &v)
+	return offset, nil
+}
+
+func (x *ConvAck) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 7:
-		offset, err = x.fastReadField7(buf, _type)
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 8:
-		offset, err = x.fastReadField8(buf, _type)
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
@@ -165,78 +202,25 @@ func (x *Command) FastRead(buf []byte, _type int8, number int32) (offset int, er
 SkipFieldError:
 	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
 ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Command[number], err)
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ConvAck[number], err)
 }
 
-func (x *Command) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.CommandId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Command) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.CommandType, offset, err = fastpb.ReadString(buf, _type)
+func (x *ConvAck) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.ConvId, offset, err = fastpb.ReadString(buf, _type)
 	return offset, err
 }
 
-func (x *Command) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.Code, offset, err = fastpb.ReadInt32(buf, _type)
+func (x *ConvAck) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
 	return offset, err
 }
 
-func (x *Command) fastReadField4(buf []byte, _type int8) (offset int, err error) {
-	x.Message, offset, err = fastpb.ReadString(buf, _type)
+func (x *ConvAck) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.From, offset, err = fastpb.ReadInt64(buf, _type)
 	return offset, err
 }
 
-func (x *Command) fastReadField5(buf []byte, _type int8) (offset int, err error) {
-	var ov Command_LoginRequest
-	x.Request = &ov
-	var v LoginRequest
-	offset, err = fastpb.ReadMessage(buf, _type, &v)
-	if err != nil {
-		return offset, err
-	}
-	ov.LoginRequest = &v
-	return offset, nil
-}
-
-func (x *Command) fastReadField6(buf []byte, _type int8) (offset int, err error) {
-	var ov Command_LogoutRequest
-	x.Request = &ov
-	var v LogoutRequest
-	offset, err = fastpb.ReadMessage(buf, _type, &v)
-	if err != nil {
-		return offset, err
-	}
-	ov.LogoutRequest = &v
-	return offset, nil
-}
-
-func (x *Command) fastReadField7(buf []byte, _type int8) (offset int, err error) {
-	var ov Command_LoginReply
-	x.Reply = &ov
-	var v LoginReply
-	offset, err = fastpb.ReadMessage(buf, _type, &v)
-	if err != nil {
-		return offset, err
-	}
-	ov.LoginReply = &v
-	return offset, nil
-}
-
-func (x *Command) fastReadField8(buf []byte, _type int8) (offset int, err error) {
-	var ov Command_LogoutReply
-	x.Reply = &ov
-	var v LogoutReply
-	offset, err = fastpb.ReadMessage(buf, _type, &v)
-	if err != nil {
-		return offset, err
-	}
-	ov.LogoutReply = &v
-	return offset, nil
-}
-
-func (x *Message) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+func (x *Command) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
 	switch number {
 	case 1:
 		offset, err = x.fastReadField1(buf, _type)
@@ -323,11 +307,6 @@ func (x *Message) FastRead(buf []byte, _type int8, number int32) (offset int, er
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 18:
-		offset, err = x.fastReadField18(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
 	case 19:
 		offset, err = x.fastReadField19(buf, _type)
 		if err != nil {
@@ -338,6 +317,56 @@ func (x *Message) FastRead(buf []byte, _type int8, number int32) (offset int, er
 		if err != nil {
 			goto ReadFieldError
 		}
+	case 21:
+		offset, err = x.fastReadField21(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 22:
+		offset, err = x.fastReadField22(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 23:
+		offset, err = x.fastReadField23(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 24:
+		offset, err = x.fastReadField24(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 25:
+		offset, err = x.fastReadField25(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 26:
+		offset, err = x.fastReadField26(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 27:
+		offset, err = x.fastReadField27(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 28:
+		offset, err = x.fastReadField28(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 29:
+		offset, err = x.fastReadField29(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 30:
+		offset, err = x.fastReadField30(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
 	default:
 		offset, err = fastpb.Skip(buf, _type, number)
 		if err != nil {
@@ -348,421 +377,330 @@ func (x *Message) FastRead(buf []byte, _type int8, number int32) (offset int, er
 SkipFieldError:
 	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
 ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Message[number], err)
-}
-
-func (x *Message) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.MessageType, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.NeedAck, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField4(buf []byte, _type int8) (offset int, err error) {
-	x.AppId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField5(buf []byte, _type int8) (offset int, err error) {
-	x.Flow, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField6(buf []byte, _type int8) (offset int, err error) {
-	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField7(buf []byte, _type int8) (offset int, err error) {
-	x.ConvId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField8(buf []byte, _type int8) (offset int, err error) {
-	x.To, offset, err = fastpb.ReadInt64(buf, _type)
-	return offset, err
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Command[number], err)
 }
 
-func (x *Message) fastReadField9(buf []byte, _type int8) (offset int, err error) {
-	x.GroupId, offset, err = fastpb.ReadInt64(buf, _type)
+func (x *Command) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.CommandId, offset, err = fastpb.ReadString(buf, _type)
 	return offset, err
 }
 
-func (x *Message) fastReadField10(buf []byte, _type int8) (offset int, err error) {
-	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
+func (x *Command) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.CommandType, offset, err = fastpb.ReadString(buf, _type)
 	return offset, err
 }
 
-func (x *Message) fastReadField11(buf []byte, _type int8) (offset int, err error) {
-	x.CTime, offset, err = fastpb.ReadInt64(buf, _type)
+func (x *Command) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Code, offset, err = fastpb.ReadInt32(buf, _type)
 	return offset, err
 }
 
-func (x *Message) fastReadField12(buf []byte, _type int8) (offset int, err error) {
-	x.STime, offset, err = fastpb.ReadInt64(buf, _type)
+func (x *Command) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.Message, offset, err = fastpb.ReadString(buf, _type)
 	return offset, err
 }
 
-func (x *Message) fastReadField13(buf []byte, _type int8) (offset int, err error) {
-	var v At
+func (x *Command) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_LoginRequest
+	x.Request = &ov
+	var v LoginRequest
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	x.At = append(x.At, &v)
+	ov.LoginRequest = &v
 	return offset, nil
 }
 
-func (x *Message) fastReadField14(buf []byte, _type int8) (offset int, err error) {
-	var v Refer
+func (x *Command) fastReadField6(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_LogoutRequest
+	x.Request = &ov
+	var v LogoutRequest
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	x.Refer = append(x.Refer, &v)
+	ov.LogoutRequest = &v
 	return offset, nil
 }
 
-func (x *Message) fastReadField15(buf []byte, _type int8) (offset int, err error) {
-	x.Code, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField16(buf []byte, _type int8) (offset int, err error) {
-	x.Message, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Message) fastReadField17(buf []byte, _type int8) (offset int, err error) {
-	var ov Message_Text
-	x.Content = &ov
-	var v Text
+func (x *Command) fastReadField7(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_LoginReply
+	x.Reply = &ov
+	var v LoginReply
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Text = &v
+	ov.LoginReply = &v
 	return offset, nil
 }
 
-func (x *Message) fastReadField18(buf []byte, _type int8) (offset int, err error) {
-	var ov Message_Image
-	x.Content = &ov
-	var v Image
+func (x *Command) fastReadField8(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_LogoutReply
+	x.Reply = &ov
+	var v LogoutReply
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Image = &v
+	ov.LogoutReply = &v
 	return offset, nil
 }
 
-func (x *Message) fastReadField19(buf []byte, _type int8) (offset int, err error) {
-	var ov Message_Audio
-	x.Content = &ov
-	var v Audio
+func (x *Command) fastReadField9(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ReadRequest
+	x.Request = &ov
+	var v ReadRequest
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Audio = &v
+	ov.ReadRequest = &v
 	return offset, nil
 }
 
-func (x *Message) fastReadField20(buf []byte, _type int8) (offset int, err error) {
-	var ov Message_Video
-	x.Content = &ov
-	var v Video
+func (x *Command) fastReadField10(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ReadReply
+	x.Reply = &ov
+	var v ReadReply
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Video = &v
+	ov.ReadReply = &v
 	return offset, nil
 }
 
-func (x *At) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 2:
-		offset, err = x.fastReadField2(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 3:
-		offset, err = x.fastReadField3(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
-		if err != nil {
-			goto SkipFieldError
-		}
+func (x *Command) fastReadField11(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ReceiptRequest
+	x.Request = &ov
+	var v ReceiptRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
 	}
+	ov.ReceiptRequest = &v
 	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_At[number], err)
 }
 
-func (x *At) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
-	return offset, err
+func (x *Command) fastReadField12(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ReceiptReply
+	x.Reply = &ov
+	var v ReceiptReply
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.ReceiptReply = &v
+	return offset, nil
 }
 
-func (x *At) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.Name, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Command) fastReadField13(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_KeyRegisterRequest
+	x.Request = &ov
+	var v KeyRegisterRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.KeyRegisterRequest = &v
+	return offset, nil
 }
 
-func (x *At) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.Avatar, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Command) fastReadField14(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_KeyRegisterReply
+	x.Reply = &ov
+	var v KeyRegisterReply
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.KeyRegisterReply = &v
+	return offset, nil
 }
 
-func (x *Refer) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 2:
-		offset, err = x.fastReadField2(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 3:
-		offset, err = x.fastReadField3(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 4:
-		offset, err = x.fastReadField4(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 5:
-		offset, err = x.fastReadField5(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 6:
-		offset, err = x.fastReadField6(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 7:
-		offset, err = x.fastReadField7(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 8:
-		offset, err = x.fastReadField8(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
-		if err != nil {
-			goto SkipFieldError
-		}
+func (x *Command) fastReadField15(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_KeyFetchRequest
+	x.Request = &ov
+	var v KeyFetchRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
 	}
+	ov.KeyFetchRequest = &v
 	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Refer[number], err)
 }
 
-func (x *Refer) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
-	return offset, err
+func (x *Command) fastReadField16(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_KeyFetchReply
+	x.Reply = &ov
+	var v KeyFetchReply
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.KeyFetchReply = &v
+	return offset, nil
 }
 
-func (x *Refer) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.Name, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Command) fastReadField17(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ReconnectRequest
+	x.Request = &ov
+	var v ReconnectRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.ReconnectRequest = &v
+	return offset, nil
 }
 
-func (x *Refer) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.Avatar, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Command) fastReadField19(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_PushRegisterRequest
+	x.Request = &ov
+	var v PushRegisterRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.PushRegisterRequest = &v
+	return offset, nil
 }
 
-func (x *Refer) fastReadField4(buf []byte, _type int8) (offset int, err error) {
-	x.CType, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Command) fastReadField20(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_PushRegisterReply
+	x.Reply = &ov
+	var v PushRegisterReply
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.PushRegisterReply = &v
+	return offset, nil
 }
 
-func (x *Refer) fastReadField5(buf []byte, _type int8) (offset int, err error) {
-	var ov Refer_Text
-	x.Content = &ov
-	var v Text
+func (x *Command) fastReadField21(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_NotifySettingRequest
+	x.Request = &ov
+	var v NotifySettingRequest
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Text = &v
+	ov.NotifySettingRequest = &v
 	return offset, nil
 }
 
-func (x *Refer) fastReadField6(buf []byte, _type int8) (offset int, err error) {
-	var ov Refer_Image
-	x.Content = &ov
-	var v Image
+func (x *Command) fastReadField22(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_NotifySettingReply
+	x.Reply = &ov
+	var v NotifySettingReply
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Image = &v
+	ov.NotifySettingReply = &v
 	return offset, nil
 }
 
-func (x *Refer) fastReadField7(buf []byte, _type int8) (offset int, err error) {
-	var ov Refer_Audio
-	x.Content = &ov
-	var v Audio
+func (x *Command) fastReadField23(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ScheduleRequest
+	x.Request = &ov
+	var v ScheduleRequest
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Audio = &v
+	ov.ScheduleRequest = &v
 	return offset, nil
 }
 
-func (x *Refer) fastReadField8(buf []byte, _type int8) (offset int, err error) {
-	var ov Refer_Video
-	x.Content = &ov
-	var v Video
+func (x *Command) fastReadField24(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ScheduleReply
+	x.Reply = &ov
+	var v ScheduleReply
 	offset, err = fastpb.ReadMessage(buf, _type, &v)
 	if err != nil {
 		return offset, err
 	}
-	ov.Video = &v
+	ov.ScheduleReply = &v
 	return offset, nil
 }
 
-func (x *Text) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
-		if err != nil {
-			goto SkipFieldError
-		}
+func (x *Command) fastReadField25(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ScheduleListRequest
+	x.Request = &ov
+	var v ScheduleListRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
 	}
+	ov.ScheduleListRequest = &v
 	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Text[number], err)
 }
 
-func (x *Text) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.Text, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Command) fastReadField26(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ScheduleListReply
+	x.Reply = &ov
+	var v ScheduleListReply
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.ScheduleListReply = &v
+	return offset, nil
 }
 
-func (x *Image) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 2:
-		offset, err = x.fastReadField2(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 3:
-		offset, err = x.fastReadField3(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
-		if err != nil {
-			goto SkipFieldError
-		}
+func (x *Command) fastReadField27(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ScheduleCancelRequest
+	x.Request = &ov
+	var v ScheduleCancelRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
 	}
+	ov.ScheduleCancelRequest = &v
 	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Image[number], err)
 }
 
-func (x *Image) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.Url, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Image) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.Width, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *Image) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.Height, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *Audio) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	case 2:
-		offset, err = x.fastReadField2(buf, _type)
-		if err != nil {
-			goto ReadFieldError
-		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
-		if err != nil {
-			goto SkipFieldError
-		}
+func (x *Command) fastReadField28(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_ScheduleCancelReply
+	x.Reply = &ov
+	var v ScheduleCancelReply
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
 	}
+	ov.ScheduleCancelReply = &v
 	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Audio[number], err)
 }
 
-func (x *Audio) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.Url, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Command) fastReadField29(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_RecallRequest
+	x.Request = &ov
+	var v RecallRequest
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.RecallRequest = &v
+	return offset, nil
 }
 
-func (x *Audio) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.Length, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
+func (x *Command) fastReadField30(buf []byte, _type int8) (offset int, err error) {
+	var ov Command_RecallReply
+	x.Reply = &ov
+	var v RecallReply
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.RecallReply = &v
+	return offset, nil
 }
 
-func (x *Video) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+func (x *Message) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
 	switch number {
 	case 1:
 		offset, err = x.fastReadField1(buf, _type)
@@ -789,163 +727,133 @@ func (x *Video) FastRead(buf []byte, _type int8, number int32) (offset int, err
 		if err != nil {
 			goto ReadFieldError
 		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
+	case 6:
+		offset, err = x.fastReadField6(buf, _type)
 		if err != nil {
-			goto SkipFieldError
+			goto ReadFieldError
 		}
-	}
-	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Video[number], err)
-}
-
-func (x *Video) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.Url, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Video) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.Cover, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *Video) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.Length, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *Video) fastReadField4(buf []byte, _type int8) (offset int, err error) {
-	x.Width, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *Video) fastReadField5(buf []byte, _type int8) (offset int, err error) {
-	x.Height, offset, err = fastpb.ReadInt32(buf, _type)
-	return offset, err
-}
-
-func (x *LoginRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
+	case 7:
+		offset, err = x.fastReadField7(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 2:
-		offset, err = x.fastReadField2(buf, _type)
+	case 8:
+		offset, err = x.fastReadField8(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 3:
-		offset, err = x.fastReadField3(buf, _type)
+	case 9:
+		offset, err = x.fastReadField9(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 4:
-		offset, err = x.fastReadField4(buf, _type)
+	case 10:
+		offset, err = x.fastReadField10(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 5:
-		offset, err = x.fastReadField5(buf, _type)
+	case 11:
+		offset, err = x.fastReadField11(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
+	case 12:
+		offset, err = x.fastReadField12(buf, _type)
 		if err != nil {
-			goto SkipFieldError
+			goto ReadFieldError
 		}
-	}
-	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_LoginRequest[number], err)
-}
-
-func (x *LoginRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.AppId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *LoginRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.UserSig, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *LoginRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.Version, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *LoginRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
-	x.Os, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *LoginRequest) fastReadField5(buf []byte, _type int8) (offset int, err error) {
-	x.DeviceId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *LoginReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
+	case 13:
+		offset, err = x.fastReadField13(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 2:
-		offset, err = x.fastReadField2(buf, _type)
+	case 14:
+		offset, err = x.fastReadField14(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
+	case 15:
+		offset, err = x.fastReadField15(buf, _type)
 		if err != nil {
-			goto SkipFieldError
+			goto ReadFieldError
 		}
-	}
-	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
-ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_LoginReply[number], err)
-}
-
-func (x *LoginReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.AppId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
-}
-
-func (x *LoginReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
-	return offset, err
-}
-
-func (x *LogoutRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	case 1:
-		offset, err = x.fastReadField1(buf, _type)
+	case 16:
+		offset, err = x.fastReadField16(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 2:
-		offset, err = x.fastReadField2(buf, _type)
+	case 17:
+		offset, err = x.fastReadField17(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 3:
-		offset, err = x.fastReadField3(buf, _type)
+	case 18:
+		offset, err = x.fastReadField18(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
-	case 4:
-		offset, err = x.fastReadField4(buf, _type)
+	case 19:
+		offset, err = x.fastReadField19(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 20:
+		offset, err = x.fastReadField20(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 21:
+		offset, err = x.fastReadField21(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 22:
+		offset, err = x.fastReadField22(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 23:
+		offset, err = x.fastReadField23(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 24:
+		offset, err = x.fastReadField24(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 25:
+		offset, err = x.fastReadField25(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 26:
+		offset, err = x.fastReadField26(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 27:
+		offset, err = x.fastReadField27(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 28:
+		offset, err = x.fastReadField28(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 29:
+		offset, err = x.fastReadField29(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 30:
+		offset, err = x.fastReadField30(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 31:
+		offset, err = x.fastReadField31(buf, _type)
 		if err != nil {
 			goto ReadFieldError
 		}
@@ -959,798 +867,6730 @@ func (x *LogoutRequest) FastRead(buf []byte, _type int8, number int32) (offset i
 SkipFieldError:
 	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
 ReadFieldError:
-	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_LogoutRequest[number], err)
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Message[number], err)
 }
 
-func (x *LogoutRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
-	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+func (x *Message) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
 	return offset, err
 }
 
-func (x *LogoutRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
-	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
-	return offset, err
+func (x *Message) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.MessageType, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.NeedAck, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.Flow, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField6(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField7(buf []byte, _type int8) (offset int, err error) {
+	x.ConvId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField8(buf []byte, _type int8) (offset int, err error) {
+	x.To, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField9(buf []byte, _type int8) (offset int, err error) {
+	x.GroupId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField10(buf []byte, _type int8) (offset int, err error) {
+	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField11(buf []byte, _type int8) (offset int, err error) {
+	x.CTime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField12(buf []byte, _type int8) (offset int, err error) {
+	x.STime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField13(buf []byte, _type int8) (offset int, err error) {
+	var v At
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.At = append(x.At, &v)
+	return offset, nil
+}
+
+func (x *Message) fastReadField14(buf []byte, _type int8) (offset int, err error) {
+	var v Refer
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Refer = append(x.Refer, &v)
+	return offset, nil
+}
+
+func (x *Message) fastReadField15(buf []byte, _type int8) (offset int, err error) {
+	x.Code, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField16(buf []byte, _type int8) (offset int, err error) {
+	x.Message, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField17(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Text
+	x.Content = &ov
+	var v Text
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Text = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField18(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Image
+	x.Content = &ov
+	var v Image
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Image = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField19(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Audio
+	x.Content = &ov
+	var v Audio
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Audio = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField20(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Video
+	x.Content = &ov
+	var v Video
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Video = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField21(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Receipt
+	x.Content = &ov
+	var v Receipt
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Receipt = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField22(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_File
+	x.Content = &ov
+	var v File
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.File = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField23(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Location
+	x.Content = &ov
+	var v Location
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Location = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField24(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Card
+	x.Content = &ov
+	var v Card
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Card = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField25(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Custom
+	x.Content = &ov
+	var v Custom
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Custom = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField26(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Merged
+	x.Content = &ov
+	var v Merged
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Merged = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField27(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Envelope
+	x.Content = &ov
+	var v Envelope
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Envelope = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField28(buf []byte, _type int8) (offset int, err error) {
+	x.ClientMsgId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Message) fastReadField29(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_Status
+	x.Content = &ov
+	var v Status
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Status = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField30(buf []byte, _type int8) (offset int, err error) {
+	var ov Message_NotifySettings
+	x.Content = &ov
+	var v NotifySettings
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.NotifySettings = &v
+	return offset, nil
+}
+
+func (x *Message) fastReadField31(buf []byte, _type int8) (offset int, err error) {
+	x.Mentioned, offset, err = fastpb.ReadBool(buf, _type)
+	return offset, err
+}
+
+func (x *At) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_At[number], err)
+}
+
+func (x *At) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *At) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Name, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *At) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Avatar, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Refer) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 6:
+		offset, err = x.fastReadField6(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 7:
+		offset, err = x.fastReadField7(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 8:
+		offset, err = x.fastReadField8(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 9:
+		offset, err = x.fastReadField9(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 10:
+		offset, err = x.fastReadField10(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 11:
+		offset, err = x.fastReadField11(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 12:
+		offset, err = x.fastReadField12(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 13:
+		offset, err = x.fastReadField13(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 14:
+		offset, err = x.fastReadField14(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 15:
+		offset, err = x.fastReadField15(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Refer[number], err)
+}
+
+func (x *Refer) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Refer) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Name, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Refer) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Avatar, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Refer) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.CType, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Refer) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Text
+	x.Content = &ov
+	var v Text
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Text = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField6(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Image
+	x.Content = &ov
+	var v Image
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Image = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField7(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Audio
+	x.Content = &ov
+	var v Audio
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Audio = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField8(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Video
+	x.Content = &ov
+	var v Video
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Video = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField9(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Refer) fastReadField10(buf []byte, _type int8) (offset int, err error) {
+	x.Recalled, offset, err = fastpb.ReadBool(buf, _type)
+	return offset, err
+}
+
+func (x *Refer) fastReadField11(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_File
+	x.Content = &ov
+	var v File
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.File = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField12(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Location
+	x.Content = &ov
+	var v Location
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Location = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField13(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Card
+	x.Content = &ov
+	var v Card
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Card = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField14(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Custom
+	x.Content = &ov
+	var v Custom
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Custom = &v
+	return offset, nil
+}
+
+func (x *Refer) fastReadField15(buf []byte, _type int8) (offset int, err error) {
+	var ov Refer_Merged
+	x.Content = &ov
+	var v Merged
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	ov.Merged = &v
+	return offset, nil
+}
+
+func (x *Text) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Text[number], err)
+}
+
+func (x *Text) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Text, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Image) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Image[number], err)
+}
+
+func (x *Image) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Url, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Image) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Width, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Image) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Height, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Audio) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Audio[number], err)
+}
+
+func (x *Audio) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Url, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Audio) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Length, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Video) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Video[number], err)
+}
+
+func (x *Video) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Url, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Video) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Cover, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Video) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Length, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Video) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.Width, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Video) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.Height, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *File) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_File[number], err)
+}
+
+func (x *File) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Url, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *File) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Name, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *File) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.FileSize, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *File) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.Mime, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Location) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Location[number], err)
+}
+
+func (x *Location) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Lat, offset, err = fastpb.ReadDouble(buf, _type)
+	return offset, err
+}
+
+func (x *Location) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Lng, offset, err = fastpb.ReadDouble(buf, _type)
+	return offset, err
+}
+
+func (x *Location) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Title, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Card) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Card[number], err)
+}
+
+func (x *Card) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Card) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Name, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Card) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Avatar, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Custom) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Custom[number], err)
+}
+
+func (x *Custom) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Type, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Custom) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Payload, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Merged) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Merged[number], err)
+}
+
+func (x *Merged) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Title, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Merged) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	var v MergedItem
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Items = append(x.Items, &v)
+	return offset, nil
+}
+
+func (x *MergedItem) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 6:
+		offset, err = x.fastReadField6(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_MergedItem[number], err)
+}
+
+func (x *MergedItem) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *MergedItem) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *MergedItem) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Name, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *MergedItem) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.MessageType, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *MergedItem) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.Summary, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *MergedItem) fastReadField6(buf []byte, _type int8) (offset int, err error) {
+	x.CTime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Envelope) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Envelope[number], err)
+}
+
+func (x *Envelope) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Ciphertext, offset, err = fastpb.ReadBytes(buf, _type)
+	return offset, err
+}
+
+func (x *Envelope) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.KeyId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Envelope) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.DeviceId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Status) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 6:
+		offset, err = x.fastReadField6(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Status[number], err)
+}
+
+func (x *Status) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Status) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.ConvId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Status) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Status) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.Status, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Status) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.STime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Status) fastReadField6(buf []byte, _type int8) (offset int, err error) {
+	x.Code, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Receipt) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_Receipt[number], err)
+}
+
+func (x *Receipt) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Receipt) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.GroupId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *Receipt) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.ReadCount, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *Receipt) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.MemberCount, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *LoginRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 6:
+		offset, err = x.fastReadField6(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_LoginRequest[number], err)
+}
+
+func (x *LoginRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LoginRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserSig, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LoginRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Version, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LoginRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.Os, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LoginRequest) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.DeviceId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LoginRequest) fastReadField6(buf []byte, _type int8) (offset int, err error) {
+	var v string
+	v, offset, err = fastpb.ReadString(buf, _type)
+	if err != nil {
+		return offset, err
+	}
+	x.Compressions = append(x.Compressions, v)
+	return offset, err
+}
+
+func (x *LoginReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_LoginReply[number], err)
+}
+
+func (x *LoginReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LoginReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *LoginReply) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Compression, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ReconnectRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ReconnectRequest[number], err)
+}
+
+func (x *ReconnectRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	var v string
+	v, offset, err = fastpb.ReadString(buf, _type)
+	if err != nil {
+		return offset, err
+	}
+	x.Addrs = append(x.Addrs, v)
+	return offset, err
+}
+
+func (x *ReconnectRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Reason, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LogoutRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_LogoutRequest[number], err)
+}
+
+func (x *LogoutRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LogoutRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *LogoutRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Os, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LogoutRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.DeviceId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *LogoutReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+}
+
+func (x *ReadRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ReadRequest[number], err)
+}
+
+func (x *ReadRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ReadRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReadRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.ConvId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ReadRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.GroupId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReadRequest) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReadReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ReadReply[number], err)
+}
+
+func (x *ReadReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReadReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Mention, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReceiptRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ReceiptRequest[number], err)
+}
+
+func (x *ReceiptRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ReceiptRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReceiptRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.GroupId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReceiptRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ReceiptRequest) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ReceiptReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ReceiptReply[number], err)
+}
+
+func (x *ReceiptReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ReceiptReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	offset, err = fastpb.ReadList(buf, _type,
+		func(buf []byte, _type int8) (n int, err error) {
+			var v int64
+			v, offset, err = fastpb.ReadInt64(buf, _type)
+			if err != nil {
+				return offset, err
+			}
+			x.ReadUserIds = append(x.ReadUserIds, v)
+			return offset, err
+		})
+	return offset, err
+}
+
+func (x *ReceiptReply) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	offset, err = fastpb.ReadList(buf, _type,
+		func(buf []byte, _type int8) (n int, err error) {
+			var v int64
+			v, offset, err = fastpb.ReadInt64(buf, _type)
+			if err != nil {
+				return offset, err
+			}
+			x.UnreadUserIds = append(x.UnreadUserIds, v)
+			return offset, err
+		})
+	return offset, err
+}
+
+func (x *PreKey) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_PreKey[number], err)
+}
+
+func (x *PreKey) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.KeyId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *PreKey) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.PublicKey, offset, err = fastpb.ReadBytes(buf, _type)
+	return offset, err
+}
+
+func (x *PreKey) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Signature, offset, err = fastpb.ReadBytes(buf, _type)
+	return offset, err
+}
+
+func (x *DeviceKeys) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_DeviceKeys[number], err)
+}
+
+func (x *DeviceKeys) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.DeviceId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *DeviceKeys) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.IdentityKey, offset, err = fastpb.ReadBytes(buf, _type)
+	return offset, err
+}
+
+func (x *DeviceKeys) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	var v PreKey
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.SignedPreKey = &v
+	return offset, nil
+}
+
+func (x *DeviceKeys) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	var v PreKey
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.OneTimePreKeys = append(x.OneTimePreKeys, &v)
+	return offset, nil
+}
+
+func (x *KeyRegisterRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_KeyRegisterRequest[number], err)
+}
+
+func (x *KeyRegisterRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *KeyRegisterRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *KeyRegisterRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	var v DeviceKeys
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Keys = &v
+	return offset, nil
+}
+
+func (x *KeyRegisterReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_KeyRegisterReply[number], err)
+}
+
+func (x *KeyRegisterReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.OneTimePreKeyCount, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *KeyFetchRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_KeyFetchRequest[number], err)
+}
+
+func (x *KeyFetchRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *KeyFetchRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *KeyFetchRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.TargetUserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *KeyFetchRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.DeviceId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *KeyFetchReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_KeyFetchReply[number], err)
+}
+
+func (x *KeyFetchReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *KeyFetchReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	var v DeviceKeys
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Devices = append(x.Devices, &v)
+	return offset, nil
+}
+
+func (x *PushRegisterRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 5:
+		offset, err = x.fastReadField5(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 6:
+		offset, err = x.fastReadField6(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_PushRegisterRequest[number], err)
+}
+
+func (x *PushRegisterRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *PushRegisterRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *PushRegisterRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Label, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *PushRegisterRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.Vendor, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *PushRegisterRequest) fastReadField5(buf []byte, _type int8) (offset int, err error) {
+	x.Token, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *PushRegisterRequest) fastReadField6(buf []byte, _type int8) (offset int, err error) {
+	x.Locale, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *PushRegisterReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+}
+
+func (x *QuietHours) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_QuietHours[number], err)
+}
+
+func (x *QuietHours) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Start, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *QuietHours) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.End, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *QuietHours) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Timezone, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ConvNotify) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ConvNotify[number], err)
+}
+
+func (x *ConvNotify) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.ConvId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ConvNotify) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Mute, offset, err = fastpb.ReadBool(buf, _type)
+	return offset, err
+}
+
+func (x *ConvNotify) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.MuteUntil, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ConvNotify) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.MentionOnly, offset, err = fastpb.ReadBool(buf, _type)
+	return offset, err
+}
+
+func (x *NotifySettings) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_NotifySettings[number], err)
+}
+
+func (x *NotifySettings) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	var v QuietHours
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.QuietHours = &v
+	return offset, nil
+}
+
+func (x *NotifySettings) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	var v ConvNotify
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Convs = append(x.Convs, &v)
+	return offset, nil
+}
+
+func (x *NotifySettings) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Version, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *NotifySettingRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_NotifySettingRequest[number], err)
+}
+
+func (x *NotifySettingRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *NotifySettingRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *NotifySettingRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	var v QuietHours
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.QuietHours = &v
+	return offset, nil
+}
+
+func (x *NotifySettingRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	var v ConvNotify
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Convs = append(x.Convs, &v)
+	return offset, nil
+}
+
+func (x *NotifySettingReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_NotifySettingReply[number], err)
+}
+
+func (x *NotifySettingReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	var v NotifySettings
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Settings = &v
+	return offset, nil
+}
+
+func (x *ScheduledMessage) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ScheduledMessage[number], err)
+}
+
+func (x *ScheduledMessage) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	var v Message
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Message = &v
+	return offset, nil
+}
+
+func (x *ScheduledMessage) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.SendTime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduledMessage) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.CreateTime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ScheduleRequest[number], err)
+}
+
+func (x *ScheduleRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	var v Message
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Message = &v
+	return offset, nil
+}
+
+func (x *ScheduleRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.SendTime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ScheduleReply[number], err)
+}
+
+func (x *ScheduleReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.SendTime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleListRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ScheduleListRequest[number], err)
+}
+
+func (x *ScheduleListRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleListRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleListReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ScheduleListReply[number], err)
+}
+
+func (x *ScheduleListReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	var v ScheduledMessage
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Messages = append(x.Messages, &v)
+	return offset, nil
+}
+
+func (x *ScheduleCancelRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ScheduleCancelRequest[number], err)
+}
+
+func (x *ScheduleCancelRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleCancelRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleCancelRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *ScheduleCancelReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_ScheduleCancelReply[number], err)
+}
+
+func (x *ScheduleCancelReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.Canceled, offset, err = fastpb.ReadBool(buf, _type)
+	return offset, err
+}
+
+func (x *RecallRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_RecallRequest[number], err)
+}
+
+func (x *RecallRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *RecallRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *RecallRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *RecallReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_RecallReply[number], err)
+}
+
+func (x *RecallReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *Packet) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	return offset
+}
+
+func (x *Packet) fastWriteField1(buf []byte) (offset int) {
+	if x.Type == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 1, x.GetType())
+	return offset
+}
+
+func (x *Packet) fastWriteField2(buf []byte) (offset int) {
+	if x.GetHeartbeat() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 2, x.GetHeartbeat())
+	return offset
+}
+
+func (x *Packet) fastWriteField3(buf []byte) (offset int) {
+	if x.GetCommand() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 3, x.GetCommand())
+	return offset
+}
+
+func (x *Packet) fastWriteField4(buf []byte) (offset int) {
+	if x.GetMessage() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 4, x.GetMessage())
+	return offset
+}
+
+func (x *Packet) fastWriteField5(buf []byte) (offset int) {
+	if x.GetAck() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 5, x.GetAck())
+	return offset
+}
+
+func (x *Heartbeat) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	return offset
+}
+
+func (x *Heartbeat) fastWriteField1(buf []byte) (offset int) {
+	if x.Value == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 1, x.GetValue())
+	return offset
+}
+
+func (x *Ack) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *Ack) fastWriteField1(buf []byte) (offset int) {
+	if len(x.MessageIds) == 0 {
+		return offset
+	}
+	for i := range x.GetMessageIds() {
+		offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageIds()[i])
+	}
+	return offset
+}
+
+func (x *Ack) fastWriteField2(buf []byte) (offset int) {
+	if x.Convs == nil {
+		return offset
+	}
+	for i := range x.GetConvs() {
+		offset += fastpb.WriteMessage(buf[offset:], 2, x.GetConvs()[i])
+	}
+	return offset
+}
+
+func (x *ConvAck) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *ConvAck) fastWriteField1(buf []byte) (offset int) {
+	if x.ConvId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetConvId())
+	return offset
+}
+
+func (x *ConvAck) fastWriteField2(buf []byte) (offset int) {
+	if x.Sequence == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetSequence())
+	return offset
+}
+
+func (x *ConvAck) fastWriteField3(buf []byte) (offset int) {
+	if x.From == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetFrom())
+	return offset
+}
+
+func (x *Command) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	offset += x.fastWriteField6(buf[offset:])
+	offset += x.fastWriteField7(buf[offset:])
+	offset += x.fastWriteField8(buf[offset:])
+	offset += x.fastWriteField9(buf[offset:])
+	offset += x.fastWriteField10(buf[offset:])
+	offset += x.fastWriteField11(buf[offset:])
+	offset += x.fastWriteField12(buf[offset:])
+	offset += x.fastWriteField13(buf[offset:])
+	offset += x.fastWriteField14(buf[offset:])
+	offset += x.fastWriteField15(buf[offset:])
+	offset += x.fastWriteField16(buf[offset:])
+	offset += x.fastWriteField17(buf[offset:])
+	offset += x.fastWriteField19(buf[offset:])
+	offset += x.fastWriteField20(buf[offset:])
+	offset += x.fastWriteField21(buf[offset:])
+	offset += x.fastWriteField22(buf[offset:])
+	offset += x.fastWriteField23(buf[offset:])
+	offset += x.fastWriteField24(buf[offset:])
+	offset += x.fastWriteField25(buf[offset:])
+	offset += x.fastWriteField26(buf[offset:])
+	offset += x.fastWriteField27(buf[offset:])
+	offset += x.fastWriteField28(buf[offset:])
+	offset += x.fastWriteField29(buf[offset:])
+	offset += x.fastWriteField30(buf[offset:])
+	return offset
+}
+
+func (x *Command) fastWriteField1(buf []byte) (offset int) {
+	if x.CommandId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetCommandId())
+	return offset
+}
+
+func (x *Command) fastWriteField2(buf []byte) (offset int) {
+	if x.CommandType == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetCommandType())
+	return offset
+}
+
+func (x *Command) fastWriteField3(buf []byte) (offset int) {
+	if x.Code == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetCode())
+	return offset
+}
+
+func (x *Command) fastWriteField4(buf []byte) (offset int) {
+	if x.Message == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetMessage())
+	return offset
+}
+
+func (x *Command) fastWriteField5(buf []byte) (offset int) {
+	if x.GetLoginRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 5, x.GetLoginRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField6(buf []byte) (offset int) {
+	if x.GetLogoutRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 6, x.GetLogoutRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField7(buf []byte) (offset int) {
+	if x.GetLoginReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 7, x.GetLoginReply())
+	return offset
+}
+
+func (x *Command) fastWriteField8(buf []byte) (offset int) {
+	if x.GetLogoutReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 8, x.GetLogoutReply())
+	return offset
+}
+
+func (x *Command) fastWriteField9(buf []byte) (offset int) {
+	if x.GetReadRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 9, x.GetReadRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField10(buf []byte) (offset int) {
+	if x.GetReadReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 10, x.GetReadReply())
+	return offset
+}
+
+func (x *Command) fastWriteField11(buf []byte) (offset int) {
+	if x.GetReceiptRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 11, x.GetReceiptRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField12(buf []byte) (offset int) {
+	if x.GetReceiptReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 12, x.GetReceiptReply())
+	return offset
+}
+
+func (x *Command) fastWriteField13(buf []byte) (offset int) {
+	if x.GetKeyRegisterRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 13, x.GetKeyRegisterRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField14(buf []byte) (offset int) {
+	if x.GetKeyRegisterReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 14, x.GetKeyRegisterReply())
+	return offset
+}
+
+func (x *Command) fastWriteField15(buf []byte) (offset int) {
+	if x.GetKeyFetchRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 15, x.GetKeyFetchRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField16(buf []byte) (offset int) {
+	if x.GetKeyFetchReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 16, x.GetKeyFetchReply())
+	return offset
+}
+
+func (x *Command) fastWriteField17(buf []byte) (offset int) {
+	if x.GetReconnectRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 17, x.GetReconnectRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField19(buf []byte) (offset int) {
+	if x.GetPushRegisterRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 19, x.GetPushRegisterRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField20(buf []byte) (offset int) {
+	if x.GetPushRegisterReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 20, x.GetPushRegisterReply())
+	return offset
+}
+
+func (x *Command) fastWriteField21(buf []byte) (offset int) {
+	if x.GetNotifySettingRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 21, x.GetNotifySettingRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField22(buf []byte) (offset int) {
+	if x.GetNotifySettingReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 22, x.GetNotifySettingReply())
+	return offset
+}
+
+func (x *Command) fastWriteField23(buf []byte) (offset int) {
+	if x.GetScheduleRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 23, x.GetScheduleRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField24(buf []byte) (offset int) {
+	if x.GetScheduleReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 24, x.GetScheduleReply())
+	return offset
+}
+
+func (x *Command) fastWriteField25(buf []byte) (offset int) {
+	if x.GetScheduleListRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 25, x.GetScheduleListRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField26(buf []byte) (offset int) {
+	if x.GetScheduleListReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 26, x.GetScheduleListReply())
+	return offset
+}
+
+func (x *Command) fastWriteField27(buf []byte) (offset int) {
+	if x.GetScheduleCancelRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 27, x.GetScheduleCancelRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField28(buf []byte) (offset int) {
+	if x.GetScheduleCancelReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 28, x.GetScheduleCancelReply())
+	return offset
+}
+
+func (x *Command) fastWriteField29(buf []byte) (offset int) {
+	if x.GetRecallRequest() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 29, x.GetRecallRequest())
+	return offset
+}
+
+func (x *Command) fastWriteField30(buf []byte) (offset int) {
+	if x.GetRecallReply() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 30, x.GetRecallReply())
+	return offset
+}
+
+func (x *Message) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	offset += x.fastWriteField6(buf[offset:])
+	offset += x.fastWriteField7(buf[offset:])
+	offset += x.fastWriteField8(buf[offset:])
+	offset += x.fastWriteField9(buf[offset:])
+	offset += x.fastWriteField10(buf[offset:])
+	offset += x.fastWriteField11(buf[offset:])
+	offset += x.fastWriteField12(buf[offset:])
+	offset += x.fastWriteField13(buf[offset:])
+	offset += x.fastWriteField14(buf[offset:])
+	offset += x.fastWriteField15(buf[offset:])
+	offset += x.fastWriteField16(buf[offset:])
+	offset += x.fastWriteField17(buf[offset:])
+	offset += x.fastWriteField18(buf[offset:])
+	offset += x.fastWriteField19(buf[offset:])
+	offset += x.fastWriteField20(buf[offset:])
+	offset += x.fastWriteField21(buf[offset:])
+	offset += x.fastWriteField22(buf[offset:])
+	offset += x.fastWriteField23(buf[offset:])
+	offset += x.fastWriteField24(buf[offset:])
+	offset += x.fastWriteField25(buf[offset:])
+	offset += x.fastWriteField26(buf[offset:])
+	offset += x.fastWriteField27(buf[offset:])
+	offset += x.fastWriteField28(buf[offset:])
+	offset += x.fastWriteField29(buf[offset:])
+	offset += x.fastWriteField30(buf[offset:])
+	offset += x.fastWriteField31(buf[offset:])
+	return offset
+}
+
+func (x *Message) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *Message) fastWriteField2(buf []byte) (offset int) {
+	if x.MessageType == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetMessageType())
+	return offset
+}
+
+func (x *Message) fastWriteField3(buf []byte) (offset int) {
+	if x.NeedAck == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetNeedAck())
+	return offset
+}
+
+func (x *Message) fastWriteField4(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetAppId())
+	return offset
+}
+
+func (x *Message) fastWriteField5(buf []byte) (offset int) {
+	if x.Flow == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 5, x.GetFlow())
+	return offset
+}
+
+func (x *Message) fastWriteField6(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 6, x.GetUserId())
+	return offset
+}
+
+func (x *Message) fastWriteField7(buf []byte) (offset int) {
+	if x.ConvId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 7, x.GetConvId())
+	return offset
+}
+
+func (x *Message) fastWriteField8(buf []byte) (offset int) {
+	if x.To == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 8, x.GetTo())
+	return offset
+}
+
+func (x *Message) fastWriteField9(buf []byte) (offset int) {
+	if x.GroupId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 9, x.GetGroupId())
+	return offset
+}
+
+func (x *Message) fastWriteField10(buf []byte) (offset int) {
+	if x.Sequence == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 10, x.GetSequence())
+	return offset
+}
+
+func (x *Message) fastWriteField11(buf []byte) (offset int) {
+	if x.CTime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 11, x.GetCTime())
+	return offset
+}
+
+func (x *Message) fastWriteField12(buf []byte) (offset int) {
+	if x.STime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 12, x.GetSTime())
+	return offset
+}
+
+func (x *Message) fastWriteField13(buf []byte) (offset int) {
+	if x.At == nil {
+		return offset
+	}
+	for i := range x.GetAt() {
+		offset += fastpb.WriteMessage(buf[offset:], 13, x.GetAt()[i])
+	}
+	return offset
+}
+
+func (x *Message) fastWriteField14(buf []byte) (offset int) {
+	if x.Refer == nil {
+		return offset
+	}
+	for i := range x.GetRefer() {
+		offset += fastpb.WriteMessage(buf[offset:], 14, x.GetRefer()[i])
+	}
+	return offset
+}
+
+func (x *Message) fastWriteField15(buf []byte) (offset int) {
+	if x.Code == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 15, x.GetCode())
+	return offset
+}
+
+func (x *Message) fastWriteField16(buf []byte) (offset int) {
+	if x.Message == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 16, x.GetMessage())
+	return offset
+}
+
+func (x *Message) fastWriteField17(buf []byte) (offset int) {
+	if x.GetText() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 17, x.GetText())
+	return offset
+}
+
+func (x *Message) fastWriteField18(buf []byte) (offset int) {
+	if x.GetImage() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 18, x.GetImage())
+	return offset
+}
+
+func (x *Message) fastWriteField19(buf []byte) (offset int) {
+	if x.GetAudio() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 19, x.GetAudio())
+	return offset
+}
+
+func (x *Message) fastWriteField20(buf []byte) (offset int) {
+	if x.GetVideo() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 20, x.GetVideo())
+	return offset
+}
+
+func (x *Message) fastWriteField21(buf []byte) (offset int) {
+	if x.GetReceipt() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 21, x.GetReceipt())
+	return offset
+}
+
+func (x *Message) fastWriteField22(buf []byte) (offset int) {
+	if x.GetFile() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 22, x.GetFile())
+	return offset
+}
+
+func (x *Message) fastWriteField23(buf []byte) (offset int) {
+	if x.GetLocation() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 23, x.GetLocation())
+	return offset
+}
+
+func (x *Message) fastWriteField24(buf []byte) (offset int) {
+	if x.GetCard() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 24, x.GetCard())
+	return offset
+}
+
+func (x *Message) fastWriteField25(buf []byte) (offset int) {
+	if x.GetCustom() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 25, x.GetCustom())
+	return offset
+}
+
+func (x *Message) fastWriteField26(buf []byte) (offset int) {
+	if x.GetMerged() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 26, x.GetMerged())
+	return offset
+}
+
+func (x *Message) fastWriteField27(buf []byte) (offset int) {
+	if x.GetEnvelope() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 27, x.GetEnvelope())
+	return offset
+}
+
+func (x *Message) fastWriteField28(buf []byte) (offset int) {
+	if x.ClientMsgId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 28, x.GetClientMsgId())
+	return offset
+}
+
+func (x *Message) fastWriteField29(buf []byte) (offset int) {
+	if x.GetStatus() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 29, x.GetStatus())
+	return offset
+}
+
+func (x *Message) fastWriteField30(buf []byte) (offset int) {
+	if x.GetNotifySettings() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 30, x.GetNotifySettings())
+	return offset
+}
+
+func (x *Message) fastWriteField31(buf []byte) (offset int) {
+	if !x.Mentioned {
+		return offset
+	}
+	offset += fastpb.WriteBool(buf[offset:], 31, x.GetMentioned())
+	return offset
+}
+
+func (x *At) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *At) fastWriteField1(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 1, x.GetUserId())
+	return offset
+}
+
+func (x *At) fastWriteField2(buf []byte) (offset int) {
+	if x.Name == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetName())
+	return offset
+}
+
+func (x *At) fastWriteField3(buf []byte) (offset int) {
+	if x.Avatar == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetAvatar())
+	return offset
+}
+
+func (x *Refer) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	offset += x.fastWriteField6(buf[offset:])
+	offset += x.fastWriteField7(buf[offset:])
+	offset += x.fastWriteField8(buf[offset:])
+	offset += x.fastWriteField9(buf[offset:])
+	offset += x.fastWriteField10(buf[offset:])
+	offset += x.fastWriteField11(buf[offset:])
+	offset += x.fastWriteField12(buf[offset:])
+	offset += x.fastWriteField13(buf[offset:])
+	offset += x.fastWriteField14(buf[offset:])
+	offset += x.fastWriteField15(buf[offset:])
+	return offset
+}
+
+func (x *Refer) fastWriteField1(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 1, x.GetUserId())
+	return offset
+}
+
+func (x *Refer) fastWriteField2(buf []byte) (offset int) {
+	if x.Name == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetName())
+	return offset
+}
+
+func (x *Refer) fastWriteField3(buf []byte) (offset int) {
+	if x.Avatar == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetAvatar())
+	return offset
+}
+
+func (x *Refer) fastWriteField4(buf []byte) (offset int) {
+	if x.CType == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetCType())
+	return offset
+}
+
+func (x *Refer) fastWriteField5(buf []byte) (offset int) {
+	if x.GetText() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 5, x.GetText())
+	return offset
+}
+
+func (x *Refer) fastWriteField6(buf []byte) (offset int) {
+	if x.GetImage() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 6, x.GetImage())
+	return offset
+}
+
+func (x *Refer) fastWriteField7(buf []byte) (offset int) {
+	if x.GetAudio() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 7, x.GetAudio())
+	return offset
+}
+
+func (x *Refer) fastWriteField8(buf []byte) (offset int) {
+	if x.GetVideo() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 8, x.GetVideo())
+	return offset
+}
+
+func (x *Refer) fastWriteField9(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 9, x.GetMessageId())
+	return offset
+}
+
+func (x *Refer) fastWriteField10(buf []byte) (offset int) {
+	if !x.Recalled {
+		return offset
+	}
+	offset += fastpb.WriteBool(buf[offset:], 10, x.GetRecalled())
+	return offset
+}
+
+func (x *Refer) fastWriteField11(buf []byte) (offset int) {
+	if x.GetFile() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 11, x.GetFile())
+	return offset
+}
+
+func (x *Refer) fastWriteField12(buf []byte) (offset int) {
+	if x.GetLocation() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 12, x.GetLocation())
+	return offset
+}
+
+func (x *Refer) fastWriteField13(buf []byte) (offset int) {
+	if x.GetCard() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 13, x.GetCard())
+	return offset
+}
+
+func (x *Refer) fastWriteField14(buf []byte) (offset int) {
+	if x.GetCustom() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 14, x.GetCustom())
+	return offset
+}
+
+func (x *Refer) fastWriteField15(buf []byte) (offset int) {
+	if x.GetMerged() == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 15, x.GetMerged())
+	return offset
+}
+
+func (x *Text) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	return offset
+}
+
+func (x *Text) fastWriteField1(buf []byte) (offset int) {
+	if x.Text == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetText())
+	return offset
+}
+
+func (x *Image) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *Image) fastWriteField1(buf []byte) (offset int) {
+	if x.Url == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetUrl())
+	return offset
+}
+
+func (x *Image) fastWriteField2(buf []byte) (offset int) {
+	if x.Width == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 2, x.GetWidth())
+	return offset
+}
+
+func (x *Image) fastWriteField3(buf []byte) (offset int) {
+	if x.Height == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetHeight())
+	return offset
+}
+
+func (x *Audio) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *Audio) fastWriteField1(buf []byte) (offset int) {
+	if x.Url == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetUrl())
+	return offset
+}
+
+func (x *Audio) fastWriteField2(buf []byte) (offset int) {
+	if x.Length == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 2, x.GetLength())
+	return offset
+}
+
+func (x *Video) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	return offset
+}
+
+func (x *Video) fastWriteField1(buf []byte) (offset int) {
+	if x.Url == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetUrl())
+	return offset
+}
+
+func (x *Video) fastWriteField2(buf []byte) (offset int) {
+	if x.Cover == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetCover())
+	return offset
+}
+
+func (x *Video) fastWriteField3(buf []byte) (offset int) {
+	if x.Length == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetLength())
+	return offset
+}
+
+func (x *Video) fastWriteField4(buf []byte) (offset int) {
+	if x.Width == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 4, x.GetWidth())
+	return offset
+}
+
+func (x *Video) fastWriteField5(buf []byte) (offset int) {
+	if x.Height == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 5, x.GetHeight())
+	return offset
+}
+
+func (x *File) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *File) fastWriteField1(buf []byte) (offset int) {
+	if x.Url == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetUrl())
+	return offset
+}
+
+func (x *File) fastWriteField2(buf []byte) (offset int) {
+	if x.Name == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetName())
+	return offset
+}
+
+func (x *File) fastWriteField3(buf []byte) (offset int) {
+	if x.FileSize == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetFileSize())
+	return offset
+}
+
+func (x *File) fastWriteField4(buf []byte) (offset int) {
+	if x.Mime == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetMime())
+	return offset
+}
+
+func (x *Location) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *Location) fastWriteField1(buf []byte) (offset int) {
+	if x.Lat == 0 {
+		return offset
+	}
+	offset += fastpb.WriteDouble(buf[offset:], 1, x.GetLat())
+	return offset
+}
+
+func (x *Location) fastWriteField2(buf []byte) (offset int) {
+	if x.Lng == 0 {
+		return offset
+	}
+	offset += fastpb.WriteDouble(buf[offset:], 2, x.GetLng())
+	return offset
+}
+
+func (x *Location) fastWriteField3(buf []byte) (offset int) {
+	if x.Title == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetTitle())
+	return offset
+}
+
+func (x *Card) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *Card) fastWriteField1(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 1, x.GetUserId())
+	return offset
+}
+
+func (x *Card) fastWriteField2(buf []byte) (offset int) {
+	if x.Name == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetName())
+	return offset
+}
+
+func (x *Card) fastWriteField3(buf []byte) (offset int) {
+	if x.Avatar == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetAvatar())
+	return offset
+}
+
+func (x *Custom) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *Custom) fastWriteField1(buf []byte) (offset int) {
+	if x.Type == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetType())
+	return offset
+}
+
+func (x *Custom) fastWriteField2(buf []byte) (offset int) {
+	if x.Payload == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetPayload())
+	return offset
+}
+
+func (x *Merged) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *Merged) fastWriteField1(buf []byte) (offset int) {
+	if x.Title == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetTitle())
+	return offset
+}
+
+func (x *Merged) fastWriteField2(buf []byte) (offset int) {
+	if x.Items == nil {
+		return offset
+	}
+	for i := range x.GetItems() {
+		offset += fastpb.WriteMessage(buf[offset:], 2, x.GetItems()[i])
+	}
+	return offset
+}
+
+func (x *MergedItem) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	offset += x.fastWriteField6(buf[offset:])
+	return offset
+}
+
+func (x *MergedItem) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *MergedItem) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *MergedItem) fastWriteField3(buf []byte) (offset int) {
+	if x.Name == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetName())
+	return offset
+}
+
+func (x *MergedItem) fastWriteField4(buf []byte) (offset int) {
+	if x.MessageType == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetMessageType())
+	return offset
+}
+
+func (x *MergedItem) fastWriteField5(buf []byte) (offset int) {
+	if x.Summary == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 5, x.GetSummary())
+	return offset
+}
+
+func (x *MergedItem) fastWriteField6(buf []byte) (offset int) {
+	if x.CTime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 6, x.GetCTime())
+	return offset
+}
+
+func (x *Envelope) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *Envelope) fastWriteField1(buf []byte) (offset int) {
+	if len(x.Ciphertext) == 0 {
+		return offset
+	}
+	offset += fastpb.WriteBytes(buf[offset:], 1, x.GetCiphertext())
+	return offset
+}
+
+func (x *Envelope) fastWriteField2(buf []byte) (offset int) {
+	if x.KeyId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetKeyId())
+	return offset
+}
+
+func (x *Envelope) fastWriteField3(buf []byte) (offset int) {
+	if x.DeviceId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetDeviceId())
+	return offset
+}
+
+func (x *Status) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	offset += x.fastWriteField6(buf[offset:])
+	return offset
+}
+
+func (x *Status) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *Status) fastWriteField2(buf []byte) (offset int) {
+	if x.ConvId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetConvId())
+	return offset
+}
+
+func (x *Status) fastWriteField3(buf []byte) (offset int) {
+	if x.Sequence == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetSequence())
+	return offset
+}
+
+func (x *Status) fastWriteField4(buf []byte) (offset int) {
+	if x.Status == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetStatus())
+	return offset
+}
+
+func (x *Status) fastWriteField5(buf []byte) (offset int) {
+	if x.STime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 5, x.GetSTime())
+	return offset
+}
+
+func (x *Status) fastWriteField6(buf []byte) (offset int) {
+	if x.Code == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 6, x.GetCode())
+	return offset
+}
+
+func (x *Receipt) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *Receipt) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *Receipt) fastWriteField2(buf []byte) (offset int) {
+	if x.GroupId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetGroupId())
+	return offset
+}
+
+func (x *Receipt) fastWriteField3(buf []byte) (offset int) {
+	if x.ReadCount == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetReadCount())
+	return offset
+}
+
+func (x *Receipt) fastWriteField4(buf []byte) (offset int) {
+	if x.MemberCount == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 4, x.GetMemberCount())
+	return offset
+}
+
+func (x *LoginRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	offset += x.fastWriteField6(buf[offset:])
+	return offset
+}
+
+func (x *LoginRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *LoginRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserSig == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetUserSig())
+	return offset
+}
+
+func (x *LoginRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.Version == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetVersion())
+	return offset
+}
+
+func (x *LoginRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.Os == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetOs())
+	return offset
+}
+
+func (x *LoginRequest) fastWriteField5(buf []byte) (offset int) {
+	if x.DeviceId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 5, x.GetDeviceId())
+	return offset
+}
+
+func (x *LoginRequest) fastWriteField6(buf []byte) (offset int) {
+	if len(x.Compressions) == 0 {
+		return offset
+	}
+	for i := range x.GetCompressions() {
+		offset += fastpb.WriteString(buf[offset:], 6, x.GetCompressions()[i])
+	}
+	return offset
+}
+
+func (x *LoginReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *LoginReply) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *LoginReply) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *LoginReply) fastWriteField3(buf []byte) (offset int) {
+	if x.Compression == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetCompression())
+	return offset
+}
+
+func (x *ReconnectRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *ReconnectRequest) fastWriteField1(buf []byte) (offset int) {
+	if len(x.Addrs) == 0 {
+		return offset
+	}
+	for i := range x.GetAddrs() {
+		offset += fastpb.WriteString(buf[offset:], 1, x.GetAddrs()[i])
+	}
+	return offset
+}
+
+func (x *ReconnectRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.Reason == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetReason())
+	return offset
+}
+
+func (x *LogoutRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *LogoutRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *LogoutRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *LogoutRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.Os == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetOs())
+	return offset
+}
+
+func (x *LogoutRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.DeviceId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetDeviceId())
+	return offset
+}
+
+func (x *LogoutReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	return offset
+}
+
+func (x *ReadRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	return offset
+}
+
+func (x *ReadRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *ReadRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *ReadRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.ConvId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetConvId())
+	return offset
+}
+
+func (x *ReadRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.GroupId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 4, x.GetGroupId())
+	return offset
+}
+
+func (x *ReadRequest) fastWriteField5(buf []byte) (offset int) {
+	if x.Sequence == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 5, x.GetSequence())
+	return offset
+}
+
+func (x *ReadReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *ReadReply) fastWriteField1(buf []byte) (offset int) {
+	if x.Sequence == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 1, x.GetSequence())
+	return offset
+}
+
+func (x *ReadReply) fastWriteField2(buf []byte) (offset int) {
+	if x.Mention == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetMention())
+	return offset
+}
+
+func (x *ReceiptRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	return offset
+}
+
+func (x *ReceiptRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *ReceiptRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *ReceiptRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.GroupId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetGroupId())
+	return offset
+}
+
+func (x *ReceiptRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetMessageId())
+	return offset
+}
+
+func (x *ReceiptRequest) fastWriteField5(buf []byte) (offset int) {
+	if x.Sequence == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 5, x.GetSequence())
+	return offset
+}
+
+func (x *ReceiptReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *ReceiptReply) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *ReceiptReply) fastWriteField2(buf []byte) (offset int) {
+	if len(x.ReadUserIds) == 0 {
+		return offset
+	}
+	offset += fastpb.WriteListPacked(buf[offset:], 2, len(x.GetReadUserIds()),
+		func(buf []byte, numTagOrKey, numIdxOrVal int32) int {
+			offset := 0
+			offset += fastpb.WriteInt64(buf[offset:], numTagOrKey, x.GetReadUserIds()[numIdxOrVal])
+			return offset
+		})
+	return offset
+}
+
+func (x *ReceiptReply) fastWriteField3(buf []byte) (offset int) {
+	if len(x.UnreadUserIds) == 0 {
+		return offset
+	}
+	offset += fastpb.WriteListPacked(buf[offset:], 3, len(x.GetUnreadUserIds()),
+		func(buf []byte, numTagOrKey, numIdxOrVal int32) int {
+			offset := 0
+			offset += fastpb.WriteInt64(buf[offset:], numTagOrKey, x.GetUnreadUserIds()[numIdxOrVal])
+			return offset
+		})
+	return offset
+}
+
+func (x *PreKey) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *PreKey) fastWriteField1(buf []byte) (offset int) {
+	if x.KeyId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetKeyId())
+	return offset
+}
+
+func (x *PreKey) fastWriteField2(buf []byte) (offset int) {
+	if len(x.PublicKey) == 0 {
+		return offset
+	}
+	offset += fastpb.WriteBytes(buf[offset:], 2, x.GetPublicKey())
+	return offset
+}
+
+func (x *PreKey) fastWriteField3(buf []byte) (offset int) {
+	if len(x.Signature) == 0 {
+		return offset
+	}
+	offset += fastpb.WriteBytes(buf[offset:], 3, x.GetSignature())
+	return offset
+}
+
+func (x *DeviceKeys) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *DeviceKeys) fastWriteField1(buf []byte) (offset int) {
+	if x.DeviceId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetDeviceId())
+	return offset
+}
+
+func (x *DeviceKeys) fastWriteField2(buf []byte) (offset int) {
+	if len(x.IdentityKey) == 0 {
+		return offset
+	}
+	offset += fastpb.WriteBytes(buf[offset:], 2, x.GetIdentityKey())
+	return offset
+}
+
+func (x *DeviceKeys) fastWriteField3(buf []byte) (offset int) {
+	if x.SignedPreKey == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 3, x.GetSignedPreKey())
+	return offset
+}
+
+func (x *DeviceKeys) fastWriteField4(buf []byte) (offset int) {
+	if x.OneTimePreKeys == nil {
+		return offset
+	}
+	for i := range x.GetOneTimePreKeys() {
+		offset += fastpb.WriteMessage(buf[offset:], 4, x.GetOneTimePreKeys()[i])
+	}
+	return offset
+}
+
+func (x *KeyRegisterRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *KeyRegisterRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *KeyRegisterRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *KeyRegisterRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.Keys == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 3, x.GetKeys())
+	return offset
+}
+
+func (x *KeyRegisterReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	return offset
+}
+
+func (x *KeyRegisterReply) fastWriteField1(buf []byte) (offset int) {
+	if x.OneTimePreKeyCount == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 1, x.GetOneTimePreKeyCount())
+	return offset
+}
+
+func (x *KeyFetchRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *KeyFetchRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *KeyFetchRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *KeyFetchRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.TargetUserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetTargetUserId())
+	return offset
+}
+
+func (x *KeyFetchRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.DeviceId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetDeviceId())
+	return offset
+}
+
+func (x *KeyFetchReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *KeyFetchReply) fastWriteField1(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 1, x.GetUserId())
+	return offset
+}
+
+func (x *KeyFetchReply) fastWriteField2(buf []byte) (offset int) {
+	if x.Devices == nil {
+		return offset
+	}
+	for i := range x.GetDevices() {
+		offset += fastpb.WriteMessage(buf[offset:], 2, x.GetDevices()[i])
+	}
+	return offset
+}
+
+func (x *PushRegisterRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	offset += x.fastWriteField5(buf[offset:])
+	offset += x.fastWriteField6(buf[offset:])
+	return offset
+}
+
+func (x *PushRegisterRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *PushRegisterRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *PushRegisterRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.Label == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetLabel())
+	return offset
+}
+
+func (x *PushRegisterRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.Vendor == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 4, x.GetVendor())
+	return offset
+}
+
+func (x *PushRegisterRequest) fastWriteField5(buf []byte) (offset int) {
+	if x.Token == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 5, x.GetToken())
+	return offset
+}
+
+func (x *PushRegisterRequest) fastWriteField6(buf []byte) (offset int) {
+	if x.Locale == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 6, x.GetLocale())
+	return offset
+}
+
+func (x *PushRegisterReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	return offset
+}
+
+func (x *QuietHours) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *QuietHours) fastWriteField1(buf []byte) (offset int) {
+	if x.Start == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetStart())
+	return offset
+}
+
+func (x *QuietHours) fastWriteField2(buf []byte) (offset int) {
+	if x.End == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 2, x.GetEnd())
+	return offset
+}
+
+func (x *QuietHours) fastWriteField3(buf []byte) (offset int) {
+	if x.Timezone == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetTimezone())
+	return offset
+}
+
+func (x *ConvNotify) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *ConvNotify) fastWriteField1(buf []byte) (offset int) {
+	if x.ConvId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetConvId())
+	return offset
+}
+
+func (x *ConvNotify) fastWriteField2(buf []byte) (offset int) {
+	if !x.Mute {
+		return offset
+	}
+	offset += fastpb.WriteBool(buf[offset:], 2, x.GetMute())
+	return offset
+}
+
+func (x *ConvNotify) fastWriteField3(buf []byte) (offset int) {
+	if x.MuteUntil == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetMuteUntil())
+	return offset
+}
+
+func (x *ConvNotify) fastWriteField4(buf []byte) (offset int) {
+	if !x.MentionOnly {
+		return offset
+	}
+	offset += fastpb.WriteBool(buf[offset:], 4, x.GetMentionOnly())
+	return offset
+}
+
+func (x *NotifySettings) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *NotifySettings) fastWriteField1(buf []byte) (offset int) {
+	if x.QuietHours == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 1, x.GetQuietHours())
+	return offset
+}
+
+func (x *NotifySettings) fastWriteField2(buf []byte) (offset int) {
+	if x.Convs == nil {
+		return offset
+	}
+	for i := range x.GetConvs() {
+		offset += fastpb.WriteMessage(buf[offset:], 2, x.GetConvs()[i])
+	}
+	return offset
+}
+
+func (x *NotifySettings) fastWriteField3(buf []byte) (offset int) {
+	if x.Version == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetVersion())
+	return offset
+}
+
+func (x *NotifySettingRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *NotifySettingRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *NotifySettingRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *NotifySettingRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.QuietHours == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 3, x.GetQuietHours())
+	return offset
+}
+
+func (x *NotifySettingRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.Convs == nil {
+		return offset
+	}
+	for i := range x.GetConvs() {
+		offset += fastpb.WriteMessage(buf[offset:], 4, x.GetConvs()[i])
+	}
+	return offset
+}
+
+func (x *NotifySettingReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	return offset
+}
+
+func (x *NotifySettingReply) fastWriteField1(buf []byte) (offset int) {
+	if x.Settings == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 1, x.GetSettings())
+	return offset
+}
+
+func (x *ScheduledMessage) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *ScheduledMessage) fastWriteField1(buf []byte) (offset int) {
+	if x.Message == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 1, x.GetMessage())
+	return offset
+}
+
+func (x *ScheduledMessage) fastWriteField2(buf []byte) (offset int) {
+	if x.SendTime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetSendTime())
+	return offset
+}
+
+func (x *ScheduledMessage) fastWriteField3(buf []byte) (offset int) {
+	if x.CreateTime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetCreateTime())
+	return offset
+}
+
+func (x *ScheduleRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *ScheduleRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *ScheduleRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *ScheduleRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.Message == nil {
+		return offset
+	}
+	offset += fastpb.WriteMessage(buf[offset:], 3, x.GetMessage())
+	return offset
+}
+
+func (x *ScheduleRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.SendTime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 4, x.GetSendTime())
+	return offset
+}
+
+func (x *ScheduleReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *ScheduleReply) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *ScheduleReply) fastWriteField2(buf []byte) (offset int) {
+	if x.SendTime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetSendTime())
+	return offset
+}
+
+func (x *ScheduleListRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	return offset
+}
+
+func (x *ScheduleListRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *ScheduleListRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *ScheduleListReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	return offset
+}
+
+func (x *ScheduleListReply) fastWriteField1(buf []byte) (offset int) {
+	if x.Messages == nil {
+		return offset
+	}
+	for i := range x.GetMessages() {
+		offset += fastpb.WriteMessage(buf[offset:], 1, x.GetMessages()[i])
+	}
+	return offset
+}
+
+func (x *ScheduleCancelRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *ScheduleCancelRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *ScheduleCancelRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *ScheduleCancelRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetMessageId())
+	return offset
+}
+
+func (x *ScheduleCancelReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	return offset
+}
+
+func (x *ScheduleCancelReply) fastWriteField1(buf []byte) (offset int) {
+	if !x.Canceled {
+		return offset
+	}
+	offset += fastpb.WriteBool(buf[offset:], 1, x.GetCanceled())
+	return offset
+}
+
+func (x *RecallRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *RecallRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *RecallRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *RecallRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 3, x.GetMessageId())
+	return offset
+}
+
+func (x *RecallReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	return offset
+}
+
+func (x *RecallReply) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *Packet) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	return n
+}
+
+func (x *Packet) sizeField1() (n int) {
+	if x.Type == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(1, x.GetType())
+	return n
+}
+
+func (x *Packet) sizeField2() (n int) {
+	if x.GetHeartbeat() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(2, x.GetHeartbeat())
+	return n
+}
+
+func (x *Packet) sizeField3() (n int) {
+	if x.GetCommand() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(3, x.GetCommand())
+	return n
+}
+
+func (x *Packet) sizeField4() (n int) {
+	if x.GetMessage() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(4, x.GetMessage())
+	return n
+}
+
+func (x *Packet) sizeField5() (n int) {
+	if x.GetAck() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(5, x.GetAck())
+	return n
+}
+
+func (x *Heartbeat) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	return n
+}
+
+func (x *Heartbeat) sizeField1() (n int) {
+	if x.Value == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(1, x.GetValue())
+	return n
+}
+
+func (x *Ack) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	return n
+}
+
+func (x *Ack) sizeField1() (n int) {
+	if len(x.MessageIds) == 0 {
+		return n
+	}
+	for i := range x.GetMessageIds() {
+		n += fastpb.SizeString(1, x.GetMessageIds()[i])
+	}
+	return n
+}
+
+func (x *Ack) sizeField2() (n int) {
+	if x.Convs == nil {
+		return n
+	}
+	for i := range x.GetConvs() {
+		n += fastpb.SizeMessage(2, x.GetConvs()[i])
+	}
+	return n
+}
+
+func (x *ConvAck) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
+}
+
+func (x *ConvAck) sizeField1() (n int) {
+	if x.ConvId == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetConvId())
+	return n
+}
+
+func (x *ConvAck) sizeField2() (n int) {
+	if x.Sequence == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(2, x.GetSequence())
+	return n
+}
+
+func (x *ConvAck) sizeField3() (n int) {
+	if x.From == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(3, x.GetFrom())
+	return n
+}
+
+func (x *Command) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	n += x.sizeField6()
+	n += x.sizeField7()
+	n += x.sizeField8()
+	n += x.sizeField9()
+	n += x.sizeField10()
+	n += x.sizeField11()
+	n += x.sizeField12()
+	n += x.sizeField13()
+	n += x.sizeField14()
+	n += x.sizeField15()
+	n += x.sizeField16()
+	n += x.sizeField17()
+	n += x.sizeField19()
+	n += x.sizeField20()
+	n += x.sizeField21()
+	n += x.sizeField22()
+	n += x.sizeField23()
+	n += x.sizeField24()
+	n += x.sizeField25()
+	n += x.sizeField26()
+	n += x.sizeField27()
+	n += x.sizeField28()
+	n += x.sizeField29()
+	n += x.sizeField30()
+	return n
+}
+
+func (x *Command) sizeField1() (n int) {
+	if x.CommandId == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetCommandId())
+	return n
+}
+
+func (x *Command) sizeField2() (n int) {
+	if x.CommandType == "" {
+		return n
+	}
+	n += fastpb.SizeString(2, x.GetCommandType())
+	return n
+}
+
+func (x *Command) sizeField3() (n int) {
+	if x.Code == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(3, x.GetCode())
+	return n
+}
+
+func (x *Command) sizeField4() (n int) {
+	if x.Message == "" {
+		return n
+	}
+	n += fastpb.SizeString(4, x.GetMessage())
+	return n
+}
+
+func (x *Command) sizeField5() (n int) {
+	if x.GetLoginRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(5, x.GetLoginRequest())
+	return n
+}
+
+func (x *Command) sizeField6() (n int) {
+	if x.GetLogoutRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(6, x.GetLogoutRequest())
+	return n
+}
+
+func (x *Command) sizeField7() (n int) {
+	if x.GetLoginReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(7, x.GetLoginReply())
+	return n
+}
+
+func (x *Command) sizeField8() (n int) {
+	if x.GetLogoutReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(8, x.GetLogoutReply())
+	return n
+}
+
+func (x *Command) sizeField9() (n int) {
+	if x.GetReadRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(9, x.GetReadRequest())
+	return n
+}
+
+func (x *Command) sizeField10() (n int) {
+	if x.GetReadReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(10, x.GetReadReply())
+	return n
+}
+
+func (x *Command) sizeField11() (n int) {
+	if x.GetReceiptRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(11, x.GetReceiptRequest())
+	return n
+}
+
+func (x *Command) sizeField12() (n int) {
+	if x.GetReceiptReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(12, x.GetReceiptReply())
+	return n
+}
+
+func (x *Command) sizeField13() (n int) {
+	if x.GetKeyRegisterRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(13, x.GetKeyRegisterRequest())
+	return n
+}
+
+func (x *Command) sizeField14() (n int) {
+	if x.GetKeyRegisterReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(14, x.GetKeyRegisterReply())
+	return n
+}
+
+func (x *Command) sizeField15() (n int) {
+	if x.GetKeyFetchRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(15, x.GetKeyFetchRequest())
+	return n
+}
+
+func (x *Command) sizeField16() (n int) {
+	if x.GetKeyFetchReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(16, x.GetKeyFetchReply())
+	return n
+}
+
+func (x *Command) sizeField17() (n int) {
+	if x.GetReconnectRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(17, x.GetReconnectRequest())
+	return n
+}
+
+func (x *Command) sizeField19() (n int) {
+	if x.GetPushRegisterRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(19, x.GetPushRegisterRequest())
+	return n
+}
+
+func (x *Command) sizeField20() (n int) {
+	if x.GetPushRegisterReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(20, x.GetPushRegisterReply())
+	return n
+}
+
+func (x *Command) sizeField21() (n int) {
+	if x.GetNotifySettingRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(21, x.GetNotifySettingRequest())
+	return n
+}
+
+func (x *Command) sizeField22() (n int) {
+	if x.GetNotifySettingReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(22, x.GetNotifySettingReply())
+	return n
+}
+
+func (x *Command) sizeField23() (n int) {
+	if x.GetScheduleRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(23, x.GetScheduleRequest())
+	return n
+}
+
+func (x *Command) sizeField24() (n int) {
+	if x.GetScheduleReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(24, x.GetScheduleReply())
+	return n
+}
+
+func (x *Command) sizeField25() (n int) {
+	if x.GetScheduleListRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(25, x.GetScheduleListRequest())
+	return n
+}
+
+func (x *Command) sizeField26() (n int) {
+	if x.GetScheduleListReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(26, x.GetScheduleListReply())
+	return n
+}
+
+func (x *Command) sizeField27() (n int) {
+	if x.GetScheduleCancelRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(27, x.GetScheduleCancelRequest())
+	return n
+}
+
+func (x *Command) sizeField28() (n int) {
+	if x.GetScheduleCancelReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(28, x.GetScheduleCancelReply())
+	return n
+}
+
+func (x *Command) sizeField29() (n int) {
+	if x.GetRecallRequest() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(29, x.GetRecallRequest())
+	return n
+}
+
+func (x *Command) sizeField30() (n int) {
+	if x.GetRecallReply() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(30, x.GetRecallReply())
+	return n
+}
+
+func (x *Message) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	n += x.sizeField6()
+	n += x.sizeField7()
+	n += x.sizeField8()
+	n += x.sizeField9()
+	n += x.sizeField10()
+	n += x.sizeField11()
+	n += x.sizeField12()
+	n += x.sizeField13()
+	n += x.sizeField14()
+	n += x.sizeField15()
+	n += x.sizeField16()
+	n += x.sizeField17()
+	n += x.sizeField18()
+	n += x.sizeField19()
+	n += x.sizeField20()
+	n += x.sizeField21()
+	n += x.sizeField22()
+	n += x.sizeField23()
+	n += x.sizeField24()
+	n += x.sizeField25()
+	n += x.sizeField26()
+	n += x.sizeField27()
+	n += x.sizeField28()
+	n += x.sizeField29()
+	n += x.sizeField30()
+	n += x.sizeField31()
+	return n
+}
+
+func (x *Message) sizeField1() (n int) {
+	if x.MessageId == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetMessageId())
+	return n
+}
+
+func (x *Message) sizeField2() (n int) {
+	if x.MessageType == "" {
+		return n
+	}
+	n += fastpb.SizeString(2, x.GetMessageType())
+	return n
+}
+
+func (x *Message) sizeField3() (n int) {
+	if x.NeedAck == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(3, x.GetNeedAck())
+	return n
+}
+
+func (x *Message) sizeField4() (n int) {
+	if x.AppId == "" {
+		return n
+	}
+	n += fastpb.SizeString(4, x.GetAppId())
+	return n
+}
+
+func (x *Message) sizeField5() (n int) {
+	if x.Flow == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(5, x.GetFlow())
+	return n
+}
+
+func (x *Message) sizeField6() (n int) {
+	if x.UserId == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(6, x.GetUserId())
+	return n
+}
+
+func (x *Message) sizeField7() (n int) {
+	if x.ConvId == "" {
+		return n
+	}
+	n += fastpb.SizeString(7, x.GetConvId())
+	return n
+}
+
+func (x *Message) sizeField8() (n int) {
+	if x.To == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(8, x.GetTo())
+	return n
+}
+
+func (x *Message) sizeField9() (n int) {
+	if x.GroupId == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(9, x.GetGroupId())
+	return n
+}
+
+func (x *Message) sizeField10() (n int) {
+	if x.Sequence == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(10, x.GetSequence())
+	return n
+}
+
+func (x *Message) sizeField11() (n int) {
+	if x.CTime == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(11, x.GetCTime())
+	return n
+}
+
+func (x *Message) sizeField12() (n int) {
+	if x.STime == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(12, x.GetSTime())
+	return n
+}
+
+func (x *Message) sizeField13() (n int) {
+	if x.At == nil {
+		return n
+	}
+	for i := range x.GetAt() {
+		n += fastpb.SizeMessage(13, x.GetAt()[i])
+	}
+	return n
+}
+
+func (x *Message) sizeField14() (n int) {
+	if x.Refer == nil {
+		return n
+	}
+	for i := range x.GetRefer() {
+		n += fastpb.SizeMessage(14, x.GetRefer()[i])
+	}
+	return n
+}
+
+func (x *Message) sizeField15() (n int) {
+	if x.Code == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(15, x.GetCode())
+	return n
+}
+
+func (x *Message) sizeField16() (n int) {
+	if x.Message == "" {
+		return n
+	}
+	n += fastpb.SizeString(16, x.GetMessage())
+	return n
+}
+
+func (x *Message) sizeField17() (n int) {
+	if x.GetText() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(17, x.GetText())
+	return n
+}
+
+func (x *Message) sizeField18() (n int) {
+	if x.GetImage() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(18, x.GetImage())
+	return n
+}
+
+func (x *Message) sizeField19() (n int) {
+	if x.GetAudio() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(19, x.GetAudio())
+	return n
+}
+
+func (x *Message) sizeField20() (n int) {
+	if x.GetVideo() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(20, x.GetVideo())
+	return n
+}
+
+func (x *Message) sizeField21() (n int) {
+	if x.GetReceipt() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(21, x.GetReceipt())
+	return n
+}
+
+func (x *Message) sizeField22() (n int) {
+	if x.GetFile() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(22, x.GetFile())
+	return n
+}
+
+func (x *Message) sizeField23() (n int) {
+	if x.GetLocation() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(23, x.GetLocation())
+	return n
+}
+
+func (x *Message) sizeField24() (n int) {
+	if x.GetCard() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(24, x.GetCard())
+	return n
+}
+
+func (x *Message) sizeField25() (n int) {
+	if x.GetCustom() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(25, x.GetCustom())
+	return n
+}
+
+func (x *Message) sizeField26() (n int) {
+	if x.GetMerged() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(26, x.GetMerged())
+	return n
+}
+
+func (x *Message) sizeField27() (n int) {
+	if x.GetEnvelope() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(27, x.GetEnvelope())
+	return n
+}
+
+func (x *Message) sizeField28() (n int) {
+	if x.ClientMsgId == "" {
+		return n
+	}
+	n += fastpb.SizeString(28, x.GetClientMsgId())
+	return n
+}
+
+func (x *Message) sizeField29() (n int) {
+	if x.GetStatus() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(29, x.GetStatus())
+	return n
+}
+
+func (x *Message) sizeField30() (n int) {
+	if x.GetNotifySettings() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(30, x.GetNotifySettings())
+	return n
+}
+
+func (x *Message) sizeField31() (n int) {
+	if !x.Mentioned {
+		return n
+	}
+	n += fastpb.SizeBool(31, x.GetMentioned())
+	return n
+}
+
+func (x *At) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
+}
+
+func (x *At) sizeField1() (n int) {
+	if x.UserId == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(1, x.GetUserId())
+	return n
+}
+
+func (x *At) sizeField2() (n int) {
+	if x.Name == "" {
+		return n
+	}
+	n += fastpb.SizeString(2, x.GetName())
+	return n
+}
+
+func (x *At) sizeField3() (n int) {
+	if x.Avatar == "" {
+		return n
+	}
+	n += fastpb.SizeString(3, x.GetAvatar())
+	return n
+}
+
+func (x *Refer) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	n += x.sizeField6()
+	n += x.sizeField7()
+	n += x.sizeField8()
+	n += x.sizeField9()
+	n += x.sizeField10()
+	n += x.sizeField11()
+	n += x.sizeField12()
+	n += x.sizeField13()
+	n += x.sizeField14()
+	n += x.sizeField15()
+	return n
+}
+
+func (x *Refer) sizeField1() (n int) {
+	if x.UserId == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(1, x.GetUserId())
+	return n
+}
+
+func (x *Refer) sizeField2() (n int) {
+	if x.Name == "" {
+		return n
+	}
+	n += fastpb.SizeString(2, x.GetName())
+	return n
+}
+
+func (x *Refer) sizeField3() (n int) {
+	if x.Avatar == "" {
+		return n
+	}
+	n += fastpb.SizeString(3, x.GetAvatar())
+	return n
+}
+
+func (x *Refer) sizeField4() (n int) {
+	if x.CType == "" {
+		return n
+	}
+	n += fastpb.SizeString(4, x.GetCType())
+	return n
+}
+
+func (x *Refer) sizeField5() (n int) {
+	if x.GetText() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(5, x.GetText())
+	return n
+}
+
+func (x *Refer) sizeField6() (n int) {
+	if x.GetImage() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(6, x.GetImage())
+	return n
+}
+
+func (x *Refer) sizeField7() (n int) {
+	if x.GetAudio() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(7, x.GetAudio())
+	return n
+}
+
+func (x *Refer) sizeField8() (n int) {
+	if x.GetVideo() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(8, x.GetVideo())
+	return n
+}
+
+func (x *Refer) sizeField9() (n int) {
+	if x.MessageId == "" {
+		return n
+	}
+	n += fastpb.SizeString(9, x.GetMessageId())
+	return n
+}
+
+func (x *Refer) sizeField10() (n int) {
+	if !x.Recalled {
+		return n
+	}
+	n += fastpb.SizeBool(10, x.GetRecalled())
+	return n
+}
+
+func (x *Refer) sizeField11() (n int) {
+	if x.GetFile() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(11, x.GetFile())
+	return n
+}
+
+func (x *Refer) sizeField12() (n int) {
+	if x.GetLocation() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(12, x.GetLocation())
+	return n
+}
+
+func (x *Refer) sizeField13() (n int) {
+	if x.GetCard() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(13, x.GetCard())
+	return n
+}
+
+func (x *Refer) sizeField14() (n int) {
+	if x.GetCustom() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(14, x.GetCustom())
+	return n
+}
+
+func (x *Refer) sizeField15() (n int) {
+	if x.GetMerged() == nil {
+		return n
+	}
+	n += fastpb.SizeMessage(15, x.GetMerged())
+	return n
+}
+
+func (x *Text) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	return n
+}
+
+func (x *Text) sizeField1() (n int) {
+	if x.Text == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetText())
+	return n
+}
+
+func (x *Image) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
+}
+
+func (x *Image) sizeField1() (n int) {
+	if x.Url == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetUrl())
+	return n
+}
+
+func (x *Image) sizeField2() (n int) {
+	if x.Width == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(2, x.GetWidth())
+	return n
+}
+
+func (x *Image) sizeField3() (n int) {
+	if x.Height == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(3, x.GetHeight())
+	return n
+}
+
+func (x *Audio) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	return n
+}
+
+func (x *Audio) sizeField1() (n int) {
+	if x.Url == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetUrl())
+	return n
+}
+
+func (x *Audio) sizeField2() (n int) {
+	if x.Length == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(2, x.GetLength())
+	return n
+}
+
+func (x *Video) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	return n
 }
 
-func (x *LogoutRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
-	x.Os, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Video) sizeField1() (n int) {
+	if x.Url == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetUrl())
+	return n
 }
 
-func (x *LogoutRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
-	x.DeviceId, offset, err = fastpb.ReadString(buf, _type)
-	return offset, err
+func (x *Video) sizeField2() (n int) {
+	if x.Cover == "" {
+		return n
+	}
+	n += fastpb.SizeString(2, x.GetCover())
+	return n
 }
 
-func (x *LogoutReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
-	switch number {
-	default:
-		offset, err = fastpb.Skip(buf, _type, number)
-		if err != nil {
-			goto SkipFieldError
-		}
+func (x *Video) sizeField3() (n int) {
+	if x.Length == 0 {
+		return n
 	}
-	return offset, nil
-SkipFieldError:
-	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+	n += fastpb.SizeInt32(3, x.GetLength())
+	return n
 }
 
-func (x *Packet) FastWrite(buf []byte) (offset int) {
-	if x == nil {
-		return offset
+func (x *Video) sizeField4() (n int) {
+	if x.Width == 0 {
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	offset += x.fastWriteField4(buf[offset:])
-	return offset
+	n += fastpb.SizeInt32(4, x.GetWidth())
+	return n
 }
 
-func (x *Packet) fastWriteField1(buf []byte) (offset int) {
-	if x.Type == 0 {
-		return offset
+func (x *Video) sizeField5() (n int) {
+	if x.Height == 0 {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 1, x.GetType())
-	return offset
+	n += fastpb.SizeInt32(5, x.GetHeight())
+	return n
 }
 
-func (x *Packet) fastWriteField2(buf []byte) (offset int) {
-	if x.GetHeartbeat() == nil {
-		return offset
+func (x *File) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 2, x.GetHeartbeat())
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	return n
 }
 
-func (x *Packet) fastWriteField3(buf []byte) (offset int) {
-	if x.GetCommand() == nil {
-		return offset
+func (x *File) sizeField1() (n int) {
+	if x.Url == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 3, x.GetCommand())
-	return offset
+	n += fastpb.SizeString(1, x.GetUrl())
+	return n
 }
 
-func (x *Packet) fastWriteField4(buf []byte) (offset int) {
-	if x.GetMessage() == nil {
-		return offset
+func (x *File) sizeField2() (n int) {
+	if x.Name == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 4, x.GetMessage())
-	return offset
+	n += fastpb.SizeString(2, x.GetName())
+	return n
 }
 
-func (x *Heartbeat) FastWrite(buf []byte) (offset int) {
-	if x == nil {
-		return offset
+func (x *File) sizeField3() (n int) {
+	if x.FileSize == 0 {
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	return offset
+	n += fastpb.SizeInt64(3, x.GetFileSize())
+	return n
 }
 
-func (x *Heartbeat) fastWriteField1(buf []byte) (offset int) {
-	if x.Value == 0 {
-		return offset
+func (x *File) sizeField4() (n int) {
+	if x.Mime == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 1, x.GetValue())
-	return offset
+	n += fastpb.SizeString(4, x.GetMime())
+	return n
 }
 
-func (x *Command) FastWrite(buf []byte) (offset int) {
+func (x *Location) Size() (n int) {
 	if x == nil {
-		return offset
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	offset += x.fastWriteField4(buf[offset:])
-	offset += x.fastWriteField5(buf[offset:])
-	offset += x.fastWriteField6(buf[offset:])
-	offset += x.fastWriteField7(buf[offset:])
-	offset += x.fastWriteField8(buf[offset:])
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
 }
 
-func (x *Command) fastWriteField1(buf []byte) (offset int) {
-	if x.CommandId == "" {
-		return offset
+func (x *Location) sizeField1() (n int) {
+	if x.Lat == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetCommandId())
-	return offset
+	n += fastpb.SizeDouble(1, x.GetLat())
+	return n
 }
 
-func (x *Command) fastWriteField2(buf []byte) (offset int) {
-	if x.CommandType == "" {
-		return offset
+func (x *Location) sizeField2() (n int) {
+	if x.Lng == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 2, x.GetCommandType())
-	return offset
+	n += fastpb.SizeDouble(2, x.GetLng())
+	return n
 }
 
-func (x *Command) fastWriteField3(buf []byte) (offset int) {
-	if x.Code == 0 {
-		return offset
+func (x *Location) sizeField3() (n int) {
+	if x.Title == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetCode())
-	return offset
+	n += fastpb.SizeString(3, x.GetTitle())
+	return n
 }
 
-func (x *Command) fastWriteField4(buf []byte) (offset int) {
-	if x.Message == "" {
-		return offset
+func (x *Card) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 4, x.GetMessage())
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
 }
 
-func (x *Command) fastWriteField5(buf []byte) (offset int) {
-	if x.GetLoginRequest() == nil {
-		return offset
+func (x *Card) sizeField1() (n int) {
+	if x.UserId == 0 {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 5, x.GetLoginRequest())
-	return offset
+	n += fastpb.SizeInt64(1, x.GetUserId())
+	return n
 }
 
-func (x *Command) fastWriteField6(buf []byte) (offset int) {
-	if x.GetLogoutRequest() == nil {
-		return offset
+func (x *Card) sizeField2() (n int) {
+	if x.Name == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 6, x.GetLogoutRequest())
-	return offset
+	n += fastpb.SizeString(2, x.GetName())
+	return n
 }
 
-func (x *Command) fastWriteField7(buf []byte) (offset int) {
-	if x.GetLoginReply() == nil {
-		return offset
+func (x *Card) sizeField3() (n int) {
+	if x.Avatar == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 7, x.GetLoginReply())
-	return offset
+	n += fastpb.SizeString(3, x.GetAvatar())
+	return n
 }
 
-func (x *Command) fastWriteField8(buf []byte) (offset int) {
-	if x.GetLogoutReply() == nil {
-		return offset
+func (x *Custom) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 8, x.GetLogoutReply())
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	return n
 }
 
-func (x *Message) FastWrite(buf []byte) (offset int) {
-	if x == nil {
-		return offset
+func (x *Custom) sizeField1() (n int) {
+	if x.Type == "" {
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	offset += x.fastWriteField4(buf[offset:])
-	offset += x.fastWriteField5(buf[offset:])
-	offset += x.fastWriteField6(buf[offset:])
-	offset += x.fastWriteField7(buf[offset:])
-	offset += x.fastWriteField8(buf[offset:])
-	offset += x.fastWriteField9(buf[offset:])
-	offset += x.fastWriteField10(buf[offset:])
-	offset += x.fastWriteField11(buf[offset:])
-	offset += x.fastWriteField12(buf[offset:])
-	offset += x.fastWriteField13(buf[offset:])
-	offset += x.fastWriteField14(buf[offset:])
-	offset += x.fastWriteField15(buf[offset:])
-	offset += x.fastWriteField16(buf[offset:])
-	offset += x.fastWriteField17(buf[offset:])
-	offset += x.fastWriteField18(buf[offset:])
-	offset += x.fastWriteField19(buf[offset:])
-	offset += x.fastWriteField20(buf[offset:])
-	return offset
+	n += fastpb.SizeString(1, x.GetType())
+	return n
 }
 
-func (x *Message) fastWriteField1(buf []byte) (offset int) {
-	if x.MessageId == "" {
-		return offset
+func (x *Custom) sizeField2() (n int) {
+	if x.Payload == "" {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
-	return offset
+	n += fastpb.SizeString(2, x.GetPayload())
+	return n
 }
 
-func (x *Message) fastWriteField2(buf []byte) (offset int) {
-	if x.MessageType == "" {
-		return offset
+func (x *Merged) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 2, x.GetMessageType())
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	return n
 }
 
-func (x *Message) fastWriteField3(buf []byte) (offset int) {
-	if x.NeedAck == 0 {
-		return offset
+func (x *Merged) sizeField1() (n int) {
+	if x.Title == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetNeedAck())
-	return offset
+	n += fastpb.SizeString(1, x.GetTitle())
+	return n
 }
 
-func (x *Message) fastWriteField4(buf []byte) (offset int) {
-	if x.AppId == "" {
-		return offset
+func (x *Merged) sizeField2() (n int) {
+	if x.Items == nil {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 4, x.GetAppId())
-	return offset
+	for i := range x.GetItems() {
+		n += fastpb.SizeMessage(2, x.GetItems()[i])
+	}
+	return n
+}
+
+func (x *MergedItem) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	n += x.sizeField6()
+	return n
 }
 
-func (x *Message) fastWriteField5(buf []byte) (offset int) {
-	if x.Flow == 0 {
-		return offset
+func (x *MergedItem) sizeField1() (n int) {
+	if x.MessageId == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 5, x.GetFlow())
-	return offset
+	n += fastpb.SizeString(1, x.GetMessageId())
+	return n
 }
 
-func (x *Message) fastWriteField6(buf []byte) (offset int) {
+func (x *MergedItem) sizeField2() (n int) {
 	if x.UserId == 0 {
-		return offset
-	}
-	offset += fastpb.WriteInt64(buf[offset:], 6, x.GetUserId())
-	return offset
-}
-
-func (x *Message) fastWriteField7(buf []byte) (offset int) {
-	if x.ConvId == "" {
-		return offset
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 7, x.GetConvId())
-	return offset
+	n += fastpb.SizeInt64(2, x.GetUserId())
+	return n
 }
 
-func (x *Message) fastWriteField8(buf []byte) (offset int) {
-	if x.To == 0 {
-		return offset
+func (x *MergedItem) sizeField3() (n int) {
+	if x.Name == "" {
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 8, x.GetTo())
-	return offset
+	n += fastpb.SizeString(3, x.GetName())
+	return n
 }
 
-func (x *Message) fastWriteField9(buf []byte) (offset int) {
-	if x.GroupId == 0 {
-		return offset
+func (x *MergedItem) sizeField4() (n int) {
+	if x.MessageType == "" {
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 9, x.GetGroupId())
-	return offset
+	n += fastpb.SizeString(4, x.GetMessageType())
+	return n
 }
 
-func (x *Message) fastWriteField10(buf []byte) (offset int) {
-	if x.Sequence == 0 {
-		return offset
+func (x *MergedItem) sizeField5() (n int) {
+	if x.Summary == "" {
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 10, x.GetSequence())
-	return offset
+	n += fastpb.SizeString(5, x.GetSummary())
+	return n
 }
 
-func (x *Message) fastWriteField11(buf []byte) (offset int) {
+func (x *MergedItem) sizeField6() (n int) {
 	if x.CTime == 0 {
-		return offset
-	}
-	offset += fastpb.WriteInt64(buf[offset:], 11, x.GetCTime())
-	return offset
-}
-
-func (x *Message) fastWriteField12(buf []byte) (offset int) {
-	if x.STime == 0 {
-		return offset
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 12, x.GetSTime())
-	return offset
+	n += fastpb.SizeInt64(6, x.GetCTime())
+	return n
 }
 
-func (x *Message) fastWriteField13(buf []byte) (offset int) {
-	if x.At == nil {
-		return offset
-	}
-	for i := range x.GetAt() {
-		offset += fastpb.WriteMessage(buf[offset:], 13, x.GetAt()[i])
+func (x *Envelope) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
 }
 
-func (x *Message) fastWriteField14(buf []byte) (offset int) {
-	if x.Refer == nil {
-		return offset
-	}
-	for i := range x.GetRefer() {
-		offset += fastpb.WriteMessage(buf[offset:], 14, x.GetRefer()[i])
+func (x *Envelope) sizeField1() (n int) {
+	if len(x.Ciphertext) == 0 {
+		return n
 	}
-	return offset
+	n += fastpb.SizeBytes(1, x.GetCiphertext())
+	return n
 }
 
-func (x *Message) fastWriteField15(buf []byte) (offset int) {
-	if x.Code == 0 {
-		return offset
+func (x *Envelope) sizeField2() (n int) {
+	if x.KeyId == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 15, x.GetCode())
-	return offset
+	n += fastpb.SizeString(2, x.GetKeyId())
+	return n
 }
 
-func (x *Message) fastWriteField16(buf []byte) (offset int) {
-	if x.Message == "" {
-		return offset
+func (x *Envelope) sizeField3() (n int) {
+	if x.DeviceId == "" {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 16, x.GetMessage())
-	return offset
+	n += fastpb.SizeString(3, x.GetDeviceId())
+	return n
 }
 
-func (x *Message) fastWriteField17(buf []byte) (offset int) {
-	if x.GetText() == nil {
-		return offset
+func (x *Status) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 17, x.GetText())
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	n += x.sizeField6()
+	return n
 }
 
-func (x *Message) fastWriteField18(buf []byte) (offset int) {
-	if x.GetImage() == nil {
-		return offset
+func (x *Status) sizeField1() (n int) {
+	if x.MessageId == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 18, x.GetImage())
-	return offset
+	n += fastpb.SizeString(1, x.GetMessageId())
+	return n
 }
 
-func (x *Message) fastWriteField19(buf []byte) (offset int) {
-	if x.GetAudio() == nil {
-		return offset
+func (x *Status) sizeField2() (n int) {
+	if x.ConvId == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 19, x.GetAudio())
-	return offset
+	n += fastpb.SizeString(2, x.GetConvId())
+	return n
 }
 
-func (x *Message) fastWriteField20(buf []byte) (offset int) {
-	if x.GetVideo() == nil {
-		return offset
+func (x *Status) sizeField3() (n int) {
+	if x.Sequence == 0 {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 20, x.GetVideo())
-	return offset
+	n += fastpb.SizeInt64(3, x.GetSequence())
+	return n
 }
 
-func (x *At) FastWrite(buf []byte) (offset int) {
-	if x == nil {
-		return offset
+func (x *Status) sizeField4() (n int) {
+	if x.Status == "" {
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	return offset
+	n += fastpb.SizeString(4, x.GetStatus())
+	return n
 }
 
-func (x *At) fastWriteField1(buf []byte) (offset int) {
-	if x.UserId == 0 {
-		return offset
+func (x *Status) sizeField5() (n int) {
+	if x.STime == 0 {
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 1, x.GetUserId())
-	return offset
+	n += fastpb.SizeInt64(5, x.GetSTime())
+	return n
 }
 
-func (x *At) fastWriteField2(buf []byte) (offset int) {
-	if x.Name == "" {
-		return offset
+func (x *Status) sizeField6() (n int) {
+	if x.Code == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 2, x.GetName())
-	return offset
+	n += fastpb.SizeInt32(6, x.GetCode())
+	return n
 }
 
-func (x *At) fastWriteField3(buf []byte) (offset int) {
-	if x.Avatar == "" {
-		return offset
+func (x *Receipt) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 3, x.GetAvatar())
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	return n
 }
 
-func (x *Refer) FastWrite(buf []byte) (offset int) {
-	if x == nil {
-		return offset
+func (x *Receipt) sizeField1() (n int) {
+	if x.MessageId == "" {
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	offset += x.fastWriteField4(buf[offset:])
-	offset += x.fastWriteField5(buf[offset:])
-	offset += x.fastWriteField6(buf[offset:])
-	offset += x.fastWriteField7(buf[offset:])
-	offset += x.fastWriteField8(buf[offset:])
-	return offset
+	n += fastpb.SizeString(1, x.GetMessageId())
+	return n
 }
 
-func (x *Refer) fastWriteField1(buf []byte) (offset int) {
-	if x.UserId == 0 {
-		return offset
+func (x *Receipt) sizeField2() (n int) {
+	if x.GroupId == 0 {
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 1, x.GetUserId())
-	return offset
+	n += fastpb.SizeInt64(2, x.GetGroupId())
+	return n
 }
 
-func (x *Refer) fastWriteField2(buf []byte) (offset int) {
-	if x.Name == "" {
-		return offset
+func (x *Receipt) sizeField3() (n int) {
+	if x.ReadCount == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 2, x.GetName())
-	return offset
+	n += fastpb.SizeInt32(3, x.GetReadCount())
+	return n
 }
 
-func (x *Refer) fastWriteField3(buf []byte) (offset int) {
-	if x.Avatar == "" {
-		return offset
+func (x *Receipt) sizeField4() (n int) {
+	if x.MemberCount == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 3, x.GetAvatar())
-	return offset
+	n += fastpb.SizeInt32(4, x.GetMemberCount())
+	return n
 }
 
-func (x *Refer) fastWriteField4(buf []byte) (offset int) {
-	if x.CType == "" {
-		return offset
+func (x *LoginRequest) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 4, x.GetCType())
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	n += x.sizeField6()
+	return n
 }
 
-func (x *Refer) fastWriteField5(buf []byte) (offset int) {
-	if x.GetText() == nil {
-		return offset
+func (x *LoginRequest) sizeField1() (n int) {
+	if x.AppId == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 5, x.GetText())
-	return offset
+	n += fastpb.SizeString(1, x.GetAppId())
+	return n
 }
 
-func (x *Refer) fastWriteField6(buf []byte) (offset int) {
-	if x.GetImage() == nil {
-		return offset
+func (x *LoginRequest) sizeField2() (n int) {
+	if x.UserSig == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 6, x.GetImage())
-	return offset
+	n += fastpb.SizeString(2, x.GetUserSig())
+	return n
 }
 
-func (x *Refer) fastWriteField7(buf []byte) (offset int) {
-	if x.GetAudio() == nil {
-		return offset
+func (x *LoginRequest) sizeField3() (n int) {
+	if x.Version == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 7, x.GetAudio())
-	return offset
+	n += fastpb.SizeString(3, x.GetVersion())
+	return n
 }
 
-func (x *Refer) fastWriteField8(buf []byte) (offset int) {
-	if x.GetVideo() == nil {
-		return offset
+func (x *LoginRequest) sizeField4() (n int) {
+	if x.Os == "" {
+		return n
 	}
-	offset += fastpb.WriteMessage(buf[offset:], 8, x.GetVideo())
-	return offset
+	n += fastpb.SizeString(4, x.GetOs())
+	return n
 }
 
-func (x *Text) FastWrite(buf []byte) (offset int) {
-	if x == nil {
-		return offset
+func (x *LoginRequest) sizeField5() (n int) {
+	if x.DeviceId == "" {
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	return offset
+	n += fastpb.SizeString(5, x.GetDeviceId())
+	return n
 }
 
-func (x *Text) fastWriteField1(buf []byte) (offset int) {
-	if x.Text == "" {
-		return offset
+func (x *LoginRequest) sizeField6() (n int) {
+	if len(x.Compressions) == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetText())
-	return offset
+	for i := range x.GetCompressions() {
+		n += fastpb.SizeString(6, x.GetCompressions()[i])
+	}
+	return n
 }
 
-func (x *Image) FastWrite(buf []byte) (offset int) {
+func (x *LoginReply) Size() (n int) {
 	if x == nil {
-		return offset
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
 }
 
-func (x *Image) fastWriteField1(buf []byte) (offset int) {
-	if x.Url == "" {
-		return offset
+func (x *LoginReply) sizeField1() (n int) {
+	if x.AppId == "" {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetUrl())
-	return offset
+	n += fastpb.SizeString(1, x.GetAppId())
+	return n
 }
 
-func (x *Image) fastWriteField2(buf []byte) (offset int) {
-	if x.Width == 0 {
-		return offset
+func (x *LoginReply) sizeField2() (n int) {
+	if x.UserId == 0 {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 2, x.GetWidth())
-	return offset
+	n += fastpb.SizeInt64(2, x.GetUserId())
+	return n
 }
 
-func (x *Image) fastWriteField3(buf []byte) (offset int) {
-	if x.Height == 0 {
-		return offset
+func (x *LoginReply) sizeField3() (n int) {
+	if x.Compression == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetHeight())
-	return offset
+	n += fastpb.SizeString(3, x.GetCompression())
+	return n
 }
 
-func (x *Audio) FastWrite(buf []byte) (offset int) {
+func (x *ReconnectRequest) Size() (n int) {
 	if x == nil {
-		return offset
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	return n
 }
 
-func (x *Audio) fastWriteField1(buf []byte) (offset int) {
-	if x.Url == "" {
-		return offset
+func (x *ReconnectRequest) sizeField1() (n int) {
+	if len(x.Addrs) == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetUrl())
-	return offset
+	for i := range x.GetAddrs() {
+		n += fastpb.SizeString(1, x.GetAddrs()[i])
+	}
+	return n
 }
 
-func (x *Audio) fastWriteField2(buf []byte) (offset int) {
-	if x.Length == 0 {
-		return offset
+func (x *ReconnectRequest) sizeField2() (n int) {
+	if x.Reason == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 2, x.GetLength())
-	return offset
+	n += fastpb.SizeString(2, x.GetReason())
+	return n
 }
 
-func (x *Video) FastWrite(buf []byte) (offset int) {
+func (x *LogoutRequest) Size() (n int) {
 	if x == nil {
-		return offset
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	offset += x.fastWriteField4(buf[offset:])
-	offset += x.fastWriteField5(buf[offset:])
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	return n
 }
 
-func (x *Video) fastWriteField1(buf []byte) (offset int) {
-	if x.Url == "" {
-		return offset
+func (x *LogoutRequest) sizeField1() (n int) {
+	if x.AppId == "" {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetUrl())
-	return offset
+	n += fastpb.SizeString(1, x.GetAppId())
+	return n
 }
 
-func (x *Video) fastWriteField2(buf []byte) (offset int) {
-	if x.Cover == "" {
-		return offset
+func (x *LogoutRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 2, x.GetCover())
-	return offset
+	n += fastpb.SizeInt64(2, x.GetUserId())
+	return n
 }
 
-func (x *Video) fastWriteField3(buf []byte) (offset int) {
-	if x.Length == 0 {
-		return offset
+func (x *LogoutRequest) sizeField3() (n int) {
+	if x.Os == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 3, x.GetLength())
-	return offset
+	n += fastpb.SizeString(3, x.GetOs())
+	return n
 }
 
-func (x *Video) fastWriteField4(buf []byte) (offset int) {
-	if x.Width == 0 {
-		return offset
+func (x *LogoutRequest) sizeField4() (n int) {
+	if x.DeviceId == "" {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 4, x.GetWidth())
-	return offset
+	n += fastpb.SizeString(4, x.GetDeviceId())
+	return n
 }
 
-func (x *Video) fastWriteField5(buf []byte) (offset int) {
-	if x.Height == 0 {
-		return offset
+func (x *LogoutReply) Size() (n int) {
+	if x == nil {
+		return n
 	}
-	offset += fastpb.WriteInt32(buf[offset:], 5, x.GetHeight())
-	return offset
+	return n
 }
 
-func (x *LoginRequest) FastWrite(buf []byte) (offset int) {
+func (x *ReadRequest) Size() (n int) {
 	if x == nil {
-		return offset
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	offset += x.fastWriteField4(buf[offset:])
-	offset += x.fastWriteField5(buf[offset:])
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	return n
 }
 
-func (x *LoginRequest) fastWriteField1(buf []byte) (offset int) {
+func (x *ReadRequest) sizeField1() (n int) {
 	if x.AppId == "" {
-		return offset
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
-	return offset
+	n += fastpb.SizeString(1, x.GetAppId())
+	return n
 }
 
-func (x *LoginRequest) fastWriteField2(buf []byte) (offset int) {
-	if x.UserSig == "" {
-		return offset
+func (x *ReadRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 2, x.GetUserSig())
-	return offset
+	n += fastpb.SizeInt64(2, x.GetUserId())
+	return n
 }
 
-func (x *LoginRequest) fastWriteField3(buf []byte) (offset int) {
-	if x.Version == "" {
-		return offset
+func (x *ReadRequest) sizeField3() (n int) {
+	if x.ConvId == "" {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 3, x.GetVersion())
-	return offset
+	n += fastpb.SizeString(3, x.GetConvId())
+	return n
 }
 
-func (x *LoginRequest) fastWriteField4(buf []byte) (offset int) {
-	if x.Os == "" {
-		return offset
+func (x *ReadRequest) sizeField4() (n int) {
+	if x.GroupId == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 4, x.GetOs())
-	return offset
+	n += fastpb.SizeInt64(4, x.GetGroupId())
+	return n
 }
 
-func (x *LoginRequest) fastWriteField5(buf []byte) (offset int) {
-	if x.DeviceId == "" {
-		return offset
+func (x *ReadRequest) sizeField5() (n int) {
+	if x.Sequence == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 5, x.GetDeviceId())
-	return offset
+	n += fastpb.SizeInt64(5, x.GetSequence())
+	return n
 }
 
-func (x *LoginReply) FastWrite(buf []byte) (offset int) {
+func (x *ReadReply) Size() (n int) {
 	if x == nil {
-		return offset
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	return n
 }
 
-func (x *LoginReply) fastWriteField1(buf []byte) (offset int) {
-	if x.AppId == "" {
-		return offset
+func (x *ReadReply) sizeField1() (n int) {
+	if x.Sequence == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
-	return offset
+	n += fastpb.SizeInt64(1, x.GetSequence())
+	return n
 }
 
-func (x *LoginReply) fastWriteField2(buf []byte) (offset int) {
-	if x.UserId == 0 {
-		return offset
+func (x *ReadReply) sizeField2() (n int) {
+	if x.Mention == 0 {
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
-	return offset
+	n += fastpb.SizeInt64(2, x.GetMention())
+	return n
 }
 
-func (x *LogoutRequest) FastWrite(buf []byte) (offset int) {
+func (x *ReceiptRequest) Size() (n int) {
 	if x == nil {
-		return offset
+		return n
 	}
-	offset += x.fastWriteField1(buf[offset:])
-	offset += x.fastWriteField2(buf[offset:])
-	offset += x.fastWriteField3(buf[offset:])
-	offset += x.fastWriteField4(buf[offset:])
-	return offset
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	return n
 }
 
-func (x *LogoutRequest) fastWriteField1(buf []byte) (offset int) {
+func (x *ReceiptRequest) sizeField1() (n int) {
 	if x.AppId == "" {
-		return offset
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
-	return offset
+	n += fastpb.SizeString(1, x.GetAppId())
+	return n
 }
 
-func (x *LogoutRequest) fastWriteField2(buf []byte) (offset int) {
+func (x *ReceiptRequest) sizeField2() (n int) {
 	if x.UserId == 0 {
-		return offset
+		return n
 	}
-	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
-	return offset
+	n += fastpb.SizeInt64(2, x.GetUserId())
+	return n
 }
 
-func (x *LogoutRequest) fastWriteField3(buf []byte) (offset int) {
-	if x.Os == "" {
-		return offset
+func (x *ReceiptRequest) sizeField3() (n int) {
+	if x.GroupId == 0 {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 3, x.GetOs())
-	return offset
+	n += fastpb.SizeInt64(3, x.GetGroupId())
+	return n
 }
 
-func (x *LogoutRequest) fastWriteField4(buf []byte) (offset int) {
-	if x.DeviceId == "" {
-		return offset
+func (x *ReceiptRequest) sizeField4() (n int) {
+	if x.MessageId == "" {
+		return n
 	}
-	offset += fastpb.WriteString(buf[offset:], 4, x.GetDeviceId())
-	return offset
+	n += fastpb.SizeString(4, x.GetMessageId())
+	return n
 }
 
-func (x *LogoutReply) FastWrite(buf []byte) (offset int) {
-	if x == nil {
-		return offset
+func (x *ReceiptRequest) sizeField5() (n int) {
+	if x.Sequence == 0 {
+		return n
 	}
-	return offset
+	n += fastpb.SizeInt64(5, x.GetSequence())
+	return n
 }
 
-func (x *Packet) Size() (n int) {
+func (x *ReceiptReply) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
 	n += x.sizeField2()
 	n += x.sizeField3()
-	n += x.sizeField4()
 	return n
 }
 
-func (x *Packet) sizeField1() (n int) {
-	if x.Type == 0 {
+func (x *ReceiptReply) sizeField1() (n int) {
+	if x.MessageId == "" {
 		return n
 	}
-	n += fastpb.SizeInt32(1, x.GetType())
+	n += fastpb.SizeString(1, x.GetMessageId())
 	return n
 }
 
-func (x *Packet) sizeField2() (n int) {
-	if x.GetHeartbeat() == nil {
+func (x *ReceiptReply) sizeField2() (n int) {
+	if len(x.ReadUserIds) == 0 {
 		return n
 	}
-	n += fastpb.SizeMessage(2, x.GetHeartbeat())
+	n += fastpb.SizeListPacked(2, len(x.GetReadUserIds()),
+		func(numTagOrKey, numIdxOrVal int32) int {
+			n := 0
+			n += fastpb.SizeInt64(numTagOrKey, x.GetReadUserIds()[numIdxOrVal])
+			return n
+		})
 	return n
 }
 
-func (x *Packet) sizeField3() (n int) {
-	if x.GetCommand() == nil {
+func (x *ReceiptReply) sizeField3() (n int) {
+	if len(x.UnreadUserIds) == 0 {
 		return n
 	}
-	n += fastpb.SizeMessage(3, x.GetCommand())
+	n += fastpb.SizeListPacked(3, len(x.GetUnreadUserIds()),
+		func(numTagOrKey, numIdxOrVal int32) int {
+			n := 0
+			n += fastpb.SizeInt64(numTagOrKey, x.GetUnreadUserIds()[numIdxOrVal])
+			return n
+		})
 	return n
 }
 
-func (x *Packet) sizeField4() (n int) {
-	if x.GetMessage() == nil {
+func (x *PreKey) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeMessage(4, x.GetMessage())
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
 	return n
 }
 
-func (x *Heartbeat) Size() (n int) {
-	if x == nil {
+func (x *PreKey) sizeField1() (n int) {
+	if x.KeyId == "" {
 		return n
 	}
-	n += x.sizeField1()
+	n += fastpb.SizeString(1, x.GetKeyId())
 	return n
 }
 
-func (x *Heartbeat) sizeField1() (n int) {
-	if x.Value == 0 {
+func (x *PreKey) sizeField2() (n int) {
+	if len(x.PublicKey) == 0 {
 		return n
 	}
-	n += fastpb.SizeInt32(1, x.GetValue())
+	n += fastpb.SizeBytes(2, x.GetPublicKey())
 	return n
 }
 
-func (x *Command) Size() (n int) {
+func (x *PreKey) sizeField3() (n int) {
+	if len(x.Signature) == 0 {
+		return n
+	}
+	n += fastpb.SizeBytes(3, x.GetSignature())
+	return n
+}
+
+func (x *DeviceKeys) Size() (n int) {
 	if x == nil {
 		return n
 	}
@@ -1758,382 +7598,390 @@ func (x *Command) Size() (n int) {
 	n += x.sizeField2()
 	n += x.sizeField3()
 	n += x.sizeField4()
-	n += x.sizeField5()
-	n += x.sizeField6()
-	n += x.sizeField7()
-	n += x.sizeField8()
 	return n
 }
 
-func (x *Command) sizeField1() (n int) {
-	if x.CommandId == "" {
+func (x *DeviceKeys) sizeField1() (n int) {
+	if x.DeviceId == "" {
 		return n
 	}
-	n += fastpb.SizeString(1, x.GetCommandId())
+	n += fastpb.SizeString(1, x.GetDeviceId())
 	return n
 }
 
-func (x *Command) sizeField2() (n int) {
-	if x.CommandType == "" {
+func (x *DeviceKeys) sizeField2() (n int) {
+	if len(x.IdentityKey) == 0 {
 		return n
 	}
-	n += fastpb.SizeString(2, x.GetCommandType())
+	n += fastpb.SizeBytes(2, x.GetIdentityKey())
 	return n
 }
 
-func (x *Command) sizeField3() (n int) {
-	if x.Code == 0 {
+func (x *DeviceKeys) sizeField3() (n int) {
+	if x.SignedPreKey == nil {
 		return n
 	}
-	n += fastpb.SizeInt32(3, x.GetCode())
+	n += fastpb.SizeMessage(3, x.GetSignedPreKey())
 	return n
 }
 
-func (x *Command) sizeField4() (n int) {
-	if x.Message == "" {
+func (x *DeviceKeys) sizeField4() (n int) {
+	if x.OneTimePreKeys == nil {
 		return n
 	}
-	n += fastpb.SizeString(4, x.GetMessage())
+	for i := range x.GetOneTimePreKeys() {
+		n += fastpb.SizeMessage(4, x.GetOneTimePreKeys()[i])
+	}
 	return n
 }
 
-func (x *Command) sizeField5() (n int) {
-	if x.GetLoginRequest() == nil {
+func (x *KeyRegisterRequest) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeMessage(5, x.GetLoginRequest())
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
 	return n
 }
 
-func (x *Command) sizeField6() (n int) {
-	if x.GetLogoutRequest() == nil {
+func (x *KeyRegisterRequest) sizeField1() (n int) {
+	if x.AppId == "" {
 		return n
 	}
-	n += fastpb.SizeMessage(6, x.GetLogoutRequest())
+	n += fastpb.SizeString(1, x.GetAppId())
 	return n
 }
 
-func (x *Command) sizeField7() (n int) {
-	if x.GetLoginReply() == nil {
+func (x *KeyRegisterRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
 		return n
 	}
-	n += fastpb.SizeMessage(7, x.GetLoginReply())
+	n += fastpb.SizeInt64(2, x.GetUserId())
 	return n
 }
 
-func (x *Command) sizeField8() (n int) {
-	if x.GetLogoutReply() == nil {
+func (x *KeyRegisterRequest) sizeField3() (n int) {
+	if x.Keys == nil {
 		return n
 	}
-	n += fastpb.SizeMessage(8, x.GetLogoutReply())
+	n += fastpb.SizeMessage(3, x.GetKeys())
 	return n
 }
 
-func (x *Message) Size() (n int) {
+func (x *KeyRegisterReply) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
-	n += x.sizeField2()
-	n += x.sizeField3()
-	n += x.sizeField4()
-	n += x.sizeField5()
-	n += x.sizeField6()
-	n += x.sizeField7()
-	n += x.sizeField8()
-	n += x.sizeField9()
-	n += x.sizeField10()
-	n += x.sizeField11()
-	n += x.sizeField12()
-	n += x.sizeField13()
-	n += x.sizeField14()
-	n += x.sizeField15()
-	n += x.sizeField16()
-	n += x.sizeField17()
-	n += x.sizeField18()
-	n += x.sizeField19()
-	n += x.sizeField20()
 	return n
 }
 
-func (x *Message) sizeField1() (n int) {
-	if x.MessageId == "" {
+func (x *KeyRegisterReply) sizeField1() (n int) {
+	if x.OneTimePreKeyCount == 0 {
 		return n
 	}
-	n += fastpb.SizeString(1, x.GetMessageId())
+	n += fastpb.SizeInt32(1, x.GetOneTimePreKeyCount())
 	return n
 }
 
-func (x *Message) sizeField2() (n int) {
-	if x.MessageType == "" {
+func (x *KeyFetchRequest) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeString(2, x.GetMessageType())
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
 	return n
 }
 
-func (x *Message) sizeField3() (n int) {
-	if x.NeedAck == 0 {
+func (x *KeyFetchRequest) sizeField1() (n int) {
+	if x.AppId == "" {
 		return n
 	}
-	n += fastpb.SizeInt32(3, x.GetNeedAck())
+	n += fastpb.SizeString(1, x.GetAppId())
 	return n
 }
 
-func (x *Message) sizeField4() (n int) {
-	if x.AppId == "" {
+func (x *KeyFetchRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
 		return n
 	}
-	n += fastpb.SizeString(4, x.GetAppId())
+	n += fastpb.SizeInt64(2, x.GetUserId())
 	return n
 }
 
-func (x *Message) sizeField5() (n int) {
-	if x.Flow == 0 {
+func (x *KeyFetchRequest) sizeField3() (n int) {
+	if x.TargetUserId == 0 {
 		return n
 	}
-	n += fastpb.SizeInt32(5, x.GetFlow())
+	n += fastpb.SizeInt64(3, x.GetTargetUserId())
 	return n
 }
 
-func (x *Message) sizeField6() (n int) {
-	if x.UserId == 0 {
+func (x *KeyFetchRequest) sizeField4() (n int) {
+	if x.DeviceId == "" {
 		return n
 	}
-	n += fastpb.SizeInt64(6, x.GetUserId())
+	n += fastpb.SizeString(4, x.GetDeviceId())
 	return n
 }
 
-func (x *Message) sizeField7() (n int) {
-	if x.ConvId == "" {
+func (x *KeyFetchReply) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeString(7, x.GetConvId())
+	n += x.sizeField1()
+	n += x.sizeField2()
 	return n
 }
 
-func (x *Message) sizeField8() (n int) {
-	if x.To == 0 {
+func (x *KeyFetchReply) sizeField1() (n int) {
+	if x.UserId == 0 {
 		return n
 	}
-	n += fastpb.SizeInt64(8, x.GetTo())
+	n += fastpb.SizeInt64(1, x.GetUserId())
 	return n
 }
 
-func (x *Message) sizeField9() (n int) {
-	if x.GroupId == 0 {
+func (x *KeyFetchReply) sizeField2() (n int) {
+	if x.Devices == nil {
 		return n
 	}
-	n += fastpb.SizeInt64(9, x.GetGroupId())
+	for i := range x.GetDevices() {
+		n += fastpb.SizeMessage(2, x.GetDevices()[i])
+	}
 	return n
 }
 
-func (x *Message) sizeField10() (n int) {
-	if x.Sequence == 0 {
+func (x *PushRegisterRequest) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeInt64(10, x.GetSequence())
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	n += x.sizeField5()
+	n += x.sizeField6()
 	return n
 }
 
-func (x *Message) sizeField11() (n int) {
-	if x.CTime == 0 {
+func (x *PushRegisterRequest) sizeField1() (n int) {
+	if x.AppId == "" {
 		return n
 	}
-	n += fastpb.SizeInt64(11, x.GetCTime())
+	n += fastpb.SizeString(1, x.GetAppId())
 	return n
 }
 
-func (x *Message) sizeField12() (n int) {
-	if x.STime == 0 {
+func (x *PushRegisterRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
 		return n
 	}
-	n += fastpb.SizeInt64(12, x.GetSTime())
+	n += fastpb.SizeInt64(2, x.GetUserId())
 	return n
 }
 
-func (x *Message) sizeField13() (n int) {
-	if x.At == nil {
+func (x *PushRegisterRequest) sizeField3() (n int) {
+	if x.Label == "" {
 		return n
 	}
-	for i := range x.GetAt() {
-		n += fastpb.SizeMessage(13, x.GetAt()[i])
-	}
+	n += fastpb.SizeString(3, x.GetLabel())
 	return n
 }
 
-func (x *Message) sizeField14() (n int) {
-	if x.Refer == nil {
+func (x *PushRegisterRequest) sizeField4() (n int) {
+	if x.Vendor == "" {
 		return n
 	}
-	for i := range x.GetRefer() {
-		n += fastpb.SizeMessage(14, x.GetRefer()[i])
+	n += fastpb.SizeString(4, x.GetVendor())
+	return n
+}
+
+func (x *PushRegisterRequest) sizeField5() (n int) {
+	if x.Token == "" {
+		return n
 	}
+	n += fastpb.SizeString(5, x.GetToken())
 	return n
 }
 
-func (x *Message) sizeField15() (n int) {
-	if x.Code == 0 {
+func (x *PushRegisterRequest) sizeField6() (n int) {
+	if x.Locale == "" {
 		return n
 	}
-	n += fastpb.SizeInt32(15, x.GetCode())
+	n += fastpb.SizeString(6, x.GetLocale())
 	return n
 }
 
-func (x *Message) sizeField16() (n int) {
-	if x.Message == "" {
+func (x *PushRegisterReply) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeString(16, x.GetMessage())
 	return n
 }
 
-func (x *Message) sizeField17() (n int) {
-	if x.GetText() == nil {
+func (x *QuietHours) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeMessage(17, x.GetText())
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
 	return n
 }
 
-func (x *Message) sizeField18() (n int) {
-	if x.GetImage() == nil {
+func (x *QuietHours) sizeField1() (n int) {
+	if x.Start == "" {
 		return n
 	}
-	n += fastpb.SizeMessage(18, x.GetImage())
+	n += fastpb.SizeString(1, x.GetStart())
 	return n
 }
 
-func (x *Message) sizeField19() (n int) {
-	if x.GetAudio() == nil {
+func (x *QuietHours) sizeField2() (n int) {
+	if x.End == "" {
 		return n
 	}
-	n += fastpb.SizeMessage(19, x.GetAudio())
+	n += fastpb.SizeString(2, x.GetEnd())
 	return n
 }
 
-func (x *Message) sizeField20() (n int) {
-	if x.GetVideo() == nil {
+func (x *QuietHours) sizeField3() (n int) {
+	if x.Timezone == "" {
 		return n
 	}
-	n += fastpb.SizeMessage(20, x.GetVideo())
+	n += fastpb.SizeString(3, x.GetTimezone())
 	return n
 }
 
-func (x *At) Size() (n int) {
+func (x *ConvNotify) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
 	n += x.sizeField2()
 	n += x.sizeField3()
+	n += x.sizeField4()
 	return n
 }
 
-func (x *At) sizeField1() (n int) {
-	if x.UserId == 0 {
+func (x *ConvNotify) sizeField1() (n int) {
+	if x.ConvId == "" {
 		return n
 	}
-	n += fastpb.SizeInt64(1, x.GetUserId())
+	n += fastpb.SizeString(1, x.GetConvId())
 	return n
 }
 
-func (x *At) sizeField2() (n int) {
-	if x.Name == "" {
+func (x *ConvNotify) sizeField2() (n int) {
+	if !x.Mute {
 		return n
 	}
-	n += fastpb.SizeString(2, x.GetName())
+	n += fastpb.SizeBool(2, x.GetMute())
 	return n
 }
 
-func (x *At) sizeField3() (n int) {
-	if x.Avatar == "" {
+func (x *ConvNotify) sizeField3() (n int) {
+	if x.MuteUntil == 0 {
 		return n
 	}
-	n += fastpb.SizeString(3, x.GetAvatar())
+	n += fastpb.SizeInt64(3, x.GetMuteUntil())
 	return n
 }
 
-func (x *Refer) Size() (n int) {
+func (x *ConvNotify) sizeField4() (n int) {
+	if !x.MentionOnly {
+		return n
+	}
+	n += fastpb.SizeBool(4, x.GetMentionOnly())
+	return n
+}
+
+func (x *NotifySettings) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
 	n += x.sizeField2()
 	n += x.sizeField3()
-	n += x.sizeField4()
-	n += x.sizeField5()
-	n += x.sizeField6()
-	n += x.sizeField7()
-	n += x.sizeField8()
 	return n
 }
 
-func (x *Refer) sizeField1() (n int) {
-	if x.UserId == 0 {
+func (x *NotifySettings) sizeField1() (n int) {
+	if x.QuietHours == nil {
 		return n
 	}
-	n += fastpb.SizeInt64(1, x.GetUserId())
+	n += fastpb.SizeMessage(1, x.GetQuietHours())
 	return n
 }
 
-func (x *Refer) sizeField2() (n int) {
-	if x.Name == "" {
+func (x *NotifySettings) sizeField2() (n int) {
+	if x.Convs == nil {
 		return n
 	}
-	n += fastpb.SizeString(2, x.GetName())
+	for i := range x.GetConvs() {
+		n += fastpb.SizeMessage(2, x.GetConvs()[i])
+	}
 	return n
 }
 
-func (x *Refer) sizeField3() (n int) {
-	if x.Avatar == "" {
+func (x *NotifySettings) sizeField3() (n int) {
+	if x.Version == 0 {
 		return n
 	}
-	n += fastpb.SizeString(3, x.GetAvatar())
+	n += fastpb.SizeInt64(3, x.GetVersion())
 	return n
 }
-
-func (x *Refer) sizeField4() (n int) {
-	if x.CType == "" {
+
+func (x *NotifySettingRequest) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeString(4, x.GetCType())
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
 	return n
 }
 
-func (x *Refer) sizeField5() (n int) {
-	if x.GetText() == nil {
+func (x *NotifySettingRequest) sizeField1() (n int) {
+	if x.AppId == "" {
 		return n
 	}
-	n += fastpb.SizeMessage(5, x.GetText())
+	n += fastpb.SizeString(1, x.GetAppId())
 	return n
 }
 
-func (x *Refer) sizeField6() (n int) {
-	if x.GetImage() == nil {
+func (x *NotifySettingRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
 		return n
 	}
-	n += fastpb.SizeMessage(6, x.GetImage())
+	n += fastpb.SizeInt64(2, x.GetUserId())
 	return n
 }
 
-func (x *Refer) sizeField7() (n int) {
-	if x.GetAudio() == nil {
+func (x *NotifySettingRequest) sizeField3() (n int) {
+	if x.QuietHours == nil {
 		return n
 	}
-	n += fastpb.SizeMessage(7, x.GetAudio())
+	n += fastpb.SizeMessage(3, x.GetQuietHours())
 	return n
 }
 
-func (x *Refer) sizeField8() (n int) {
-	if x.GetVideo() == nil {
+func (x *NotifySettingRequest) sizeField4() (n int) {
+	if x.Convs == nil {
 		return n
 	}
-	n += fastpb.SizeMessage(8, x.GetVideo())
+	for i := range x.GetConvs() {
+		n += fastpb.SizeMessage(4, x.GetConvs()[i])
+	}
 	return n
 }
 
-func (x *Text) Size() (n int) {
+func (x *NotifySettingReply) Size() (n int) {
 	if x == nil {
 		return n
 	}
@@ -2141,15 +7989,15 @@ func (x *Text) Size() (n int) {
 	return n
 }
 
-func (x *Text) sizeField1() (n int) {
-	if x.Text == "" {
+func (x *NotifySettingReply) sizeField1() (n int) {
+	if x.Settings == nil {
 		return n
 	}
-	n += fastpb.SizeString(1, x.GetText())
+	n += fastpb.SizeMessage(1, x.GetSettings())
 	return n
 }
 
-func (x *Image) Size() (n int) {
+func (x *ScheduledMessage) Size() (n int) {
 	if x == nil {
 		return n
 	}
@@ -2159,120 +8007,108 @@ func (x *Image) Size() (n int) {
 	return n
 }
 
-func (x *Image) sizeField1() (n int) {
-	if x.Url == "" {
+func (x *ScheduledMessage) sizeField1() (n int) {
+	if x.Message == nil {
 		return n
 	}
-	n += fastpb.SizeString(1, x.GetUrl())
+	n += fastpb.SizeMessage(1, x.GetMessage())
 	return n
 }
 
-func (x *Image) sizeField2() (n int) {
-	if x.Width == 0 {
+func (x *ScheduledMessage) sizeField2() (n int) {
+	if x.SendTime == 0 {
 		return n
 	}
-	n += fastpb.SizeInt32(2, x.GetWidth())
+	n += fastpb.SizeInt64(2, x.GetSendTime())
 	return n
 }
 
-func (x *Image) sizeField3() (n int) {
-	if x.Height == 0 {
+func (x *ScheduledMessage) sizeField3() (n int) {
+	if x.CreateTime == 0 {
 		return n
 	}
-	n += fastpb.SizeInt32(3, x.GetHeight())
+	n += fastpb.SizeInt64(3, x.GetCreateTime())
 	return n
 }
 
-func (x *Audio) Size() (n int) {
+func (x *ScheduleRequest) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
 	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
 	return n
 }
 
-func (x *Audio) sizeField1() (n int) {
-	if x.Url == "" {
-		return n
-	}
-	n += fastpb.SizeString(1, x.GetUrl())
-	return n
-}
-
-func (x *Audio) sizeField2() (n int) {
-	if x.Length == 0 {
+func (x *ScheduleRequest) sizeField1() (n int) {
+	if x.AppId == "" {
 		return n
 	}
-	n += fastpb.SizeInt32(2, x.GetLength())
+	n += fastpb.SizeString(1, x.GetAppId())
 	return n
 }
 
-func (x *Video) Size() (n int) {
-	if x == nil {
+func (x *ScheduleRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
 		return n
 	}
-	n += x.sizeField1()
-	n += x.sizeField2()
-	n += x.sizeField3()
-	n += x.sizeField4()
-	n += x.sizeField5()
+	n += fastpb.SizeInt64(2, x.GetUserId())
 	return n
 }
 
-func (x *Video) sizeField1() (n int) {
-	if x.Url == "" {
+func (x *ScheduleRequest) sizeField3() (n int) {
+	if x.Message == nil {
 		return n
 	}
-	n += fastpb.SizeString(1, x.GetUrl())
+	n += fastpb.SizeMessage(3, x.GetMessage())
 	return n
 }
 
-func (x *Video) sizeField2() (n int) {
-	if x.Cover == "" {
+func (x *ScheduleRequest) sizeField4() (n int) {
+	if x.SendTime == 0 {
 		return n
 	}
-	n += fastpb.SizeString(2, x.GetCover())
+	n += fastpb.SizeInt64(4, x.GetSendTime())
 	return n
 }
 
-func (x *Video) sizeField3() (n int) {
-	if x.Length == 0 {
+func (x *ScheduleReply) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeInt32(3, x.GetLength())
+	n += x.sizeField1()
+	n += x.sizeField2()
 	return n
 }
 
-func (x *Video) sizeField4() (n int) {
-	if x.Width == 0 {
+func (x *ScheduleReply) sizeField1() (n int) {
+	if x.MessageId == "" {
 		return n
 	}
-	n += fastpb.SizeInt32(4, x.GetWidth())
+	n += fastpb.SizeString(1, x.GetMessageId())
 	return n
 }
 
-func (x *Video) sizeField5() (n int) {
-	if x.Height == 0 {
+func (x *ScheduleReply) sizeField2() (n int) {
+	if x.SendTime == 0 {
 		return n
 	}
-	n += fastpb.SizeInt32(5, x.GetHeight())
+	n += fastpb.SizeInt64(2, x.GetSendTime())
 	return n
 }
 
-func (x *LoginRequest) Size() (n int) {
+func (x *ScheduleListRequest) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
 	n += x.sizeField2()
-	n += x.sizeField3()
-	n += x.sizeField4()
-	n += x.sizeField5()
 	return n
 }
 
-func (x *LoginRequest) sizeField1() (n int) {
+func (x *ScheduleListRequest) sizeField1() (n int) {
 	if x.AppId == "" {
 		return n
 	}
@@ -2280,48 +8116,43 @@ func (x *LoginRequest) sizeField1() (n int) {
 	return n
 }
 
-func (x *LoginRequest) sizeField2() (n int) {
-	if x.UserSig == "" {
+func (x *ScheduleListRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
 		return n
 	}
-	n += fastpb.SizeString(2, x.GetUserSig())
+	n += fastpb.SizeInt64(2, x.GetUserId())
 	return n
 }
 
-func (x *LoginRequest) sizeField3() (n int) {
-	if x.Version == "" {
+func (x *ScheduleListReply) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeString(3, x.GetVersion())
+	n += x.sizeField1()
 	return n
 }
 
-func (x *LoginRequest) sizeField4() (n int) {
-	if x.Os == "" {
+func (x *ScheduleListReply) sizeField1() (n int) {
+	if x.Messages == nil {
 		return n
 	}
-	n += fastpb.SizeString(4, x.GetOs())
-	return n
-}
-
-func (x *LoginRequest) sizeField5() (n int) {
-	if x.DeviceId == "" {
-		return n
+	for i := range x.GetMessages() {
+		n += fastpb.SizeMessage(1, x.GetMessages()[i])
 	}
-	n += fastpb.SizeString(5, x.GetDeviceId())
 	return n
 }
 
-func (x *LoginReply) Size() (n int) {
+func (x *ScheduleCancelRequest) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
 	n += x.sizeField2()
+	n += x.sizeField3()
 	return n
 }
 
-func (x *LoginReply) sizeField1() (n int) {
+func (x *ScheduleCancelRequest) sizeField1() (n int) {
 	if x.AppId == "" {
 		return n
 	}
@@ -2329,7 +8160,7 @@ func (x *LoginReply) sizeField1() (n int) {
 	return n
 }
 
-func (x *LoginReply) sizeField2() (n int) {
+func (x *ScheduleCancelRequest) sizeField2() (n int) {
 	if x.UserId == 0 {
 		return n
 	}
@@ -2337,18 +8168,41 @@ func (x *LoginReply) sizeField2() (n int) {
 	return n
 }
 
-func (x *LogoutRequest) Size() (n int) {
+func (x *ScheduleCancelRequest) sizeField3() (n int) {
+	if x.MessageId == "" {
+		return n
+	}
+	n += fastpb.SizeString(3, x.GetMessageId())
+	return n
+}
+
+func (x *ScheduleCancelReply) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	return n
+}
+
+func (x *ScheduleCancelReply) sizeField1() (n int) {
+	if !x.Canceled {
+		return n
+	}
+	n += fastpb.SizeBool(1, x.GetCanceled())
+	return n
+}
+
+func (x *RecallRequest) Size() (n int) {
 	if x == nil {
 		return n
 	}
 	n += x.sizeField1()
 	n += x.sizeField2()
 	n += x.sizeField3()
-	n += x.sizeField4()
 	return n
 }
 
-func (x *LogoutRequest) sizeField1() (n int) {
+func (x *RecallRequest) sizeField1() (n int) {
 	if x.AppId == "" {
 		return n
 	}
@@ -2356,7 +8210,7 @@ func (x *LogoutRequest) sizeField1() (n int) {
 	return n
 }
 
-func (x *LogoutRequest) sizeField2() (n int) {
+func (x *RecallRequest) sizeField2() (n int) {
 	if x.UserId == 0 {
 		return n
 	}
@@ -2364,26 +8218,27 @@ func (x *LogoutRequest) sizeField2() (n int) {
 	return n
 }
 
-func (x *LogoutRequest) sizeField3() (n int) {
-	if x.Os == "" {
+func (x *RecallRequest) sizeField3() (n int) {
+	if x.MessageId == "" {
 		return n
 	}
-	n += fastpb.SizeString(3, x.GetOs())
+	n += fastpb.SizeString(3, x.GetMessageId())
 	return n
 }
 
-func (x *LogoutRequest) sizeField4() (n int) {
-	if x.DeviceId == "" {
+func (x *RecallReply) Size() (n int) {
+	if x == nil {
 		return n
 	}
-	n += fastpb.SizeString(4, x.GetDeviceId())
+	n += x.sizeField1()
 	return n
 }
 
-func (x *LogoutReply) Size() (n int) {
-	if x == nil {
+func (x *RecallReply) sizeField1() (n int) {
+	if x.MessageId == "" {
 		return n
 	}
+	n += fastpb.SizeString(1, x.GetMessageId())
 	return n
 }
 
@@ -2392,21 +8247,54 @@ var fieldIDToName_Packet = map[int32]string{
 	2: "Heartbeat",
 	3: "Command",
 	4: "Message",
+	5: "Ack",
 }
 
 var fieldIDToName_Heartbeat = map[int32]string{
 	1: "Value",
 }
 
+var fieldIDToName_Ack = map[int32]string{
+	1: "MessageIds",
+	2: "Convs",
+}
+
+var fieldIDToName_ConvAck = map[int32]string{
+	1: "ConvId",
+	2: "Sequence",
+	3: "From",
+}
+
 var fieldIDToName_Command = map[int32]string{
-	1: "CommandId",
-	2: "CommandType",
-	3: "Code",
-	4: "Message",
-	5: "LoginRequest",
-	6: "LogoutRequest",
-	7: "LoginReply",
-	8: "LogoutReply",
+	1:  "CommandId",
+	2:  "CommandType",
+	3:  "Code",
+	4:  "Message",
+	5:  "LoginRequest",
+	6:  "LogoutRequest",
+	7:  "LoginReply",
+	8:  "LogoutReply",
+	9:  "ReadRequest",
+	10: "ReadReply",
+	11: "ReceiptRequest",
+	12: "ReceiptReply",
+	13: "KeyRegisterRequest",
+	14: "KeyRegisterReply",
+	15: "KeyFetchRequest",
+	16: "KeyFetchReply",
+	17: "ReconnectRequest",
+	19: "PushRegisterRequest",
+	20: "PushRegisterReply",
+	21: "NotifySettingRequest",
+	22: "NotifySettingReply",
+	23: "ScheduleRequest",
+	24: "ScheduleReply",
+	25: "ScheduleListRequest",
+	26: "ScheduleListReply",
+	27: "ScheduleCancelRequest",
+	28: "ScheduleCancelReply",
+	29: "RecallRequest",
+	30: "RecallReply",
 }
 
 var fieldIDToName_Message = map[int32]string{
@@ -2430,6 +8318,17 @@ var fieldIDToName_Message = map[int32]string{
 	18: "Image",
 	19: "Audio",
 	20: "Video",
+	21: "Receipt",
+	22: "File",
+	23: "Location",
+	24: "Card",
+	25: "Custom",
+	26: "Merged",
+	27: "Envelope",
+	28: "ClientMsgId",
+	29: "Status",
+	30: "NotifySettings",
+	31: "Mentioned",
 }
 
 var fieldIDToName_At = map[int32]string{
@@ -2439,14 +8338,21 @@ var fieldIDToName_At = map[int32]string{
 }
 
 var fieldIDToName_Refer = map[int32]string{
-	1: "UserId",
-	2: "Name",
-	3: "Avatar",
-	4: "CType",
-	5: "Text",
-	6: "Image",
-	7: "Audio",
-	8: "Video",
+	1:  "UserId",
+	2:  "Name",
+	3:  "Avatar",
+	4:  "CType",
+	5:  "Text",
+	6:  "Image",
+	7:  "Audio",
+	8:  "Video",
+	9:  "MessageId",
+	10: "Recalled",
+	11: "File",
+	12: "Location",
+	13: "Card",
+	14: "Custom",
+	15: "Merged",
 }
 
 var fieldIDToName_Text = map[int32]string{
@@ -2472,17 +8378,84 @@ var fieldIDToName_Video = map[int32]string{
 	5: "Height",
 }
 
+var fieldIDToName_File = map[int32]string{
+	1: "Url",
+	2: "Name",
+	3: "FileSize",
+	4: "Mime",
+}
+
+var fieldIDToName_Location = map[int32]string{
+	1: "Lat",
+	2: "Lng",
+	3: "Title",
+}
+
+var fieldIDToName_Card = map[int32]string{
+	1: "UserId",
+	2: "Name",
+	3: "Avatar",
+}
+
+var fieldIDToName_Custom = map[int32]string{
+	1: "Type",
+	2: "Payload",
+}
+
+var fieldIDToName_Merged = map[int32]string{
+	1: "Title",
+	2: "Items",
+}
+
+var fieldIDToName_MergedItem = map[int32]string{
+	1: "MessageId",
+	2: "UserId",
+	3: "Name",
+	4: "MessageType",
+	5: "Summary",
+	6: "CTime",
+}
+
+var fieldIDToName_Envelope = map[int32]string{
+	1: "Ciphertext",
+	2: "KeyId",
+	3: "DeviceId",
+}
+
+var fieldIDToName_Status = map[int32]string{
+	1: "MessageId",
+	2: "ConvId",
+	3: "Sequence",
+	4: "Status",
+	5: "STime",
+	6: "Code",
+}
+
+var fieldIDToName_Receipt = map[int32]string{
+	1: "MessageId",
+	2: "GroupId",
+	3: "ReadCount",
+	4: "MemberCount",
+}
+
 var fieldIDToName_LoginRequest = map[int32]string{
 	1: "AppId",
 	2: "UserSig",
 	3: "Version",
 	4: "Os",
 	5: "DeviceId",
+	6: "Compressions",
 }
 
 var fieldIDToName_LoginReply = map[int32]string{
 	1: "AppId",
 	2: "UserId",
+	3: "Compression",
+}
+
+var fieldIDToName_ReconnectRequest = map[int32]string{
+	1: "Addrs",
+	2: "Reason",
 }
 
 var fieldIDToName_LogoutRequest = map[int32]string{
@@ -2493,3 +8466,153 @@ var fieldIDToName_LogoutRequest = map[int32]string{
 }
 
 var fieldIDToName_LogoutReply = map[int32]string{}
+
+var fieldIDToName_ReadRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "ConvId",
+	4: "GroupId",
+	5: "Sequence",
+}
+
+var fieldIDToName_ReadReply = map[int32]string{
+	1: "Sequence",
+	2: "Mention",
+}
+
+var fieldIDToName_ReceiptRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "GroupId",
+	4: "MessageId",
+	5: "Sequence",
+}
+
+var fieldIDToName_ReceiptReply = map[int32]string{
+	1: "MessageId",
+	2: "ReadUserIds",
+	3: "UnreadUserIds",
+}
+
+var fieldIDToName_PreKey = map[int32]string{
+	1: "KeyId",
+	2: "PublicKey",
+	3: "Signature",
+}
+
+var fieldIDToName_DeviceKeys = map[int32]string{
+	1: "DeviceId",
+	2: "IdentityKey",
+	3: "SignedPreKey",
+	4: "OneTimePreKeys",
+}
+
+var fieldIDToName_KeyRegisterRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "Keys",
+}
+
+var fieldIDToName_KeyRegisterReply = map[int32]string{
+	1: "OneTimePreKeyCount",
+}
+
+var fieldIDToName_KeyFetchRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "TargetUserId",
+	4: "DeviceId",
+}
+
+var fieldIDToName_KeyFetchReply = map[int32]string{
+	1: "UserId",
+	2: "Devices",
+}
+
+var fieldIDToName_PushRegisterRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "Label",
+	4: "Vendor",
+	5: "Token",
+	6: "Locale",
+}
+
+var fieldIDToName_PushRegisterReply = map[int32]string{}
+
+var fieldIDToName_QuietHours = map[int32]string{
+	1: "Start",
+	2: "End",
+	3: "Timezone",
+}
+
+var fieldIDToName_ConvNotify = map[int32]string{
+	1: "ConvId",
+	2: "Mute",
+	3: "MuteUntil",
+	4: "MentionOnly",
+}
+
+var fieldIDToName_NotifySettings = map[int32]string{
+	1: "QuietHours",
+	2: "Convs",
+	3: "Version",
+}
+
+var fieldIDToName_NotifySettingRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "QuietHours",
+	4: "Convs",
+}
+
+var fieldIDToName_NotifySettingReply = map[int32]string{
+	1: "Settings",
+}
+
+var fieldIDToName_ScheduledMessage = map[int32]string{
+	1: "Message",
+	2: "SendTime",
+	3: "CreateTime",
+}
+
+var fieldIDToName_ScheduleRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "Message",
+	4: "SendTime",
+}
+
+var fieldIDToName_ScheduleReply = map[int32]string{
+	1: "MessageId",
+	2: "SendTime",
+}
+
+var fieldIDToName_ScheduleListRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+}
+
+var fieldIDToName_ScheduleListReply = map[int32]string{
+	1: "Messages",
+}
+
+var fieldIDToName_ScheduleCancelRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "MessageId",
+}
+
+var fieldIDToName_ScheduleCancelReply = map[int32]string{
+	1: "Canceled",
+}
+
+var fieldIDToName_RecallRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "MessageId",
+}
+
+var fieldIDToName_RecallReply = map[int32]string{
+	1: "MessageId",
+}
@@ -32,6 +32,7 @@ type Packet struct {
 	//	*Packet_Heartbeat
 	//	*Packet_Command
 	//	*Packet_Message
+	//	*Packet_Ack
 	Body isPacket_Body `protobuf_oneof:"body"`
 }
 
@@ -102,6 +103,13 @@ func (x *Packet) GetMessage() *Message {
 	return nil
 }
 
+func (x *Packet) GetAck() *Ack {
+	if x, ok := x.GetBody().(*Packet_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
 type isPacket_Body interface {
 	isPacket_Body()
 }
@@ -118,12 +126,18 @@ type Packet_Message struct {
 	Message *Message `protobuf:"bytes,4,opt,name=message,proto3,oneof"`
 }
 
+type Packet_Ack struct {
+	Ack *Ack `protobuf:"bytes,5,opt,name=ack,proto3,oneof"`
+}
+
 func (*Packet_Heartbeat) isPacket_Body() {}
 
 func (*Packet_Command) isPacket_Body() {}
 
 func (*Packet_Message) isPacket_Body() {}
 
+func (*Packet_Ack) isPacket_Body() {}
+
 type Heartbeat struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -171,6 +185,126 @@ func (x *Heartbeat) GetValue() int32 {
 	return 0
 }
 
+// 客户端批量ack收到的消息，messageIds和convs可以同时使用
+type Ack struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageIds []string   `protobuf:"bytes,1,rep,name=messageIds,proto3" json:"messageIds,omitempty"`
+	Convs      []*ConvAck `protobuf:"bytes,2,rep,name=convs,proto3" json:"convs,omitempty"`
+}
+
+func (x *Ack) Reset() {
+	*x = Ack{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack) ProtoMessage() {}
+
+func (x *Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ack.ProtoReflect.Descriptor instead.
+func (*Ack) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Ack) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *Ack) GetConvs() []*ConvAck {
+	if x != nil {
+		return x.Convs
+	}
+	return nil
+}
+
+// 累计ack：会话中sequence在[from, sequence]内的消息都已收到
+type ConvAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConvId   string `protobuf:"bytes,1,opt,name=convId,proto3" json:"convId,omitempty"`
+	Sequence int64  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	From     int64  `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"` //连续收到的第一条，客户端登录前的消息不在范围内
+}
+
+func (x *ConvAck) Reset() {
+	*x = ConvAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvAck) ProtoMessage() {}
+
+func (x *ConvAck) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvAck.ProtoReflect.Descriptor instead.
+func (*ConvAck) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConvAck) GetConvId() string {
+	if x != nil {
+		return x.ConvId
+	}
+	return ""
+}
+
+func (x *ConvAck) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ConvAck) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
 type Command struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -184,18 +318,39 @@ type Command struct {
 	//
 	//	*Command_LoginRequest
 	//	*Command_LogoutRequest
+	//	*Command_ReadRequest
+	//	*Command_ReceiptRequest
+	//	*Command_KeyRegisterRequest
+	//	*Command_KeyFetchRequest
+	//	*Command_ReconnectRequest
+	//	*Command_PushRegisterRequest
+	//	*Command_NotifySettingRequest
+	//	*Command_ScheduleRequest
+	//	*Command_ScheduleListRequest
+	//	*Command_ScheduleCancelRequest
+	//	*Command_RecallRequest
 	Request isCommand_Request `protobuf_oneof:"request"`
 	// Types that are assignable to Reply:
 	//
 	//	*Command_LoginReply
 	//	*Command_LogoutReply
+	//	*Command_ReadReply
+	//	*Command_ReceiptReply
+	//	*Command_KeyRegisterReply
+	//	*Command_KeyFetchReply
+	//	*Command_PushRegisterReply
+	//	*Command_NotifySettingReply
+	//	*Command_ScheduleReply
+	//	*Command_ScheduleListReply
+	//	*Command_ScheduleCancelReply
+	//	*Command_RecallReply
 	Reply isCommand_Reply `protobuf_oneof:"reply"`
 }
 
 func (x *Command) Reset() {
 	*x = Command{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[2]
+		mi := &file_packet_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -208,7 +363,7 @@ func (x *Command) String() string {
 func (*Command) ProtoMessage() {}
 
 func (x *Command) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[2]
+	mi := &file_packet_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,7 +376,7 @@ func (x *Command) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Command.ProtoReflect.Descriptor instead.
 func (*Command) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{2}
+	return file_packet_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Command) GetCommandId() string {
@@ -273,6 +428,83 @@ func (x *Command) GetLogoutRequest() *LogoutRequest {
 	return nil
 }
 
+func (x *Command) GetReadRequest() *ReadRequest {
+	if x, ok := x.GetRequest().(*Command_ReadRequest); ok {
+		return x.ReadRequest
+	}
+	return nil
+}
+
+func (x *Command) GetReceiptRequest() *ReceiptRequest {
+	if x, ok := x.GetRequest().(*Command_ReceiptRequest); ok {
+		return x.ReceiptRequest
+	}
+	return nil
+}
+
+func (x *Command) GetKeyRegisterRequest() *KeyRegisterRequest {
+	if x, ok := x.GetRequest().(*Command_KeyRegisterRequest); ok {
+		return x.KeyRegisterRequest
+	}
+	return nil
+}
+
+func (x *Command) GetKeyFetchRequest() *KeyFetchRequest {
+	if x, ok := x.GetRequest().(*Command_KeyFetchRequest); ok {
+		return x.KeyFetchRequest
+	}
+	return nil
+}
+
+func (x *Command) GetReconnectRequest() *ReconnectRequest {
+	if x, ok := x.GetRequest().(*Command_ReconnectRequest); ok {
+		return x.ReconnectRequest
+	}
+	return nil
+}
+
+func (x *Command) GetPushRegisterRequest() *PushRegisterRequest {
+	if x, ok := x.GetRequest().(*Command_PushRegisterRequest); ok {
+		return x.PushRegisterRequest
+	}
+	return nil
+}
+
+func (x *Command) GetNotifySettingRequest() *NotifySettingRequest {
+	if x, ok := x.GetRequest().(*Command_NotifySettingRequest); ok {
+		return x.NotifySettingRequest
+	}
+	return nil
+}
+
+func (x *Command) GetScheduleRequest() *ScheduleRequest {
+	if x, ok := x.GetRequest().(*Command_ScheduleRequest); ok {
+		return x.ScheduleRequest
+	}
+	return nil
+}
+
+func (x *Command) GetScheduleListRequest() *ScheduleListRequest {
+	if x, ok := x.GetRequest().(*Command_ScheduleListRequest); ok {
+		return x.ScheduleListRequest
+	}
+	return nil
+}
+
+func (x *Command) GetScheduleCancelRequest() *ScheduleCancelRequest {
+	if x, ok := x.GetRequest().(*Command_ScheduleCancelRequest); ok {
+		return x.ScheduleCancelRequest
+	}
+	return nil
+}
+
+func (x *Command) GetRecallRequest() *RecallRequest {
+	if x, ok := x.GetRequest().(*Command_RecallRequest); ok {
+		return x.RecallRequest
+	}
+	return nil
+}
+
 func (m *Command) GetReply() isCommand_Reply {
 	if m != nil {
 		return m.Reply
@@ -294,6 +526,76 @@ func (x *Command) GetLogoutReply() *LogoutReply {
 	return nil
 }
 
+func (x *Command) GetReadReply() *ReadReply {
+	if x, ok := x.GetReply().(*Command_ReadReply); ok {
+		return x.ReadReply
+	}
+	return nil
+}
+
+func (x *Command) GetReceiptReply() *ReceiptReply {
+	if x, ok := x.GetReply().(*Command_ReceiptReply); ok {
+		return x.ReceiptReply
+	}
+	return nil
+}
+
+func (x *Command) GetKeyRegisterReply() *KeyRegisterReply {
+	if x, ok := x.GetReply().(*Command_KeyRegisterReply); ok {
+		return x.KeyRegisterReply
+	}
+	return nil
+}
+
+func (x *Command) GetKeyFetchReply() *KeyFetchReply {
+	if x, ok := x.GetReply().(*Command_KeyFetchReply); ok {
+		return x.KeyFetchReply
+	}
+	return nil
+}
+
+func (x *Command) GetPushRegisterReply() *PushRegisterReply {
+	if x, ok := x.GetReply().(*Command_PushRegisterReply); ok {
+		return x.PushRegisterReply
+	}
+	return nil
+}
+
+func (x *Command) GetNotifySettingReply() *NotifySettingReply {
+	if x, ok := x.GetReply().(*Command_NotifySettingReply); ok {
+		return x.NotifySettingReply
+	}
+	return nil
+}
+
+func (x *Command) GetScheduleReply() *ScheduleReply {
+	if x, ok := x.GetReply().(*Command_ScheduleReply); ok {
+		return x.ScheduleReply
+	}
+	return nil
+}
+
+func (x *Command) GetScheduleListReply() *ScheduleListReply {
+	if x, ok := x.GetReply().(*Command_ScheduleListReply); ok {
+		return x.ScheduleListReply
+	}
+	return nil
+}
+
+func (x *Command) GetScheduleCancelReply() *ScheduleCancelReply {
+	if x, ok := x.GetReply().(*Command_ScheduleCancelReply); ok {
+		return x.ScheduleCancelReply
+	}
+	return nil
+}
+
+func (x *Command) GetRecallReply() *RecallReply {
+	if x, ok := x.GetReply().(*Command_RecallReply); ok {
+		return x.RecallReply
+	}
+	return nil
+}
+
 type isCommand_Request interface {
 	isCommand_Request()
 }
@@ -306,10 +608,76 @@ type Command_LogoutRequest struct {
 	LogoutRequest *LogoutRequest `protobuf:"bytes,6,opt,name=logoutRequest,proto3,oneof"`
 }
 
+type Command_ReadRequest struct {
+	ReadRequest *ReadRequest `protobuf:"bytes,9,opt,name=readRequest,proto3,oneof"`
+}
+
+type Command_ReceiptRequest struct {
+	ReceiptRequest *ReceiptRequest `protobuf:"bytes,11,opt,name=receiptRequest,proto3,oneof"`
+}
+
+type Command_KeyRegisterRequest struct {
+	KeyRegisterRequest *KeyRegisterRequest `protobuf:"bytes,13,opt,name=keyRegisterRequest,proto3,oneof"`
+}
+
+type Command_KeyFetchRequest struct {
+	KeyFetchRequest *KeyFetchRequest `protobuf:"bytes,15,opt,name=keyFetchRequest,proto3,oneof"`
+}
+
+type Command_ReconnectRequest struct {
+	ReconnectRequest *ReconnectRequest `protobuf:"bytes,17,opt,name=reconnectRequest,proto3,oneof"`
+}
+
+type Command_PushRegisterRequest struct {
+	PushRegisterRequest *PushRegisterRequest `protobuf:"bytes,19,opt,name=pushRegisterRequest,proto3,oneof"`
+}
+
+type Command_NotifySettingRequest struct {
+	NotifySettingRequest *NotifySettingRequest `protobuf:"bytes,21,opt,name=notifySettingRequest,proto3,oneof"`
+}
+
+type Command_ScheduleRequest struct {
+	ScheduleRequest *ScheduleRequest `protobuf:"bytes,23,opt,name=scheduleRequest,proto3,oneof"`
+}
+
+type Command_ScheduleListRequest struct {
+	ScheduleListRequest *ScheduleListRequest `protobuf:"bytes,25,opt,name=scheduleListRequest,proto3,oneof"`
+}
+
+type Command_ScheduleCancelRequest struct {
+	ScheduleCancelRequest *ScheduleCancelRequest `protobuf:"bytes,27,opt,name=scheduleCancelRequest,proto3,oneof"`
+}
+
+type Command_RecallRequest struct {
+	RecallRequest *RecallRequest `protobuf:"bytes,29,opt,name=recallRequest,proto3,oneof"`
+}
+
 func (*Command_LoginRequest) isCommand_Request() {}
 
 func (*Command_LogoutRequest) isCommand_Request() {}
 
+func (*Command_ReadRequest) isCommand_Request() {}
+
+func (*Command_ReceiptRequest) isCommand_Request() {}
+
+func (*Command_KeyRegisterRequest) isCommand_Request() {}
+
+func (*Command_KeyFetchRequest) isCommand_Request() {}
+
+func (*Command_ReconnectRequest) isCommand_Request() {}
+
+func (*Command_PushRegisterRequest) isCommand_Request() {}
+
+func (*Command_NotifySettingRequest) isCommand_Request() {}
+
+func (*Command_ScheduleRequest) isCommand_Request() {}
+
+func (*Command_ScheduleListRequest) isCommand_Request() {}
+
+func (*Command_ScheduleCancelRequest) isCommand_Request() {}
+
+func (*Command_RecallRequest) isCommand_Request() {}
+
 type isCommand_Reply interface {
 	isCommand_Reply()
 }
@@ -322,10 +690,70 @@ type Command_LogoutReply struct {
 	LogoutReply *LogoutReply `protobuf:"bytes,8,opt,name=logoutReply,proto3,oneof"`
 }
 
+type Command_ReadReply struct {
+	ReadReply *ReadReply `protobuf:"bytes,10,opt,name=readReply,proto3,oneof"`
+}
+
+type Command_ReceiptReply struct {
+	ReceiptReply *ReceiptReply `protobuf:"bytes,12,opt,name=receiptReply,proto3,oneof"`
+}
+
+type Command_KeyRegisterReply struct {
+	KeyRegisterReply *KeyRegisterReply `protobuf:"bytes,14,opt,name=keyRegisterReply,proto3,oneof"`
+}
+
+type Command_KeyFetchReply struct {
+	KeyFetchReply *KeyFetchReply `protobuf:"bytes,16,opt,name=keyFetchReply,proto3,oneof"`
+}
+
+type Command_PushRegisterReply struct {
+	PushRegisterReply *PushRegisterReply `protobuf:"bytes,20,opt,name=pushRegisterReply,proto3,oneof"`
+}
+
+type Command_NotifySettingReply struct {
+	NotifySettingReply *NotifySettingReply `protobuf:"bytes,22,opt,name=notifySettingReply,proto3,oneof"`
+}
+
+type Command_ScheduleReply struct {
+	ScheduleReply *ScheduleReply `protobuf:"bytes,24,opt,name=scheduleReply,proto3,oneof"`
+}
+
+type Command_ScheduleListReply struct {
+	ScheduleListReply *ScheduleListReply `protobuf:"bytes,26,opt,name=scheduleListReply,proto3,oneof"`
+}
+
+type Command_ScheduleCancelReply struct {
+	ScheduleCancelReply *ScheduleCancelReply `protobuf:"bytes,28,opt,name=scheduleCancelReply,proto3,oneof"`
+}
+
+type Command_RecallReply struct {
+	RecallReply *RecallReply `protobuf:"bytes,30,opt,name=recallReply,proto3,oneof"`
+}
+
 func (*Command_LoginReply) isCommand_Reply() {}
 
 func (*Command_LogoutReply) isCommand_Reply() {}
 
+func (*Command_ReadReply) isCommand_Reply() {}
+
+func (*Command_ReceiptReply) isCommand_Reply() {}
+
+func (*Command_KeyRegisterReply) isCommand_Reply() {}
+
+func (*Command_KeyFetchReply) isCommand_Reply() {}
+
+func (*Command_PushRegisterReply) isCommand_Reply() {}
+
+func (*Command_NotifySettingReply) isCommand_Reply() {}
+
+func (*Command_ScheduleReply) isCommand_Reply() {}
+
+func (*Command_ScheduleListReply) isCommand_Reply() {}
+
+func (*Command_ScheduleCancelReply) isCommand_Reply() {}
+
+func (*Command_RecallReply) isCommand_Reply() {}
+
 type Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -353,13 +781,24 @@ type Message struct {
 	//	*Message_Image
 	//	*Message_Audio
 	//	*Message_Video
-	Content isMessage_Content `protobuf_oneof:"content"`
+	//	*Message_Receipt
+	//	*Message_File
+	//	*Message_Location
+	//	*Message_Card
+	//	*Message_Custom
+	//	*Message_Merged
+	//	*Message_Envelope
+	//	*Message_Status
+	//	*Message_NotifySettings
+	Content     isMessage_Content `protobuf_oneof:"content"`
+	ClientMsgId string            `protobuf:"bytes,28,opt,name=clientMsgId,proto3" json:"clientMsgId,omitempty"`
+	Mentioned   bool              `protobuf:"varint,31,opt,name=mentioned,proto3" json:"mentioned,omitempty"` //接收者被@，服务端按接收者设置
 }
 
 func (x *Message) Reset() {
 	*x = Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[3]
+		mi := &file_packet_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -372,7 +811,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[3]
+	mi := &file_packet_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -385,7 +824,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{3}
+	return file_packet_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Message) GetMessageId() string {
@@ -535,7 +974,84 @@ func (x *Message) GetVideo() *Video {
 	return nil
 }
 
-type isMessage_Content interface {
+func (x *Message) GetReceipt() *Receipt {
+	if x, ok := x.GetContent().(*Message_Receipt); ok {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *Message) GetFile() *File {
+	if x, ok := x.GetContent().(*Message_File); ok {
+		return x.File
+	}
+	return nil
+}
+
+func (x *Message) GetLocation() *Location {
+	if x, ok := x.GetContent().(*Message_Location); ok {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Message) GetCard() *Card {
+	if x, ok := x.GetContent().(*Message_Card); ok {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *Message) GetCustom() *Custom {
+	if x, ok := x.GetContent().(*Message_Custom); ok {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *Message) GetMerged() *Merged {
+	if x, ok := x.GetContent().(*Message_Merged); ok {
+		return x.Merged
+	}
+	return nil
+}
+
+func (x *Message) GetEnvelope() *Envelope {
+	if x, ok := x.GetContent().(*Message_Envelope); ok {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *Message) GetStatus() *Status {
+	if x, ok := x.GetContent().(*Message_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *Message) GetNotifySettings() *NotifySettings {
+	if x, ok := x.GetContent().(*Message_NotifySettings); ok {
+		return x.NotifySettings
+	}
+	return nil
+}
+
+func (x *Message) GetClientMsgId() string {
+	if x != nil {
+		return x.ClientMsgId
+	}
+	return ""
+}
+
+func (x *Message) GetMentioned() bool {
+	if x != nil {
+		return x.Mentioned
+	}
+	return false
+}
+
+type isMessage_Content interface {
 	isMessage_Content()
 }
 
@@ -555,6 +1071,42 @@ type Message_Video struct {
 	Video *Video `protobuf:"bytes,20,opt,name=video,proto3,oneof"`
 }
 
+type Message_Receipt struct {
+	Receipt *Receipt `protobuf:"bytes,21,opt,name=receipt,proto3,oneof"`
+}
+
+type Message_File struct {
+	File *File `protobuf:"bytes,22,opt,name=file,proto3,oneof"`
+}
+
+type Message_Location struct {
+	Location *Location `protobuf:"bytes,23,opt,name=location,proto3,oneof"`
+}
+
+type Message_Card struct {
+	Card *Card `protobuf:"bytes,24,opt,name=card,proto3,oneof"`
+}
+
+type Message_Custom struct {
+	Custom *Custom `protobuf:"bytes,25,opt,name=custom,proto3,oneof"`
+}
+
+type Message_Merged struct {
+	Merged *Merged `protobuf:"bytes,26,opt,name=merged,proto3,oneof"`
+}
+
+type Message_Envelope struct {
+	Envelope *Envelope `protobuf:"bytes,27,opt,name=envelope,proto3,oneof"`
+}
+
+type Message_Status struct {
+	Status *Status `protobuf:"bytes,29,opt,name=status,proto3,oneof"`
+}
+
+type Message_NotifySettings struct {
+	NotifySettings *NotifySettings `protobuf:"bytes,30,opt,name=notifySettings,proto3,oneof"`
+}
+
 func (*Message_Text) isMessage_Content() {}
 
 func (*Message_Image) isMessage_Content() {}
@@ -563,6 +1115,24 @@ func (*Message_Audio) isMessage_Content() {}
 
 func (*Message_Video) isMessage_Content() {}
 
+func (*Message_Receipt) isMessage_Content() {}
+
+func (*Message_File) isMessage_Content() {}
+
+func (*Message_Location) isMessage_Content() {}
+
+func (*Message_Card) isMessage_Content() {}
+
+func (*Message_Custom) isMessage_Content() {}
+
+func (*Message_Merged) isMessage_Content() {}
+
+func (*Message_Envelope) isMessage_Content() {}
+
+func (*Message_Status) isMessage_Content() {}
+
+func (*Message_NotifySettings) isMessage_Content() {}
+
 type At struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -576,7 +1146,7 @@ type At struct {
 func (x *At) Reset() {
 	*x = At{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[4]
+		mi := &file_packet_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -589,7 +1159,7 @@ func (x *At) String() string {
 func (*At) ProtoMessage() {}
 
 func (x *At) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[4]
+	mi := &file_packet_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +1172,7 @@ func (x *At) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use At.ProtoReflect.Descriptor instead.
 func (*At) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{4}
+	return file_packet_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *At) GetUserId() int64 {
@@ -641,13 +1211,20 @@ type Refer struct {
 	//	*Refer_Image
 	//	*Refer_Audio
 	//	*Refer_Video
-	Content isRefer_Content `protobuf_oneof:"content"`
+	//	*Refer_File
+	//	*Refer_Location
+	//	*Refer_Card
+	//	*Refer_Custom
+	//	*Refer_Merged
+	Content   isRefer_Content `protobuf_oneof:"content"`
+	MessageId string          `protobuf:"bytes,9,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	Recalled  bool            `protobuf:"varint,10,opt,name=recalled,proto3" json:"recalled,omitempty"`
 }
 
 func (x *Refer) Reset() {
 	*x = Refer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[5]
+		mi := &file_packet_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -660,7 +1237,7 @@ func (x *Refer) String() string {
 func (*Refer) ProtoMessage() {}
 
 func (x *Refer) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[5]
+	mi := &file_packet_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,7 +1250,7 @@ func (x *Refer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Refer.ProtoReflect.Descriptor instead.
 func (*Refer) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{5}
+	return file_packet_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Refer) GetUserId() int64 {
@@ -739,6 +1316,55 @@ func (x *Refer) GetVideo() *Video {
 	return nil
 }
 
+func (x *Refer) GetFile() *File {
+	if x, ok := x.GetContent().(*Refer_File); ok {
+		return x.File
+	}
+	return nil
+}
+
+func (x *Refer) GetLocation() *Location {
+	if x, ok := x.GetContent().(*Refer_Location); ok {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Refer) GetCard() *Card {
+	if x, ok := x.GetContent().(*Refer_Card); ok {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *Refer) GetCustom() *Custom {
+	if x, ok := x.GetContent().(*Refer_Custom); ok {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *Refer) GetMerged() *Merged {
+	if x, ok := x.GetContent().(*Refer_Merged); ok {
+		return x.Merged
+	}
+	return nil
+}
+
+func (x *Refer) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *Refer) GetRecalled() bool {
+	if x != nil {
+		return x.Recalled
+	}
+	return false
+}
+
 type isRefer_Content interface {
 	isRefer_Content()
 }
@@ -759,6 +1385,26 @@ type Refer_Video struct {
 	Video *Video `protobuf:"bytes,8,opt,name=video,proto3,oneof"`
 }
 
+type Refer_File struct {
+	File *File `protobuf:"bytes,11,opt,name=file,proto3,oneof"`
+}
+
+type Refer_Location struct {
+	Location *Location `protobuf:"bytes,12,opt,name=location,proto3,oneof"`
+}
+
+type Refer_Card struct {
+	Card *Card `protobuf:"bytes,13,opt,name=card,proto3,oneof"`
+}
+
+type Refer_Custom struct {
+	Custom *Custom `protobuf:"bytes,14,opt,name=custom,proto3,oneof"`
+}
+
+type Refer_Merged struct {
+	Merged *Merged `protobuf:"bytes,15,opt,name=merged,proto3,oneof"`
+}
+
 func (*Refer_Text) isRefer_Content() {}
 
 func (*Refer_Image) isRefer_Content() {}
@@ -767,6 +1413,16 @@ func (*Refer_Audio) isRefer_Content() {}
 
 func (*Refer_Video) isRefer_Content() {}
 
+func (*Refer_File) isRefer_Content() {}
+
+func (*Refer_Location) isRefer_Content() {}
+
+func (*Refer_Card) isRefer_Content() {}
+
+func (*Refer_Custom) isRefer_Content() {}
+
+func (*Refer_Merged) isRefer_Content() {}
+
 type Text struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -778,7 +1434,7 @@ type Text struct {
 func (x *Text) Reset() {
 	*x = Text{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[6]
+		mi := &file_packet_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -791,7 +1447,7 @@ func (x *Text) String() string {
 func (*Text) ProtoMessage() {}
 
 func (x *Text) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[6]
+	mi := &file_packet_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1460,7 @@ func (x *Text) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Text.ProtoReflect.Descriptor instead.
 func (*Text) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{6}
+	return file_packet_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Text) GetText() string {
@@ -827,7 +1483,7 @@ type Image struct {
 func (x *Image) Reset() {
 	*x = Image{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[7]
+		mi := &file_packet_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -840,7 +1496,7 @@ func (x *Image) String() string {
 func (*Image) ProtoMessage() {}
 
 func (x *Image) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[7]
+	mi := &file_packet_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -853,7 +1509,7 @@ func (x *Image) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Image.ProtoReflect.Descriptor instead.
 func (*Image) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{7}
+	return file_packet_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Image) GetUrl() string {
@@ -889,7 +1545,7 @@ type Audio struct {
 func (x *Audio) Reset() {
 	*x = Audio{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[8]
+		mi := &file_packet_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -902,7 +1558,7 @@ func (x *Audio) String() string {
 func (*Audio) ProtoMessage() {}
 
 func (x *Audio) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[8]
+	mi := &file_packet_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -915,7 +1571,7 @@ func (x *Audio) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Audio.ProtoReflect.Descriptor instead.
 func (*Audio) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{8}
+	return file_packet_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Audio) GetUrl() string {
@@ -947,7 +1603,7 @@ type Video struct {
 func (x *Video) Reset() {
 	*x = Video{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[9]
+		mi := &file_packet_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -960,7 +1616,7 @@ func (x *Video) String() string {
 func (*Video) ProtoMessage() {}
 
 func (x *Video) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[9]
+	mi := &file_packet_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,7 +1629,7 @@ func (x *Video) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Video.ProtoReflect.Descriptor instead.
 func (*Video) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{9}
+	return file_packet_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Video) GetUrl() string {
@@ -1011,35 +1667,34 @@ func (x *Video) GetHeight() int32 {
 	return 0
 }
 
-type LoginRequest struct {
+type File struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppId    string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
-	UserSig  string `protobuf:"bytes,2,opt,name=userSig,proto3" json:"userSig,omitempty"`
-	Version  string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
-	Os       string `protobuf:"bytes,4,opt,name=os,proto3" json:"os,omitempty"`
-	DeviceId string `protobuf:"bytes,5,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Url      string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	FileSize int64  `protobuf:"varint,3,opt,name=fileSize,proto3" json:"fileSize,omitempty"` // size 与 fastpb 生成的 Size() 冲突
+	Mime     string `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
+func (x *File) Reset() {
+	*x = File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[10]
+		mi := &file_packet_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoginRequest) String() string {
+func (x *File) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*File) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[10]
+func (x *File) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1050,72 +1705,66 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *LoginRequest) GetAppId() string {
-	if x != nil {
-		return x.AppId
-	}
-	return ""
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *LoginRequest) GetUserSig() string {
+func (x *File) GetUrl() string {
 	if x != nil {
-		return x.UserSig
+		return x.Url
 	}
 	return ""
 }
 
-func (x *LoginRequest) GetVersion() string {
+func (x *File) GetName() string {
 	if x != nil {
-		return x.Version
+		return x.Name
 	}
 	return ""
 }
 
-func (x *LoginRequest) GetOs() string {
+func (x *File) GetFileSize() int64 {
 	if x != nil {
-		return x.Os
+		return x.FileSize
 	}
-	return ""
+	return 0
 }
 
-func (x *LoginRequest) GetDeviceId() string {
+func (x *File) GetMime() string {
 	if x != nil {
-		return x.DeviceId
+		return x.Mime
 	}
 	return ""
 }
 
-type LoginReply struct {
+type Location struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppId  string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
-	UserId int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Lat   float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng   float64 `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+	Title string  `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
 }
 
-func (x *LoginReply) Reset() {
-	*x = LoginReply{}
+func (x *Location) Reset() {
+	*x = Location{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[11]
+		mi := &file_packet_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoginReply) String() string {
+func (x *Location) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginReply) ProtoMessage() {}
+func (*Location) ProtoMessage() {}
 
-func (x *LoginReply) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[11]
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1126,53 +1775,59 @@ func (x *LoginReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginReply.ProtoReflect.Descriptor instead.
-func (*LoginReply) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *LoginReply) GetAppId() string {
+func (x *Location) GetLat() float64 {
 	if x != nil {
-		return x.AppId
+		return x.Lat
 	}
-	return ""
+	return 0
 }
 
-func (x *LoginReply) GetUserId() int64 {
+func (x *Location) GetLng() float64 {
 	if x != nil {
-		return x.UserId
+		return x.Lng
 	}
 	return 0
 }
 
-type LogoutRequest struct {
+func (x *Location) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type Card struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AppId    string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
-	UserId   int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
-	Os       string `protobuf:"bytes,3,opt,name=os,proto3" json:"os,omitempty"`
-	DeviceId string `protobuf:"bytes,4,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	UserId int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Avatar string `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
 }
 
-func (x *LogoutRequest) Reset() {
-	*x = LogoutRequest{}
+func (x *Card) Reset() {
+	*x = Card{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[12]
+		mi := &file_packet_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LogoutRequest) String() string {
+func (x *Card) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutRequest) ProtoMessage() {}
+func (*Card) ProtoMessage() {}
 
-func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[12]
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1183,62 +1838,752 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
-func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *LogoutRequest) GetAppId() string {
-	if x != nil {
-		return x.AppId
-	}
-	return ""
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *LogoutRequest) GetUserId() int64 {
+func (x *Card) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *LogoutRequest) GetOs() string {
+func (x *Card) GetName() string {
 	if x != nil {
-		return x.Os
+		return x.Name
 	}
 	return ""
 }
 
-func (x *LogoutRequest) GetDeviceId() string {
+func (x *Card) GetAvatar() string {
 	if x != nil {
-		return x.DeviceId
+		return x.Avatar
 	}
 	return ""
 }
 
-type LogoutReply struct {
+type Custom struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload string `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
 }
 
-func (x *LogoutReply) Reset() {
-	*x = LogoutReply{}
+func (x *Custom) Reset() {
+	*x = Custom{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_packet_proto_msgTypes[13]
+		mi := &file_packet_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LogoutReply) String() string {
+func (x *Custom) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutReply) ProtoMessage() {}
+func (*Custom) ProtoMessage() {}
 
-func (x *LogoutReply) ProtoReflect() protoreflect.Message {
-	mi := &file_packet_proto_msgTypes[13]
+func (x *Custom) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Custom.ProtoReflect.Descriptor instead.
+func (*Custom) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Custom) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Custom) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+type Merged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title string        `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Items []*MergedItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Merged) Reset() {
+	*x = Merged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Merged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Merged) ProtoMessage() {}
+
+func (x *Merged) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Merged.ProtoReflect.Descriptor instead.
+func (*Merged) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Merged) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Merged) GetItems() []*MergedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type MergedItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId   string `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	UserId      int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	MessageType string `protobuf:"bytes,4,opt,name=messageType,proto3" json:"messageType,omitempty"`
+	Summary     string `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	CTime       int64  `protobuf:"varint,6,opt,name=cTime,proto3" json:"cTime,omitempty"`
+}
+
+func (x *MergedItem) Reset() {
+	*x = MergedItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergedItem) ProtoMessage() {}
+
+func (x *MergedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergedItem.ProtoReflect.Descriptor instead.
+func (*MergedItem) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MergedItem) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MergedItem) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MergedItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MergedItem) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *MergedItem) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *MergedItem) GetCTime() int64 {
+	if x != nil {
+		return x.CTime
+	}
+	return 0
+}
+
+// 端到端加密的消息体，服务端只转发和保存，不解析
+type Envelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ciphertext []byte `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	KeyId      string `protobuf:"bytes,2,opt,name=keyId,proto3" json:"keyId,omitempty"`       //加密使用的密钥id，客户端据此找到会话密钥
+	DeviceId   string `protobuf:"bytes,3,opt,name=deviceId,proto3" json:"deviceId,omitempty"` //发送者设备
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Envelope) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+func (x *Envelope) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *Envelope) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+// 消息的投递状态，推送给发送者
+type Status struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId string `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	ConvId    string `protobuf:"bytes,2,opt,name=convId,proto3" json:"convId,omitempty"`
+	Sequence  int64  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Status    string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` //delivered/offline/rejected
+	STime     int64  `protobuf:"varint,5,opt,name=sTime,proto3" json:"sTime,omitempty"`
+	Code      int32  `protobuf:"varint,6,opt,name=code,proto3" json:"code,omitempty"` //rejected时的错误码
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Status) ProtoMessage() {}
+
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Status) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *Status) GetConvId() string {
+	if x != nil {
+		return x.ConvId
+	}
+	return ""
+}
+
+func (x *Status) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Status) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Status) GetSTime() int64 {
+	if x != nil {
+		return x.STime
+	}
+	return 0
+}
+
+func (x *Status) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+type Receipt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId   string `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	GroupId     int64  `protobuf:"varint,2,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	ReadCount   int32  `protobuf:"varint,3,opt,name=readCount,proto3" json:"readCount,omitempty"`
+	MemberCount int32  `protobuf:"varint,4,opt,name=memberCount,proto3" json:"memberCount,omitempty"`
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Receipt) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *Receipt) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *Receipt) GetReadCount() int32 {
+	if x != nil {
+		return x.ReadCount
+	}
+	return 0
+}
+
+func (x *Receipt) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId        string   `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserSig      string   `protobuf:"bytes,2,opt,name=userSig,proto3" json:"userSig,omitempty"`
+	Version      string   `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Os           string   `protobuf:"bytes,4,opt,name=os,proto3" json:"os,omitempty"`
+	DeviceId     string   `protobuf:"bytes,5,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Compressions []string `protobuf:"bytes,6,rep,name=compressions,proto3" json:"compressions,omitempty"` //客户端支持的压缩算法，按优先级排列，zstd/gzip
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LoginRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetUserSig() string {
+	if x != nil {
+		return x.UserSig
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetCompressions() []string {
+	if x != nil {
+		return x.Compressions
+	}
+	return nil
+}
+
+type LoginReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId       string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId      int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Compression string `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"` //协商结果，为空时不压缩
+}
+
+func (x *LoginReply) Reset() {
+	*x = LoginReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoginReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginReply) ProtoMessage() {}
+
+func (x *LoginReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginReply.ProtoReflect.Descriptor instead.
+func (*LoginReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LoginReply) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *LoginReply) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LoginReply) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+// broker下线前通知客户端连接其他broker，服务端发起，客户端不需要回复
+type ReconnectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addrs  []string `protobuf:"bytes,1,rep,name=addrs,proto3" json:"addrs,omitempty"` //可用的broker地址，为空时客户端按自己的策略选择
+	Reason string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ReconnectRequest) Reset() {
+	*x = ReconnectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconnectRequest) ProtoMessage() {}
+
+func (x *ReconnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconnectRequest.ProtoReflect.Descriptor instead.
+func (*ReconnectRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReconnectRequest) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *ReconnectRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId    string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId   int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Os       string `protobuf:"bytes,3,opt,name=os,proto3" json:"os,omitempty"`
+	DeviceId string `protobuf:"bytes,4,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LogoutRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *LogoutRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LogoutRequest) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *LogoutRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type LogoutReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LogoutReply) Reset() {
+	*x = LogoutReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogoutReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutReply) ProtoMessage() {}
+
+func (x *LogoutReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1251,14 +2596,1622 @@ func (x *LogoutReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutReply.ProtoReflect.Descriptor instead.
 func (*LogoutReply) Descriptor() ([]byte, []int) {
-	return file_packet_proto_rawDescGZIP(), []int{13}
+	return file_packet_proto_rawDescGZIP(), []int{25}
+}
+
+type ReadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId    string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId   int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConvId   string `protobuf:"bytes,3,opt,name=convId,proto3" json:"convId,omitempty"`
+	GroupId  int64  `protobuf:"varint,4,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	Sequence int64  `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *ReadRequest) Reset() {
+	*x = ReadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRequest) ProtoMessage() {}
+
+func (x *ReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRequest.ProtoReflect.Descriptor instead.
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReadRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ReadRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReadRequest) GetConvId() string {
+	if x != nil {
+		return x.ConvId
+	}
+	return ""
+}
+
+func (x *ReadRequest) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *ReadRequest) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type ReadReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Mention  int64 `protobuf:"varint,2,opt,name=mention,proto3" json:"mention,omitempty"` //已读后最早一条未读@的sequence，没有时为0
+}
+
+func (x *ReadReply) Reset() {
+	*x = ReadReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadReply) ProtoMessage() {}
+
+func (x *ReadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadReply.ProtoReflect.Descriptor instead.
+func (*ReadReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReadReply) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ReadReply) GetMention() int64 {
+	if x != nil {
+		return x.Mention
+	}
+	return 0
+}
+
+type ReceiptRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId     string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId    int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	GroupId   int64  `protobuf:"varint,3,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	MessageId string `protobuf:"bytes,4,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	Sequence  int64  `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *ReceiptRequest) Reset() {
+	*x = ReceiptRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptRequest) ProtoMessage() {}
+
+func (x *ReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptRequest.ProtoReflect.Descriptor instead.
+func (*ReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReceiptRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ReceiptRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReceiptRequest) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *ReceiptRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReceiptRequest) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type ReceiptReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId     string  `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	ReadUserIds   []int64 `protobuf:"varint,2,rep,packed,name=readUserIds,proto3" json:"readUserIds,omitempty"`
+	UnreadUserIds []int64 `protobuf:"varint,3,rep,packed,name=unreadUserIds,proto3" json:"unreadUserIds,omitempty"`
+}
+
+func (x *ReceiptReply) Reset() {
+	*x = ReceiptReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReceiptReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptReply) ProtoMessage() {}
+
+func (x *ReceiptReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptReply.ProtoReflect.Descriptor instead.
+func (*ReceiptReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReceiptReply) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReceiptReply) GetReadUserIds() []int64 {
+	if x != nil {
+		return x.ReadUserIds
+	}
+	return nil
+}
+
+func (x *ReceiptReply) GetUnreadUserIds() []int64 {
+	if x != nil {
+		return x.UnreadUserIds
+	}
+	return nil
+}
+
+type PreKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyId     string `protobuf:"bytes,1,opt,name=keyId,proto3" json:"keyId,omitempty"`
+	PublicKey []byte `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"` //signed pre-key由identity key签名，one-time pre-key为空
+}
+
+func (x *PreKey) Reset() {
+	*x = PreKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreKey) ProtoMessage() {}
+
+func (x *PreKey) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreKey.ProtoReflect.Descriptor instead.
+func (*PreKey) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PreKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *PreKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *PreKey) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// 设备的公钥，服务端不校验签名，由客户端校验
+type DeviceKeys struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId       string    `protobuf:"bytes,1,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	IdentityKey    []byte    `protobuf:"bytes,2,opt,name=identityKey,proto3" json:"identityKey,omitempty"`
+	SignedPreKey   *PreKey   `protobuf:"bytes,3,opt,name=signedPreKey,proto3" json:"signedPreKey,omitempty"`
+	OneTimePreKeys []*PreKey `protobuf:"bytes,4,rep,name=oneTimePreKeys,proto3" json:"oneTimePreKeys,omitempty"`
+}
+
+func (x *DeviceKeys) Reset() {
+	*x = DeviceKeys{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceKeys) ProtoMessage() {}
+
+func (x *DeviceKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceKeys.ProtoReflect.Descriptor instead.
+func (*DeviceKeys) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeviceKeys) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceKeys) GetIdentityKey() []byte {
+	if x != nil {
+		return x.IdentityKey
+	}
+	return nil
+}
+
+func (x *DeviceKeys) GetSignedPreKey() *PreKey {
+	if x != nil {
+		return x.SignedPreKey
+	}
+	return nil
+}
+
+func (x *DeviceKeys) GetOneTimePreKeys() []*PreKey {
+	if x != nil {
+		return x.OneTimePreKeys
+	}
+	return nil
+}
+
+type KeyRegisterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId  string      `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId int64       `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Keys   *DeviceKeys `protobuf:"bytes,3,opt,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *KeyRegisterRequest) Reset() {
+	*x = KeyRegisterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRegisterRequest) ProtoMessage() {}
+
+func (x *KeyRegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRegisterRequest.ProtoReflect.Descriptor instead.
+func (*KeyRegisterRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *KeyRegisterRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *KeyRegisterRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *KeyRegisterRequest) GetKeys() *DeviceKeys {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type KeyRegisterReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OneTimePreKeyCount int32 `protobuf:"varint,1,opt,name=oneTimePreKeyCount,proto3" json:"oneTimePreKeyCount,omitempty"` //服务端剩余的one-time pre-key，不足时客户端补充
+}
+
+func (x *KeyRegisterReply) Reset() {
+	*x = KeyRegisterReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRegisterReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRegisterReply) ProtoMessage() {}
+
+func (x *KeyRegisterReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRegisterReply.ProtoReflect.Descriptor instead.
+func (*KeyRegisterReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *KeyRegisterReply) GetOneTimePreKeyCount() int32 {
+	if x != nil {
+		return x.OneTimePreKeyCount
+	}
+	return 0
+}
+
+type KeyFetchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId        string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId       int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	TargetUserId int64  `protobuf:"varint,3,opt,name=targetUserId,proto3" json:"targetUserId,omitempty"`
+	DeviceId     string `protobuf:"bytes,4,opt,name=deviceId,proto3" json:"deviceId,omitempty"` //为空时返回全部设备
+}
+
+func (x *KeyFetchRequest) Reset() {
+	*x = KeyFetchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyFetchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyFetchRequest) ProtoMessage() {}
+
+func (x *KeyFetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyFetchRequest.ProtoReflect.Descriptor instead.
+func (*KeyFetchRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *KeyFetchRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *KeyFetchRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *KeyFetchRequest) GetTargetUserId() int64 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+func (x *KeyFetchRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type KeyFetchReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId  int64         `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Devices []*DeviceKeys `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"` //每个设备最多一个one-time pre-key，取出后即删除
+}
+
+func (x *KeyFetchReply) Reset() {
+	*x = KeyFetchReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyFetchReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyFetchReply) ProtoMessage() {}
+
+func (x *KeyFetchReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyFetchReply.ProtoReflect.Descriptor instead.
+func (*KeyFetchReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *KeyFetchReply) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *KeyFetchReply) GetDevices() []*DeviceKeys {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+// 注册设备的推送token，token为空时注销
+type PushRegisterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId  string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Label  string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`   //当前连接的label，由broker填写
+	Vendor string `protobuf:"bytes,4,opt,name=vendor,proto3" json:"vendor,omitempty"` //推送通道iOS/Xiaomi/Huawei/Samsung/Honor/Oppo/Vivo，为空时使用登录的os
+	Token  string `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	Locale string `protobuf:"bytes,6,opt,name=locale,proto3" json:"locale,omitempty"` //通知文案的语言，如zh-CN、en-US
+}
+
+func (x *PushRegisterRequest) Reset() {
+	*x = PushRegisterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushRegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushRegisterRequest) ProtoMessage() {}
+
+func (x *PushRegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushRegisterRequest.ProtoReflect.Descriptor instead.
+func (*PushRegisterRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PushRegisterRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *PushRegisterRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PushRegisterRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *PushRegisterRequest) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *PushRegisterRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PushRegisterRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type PushRegisterReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PushRegisterReply) Reset() {
+	*x = PushRegisterReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushRegisterReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushRegisterReply) ProtoMessage() {}
+
+func (x *PushRegisterReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushRegisterReply.ProtoReflect.Descriptor instead.
+func (*PushRegisterReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{37}
+}
+
+// 免打扰时段，start/end为当地时间HH:mm，end早于start时跨天
+type QuietHours struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start    string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End      string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Timezone string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"` //IANA时区，如Asia/Shanghai，为空时使用UTC
+}
+
+func (x *QuietHours) Reset() {
+	*x = QuietHours{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuietHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuietHours) ProtoMessage() {}
+
+func (x *QuietHours) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuietHours.ProtoReflect.Descriptor instead.
+func (*QuietHours) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *QuietHours) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *QuietHours) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *QuietHours) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// 会话的通知设置
+type ConvNotify struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConvId      string `protobuf:"bytes,1,opt,name=convId,proto3" json:"convId,omitempty"`
+	Mute        bool   `protobuf:"varint,2,opt,name=mute,proto3" json:"mute,omitempty"`               //静音，被@时仍然通知
+	MuteUntil   int64  `protobuf:"varint,3,opt,name=muteUntil,proto3" json:"muteUntil,omitempty"`     //静音截止时间 毫秒，0表示一直静音
+	MentionOnly bool   `protobuf:"varint,4,opt,name=mentionOnly,proto3" json:"mentionOnly,omitempty"` //群会话只在被@时通知
+}
+
+func (x *ConvNotify) Reset() {
+	*x = ConvNotify{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvNotify) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvNotify) ProtoMessage() {}
+
+func (x *ConvNotify) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvNotify.ProtoReflect.Descriptor instead.
+func (*ConvNotify) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ConvNotify) GetConvId() string {
+	if x != nil {
+		return x.ConvId
+	}
+	return ""
+}
+
+func (x *ConvNotify) GetMute() bool {
+	if x != nil {
+		return x.Mute
+	}
+	return false
+}
+
+func (x *ConvNotify) GetMuteUntil() int64 {
+	if x != nil {
+		return x.MuteUntil
+	}
+	return 0
+}
+
+func (x *ConvNotify) GetMentionOnly() bool {
+	if x != nil {
+		return x.MentionOnly
+	}
+	return false
+}
+
+// 用户的通知设置，修改后同步给用户的所有设备
+type NotifySettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuietHours *QuietHours   `protobuf:"bytes,1,opt,name=quietHours,proto3" json:"quietHours,omitempty"` //为空时没有免打扰时段
+	Convs      []*ConvNotify `protobuf:"bytes,2,rep,name=convs,proto3" json:"convs,omitempty"`
+	Version    int64         `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"` //每次修改递增
+}
+
+func (x *NotifySettings) Reset() {
+	*x = NotifySettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotifySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifySettings) ProtoMessage() {}
+
+func (x *NotifySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifySettings.ProtoReflect.Descriptor instead.
+func (*NotifySettings) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *NotifySettings) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+func (x *NotifySettings) GetConvs() []*ConvNotify {
+	if x != nil {
+		return x.Convs
+	}
+	return nil
+}
+
+func (x *NotifySettings) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// 修改通知设置，quietHours和convs都为空时只查询
+type NotifySettingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      string        `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId     int64         `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	QuietHours *QuietHours   `protobuf:"bytes,3,opt,name=quietHours,proto3" json:"quietHours,omitempty"` //不为空时替换，start和end都为空时清除
+	Convs      []*ConvNotify `protobuf:"bytes,4,rep,name=convs,proto3" json:"convs,omitempty"`           //按convId替换，mute和mentionOnly都为false时删除
+}
+
+func (x *NotifySettingRequest) Reset() {
+	*x = NotifySettingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotifySettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifySettingRequest) ProtoMessage() {}
+
+func (x *NotifySettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifySettingRequest.ProtoReflect.Descriptor instead.
+func (*NotifySettingRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *NotifySettingRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *NotifySettingRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *NotifySettingRequest) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+func (x *NotifySettingRequest) GetConvs() []*ConvNotify {
+	if x != nil {
+		return x.Convs
+	}
+	return nil
+}
+
+type NotifySettingReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *NotifySettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *NotifySettingReply) Reset() {
+	*x = NotifySettingReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotifySettingReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifySettingReply) ProtoMessage() {}
+
+func (x *NotifySettingReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifySettingReply.ProtoReflect.Descriptor instead.
+func (*NotifySettingReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *NotifySettingReply) GetSettings() *NotifySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// 定时发送的消息，到期后按普通消息路由
+type ScheduledMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message    *Message `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	SendTime   int64    `protobuf:"varint,2,opt,name=sendTime,proto3" json:"sendTime,omitempty"`     //发送时间 毫秒
+	CreateTime int64    `protobuf:"varint,3,opt,name=createTime,proto3" json:"createTime,omitempty"` //提交时间 毫秒
+}
+
+func (x *ScheduledMessage) Reset() {
+	*x = ScheduledMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMessage) ProtoMessage() {}
+
+func (x *ScheduledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMessage.ProtoReflect.Descriptor instead.
+func (*ScheduledMessage) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ScheduledMessage) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ScheduledMessage) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+// 提交定时发送的消息，messageId为空时由router生成，取消时使用
+type ScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId    string   `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId   int64    `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Message  *Message `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	SendTime int64    `protobuf:"varint,4,opt,name=sendTime,proto3" json:"sendTime,omitempty"` //发送时间 毫秒
+}
+
+func (x *ScheduleRequest) Reset() {
+	*x = ScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRequest) ProtoMessage() {}
+
+func (x *ScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ScheduleRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ScheduleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ScheduleRequest) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ScheduleRequest) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+type ScheduleReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId string `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	SendTime  int64  `protobuf:"varint,2,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+}
+
+func (x *ScheduleReply) Reset() {
+	*x = ScheduleReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleReply) ProtoMessage() {}
+
+func (x *ScheduleReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleReply.ProtoReflect.Descriptor instead.
+func (*ScheduleReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ScheduleReply) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ScheduleReply) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+// 查询未发送的定时消息，按发送时间排序
+type ScheduleListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId  string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+}
+
+func (x *ScheduleListRequest) Reset() {
+	*x = ScheduleListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleListRequest) ProtoMessage() {}
+
+func (x *ScheduleListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleListRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleListRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ScheduleListRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ScheduleListRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ScheduleListReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messages []*ScheduledMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *ScheduleListReply) Reset() {
+	*x = ScheduleListReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleListReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleListReply) ProtoMessage() {}
+
+func (x *ScheduleListReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleListReply.ProtoReflect.Descriptor instead.
+func (*ScheduleListReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ScheduleListReply) GetMessages() []*ScheduledMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// 取消未发送的定时消息
+type ScheduleCancelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId     string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId    int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	MessageId string `protobuf:"bytes,3,opt,name=messageId,proto3" json:"messageId,omitempty"`
+}
+
+func (x *ScheduleCancelRequest) Reset() {
+	*x = ScheduleCancelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleCancelRequest) ProtoMessage() {}
+
+func (x *ScheduleCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleCancelRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleCancelRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ScheduleCancelRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ScheduleCancelRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ScheduleCancelRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type ScheduleCancelReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Canceled bool `protobuf:"varint,1,opt,name=canceled,proto3" json:"canceled,omitempty"` //已开始发送或不存在时为false
+}
+
+func (x *ScheduleCancelReply) Reset() {
+	*x = ScheduleCancelReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleCancelReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleCancelReply) ProtoMessage() {}
+
+func (x *ScheduleCancelReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleCancelReply.ProtoReflect.Descriptor instead.
+func (*ScheduleCancelReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ScheduleCancelReply) GetCanceled() bool {
+	if x != nil {
+		return x.Canceled
+	}
+	return false
+}
+
+// 撤回自己发送的消息，之后引用该消息时只显示墓碑
+type RecallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId     string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId    int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	MessageId string `protobuf:"bytes,3,opt,name=messageId,proto3" json:"messageId,omitempty"`
+}
+
+func (x *RecallRequest) Reset() {
+	*x = RecallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallRequest) ProtoMessage() {}
+
+func (x *RecallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallRequest.ProtoReflect.Descriptor instead.
+func (*RecallRequest) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RecallRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *RecallRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RecallRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type RecallReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId string `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+}
+
+func (x *RecallReply) Reset() {
+	*x = RecallReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packet_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecallReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallReply) ProtoMessage() {}
+
+func (x *RecallReply) ProtoReflect() protoreflect.Message {
+	mi := &file_packet_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallReply.ProtoReflect.Descriptor instead.
+func (*RecallReply) Descriptor() ([]byte, []int) {
+	return file_packet_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RecallReply) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
 }
 
 var File_packet_proto protoreflect.FileDescriptor
 
 var file_packet_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03,
-	0x61, 0x70, 0x69, 0x22, 0xa8, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12,
+	0x61, 0x70, 0x69, 0x22, 0xc6, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12,
 	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79,
 	0x70, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x65, 0x61, 0x72,
@@ -1268,128 +4221,503 @@ var file_packet_proto_rawDesc = []byte{
 	0x64, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x07,
 	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
 	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x21,
-	0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0xe9, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a, 0x0c, 0x6c,
-	0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
-	0x00, 0x52, 0x0d, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x31, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c,
-	0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x0b, 0x6c, 0x6f,
-	0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xb0, 0x04,
-	0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x65,
-	0x64, 0x41, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6e, 0x65, 0x65, 0x64,
-	0x41, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x6f,
-	0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x0a,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x12, 0x0e, 0x0a,
-	0x02, 0x74, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x18, 0x0a,
-	0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65,
-	0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65,
-	0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x63, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x54, 0x69,
-	0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x17, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x41, 0x74, 0x52, 0x02, 0x61, 0x74, 0x12, 0x20, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x65,
-	0x72, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65,
-	0x66, 0x65, 0x72, 0x52, 0x05, 0x72, 0x65, 0x66, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
-	0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x78,
-	0x74, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x69, 0x6d, 0x61,
-	0x67, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a,
-	0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x61, 0x75, 0x64, 0x69,
-	0x6f, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x00, 0x52, 0x05,
-	0x76, 0x69, 0x64, 0x65, 0x6f, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x48, 0x0a, 0x02, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x22, 0xf9, 0x01, 0x0a, 0x05, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x54, 0x79, 0x70,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f,
-	0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
-	0x22, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x48, 0x00,
-	0x52, 0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x69, 0x64,
-	0x65, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x42, 0x09, 0x0a, 0x07, 0x63,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x1a, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65,
-	0x78, 0x74, 0x22, 0x47, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75,
-	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a,
-	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69,
-	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x31, 0x0a, 0x05, 0x41,
-	0x75, 0x64, 0x69, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x75,
-	0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x76,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x12,
-	0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a,
-	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x84, 0x01, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52,
+	0x03, 0x61, 0x63, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x21, 0x0a, 0x09,
+	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x49, 0x0a, 0x03, 0x41, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x49, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x63, 0x6f, 0x6e, 0x76, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x76,
+	0x41, 0x63, 0x6b, 0x52, 0x05, 0x63, 0x6f, 0x6e, 0x76, 0x73, 0x22, 0x51, 0x0a, 0x07, 0x43, 0x6f,
+	0x6e, 0x76, 0x41, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x22, 0xf4, 0x0d,
+	0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3a, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f,
+	0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x6c,
+	0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x0b,
+	0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x49, 0x0a, 0x12, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x12, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f,
+	0x6b, 0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4b, 0x65, 0x79, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x6b,
+	0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x43,
+	0x0a, 0x10, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x10, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x13, 0x70, 0x75, 0x73, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x70, 0x75,
+	0x73, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x4f, 0x0a, 0x14, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x14, 0x6e, 0x6f,
+	0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x13, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x73,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x52, 0x0a, 0x15, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x15, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x31, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x69, 0x6e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x0b,
+	0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x09, 0x72,
+	0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01,
+	0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x0c, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x43, 0x0a, 0x10, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x10, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x0d, 0x6b, 0x65, 0x79,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4b, 0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x0d, 0x6b, 0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x11, 0x70, 0x75, 0x73, 0x68, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x11, 0x70, 0x75, 0x73, 0x68,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x49, 0x0a,
+	0x12, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x48, 0x01, 0x52, 0x12, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4c, 0x0a, 0x13,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x48, 0x01, 0x52, 0x13, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x72, 0x65,
+	0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x48, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0xea, 0x07, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x20,
+	0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x65, 0x64, 0x41, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x6e, 0x65, 0x65, 0x64, 0x41, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70,
+	0x70, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x66, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f,
+	0x6e, 0x76, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x07, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x74, 0x52, 0x02, 0x61, 0x74, 0x12,
+	0x20, 0x0a, 0x05, 0x72, 0x65, 0x66, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x52, 0x05, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1f, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x22, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x48,
+	0x00, 0x52, 0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x69, 0x64, 0x65,
+	0x6f, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x69,
+	0x64, 0x65, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x28, 0x0a, 0x07,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x48, 0x00, 0x52, 0x07, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x48,
+	0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x48, 0x00, 0x52,
+	0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x18,
+	0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x48, 0x00, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x12, 0x25, 0x0a, 0x06,
+	0x6d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6e, 0x76, 0x65,
+	0x6c, 0x6f, 0x70, 0x65, 0x48, 0x00, 0x52, 0x08, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65,
+	0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3d, 0x0a, 0x0e, 0x6e, 0x6f, 0x74, 0x69, 0x66,
+	0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x48, 0x00, 0x52, 0x0e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x4d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x65, 0x64, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6d, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x48, 0x0a, 0x02, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x22, 0xf0, 0x03, 0x0a, 0x05,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1f, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x22, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x48,
+	0x00, 0x52, 0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x69, 0x64, 0x65,
+	0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x69,
+	0x64, 0x65, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x1f, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x2b, 0x0a,
+	0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00,
+	0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x04, 0x63, 0x61,
+	0x72, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43,
+	0x61, 0x72, 0x64, 0x48, 0x00, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x48, 0x00, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x12, 0x25, 0x0a, 0x06, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x06, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x63, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x1a,
+	0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x47, 0x0a, 0x05, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x22, 0x31, 0x0a, 0x05, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x75, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x5c, 0x0a,
+	0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x22, 0x44, 0x0a, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x22, 0x4a, 0x0a, 0x04, 0x43, 0x61, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x22, 0x36, 0x0a,
+	0x06, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x45, 0x0a, 0x06, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x64, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xa8, 0x01, 0x0a,
+	0x0a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x63, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x5c, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x65, 0x6c,
+	0x6f, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x9c, 0x01, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x65, 0x61,
+	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xa8, 0x01, 0x0a, 0x0c, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x6f, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x5c, 0x0a, 0x0a, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x40, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x69, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x6f, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x0d,
+	0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x89, 0x01,
+	0x0a, 0x0b, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70,
+	0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6e, 0x76, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e,
+	0x76, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x61,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x92, 0x01, 0x0a,
+	0x0e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x22, 0x74, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x5a, 0x0a, 0x06, 0x50, 0x72, 0x65, 0x4b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x65,
+	0x79, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x20,
+	0x0a, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4b, 0x65, 0x79,
+	0x12, 0x2f, 0x0a, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x72, 0x65, 0x4b, 0x65, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x72, 0x65,
+	0x4b, 0x65, 0x79, 0x52, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x72, 0x65, 0x4b, 0x65,
+	0x79, 0x12, 0x33, 0x0a, 0x0e, 0x6f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x50, 0x72, 0x65, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x50, 0x72, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x0e, 0x6f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x50,
+	0x72, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x67, 0x0a, 0x12, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x04, 0x6b, 0x65,
+	0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22,
+	0x42, 0x0a, 0x10, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x6f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x50, 0x72,
+	0x65, 0x4b, 0x65, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x12, 0x6f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x50, 0x72, 0x65, 0x4b, 0x65, 0x79, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x7f, 0x0a, 0x0f, 0x4b, 0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75,
-	0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73,
-	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x0a,
-	0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70,
-	0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x69, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x6f,
-	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x64, 0x22, 0x52, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a,
+	0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52,
+	0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x13, 0x50, 0x75, 0x73,
+	0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x50, 0x75,
+	0x73, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x50, 0x0a, 0x0a, 0x51, 0x75, 0x69, 0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x22, 0x78, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x76, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x75, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x75, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
+	0x75, 0x74, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x6d, 0x75, 0x74, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x82, 0x01, 0x0a, 0x0e,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2f,
+	0x0a, 0x0a, 0x71, 0x75, 0x69, 0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x69, 0x65, 0x74, 0x48, 0x6f,
+	0x75, 0x72, 0x73, 0x52, 0x0a, 0x71, 0x75, 0x69, 0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12,
+	0x25, 0x0a, 0x05, 0x63, 0x6f, 0x6e, 0x76, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52,
+	0x05, 0x63, 0x6f, 0x6e, 0x76, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x9c, 0x01, 0x0a, 0x14, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70,
 	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12,
 	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x49, 0x64, 0x22, 0x0d, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6d, 0x61, 0x67, 0x69, 0x63, 0x6e, 0x61, 0x6e, 0x61, 0x39, 0x39, 0x39, 0x2f, 0x69, 0x6d,
-	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6b, 0x69, 0x74, 0x65, 0x78, 0x5f, 0x67, 0x65, 0x6e, 0x2f, 0x61,
-	0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x0a, 0x71, 0x75, 0x69, 0x65, 0x74,
+	0x48, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x51, 0x75, 0x69, 0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x52, 0x0a, 0x71, 0x75,
+	0x69, 0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x63, 0x6f, 0x6e, 0x76,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f,
+	0x6e, 0x76, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x05, 0x63, 0x6f, 0x6e, 0x76, 0x73, 0x22,
+	0x45, 0x0a, 0x12, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x76, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x83,
+	0x01, 0x0a, 0x0f, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x26, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0x49, 0x0a, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x43, 0x0a, 0x13, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x46, 0x0a, 0x11, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x08, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x63, 0x0a, 0x15,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49,
+	0x64, 0x22, 0x31, 0x0a, 0x13, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x65, 0x64, 0x22, 0x5b, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49,
+	0x64, 0x22, 0x2b, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x42, 0x2e,
+	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x67,
+	0x69, 0x63, 0x6e, 0x61, 0x6e, 0x61, 0x39, 0x39, 0x39, 0x2f, 0x69, 0x6d, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x6b, 0x69, 0x74, 0x65, 0x78, 0x5f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1404,46 +4732,134 @@ func file_packet_proto_rawDescGZIP() []byte {
 	return file_packet_proto_rawDescData
 }
 
-var file_packet_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_packet_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_packet_proto_goTypes = []interface{}{
-	(*Packet)(nil),        // 0: api.Packet
-	(*Heartbeat)(nil),     // 1: api.Heartbeat
-	(*Command)(nil),       // 2: api.Command
-	(*Message)(nil),       // 3: api.Message
-	(*At)(nil),            // 4: api.At
-	(*Refer)(nil),         // 5: api.Refer
-	(*Text)(nil),          // 6: api.Text
-	(*Image)(nil),         // 7: api.Image
-	(*Audio)(nil),         // 8: api.Audio
-	(*Video)(nil),         // 9: api.Video
-	(*LoginRequest)(nil),  // 10: api.LoginRequest
-	(*LoginReply)(nil),    // 11: api.LoginReply
-	(*LogoutRequest)(nil), // 12: api.LogoutRequest
-	(*LogoutReply)(nil),   // 13: api.LogoutReply
+	(*Packet)(nil),                // 0: api.Packet
+	(*Heartbeat)(nil),             // 1: api.Heartbeat
+	(*Ack)(nil),                   // 2: api.Ack
+	(*ConvAck)(nil),               // 3: api.ConvAck
+	(*Command)(nil),               // 4: api.Command
+	(*Message)(nil),               // 5: api.Message
+	(*At)(nil),                    // 6: api.At
+	(*Refer)(nil),                 // 7: api.Refer
+	(*Text)(nil),                  // 8: api.Text
+	(*Image)(nil),                 // 9: api.Image
+	(*Audio)(nil),                 // 10: api.Audio
+	(*Video)(nil),                 // 11: api.Video
+	(*File)(nil),                  // 12: api.File
+	(*Location)(nil),              // 13: api.Location
+	(*Card)(nil),                  // 14: api.Card
+	(*Custom)(nil),                // 15: api.Custom
+	(*Merged)(nil),                // 16: api.Merged
+	(*MergedItem)(nil),            // 17: api.MergedItem
+	(*Envelope)(nil),              // 18: api.Envelope
+	(*Status)(nil),                // 19: api.Status
+	(*Receipt)(nil),               // 20: api.Receipt
+	(*LoginRequest)(nil),          // 21: api.LoginRequest
+	(*LoginReply)(nil),            // 22: api.LoginReply
+	(*ReconnectRequest)(nil),      // 23: api.ReconnectRequest
+	(*LogoutRequest)(nil),         // 24: api.LogoutRequest
+	(*LogoutReply)(nil),           // 25: api.LogoutReply
+	(*ReadRequest)(nil),           // 26: api.ReadRequest
+	(*ReadReply)(nil),             // 27: api.ReadReply
+	(*ReceiptRequest)(nil),        // 28: api.ReceiptRequest
+	(*ReceiptReply)(nil),          // 29: api.ReceiptReply
+	(*PreKey)(nil),                // 30: api.PreKey
+	(*DeviceKeys)(nil),            // 31: api.DeviceKeys
+	(*KeyRegisterRequest)(nil),    // 32: api.KeyRegisterRequest
+	(*KeyRegisterReply)(nil),      // 33: api.KeyRegisterReply
+	(*KeyFetchRequest)(nil),       // 34: api.KeyFetchRequest
+	(*KeyFetchReply)(nil),         // 35: api.KeyFetchReply
+	(*PushRegisterRequest)(nil),   // 36: api.PushRegisterRequest
+	(*PushRegisterReply)(nil),     // 37: api.PushRegisterReply
+	(*QuietHours)(nil),            // 38: api.QuietHours
+	(*ConvNotify)(nil),            // 39: api.ConvNotify
+	(*NotifySettings)(nil),        // 40: api.NotifySettings
+	(*NotifySettingRequest)(nil),  // 41: api.NotifySettingRequest
+	(*NotifySettingReply)(nil),    // 42: api.NotifySettingReply
+	(*ScheduledMessage)(nil),      // 43: api.ScheduledMessage
+	(*ScheduleRequest)(nil),       // 44: api.ScheduleRequest
+	(*ScheduleReply)(nil),         // 45: api.ScheduleReply
+	(*ScheduleListRequest)(nil),   // 46: api.ScheduleListRequest
+	(*ScheduleListReply)(nil),     // 47: api.ScheduleListReply
+	(*ScheduleCancelRequest)(nil), // 48: api.ScheduleCancelRequest
+	(*ScheduleCancelReply)(nil),   // 49: api.ScheduleCancelReply
+	(*RecallRequest)(nil),         // 50: api.RecallRequest
+	(*RecallReply)(nil),           // 51: api.RecallReply
 }
 var file_packet_proto_depIdxs = []int32{
 	1,  // 0: api.Packet.heartbeat:type_name -> api.Heartbeat
-	2,  // 1: api.Packet.command:type_name -> api.Command
-	3,  // 2: api.Packet.message:type_name -> api.Message
-	10, // 3: api.Command.loginRequest:type_name -> api.LoginRequest
-	12, // 4: api.Command.logoutRequest:type_name -> api.LogoutRequest
-	11, // 5: api.Command.loginReply:type_name -> api.LoginReply
-	13, // 6: api.Command.logoutReply:type_name -> api.LogoutReply
-	4,  // 7: api.Message.at:type_name -> api.At
-	5,  // 8: api.Message.refer:type_name -> api.Refer
-	6,  // 9: api.Message.text:type_name -> api.Text
-	7,  // 10: api.Message.image:type_name -> api.Image
-	8,  // 11: api.Message.audio:type_name -> api.Audio
-	9,  // 12: api.Message.video:type_name -> api.Video
-	6,  // 13: api.Refer.text:type_name -> api.Text
-	7,  // 14: api.Refer.image:type_name -> api.Image
-	8,  // 15: api.Refer.audio:type_name -> api.Audio
-	9,  // 16: api.Refer.video:type_name -> api.Video
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	4,  // 1: api.Packet.command:type_name -> api.Command
+	5,  // 2: api.Packet.message:type_name -> api.Message
+	2,  // 3: api.Packet.ack:type_name -> api.Ack
+	3,  // 4: api.Ack.convs:type_name -> api.ConvAck
+	21, // 5: api.Command.loginRequest:type_name -> api.LoginRequest
+	24, // 6: api.Command.logoutRequest:type_name -> api.LogoutRequest
+	26, // 7: api.Command.readRequest:type_name -> api.ReadRequest
+	28, // 8: api.Command.receiptRequest:type_name -> api.ReceiptRequest
+	32, // 9: api.Command.keyRegisterRequest:type_name -> api.KeyRegisterRequest
+	34, // 10: api.Command.keyFetchRequest:type_name -> api.KeyFetchRequest
+	23, // 11: api.Command.reconnectRequest:type_name -> api.ReconnectRequest
+	36, // 12: api.Command.pushRegisterRequest:type_name -> api.PushRegisterRequest
+	41, // 13: api.Command.notifySettingRequest:type_name -> api.NotifySettingRequest
+	44, // 14: api.Command.scheduleRequest:type_name -> api.ScheduleRequest
+	46, // 15: api.Command.scheduleListRequest:type_name -> api.ScheduleListRequest
+	48, // 16: api.Command.scheduleCancelRequest:type_name -> api.ScheduleCancelRequest
+	50, // 17: api.Command.recallRequest:type_name -> api.RecallRequest
+	22, // 18: api.Command.loginReply:type_name -> api.LoginReply
+	25, // 19: api.Command.logoutReply:type_name -> api.LogoutReply
+	27, // 20: api.Command.readReply:type_name -> api.ReadReply
+	29, // 21: api.Command.receiptReply:type_name -> api.ReceiptReply
+	33, // 22: api.Command.keyRegisterReply:type_name -> api.KeyRegisterReply
+	35, // 23: api.Command.keyFetchReply:type_name -> api.KeyFetchReply
+	37, // 24: api.Command.pushRegisterReply:type_name -> api.PushRegisterReply
+	42, // 25: api.Command.notifySettingReply:type_name -> api.NotifySettingReply
+	45, // 26: api.Command.scheduleReply:type_name -> api.ScheduleReply
+	47, // 27: api.Command.scheduleListReply:type_name -> api.ScheduleListReply
+	49, // 28: api.Command.scheduleCancelReply:type_name -> api.ScheduleCancelReply
+	51, // 29: api.Command.recallReply:type_name -> api.RecallReply
+	6,  // 30: api.Message.at:type_name -> api.At
+	7,  // 31: api.Message.refer:type_name -> api.Refer
+	8,  // 32: api.Message.text:type_name -> api.Text
+	9,  // 33: api.Message.image:type_name -> api.Image
+	10, // 34: api.Message.audio:type_name -> api.Audio
+	11, // 35: api.Message.video:type_name -> api.Video
+	20, // 36: api.Message.receipt:type_name -> api.Receipt
+	12, // 37: api.Message.file:type_name -> api.File
+	13, // 38: api.Message.location:type_name -> api.Location
+	14, // 39: api.Message.card:type_name -> api.Card
+	15, // 40: api.Message.custom:type_name -> api.Custom
+	16, // 41: api.Message.merged:type_name -> api.Merged
+	18, // 42: api.Message.envelope:type_name -> api.Envelope
+	19, // 43: api.Message.status:type_name -> api.Status
+	40, // 44: api.Message.notifySettings:type_name -> api.NotifySettings
+	8,  // 45: api.Refer.text:type_name -> api.Text
+	9,  // 46: api.Refer.image:type_name -> api.Image
+	10, // 47: api.Refer.audio:type_name -> api.Audio
+	11, // 48: api.Refer.video:type_name -> api.Video
+	12, // 49: api.Refer.file:type_name -> api.File
+	13, // 50: api.Refer.location:type_name -> api.Location
+	14, // 51: api.Refer.card:type_name -> api.Card
+	15, // 52: api.Refer.custom:type_name -> api.Custom
+	16, // 53: api.Refer.merged:type_name -> api.Merged
+	17, // 54: api.Merged.items:type_name -> api.MergedItem
+	30, // 55: api.DeviceKeys.signedPreKey:type_name -> api.PreKey
+	30, // 56: api.DeviceKeys.oneTimePreKeys:type_name -> api.PreKey
+	31, // 57: api.KeyRegisterRequest.keys:type_name -> api.DeviceKeys
+	31, // 58: api.KeyFetchReply.devices:type_name -> api.DeviceKeys
+	38, // 59: api.NotifySettings.quietHours:type_name -> api.QuietHours
+	39, // 60: api.NotifySettings.convs:type_name -> api.ConvNotify
+	38, // 61: api.NotifySettingRequest.quietHours:type_name -> api.QuietHours
+	39, // 62: api.NotifySettingRequest.convs:type_name -> api.ConvNotify
+	40, // 63: api.NotifySettingReply.settings:type_name -> api.NotifySettings
+	5,  // 64: api.ScheduledMessage.message:type_name -> api.Message
+	5,  // 65: api.ScheduleRequest.message:type_name -> api.Message
+	43, // 66: api.ScheduleListReply.messages:type_name -> api.ScheduledMessage
+	67, // [67:67] is the sub-list for method output_type
+	67, // [67:67] is the sub-list for method input_type
+	67, // [67:67] is the sub-list for extension type_name
+	67, // [67:67] is the sub-list for extension extendee
+	0,  // [0:67] is the sub-list for field type_name
 }
 
 func init() { file_packet_proto_init() }
@@ -1464,8 +4880,200 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Heartbeat); i {
+		file_packet_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Heartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ack); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Command); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*At); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Refer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Text); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Image); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Audio); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Video); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Location); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Card); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Custom); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Merged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergedItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1476,8 +5084,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command); i {
+		file_packet_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Envelope); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1488,8 +5096,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Message); i {
+		file_packet_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Status); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1500,8 +5108,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*At); i {
+		file_packet_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Receipt); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1512,8 +5120,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Refer); i {
+		file_packet_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1524,8 +5132,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Text); i {
+		file_packet_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1536,8 +5144,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Image); i {
+		file_packet_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconnectRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1548,8 +5156,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Audio); i {
+		file_packet_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogoutRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1560,8 +5168,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Video); i {
+		file_packet_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogoutReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1572,8 +5180,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoginRequest); i {
+		file_packet_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1584,8 +5192,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoginReply); i {
+		file_packet_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1596,8 +5204,8 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogoutRequest); i {
+		file_packet_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReceiptRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1608,8 +5216,272 @@ func file_packet_proto_init() {
 				return nil
 			}
 		}
-		file_packet_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogoutReply); i {
+		file_packet_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReceiptReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceKeys); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRegisterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRegisterReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyFetchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyFetchReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushRegisterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushRegisterReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuietHours); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvNotify); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotifySettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotifySettingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotifySettingReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleListReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleCancelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleCancelReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecallRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_packet_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecallReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1625,24 +5497,60 @@ func file_packet_proto_init() {
 		(*Packet_Heartbeat)(nil),
 		(*Packet_Command)(nil),
 		(*Packet_Message)(nil),
+		(*Packet_Ack)(nil),
 	}
-	file_packet_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_packet_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*Command_LoginRequest)(nil),
 		(*Command_LogoutRequest)(nil),
+		(*Command_ReadRequest)(nil),
+		(*Command_ReceiptRequest)(nil),
+		(*Command_KeyRegisterRequest)(nil),
+		(*Command_KeyFetchRequest)(nil),
+		(*Command_ReconnectRequest)(nil),
+		(*Command_PushRegisterRequest)(nil),
+		(*Command_NotifySettingRequest)(nil),
+		(*Command_ScheduleRequest)(nil),
+		(*Command_ScheduleListRequest)(nil),
+		(*Command_ScheduleCancelRequest)(nil),
+		(*Command_RecallRequest)(nil),
 		(*Command_LoginReply)(nil),
 		(*Command_LogoutReply)(nil),
+		(*Command_ReadReply)(nil),
+		(*Command_ReceiptReply)(nil),
+		(*Command_KeyRegisterReply)(nil),
+		(*Command_KeyFetchReply)(nil),
+		(*Command_PushRegisterReply)(nil),
+		(*Command_NotifySettingReply)(nil),
+		(*Command_ScheduleReply)(nil),
+		(*Command_ScheduleListReply)(nil),
+		(*Command_ScheduleCancelReply)(nil),
+		(*Command_RecallReply)(nil),
 	}
-	file_packet_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_packet_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*Message_Text)(nil),
 		(*Message_Image)(nil),
 		(*Message_Audio)(nil),
 		(*Message_Video)(nil),
+		(*Message_Receipt)(nil),
+		(*Message_File)(nil),
+		(*Message_Location)(nil),
+		(*Message_Card)(nil),
+		(*Message_Custom)(nil),
+		(*Message_Merged)(nil),
+		(*Message_Envelope)(nil),
+		(*Message_Status)(nil),
+		(*Message_NotifySettings)(nil),
 	}
-	file_packet_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_packet_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*Refer_Text)(nil),
 		(*Refer_Image)(nil),
 		(*Refer_Audio)(nil),
 		(*Refer_Video)(nil),
+		(*Refer_File)(nil),
+		(*Refer_Location)(nil),
+		(*Refer_Card)(nil),
+		(*Refer_Custom)(nil),
+		(*Refer_Merged)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1650,7 +5558,7 @@ func file_packet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_packet_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
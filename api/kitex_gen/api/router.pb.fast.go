@@ -14,6 +14,209 @@ var (
 
 func (x *RouteReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
 	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_RouteReply[number], err)
+}
+
+func (x *RouteReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.MessageId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *RouteReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Sequence, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *RouteReply) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Duplicate, offset, err = fastpb.ReadBool(buf, _type)
+	return offset, err
+}
+
+func (x *RouteReply) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.STime, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *DeliveredRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_DeliveredRequest[number], err)
+}
+
+func (x *DeliveredRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *DeliveredRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	var v string
+	v, offset, err = fastpb.ReadString(buf, _type)
+	if err != nil {
+		return offset, err
+	}
+	x.MessageIds = append(x.MessageIds, v)
+	return offset, err
+}
+
+func (x *DeliveredRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *DeliveredRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	var v string
+	v, offset, err = fastpb.ReadString(buf, _type)
+	if err != nil {
+		return offset, err
+	}
+	x.ConvIds = append(x.ConvIds, v)
+	return offset, err
+}
+
+func (x *DeliveredReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+}
+
+func (x *OfflineRequest) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 4:
+		offset, err = x.fastReadField4(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	default:
+		offset, err = fastpb.Skip(buf, _type, number)
+		if err != nil {
+			goto SkipFieldError
+		}
+	}
+	return offset, nil
+SkipFieldError:
+	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_OfflineRequest[number], err)
+}
+
+func (x *OfflineRequest) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	x.AppId, offset, err = fastpb.ReadString(buf, _type)
+	return offset, err
+}
+
+func (x *OfflineRequest) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.UserId, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *OfflineRequest) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.Since, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *OfflineRequest) fastReadField4(buf []byte, _type int8) (offset int, err error) {
+	x.Limit, offset, err = fastpb.ReadInt32(buf, _type)
+	return offset, err
+}
+
+func (x *OfflineReply) FastRead(buf []byte, _type int8, number int32) (offset int, err error) {
+	switch number {
+	case 1:
+		offset, err = x.fastReadField1(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 2:
+		offset, err = x.fastReadField2(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
+	case 3:
+		offset, err = x.fastReadField3(buf, _type)
+		if err != nil {
+			goto ReadFieldError
+		}
 	default:
 		offset, err = fastpb.Skip(buf, _type, number)
 		if err != nil {
@@ -23,20 +226,407 @@ func (x *RouteReply) FastRead(buf []byte, _type int8, number int32) (offset int,
 	return offset, nil
 SkipFieldError:
 	return offset, fmt.Errorf("%T cannot parse invalid wire-format data, error: %s", x, err)
+ReadFieldError:
+	return offset, fmt.Errorf("%T read field %d '%s' error: %s", x, number, fieldIDToName_OfflineReply[number], err)
+}
+
+func (x *OfflineReply) fastReadField1(buf []byte, _type int8) (offset int, err error) {
+	var v Message
+	offset, err = fastpb.ReadMessage(buf, _type, &v)
+	if err != nil {
+		return offset, err
+	}
+	x.Messages = append(x.Messages, &v)
+	return offset, nil
+}
+
+func (x *OfflineReply) fastReadField2(buf []byte, _type int8) (offset int, err error) {
+	x.Next, offset, err = fastpb.ReadInt64(buf, _type)
+	return offset, err
+}
+
+func (x *OfflineReply) fastReadField3(buf []byte, _type int8) (offset int, err error) {
+	x.More, offset, err = fastpb.ReadBool(buf, _type)
+	return offset, err
 }
 
 func (x *RouteReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *RouteReply) fastWriteField1(buf []byte) (offset int) {
+	if x.MessageId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetMessageId())
+	return offset
+}
+
+func (x *RouteReply) fastWriteField2(buf []byte) (offset int) {
+	if x.Sequence == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetSequence())
+	return offset
+}
+
+func (x *RouteReply) fastWriteField3(buf []byte) (offset int) {
+	if !x.Duplicate {
+		return offset
+	}
+	offset += fastpb.WriteBool(buf[offset:], 3, x.GetDuplicate())
+	return offset
+}
+
+func (x *RouteReply) fastWriteField4(buf []byte) (offset int) {
+	if x.STime == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 4, x.GetSTime())
+	return offset
+}
+
+func (x *DeliveredRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *DeliveredRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *DeliveredRequest) fastWriteField2(buf []byte) (offset int) {
+	if len(x.MessageIds) == 0 {
+		return offset
+	}
+	for i := range x.GetMessageIds() {
+		offset += fastpb.WriteString(buf[offset:], 2, x.GetMessageIds()[i])
+	}
+	return offset
+}
+
+func (x *DeliveredRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetUserId())
+	return offset
+}
+
+func (x *DeliveredRequest) fastWriteField4(buf []byte) (offset int) {
+	if len(x.ConvIds) == 0 {
+		return offset
+	}
+	for i := range x.GetConvIds() {
+		offset += fastpb.WriteString(buf[offset:], 4, x.GetConvIds()[i])
+	}
+	return offset
+}
+
+func (x *DeliveredReply) FastWrite(buf []byte) (offset int) {
 	if x == nil {
 		return offset
 	}
 	return offset
 }
 
+func (x *OfflineRequest) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	offset += x.fastWriteField4(buf[offset:])
+	return offset
+}
+
+func (x *OfflineRequest) fastWriteField1(buf []byte) (offset int) {
+	if x.AppId == "" {
+		return offset
+	}
+	offset += fastpb.WriteString(buf[offset:], 1, x.GetAppId())
+	return offset
+}
+
+func (x *OfflineRequest) fastWriteField2(buf []byte) (offset int) {
+	if x.UserId == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetUserId())
+	return offset
+}
+
+func (x *OfflineRequest) fastWriteField3(buf []byte) (offset int) {
+	if x.Since == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 3, x.GetSince())
+	return offset
+}
+
+func (x *OfflineRequest) fastWriteField4(buf []byte) (offset int) {
+	if x.Limit == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt32(buf[offset:], 4, x.GetLimit())
+	return offset
+}
+
+func (x *OfflineReply) FastWrite(buf []byte) (offset int) {
+	if x == nil {
+		return offset
+	}
+	offset += x.fastWriteField1(buf[offset:])
+	offset += x.fastWriteField2(buf[offset:])
+	offset += x.fastWriteField3(buf[offset:])
+	return offset
+}
+
+func (x *OfflineReply) fastWriteField1(buf []byte) (offset int) {
+	if x.Messages == nil {
+		return offset
+	}
+	for i := range x.GetMessages() {
+		offset += fastpb.WriteMessage(buf[offset:], 1, x.GetMessages()[i])
+	}
+	return offset
+}
+
+func (x *OfflineReply) fastWriteField2(buf []byte) (offset int) {
+	if x.Next == 0 {
+		return offset
+	}
+	offset += fastpb.WriteInt64(buf[offset:], 2, x.GetNext())
+	return offset
+}
+
+func (x *OfflineReply) fastWriteField3(buf []byte) (offset int) {
+	if !x.More {
+		return offset
+	}
+	offset += fastpb.WriteBool(buf[offset:], 3, x.GetMore())
+	return offset
+}
+
 func (x *RouteReply) Size() (n int) {
 	if x == nil {
 		return n
 	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	return n
+}
+
+func (x *RouteReply) sizeField1() (n int) {
+	if x.MessageId == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetMessageId())
+	return n
+}
+
+func (x *RouteReply) sizeField2() (n int) {
+	if x.Sequence == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(2, x.GetSequence())
+	return n
+}
+
+func (x *RouteReply) sizeField3() (n int) {
+	if !x.Duplicate {
+		return n
+	}
+	n += fastpb.SizeBool(3, x.GetDuplicate())
+	return n
+}
+
+func (x *RouteReply) sizeField4() (n int) {
+	if x.STime == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(4, x.GetSTime())
+	return n
+}
+
+func (x *DeliveredRequest) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	return n
+}
+
+func (x *DeliveredRequest) sizeField1() (n int) {
+	if x.AppId == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetAppId())
+	return n
+}
+
+func (x *DeliveredRequest) sizeField2() (n int) {
+	if len(x.MessageIds) == 0 {
+		return n
+	}
+	for i := range x.GetMessageIds() {
+		n += fastpb.SizeString(2, x.GetMessageIds()[i])
+	}
+	return n
+}
+
+func (x *DeliveredRequest) sizeField3() (n int) {
+	if x.UserId == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(3, x.GetUserId())
+	return n
+}
+
+func (x *DeliveredRequest) sizeField4() (n int) {
+	if len(x.ConvIds) == 0 {
+		return n
+	}
+	for i := range x.GetConvIds() {
+		n += fastpb.SizeString(4, x.GetConvIds()[i])
+	}
+	return n
+}
+
+func (x *DeliveredReply) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	return n
+}
+
+func (x *OfflineRequest) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	n += x.sizeField4()
+	return n
+}
+
+func (x *OfflineRequest) sizeField1() (n int) {
+	if x.AppId == "" {
+		return n
+	}
+	n += fastpb.SizeString(1, x.GetAppId())
+	return n
+}
+
+func (x *OfflineRequest) sizeField2() (n int) {
+	if x.UserId == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(2, x.GetUserId())
 	return n
 }
 
-var fieldIDToName_RouteReply = map[int32]string{}
+func (x *OfflineRequest) sizeField3() (n int) {
+	if x.Since == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(3, x.GetSince())
+	return n
+}
+
+func (x *OfflineRequest) sizeField4() (n int) {
+	if x.Limit == 0 {
+		return n
+	}
+	n += fastpb.SizeInt32(4, x.GetLimit())
+	return n
+}
+
+func (x *OfflineReply) Size() (n int) {
+	if x == nil {
+		return n
+	}
+	n += x.sizeField1()
+	n += x.sizeField2()
+	n += x.sizeField3()
+	return n
+}
+
+func (x *OfflineReply) sizeField1() (n int) {
+	if x.Messages == nil {
+		return n
+	}
+	for i := range x.GetMessages() {
+		n += fastpb.SizeMessage(1, x.GetMessages()[i])
+	}
+	return n
+}
+
+func (x *OfflineReply) sizeField2() (n int) {
+	if x.Next == 0 {
+		return n
+	}
+	n += fastpb.SizeInt64(2, x.GetNext())
+	return n
+}
+
+func (x *OfflineReply) sizeField3() (n int) {
+	if !x.More {
+		return n
+	}
+	n += fastpb.SizeBool(3, x.GetMore())
+	return n
+}
+
+var fieldIDToName_RouteReply = map[int32]string{
+	1: "MessageId",
+	2: "Sequence",
+	3: "Duplicate",
+	4: "STime",
+}
+
+var fieldIDToName_DeliveredRequest = map[int32]string{
+	1: "AppId",
+	2: "MessageIds",
+	3: "UserId",
+	4: "ConvIds",
+}
+
+var fieldIDToName_DeliveredReply = map[int32]string{}
+
+var fieldIDToName_OfflineRequest = map[int32]string{
+	1: "AppId",
+	2: "UserId",
+	3: "Since",
+	4: "Limit",
+}
+
+var fieldIDToName_OfflineReply = map[int32]string{
+	1: "Messages",
+	2: "Next",
+	3: "More",
+}
@@ -25,6 +25,11 @@ type RouteReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	MessageId string `protobuf:"bytes,1,opt,name=messageId,proto3" json:"messageId,omitempty"`
+	Sequence  int64  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Duplicate bool   `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	STime     int64  `protobuf:"varint,4,opt,name=sTime,proto3" json:"sTime,omitempty"`
 }
 
 func (x *RouteReply) Reset() {
@@ -59,20 +64,367 @@ func (*RouteReply) Descriptor() ([]byte, []int) {
 	return file_router_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *RouteReply) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RouteReply) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *RouteReply) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *RouteReply) GetSTime() int64 {
+	if x != nil {
+		return x.STime
+	}
+	return 0
+}
+
+// 接收者的设备ack了消息
+type DeliveredRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId      string   `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	MessageIds []string `protobuf:"bytes,2,rep,name=messageIds,proto3" json:"messageIds,omitempty"`
+	UserId     int64    `protobuf:"varint,3,opt,name=userId,proto3" json:"userId,omitempty"`  //ack的接收者
+	ConvIds    []string `protobuf:"bytes,4,rep,name=convIds,proto3" json:"convIds,omitempty"` //消息所在的会话，去重
+}
+
+func (x *DeliveredRequest) Reset() {
+	*x = DeliveredRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveredRequest) ProtoMessage() {}
+
+func (x *DeliveredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveredRequest.ProtoReflect.Descriptor instead.
+func (*DeliveredRequest) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeliveredRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *DeliveredRequest) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *DeliveredRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeliveredRequest) GetConvIds() []string {
+	if x != nil {
+		return x.ConvIds
+	}
+	return nil
+}
+
+type DeliveredReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeliveredReply) Reset() {
+	*x = DeliveredReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveredReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveredReply) ProtoMessage() {}
+
+func (x *DeliveredReply) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveredReply.ProtoReflect.Descriptor instead.
+func (*DeliveredReply) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{2}
+}
+
+// 登录后拉取离线收件箱，按sTime从旧到新分页
+type OfflineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppId  string `protobuf:"bytes,1,opt,name=appId,proto3" json:"appId,omitempty"`
+	UserId int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Since  int64  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"` //上一页返回的next，首次为0
+	Limit  int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *OfflineRequest) Reset() {
+	*x = OfflineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfflineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfflineRequest) ProtoMessage() {}
+
+func (x *OfflineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfflineRequest.ProtoReflect.Descriptor instead.
+func (*OfflineRequest) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OfflineRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *OfflineRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *OfflineRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *OfflineRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type OfflineReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messages []*Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Next     int64      `protobuf:"varint,2,opt,name=next,proto3" json:"next,omitempty"` //下一页的since
+	More     bool       `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`
+}
+
+func (x *OfflineReply) Reset() {
+	*x = OfflineReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfflineReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfflineReply) ProtoMessage() {}
+
+func (x *OfflineReply) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfflineReply.ProtoReflect.Descriptor instead.
+func (*OfflineReply) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OfflineReply) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *OfflineReply) GetNext() int64 {
+	if x != nil {
+		return x.Next
+	}
+	return 0
+}
+
+func (x *OfflineReply) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
 var File_router_proto protoreflect.FileDescriptor
 
 var file_router_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03,
 	0x61, 0x70, 0x69, 0x1a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x0c, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x32,
-	0x39, 0x0a, 0x0d, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x28, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x6f,
-	0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x67, 0x69, 0x63, 0x6e, 0x61,
-	0x6e, 0x61, 0x39, 0x39, 0x39, 0x2f, 0x69, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6b, 0x69, 0x74,
-	0x65, 0x78, 0x5f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x6f, 0x22, 0x7a, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x75, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x7a, 0x0a,
+	0x10, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x76, 0x49, 0x64, 0x73, 0x22, 0x10, 0x0a, 0x0e, 0x44, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x6a, 0x0a, 0x0e, 0x4f,
+	0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x70,
+	0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x60, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x28, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x32, 0x8f, 0x06, 0x0a, 0x0d, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x12, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x1a, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x04, 0x52, 0x65, 0x61, 0x64, 0x12, 0x10, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x38, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x70, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0c, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x17, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x37, 0x0a,
+	0x09, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x14, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4b, 0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4b, 0x65, 0x79, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x65, 0x64, 0x12, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x42, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x73,
+	0x68, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x74, 0x69,
+	0x66, 0x79, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x43, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0f, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x12, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x30, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x12, 0x12, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x37, 0x0a, 0x0b, 0x50, 0x75, 0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e,
+	0x65, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x66, 0x66,
+	0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2e, 0x5a, 0x2c, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x67, 0x69, 0x63, 0x6e,
+	0x61, 0x6e, 0x61, 0x39, 0x39, 0x39, 0x2f, 0x69, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6b, 0x69,
+	0x74, 0x65, 0x78, 0x5f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -87,19 +439,68 @@ func file_router_proto_rawDescGZIP() []byte {
 	return file_router_proto_rawDescData
 }
 
-var file_router_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_router_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_router_proto_goTypes = []interface{}{
-	(*RouteReply)(nil), // 0: api.RouteReply
-	(*Message)(nil),    // 1: api.Message
+	(*RouteReply)(nil),            // 0: api.RouteReply
+	(*DeliveredRequest)(nil),      // 1: api.DeliveredRequest
+	(*DeliveredReply)(nil),        // 2: api.DeliveredReply
+	(*OfflineRequest)(nil),        // 3: api.OfflineRequest
+	(*OfflineReply)(nil),          // 4: api.OfflineReply
+	(*Message)(nil),               // 5: api.Message
+	(*ReadRequest)(nil),           // 6: api.ReadRequest
+	(*ReceiptRequest)(nil),        // 7: api.ReceiptRequest
+	(*KeyRegisterRequest)(nil),    // 8: api.KeyRegisterRequest
+	(*KeyFetchRequest)(nil),       // 9: api.KeyFetchRequest
+	(*PushRegisterRequest)(nil),   // 10: api.PushRegisterRequest
+	(*NotifySettingRequest)(nil),  // 11: api.NotifySettingRequest
+	(*ScheduleRequest)(nil),       // 12: api.ScheduleRequest
+	(*ScheduleListRequest)(nil),   // 13: api.ScheduleListRequest
+	(*ScheduleCancelRequest)(nil), // 14: api.ScheduleCancelRequest
+	(*RecallRequest)(nil),         // 15: api.RecallRequest
+	(*ReadReply)(nil),             // 16: api.ReadReply
+	(*ReceiptReply)(nil),          // 17: api.ReceiptReply
+	(*KeyRegisterReply)(nil),      // 18: api.KeyRegisterReply
+	(*KeyFetchReply)(nil),         // 19: api.KeyFetchReply
+	(*PushRegisterReply)(nil),     // 20: api.PushRegisterReply
+	(*NotifySettingReply)(nil),    // 21: api.NotifySettingReply
+	(*ScheduleReply)(nil),         // 22: api.ScheduleReply
+	(*ScheduleListReply)(nil),     // 23: api.ScheduleListReply
+	(*ScheduleCancelReply)(nil),   // 24: api.ScheduleCancelReply
+	(*RecallReply)(nil),           // 25: api.RecallReply
 }
 var file_router_proto_depIdxs = []int32{
-	1, // 0: api.RouterService.Route:input_type -> api.Message
-	0, // 1: api.RouterService.Route:output_type -> api.RouteReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5,  // 0: api.OfflineReply.messages:type_name -> api.Message
+	5,  // 1: api.RouterService.Route:input_type -> api.Message
+	6,  // 2: api.RouterService.Read:input_type -> api.ReadRequest
+	7,  // 3: api.RouterService.QueryReceipt:input_type -> api.ReceiptRequest
+	8,  // 4: api.RouterService.RegisterKeys:input_type -> api.KeyRegisterRequest
+	9,  // 5: api.RouterService.FetchKeys:input_type -> api.KeyFetchRequest
+	1,  // 6: api.RouterService.Delivered:input_type -> api.DeliveredRequest
+	10, // 7: api.RouterService.RegisterPush:input_type -> api.PushRegisterRequest
+	11, // 8: api.RouterService.SetNotify:input_type -> api.NotifySettingRequest
+	12, // 9: api.RouterService.Schedule:input_type -> api.ScheduleRequest
+	13, // 10: api.RouterService.ListScheduled:input_type -> api.ScheduleListRequest
+	14, // 11: api.RouterService.CancelScheduled:input_type -> api.ScheduleCancelRequest
+	15, // 12: api.RouterService.Recall:input_type -> api.RecallRequest
+	3,  // 13: api.RouterService.PullOffline:input_type -> api.OfflineRequest
+	0,  // 14: api.RouterService.Route:output_type -> api.RouteReply
+	16, // 15: api.RouterService.Read:output_type -> api.ReadReply
+	17, // 16: api.RouterService.QueryReceipt:output_type -> api.ReceiptReply
+	18, // 17: api.RouterService.RegisterKeys:output_type -> api.KeyRegisterReply
+	19, // 18: api.RouterService.FetchKeys:output_type -> api.KeyFetchReply
+	2,  // 19: api.RouterService.Delivered:output_type -> api.DeliveredReply
+	20, // 20: api.RouterService.RegisterPush:output_type -> api.PushRegisterReply
+	21, // 21: api.RouterService.SetNotify:output_type -> api.NotifySettingReply
+	22, // 22: api.RouterService.Schedule:output_type -> api.ScheduleReply
+	23, // 23: api.RouterService.ListScheduled:output_type -> api.ScheduleListReply
+	24, // 24: api.RouterService.CancelScheduled:output_type -> api.ScheduleCancelReply
+	25, // 25: api.RouterService.Recall:output_type -> api.RecallReply
+	4,  // 26: api.RouterService.PullOffline:output_type -> api.OfflineReply
+	14, // [14:27] is the sub-list for method output_type
+	1,  // [1:14] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_router_proto_init() }
@@ -121,6 +522,54 @@ func file_router_proto_init() {
 				return nil
 			}
 		}
+		file_router_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveredRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveredReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfflineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfflineReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -128,7 +577,7 @@ func file_router_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_router_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -148,4 +597,16 @@ var _ context.Context
 
 type RouterService interface {
 	Route(ctx context.Context, req *Message) (res *RouteReply, err error)
+	Read(ctx context.Context, req *ReadRequest) (res *ReadReply, err error)
+	QueryReceipt(ctx context.Context, req *ReceiptRequest) (res *ReceiptReply, err error)
+	RegisterKeys(ctx context.Context, req *KeyRegisterRequest) (res *KeyRegisterReply, err error)
+	FetchKeys(ctx context.Context, req *KeyFetchRequest) (res *KeyFetchReply, err error)
+	Delivered(ctx context.Context, req *DeliveredRequest) (res *DeliveredReply, err error)
+	RegisterPush(ctx context.Context, req *PushRegisterRequest) (res *PushRegisterReply, err error)
+	SetNotify(ctx context.Context, req *NotifySettingRequest) (res *NotifySettingReply, err error)
+	Schedule(ctx context.Context, req *ScheduleRequest) (res *ScheduleReply, err error)
+	ListScheduled(ctx context.Context, req *ScheduleListRequest) (res *ScheduleListReply, err error)
+	CancelScheduled(ctx context.Context, req *ScheduleCancelRequest) (res *ScheduleCancelReply, err error)
+	Recall(ctx context.Context, req *RecallRequest) (res *RecallReply, err error)
+	PullOffline(ctx context.Context, req *OfflineRequest) (res *OfflineReply, err error)
 }
@@ -12,6 +12,18 @@ import (
 // Client is designed to provide IDL-compatible methods with call-option parameter for kitex framework.
 type Client interface {
 	Route(ctx context.Context, Req *api.Message, callOptions ...callopt.Option) (r *api.RouteReply, err error)
+	Read(ctx context.Context, Req *api.ReadRequest, callOptions ...callopt.Option) (r *api.ReadReply, err error)
+	QueryReceipt(ctx context.Context, Req *api.ReceiptRequest, callOptions ...callopt.Option) (r *api.ReceiptReply, err error)
+	RegisterKeys(ctx context.Context, Req *api.KeyRegisterRequest, callOptions ...callopt.Option) (r *api.KeyRegisterReply, err error)
+	FetchKeys(ctx context.Context, Req *api.KeyFetchRequest, callOptions ...callopt.Option) (r *api.KeyFetchReply, err error)
+	Delivered(ctx context.Context, Req *api.DeliveredRequest, callOptions ...callopt.Option) (r *api.DeliveredReply, err error)
+	RegisterPush(ctx context.Context, Req *api.PushRegisterRequest, callOptions ...callopt.Option) (r *api.PushRegisterReply, err error)
+	SetNotify(ctx context.Context, Req *api.NotifySettingRequest, callOptions ...callopt.Option) (r *api.NotifySettingReply, err error)
+	Schedule(ctx context.Context, Req *api.ScheduleRequest, callOptions ...callopt.Option) (r *api.ScheduleReply, err error)
+	ListScheduled(ctx context.Context, Req *api.ScheduleListRequest, callOptions ...callopt.Option) (r *api.ScheduleListReply, err error)
+	CancelScheduled(ctx context.Context, Req *api.ScheduleCancelRequest, callOptions ...callopt.Option) (r *api.ScheduleCancelReply, err error)
+	Recall(ctx context.Context, Req *api.RecallRequest, callOptions ...callopt.Option) (r *api.RecallReply, err error)
+	PullOffline(ctx context.Context, Req *api.OfflineRequest, callOptions ...callopt.Option) (r *api.OfflineReply, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -47,3 +59,63 @@ func (p *kRouterServiceClient) Route(ctx context.Context, Req *api.Message, call
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.Route(ctx, Req)
 }
+
+func (p *kRouterServiceClient) Read(ctx context.Context, Req *api.ReadRequest, callOptions ...callopt.Option) (r *api.ReadReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Read(ctx, Req)
+}
+
+func (p *kRouterServiceClient) QueryReceipt(ctx context.Context, Req *api.ReceiptRequest, callOptions ...callopt.Option) (r *api.ReceiptReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.QueryReceipt(ctx, Req)
+}
+
+func (p *kRouterServiceClient) RegisterKeys(ctx context.Context, Req *api.KeyRegisterRequest, callOptions ...callopt.Option) (r *api.KeyRegisterReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.RegisterKeys(ctx, Req)
+}
+
+func (p *kRouterServiceClient) FetchKeys(ctx context.Context, Req *api.KeyFetchRequest, callOptions ...callopt.Option) (r *api.KeyFetchReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.FetchKeys(ctx, Req)
+}
+
+func (p *kRouterServiceClient) Delivered(ctx context.Context, Req *api.DeliveredRequest, callOptions ...callopt.Option) (r *api.DeliveredReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Delivered(ctx, Req)
+}
+
+func (p *kRouterServiceClient) RegisterPush(ctx context.Context, Req *api.PushRegisterRequest, callOptions ...callopt.Option) (r *api.PushRegisterReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.RegisterPush(ctx, Req)
+}
+
+func (p *kRouterServiceClient) SetNotify(ctx context.Context, Req *api.NotifySettingRequest, callOptions ...callopt.Option) (r *api.NotifySettingReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.SetNotify(ctx, Req)
+}
+
+func (p *kRouterServiceClient) Schedule(ctx context.Context, Req *api.ScheduleRequest, callOptions ...callopt.Option) (r *api.ScheduleReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Schedule(ctx, Req)
+}
+
+func (p *kRouterServiceClient) ListScheduled(ctx context.Context, Req *api.ScheduleListRequest, callOptions ...callopt.Option) (r *api.ScheduleListReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListScheduled(ctx, Req)
+}
+
+func (p *kRouterServiceClient) CancelScheduled(ctx context.Context, Req *api.ScheduleCancelRequest, callOptions ...callopt.Option) (r *api.ScheduleCancelReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CancelScheduled(ctx, Req)
+}
+
+func (p *kRouterServiceClient) Recall(ctx context.Context, Req *api.RecallRequest, callOptions ...callopt.Option) (r *api.RecallReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Recall(ctx, Req)
+}
+
+func (p *kRouterServiceClient) PullOffline(ctx context.Context, Req *api.OfflineRequest, callOptions ...callopt.Option) (r *api.OfflineReply, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.PullOffline(ctx, Req)
+}
@@ -22,6 +22,90 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingUnary),
 	),
+	"Read": kitex.NewMethodInfo(
+		readHandler,
+		newReadArgs,
+		newReadResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"QueryReceipt": kitex.NewMethodInfo(
+		queryReceiptHandler,
+		newQueryReceiptArgs,
+		newQueryReceiptResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"RegisterKeys": kitex.NewMethodInfo(
+		registerKeysHandler,
+		newRegisterKeysArgs,
+		newRegisterKeysResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"FetchKeys": kitex.NewMethodInfo(
+		fetchKeysHandler,
+		newFetchKeysArgs,
+		newFetchKeysResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"Delivered": kitex.NewMethodInfo(
+		deliveredHandler,
+		newDeliveredArgs,
+		newDeliveredResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"RegisterPush": kitex.NewMethodInfo(
+		registerPushHandler,
+		newRegisterPushArgs,
+		newRegisterPushResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"SetNotify": kitex.NewMethodInfo(
+		setNotifyHandler,
+		newSetNotifyArgs,
+		newSetNotifyResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"Schedule": kitex.NewMethodInfo(
+		scheduleHandler,
+		newScheduleArgs,
+		newScheduleResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"ListScheduled": kitex.NewMethodInfo(
+		listScheduledHandler,
+		newListScheduledArgs,
+		newListScheduledResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"CancelScheduled": kitex.NewMethodInfo(
+		cancelScheduledHandler,
+		newCancelScheduledArgs,
+		newCancelScheduledResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"Recall": kitex.NewMethodInfo(
+		recallHandler,
+		newRecallArgs,
+		newRecallResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
+	"PullOffline": kitex.NewMethodInfo(
+		pullOfflineHandler,
+		newPullOfflineArgs,
+		newPullOfflineResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingUnary),
+	),
 }
 
 var (
@@ -241,21 +325,1977 @@ func (p *RouteResult) GetResult() interface{} {
 	return p.Success
 }
 
-type kClient struct {
-	c client.Client
+func readHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.ReadRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).Read(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *ReadArgs:
+		success, err := handler.(api.RouterService).Read(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*ReadResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newReadArgs() interface{} {
+	return &ReadArgs{}
 }
 
-func newServiceClient(c client.Client) *kClient {
-	return &kClient{
-		c: c,
+func newReadResult() interface{} {
+	return &ReadResult{}
+}
+
+type ReadArgs struct {
+	Req *api.ReadRequest
+}
+
+func (p *ReadArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.ReadRequest)
 	}
+	return p.Req.FastRead(buf, _type, number)
 }
 
-func (p *kClient) Route(ctx context.Context, Req *api.Message) (r *api.RouteReply, err error) {
-	var _args RouteArgs
-	_args.Req = Req
-	var _result RouteResult
-	if err = p.c.Call(ctx, "Route", &_args, &_result); err != nil {
+func (p *ReadArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *ReadArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *ReadArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *ReadArgs) Unmarshal(in []byte) error {
+	msg := new(api.ReadRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var ReadArgs_Req_DEFAULT *api.ReadRequest
+
+func (p *ReadArgs) GetReq() *api.ReadRequest {
+	if !p.IsSetReq() {
+		return ReadArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *ReadArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ReadArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type ReadResult struct {
+	Success *api.ReadReply
+}
+
+var ReadResult_Success_DEFAULT *api.ReadReply
+
+func (p *ReadResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.ReadReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *ReadResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *ReadResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *ReadResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *ReadResult) Unmarshal(in []byte) error {
+	msg := new(api.ReadReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *ReadResult) GetSuccess() *api.ReadReply {
+	if !p.IsSetSuccess() {
+		return ReadResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *ReadResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.ReadReply)
+}
+
+func (p *ReadResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ReadResult) GetResult() interface{} {
+	return p.Success
+}
+
+func queryReceiptHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.ReceiptRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).QueryReceipt(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *QueryReceiptArgs:
+		success, err := handler.(api.RouterService).QueryReceipt(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*QueryReceiptResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newQueryReceiptArgs() interface{} {
+	return &QueryReceiptArgs{}
+}
+
+func newQueryReceiptResult() interface{} {
+	return &QueryReceiptResult{}
+}
+
+type QueryReceiptArgs struct {
+	Req *api.ReceiptRequest
+}
+
+func (p *QueryReceiptArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.ReceiptRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *QueryReceiptArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *QueryReceiptArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *QueryReceiptArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *QueryReceiptArgs) Unmarshal(in []byte) error {
+	msg := new(api.ReceiptRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var QueryReceiptArgs_Req_DEFAULT *api.ReceiptRequest
+
+func (p *QueryReceiptArgs) GetReq() *api.ReceiptRequest {
+	if !p.IsSetReq() {
+		return QueryReceiptArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *QueryReceiptArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *QueryReceiptArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type QueryReceiptResult struct {
+	Success *api.ReceiptReply
+}
+
+var QueryReceiptResult_Success_DEFAULT *api.ReceiptReply
+
+func (p *QueryReceiptResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.ReceiptReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *QueryReceiptResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *QueryReceiptResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *QueryReceiptResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *QueryReceiptResult) Unmarshal(in []byte) error {
+	msg := new(api.ReceiptReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *QueryReceiptResult) GetSuccess() *api.ReceiptReply {
+	if !p.IsSetSuccess() {
+		return QueryReceiptResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *QueryReceiptResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.ReceiptReply)
+}
+
+func (p *QueryReceiptResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *QueryReceiptResult) GetResult() interface{} {
+	return p.Success
+}
+
+func registerKeysHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.KeyRegisterRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).RegisterKeys(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *RegisterKeysArgs:
+		success, err := handler.(api.RouterService).RegisterKeys(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*RegisterKeysResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newRegisterKeysArgs() interface{} {
+	return &RegisterKeysArgs{}
+}
+
+func newRegisterKeysResult() interface{} {
+	return &RegisterKeysResult{}
+}
+
+type RegisterKeysArgs struct {
+	Req *api.KeyRegisterRequest
+}
+
+func (p *RegisterKeysArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.KeyRegisterRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *RegisterKeysArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *RegisterKeysArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *RegisterKeysArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *RegisterKeysArgs) Unmarshal(in []byte) error {
+	msg := new(api.KeyRegisterRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var RegisterKeysArgs_Req_DEFAULT *api.KeyRegisterRequest
+
+func (p *RegisterKeysArgs) GetReq() *api.KeyRegisterRequest {
+	if !p.IsSetReq() {
+		return RegisterKeysArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *RegisterKeysArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *RegisterKeysArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type RegisterKeysResult struct {
+	Success *api.KeyRegisterReply
+}
+
+var RegisterKeysResult_Success_DEFAULT *api.KeyRegisterReply
+
+func (p *RegisterKeysResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.KeyRegisterReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *RegisterKeysResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *RegisterKeysResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *RegisterKeysResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *RegisterKeysResult) Unmarshal(in []byte) error {
+	msg := new(api.KeyRegisterReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *RegisterKeysResult) GetSuccess() *api.KeyRegisterReply {
+	if !p.IsSetSuccess() {
+		return RegisterKeysResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *RegisterKeysResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.KeyRegisterReply)
+}
+
+func (p *RegisterKeysResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *RegisterKeysResult) GetResult() interface{} {
+	return p.Success
+}
+
+func fetchKeysHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.KeyFetchRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).FetchKeys(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *FetchKeysArgs:
+		success, err := handler.(api.RouterService).FetchKeys(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*FetchKeysResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newFetchKeysArgs() interface{} {
+	return &FetchKeysArgs{}
+}
+
+func newFetchKeysResult() interface{} {
+	return &FetchKeysResult{}
+}
+
+type FetchKeysArgs struct {
+	Req *api.KeyFetchRequest
+}
+
+func (p *FetchKeysArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.KeyFetchRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *FetchKeysArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *FetchKeysArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *FetchKeysArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *FetchKeysArgs) Unmarshal(in []byte) error {
+	msg := new(api.KeyFetchRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var FetchKeysArgs_Req_DEFAULT *api.KeyFetchRequest
+
+func (p *FetchKeysArgs) GetReq() *api.KeyFetchRequest {
+	if !p.IsSetReq() {
+		return FetchKeysArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *FetchKeysArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *FetchKeysArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type FetchKeysResult struct {
+	Success *api.KeyFetchReply
+}
+
+var FetchKeysResult_Success_DEFAULT *api.KeyFetchReply
+
+func (p *FetchKeysResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.KeyFetchReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *FetchKeysResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *FetchKeysResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *FetchKeysResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *FetchKeysResult) Unmarshal(in []byte) error {
+	msg := new(api.KeyFetchReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *FetchKeysResult) GetSuccess() *api.KeyFetchReply {
+	if !p.IsSetSuccess() {
+		return FetchKeysResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *FetchKeysResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.KeyFetchReply)
+}
+
+func (p *FetchKeysResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *FetchKeysResult) GetResult() interface{} {
+	return p.Success
+}
+
+func deliveredHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.DeliveredRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).Delivered(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *DeliveredArgs:
+		success, err := handler.(api.RouterService).Delivered(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*DeliveredResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newDeliveredArgs() interface{} {
+	return &DeliveredArgs{}
+}
+
+func newDeliveredResult() interface{} {
+	return &DeliveredResult{}
+}
+
+type DeliveredArgs struct {
+	Req *api.DeliveredRequest
+}
+
+func (p *DeliveredArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.DeliveredRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *DeliveredArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *DeliveredArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *DeliveredArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *DeliveredArgs) Unmarshal(in []byte) error {
+	msg := new(api.DeliveredRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var DeliveredArgs_Req_DEFAULT *api.DeliveredRequest
+
+func (p *DeliveredArgs) GetReq() *api.DeliveredRequest {
+	if !p.IsSetReq() {
+		return DeliveredArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *DeliveredArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *DeliveredArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type DeliveredResult struct {
+	Success *api.DeliveredReply
+}
+
+var DeliveredResult_Success_DEFAULT *api.DeliveredReply
+
+func (p *DeliveredResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.DeliveredReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *DeliveredResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *DeliveredResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *DeliveredResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *DeliveredResult) Unmarshal(in []byte) error {
+	msg := new(api.DeliveredReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *DeliveredResult) GetSuccess() *api.DeliveredReply {
+	if !p.IsSetSuccess() {
+		return DeliveredResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *DeliveredResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.DeliveredReply)
+}
+
+func (p *DeliveredResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *DeliveredResult) GetResult() interface{} {
+	return p.Success
+}
+
+func registerPushHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.PushRegisterRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).RegisterPush(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *RegisterPushArgs:
+		success, err := handler.(api.RouterService).RegisterPush(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*RegisterPushResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newRegisterPushArgs() interface{} {
+	return &RegisterPushArgs{}
+}
+
+func newRegisterPushResult() interface{} {
+	return &RegisterPushResult{}
+}
+
+type RegisterPushArgs struct {
+	Req *api.PushRegisterRequest
+}
+
+func (p *RegisterPushArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.PushRegisterRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *RegisterPushArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *RegisterPushArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *RegisterPushArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *RegisterPushArgs) Unmarshal(in []byte) error {
+	msg := new(api.PushRegisterRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var RegisterPushArgs_Req_DEFAULT *api.PushRegisterRequest
+
+func (p *RegisterPushArgs) GetReq() *api.PushRegisterRequest {
+	if !p.IsSetReq() {
+		return RegisterPushArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *RegisterPushArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *RegisterPushArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type RegisterPushResult struct {
+	Success *api.PushRegisterReply
+}
+
+var RegisterPushResult_Success_DEFAULT *api.PushRegisterReply
+
+func (p *RegisterPushResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.PushRegisterReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *RegisterPushResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *RegisterPushResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *RegisterPushResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *RegisterPushResult) Unmarshal(in []byte) error {
+	msg := new(api.PushRegisterReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *RegisterPushResult) GetSuccess() *api.PushRegisterReply {
+	if !p.IsSetSuccess() {
+		return RegisterPushResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *RegisterPushResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.PushRegisterReply)
+}
+
+func (p *RegisterPushResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *RegisterPushResult) GetResult() interface{} {
+	return p.Success
+}
+
+func setNotifyHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.NotifySettingRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).SetNotify(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *SetNotifyArgs:
+		success, err := handler.(api.RouterService).SetNotify(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*SetNotifyResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newSetNotifyArgs() interface{} {
+	return &SetNotifyArgs{}
+}
+
+func newSetNotifyResult() interface{} {
+	return &SetNotifyResult{}
+}
+
+type SetNotifyArgs struct {
+	Req *api.NotifySettingRequest
+}
+
+func (p *SetNotifyArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.NotifySettingRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *SetNotifyArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *SetNotifyArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *SetNotifyArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *SetNotifyArgs) Unmarshal(in []byte) error {
+	msg := new(api.NotifySettingRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var SetNotifyArgs_Req_DEFAULT *api.NotifySettingRequest
+
+func (p *SetNotifyArgs) GetReq() *api.NotifySettingRequest {
+	if !p.IsSetReq() {
+		return SetNotifyArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *SetNotifyArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *SetNotifyArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type SetNotifyResult struct {
+	Success *api.NotifySettingReply
+}
+
+var SetNotifyResult_Success_DEFAULT *api.NotifySettingReply
+
+func (p *SetNotifyResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.NotifySettingReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *SetNotifyResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *SetNotifyResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *SetNotifyResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *SetNotifyResult) Unmarshal(in []byte) error {
+	msg := new(api.NotifySettingReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *SetNotifyResult) GetSuccess() *api.NotifySettingReply {
+	if !p.IsSetSuccess() {
+		return SetNotifyResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *SetNotifyResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.NotifySettingReply)
+}
+
+func (p *SetNotifyResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *SetNotifyResult) GetResult() interface{} {
+	return p.Success
+}
+
+func scheduleHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.ScheduleRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).Schedule(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *ScheduleArgs:
+		success, err := handler.(api.RouterService).Schedule(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*ScheduleResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newScheduleArgs() interface{} {
+	return &ScheduleArgs{}
+}
+
+func newScheduleResult() interface{} {
+	return &ScheduleResult{}
+}
+
+type ScheduleArgs struct {
+	Req *api.ScheduleRequest
+}
+
+func (p *ScheduleArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.ScheduleRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *ScheduleArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *ScheduleArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *ScheduleArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *ScheduleArgs) Unmarshal(in []byte) error {
+	msg := new(api.ScheduleRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var ScheduleArgs_Req_DEFAULT *api.ScheduleRequest
+
+func (p *ScheduleArgs) GetReq() *api.ScheduleRequest {
+	if !p.IsSetReq() {
+		return ScheduleArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *ScheduleArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ScheduleArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type ScheduleResult struct {
+	Success *api.ScheduleReply
+}
+
+var ScheduleResult_Success_DEFAULT *api.ScheduleReply
+
+func (p *ScheduleResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.ScheduleReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *ScheduleResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *ScheduleResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *ScheduleResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *ScheduleResult) Unmarshal(in []byte) error {
+	msg := new(api.ScheduleReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *ScheduleResult) GetSuccess() *api.ScheduleReply {
+	if !p.IsSetSuccess() {
+		return ScheduleResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *ScheduleResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.ScheduleReply)
+}
+
+func (p *ScheduleResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ScheduleResult) GetResult() interface{} {
+	return p.Success
+}
+
+func listScheduledHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.ScheduleListRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).ListScheduled(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *ListScheduledArgs:
+		success, err := handler.(api.RouterService).ListScheduled(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*ListScheduledResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newListScheduledArgs() interface{} {
+	return &ListScheduledArgs{}
+}
+
+func newListScheduledResult() interface{} {
+	return &ListScheduledResult{}
+}
+
+type ListScheduledArgs struct {
+	Req *api.ScheduleListRequest
+}
+
+func (p *ListScheduledArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.ScheduleListRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *ListScheduledArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *ListScheduledArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *ListScheduledArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *ListScheduledArgs) Unmarshal(in []byte) error {
+	msg := new(api.ScheduleListRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var ListScheduledArgs_Req_DEFAULT *api.ScheduleListRequest
+
+func (p *ListScheduledArgs) GetReq() *api.ScheduleListRequest {
+	if !p.IsSetReq() {
+		return ListScheduledArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *ListScheduledArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *ListScheduledArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type ListScheduledResult struct {
+	Success *api.ScheduleListReply
+}
+
+var ListScheduledResult_Success_DEFAULT *api.ScheduleListReply
+
+func (p *ListScheduledResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.ScheduleListReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *ListScheduledResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *ListScheduledResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *ListScheduledResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *ListScheduledResult) Unmarshal(in []byte) error {
+	msg := new(api.ScheduleListReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *ListScheduledResult) GetSuccess() *api.ScheduleListReply {
+	if !p.IsSetSuccess() {
+		return ListScheduledResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *ListScheduledResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.ScheduleListReply)
+}
+
+func (p *ListScheduledResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ListScheduledResult) GetResult() interface{} {
+	return p.Success
+}
+
+func cancelScheduledHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.ScheduleCancelRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).CancelScheduled(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *CancelScheduledArgs:
+		success, err := handler.(api.RouterService).CancelScheduled(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*CancelScheduledResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newCancelScheduledArgs() interface{} {
+	return &CancelScheduledArgs{}
+}
+
+func newCancelScheduledResult() interface{} {
+	return &CancelScheduledResult{}
+}
+
+type CancelScheduledArgs struct {
+	Req *api.ScheduleCancelRequest
+}
+
+func (p *CancelScheduledArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.ScheduleCancelRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *CancelScheduledArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *CancelScheduledArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *CancelScheduledArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *CancelScheduledArgs) Unmarshal(in []byte) error {
+	msg := new(api.ScheduleCancelRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var CancelScheduledArgs_Req_DEFAULT *api.ScheduleCancelRequest
+
+func (p *CancelScheduledArgs) GetReq() *api.ScheduleCancelRequest {
+	if !p.IsSetReq() {
+		return CancelScheduledArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *CancelScheduledArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CancelScheduledArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type CancelScheduledResult struct {
+	Success *api.ScheduleCancelReply
+}
+
+var CancelScheduledResult_Success_DEFAULT *api.ScheduleCancelReply
+
+func (p *CancelScheduledResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.ScheduleCancelReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *CancelScheduledResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *CancelScheduledResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *CancelScheduledResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *CancelScheduledResult) Unmarshal(in []byte) error {
+	msg := new(api.ScheduleCancelReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *CancelScheduledResult) GetSuccess() *api.ScheduleCancelReply {
+	if !p.IsSetSuccess() {
+		return CancelScheduledResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *CancelScheduledResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.ScheduleCancelReply)
+}
+
+func (p *CancelScheduledResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CancelScheduledResult) GetResult() interface{} {
+	return p.Success
+}
+
+func recallHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.RecallRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).Recall(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *RecallArgs:
+		success, err := handler.(api.RouterService).Recall(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*RecallResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newRecallArgs() interface{} {
+	return &RecallArgs{}
+}
+
+func newRecallResult() interface{} {
+	return &RecallResult{}
+}
+
+type RecallArgs struct {
+	Req *api.RecallRequest
+}
+
+func (p *RecallArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.RecallRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *RecallArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *RecallArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *RecallArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *RecallArgs) Unmarshal(in []byte) error {
+	msg := new(api.RecallRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var RecallArgs_Req_DEFAULT *api.RecallRequest
+
+func (p *RecallArgs) GetReq() *api.RecallRequest {
+	if !p.IsSetReq() {
+		return RecallArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *RecallArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *RecallArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type RecallResult struct {
+	Success *api.RecallReply
+}
+
+var RecallResult_Success_DEFAULT *api.RecallReply
+
+func (p *RecallResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.RecallReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *RecallResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *RecallResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *RecallResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *RecallResult) Unmarshal(in []byte) error {
+	msg := new(api.RecallReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *RecallResult) GetSuccess() *api.RecallReply {
+	if !p.IsSetSuccess() {
+		return RecallResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *RecallResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.RecallReply)
+}
+
+func (p *RecallResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *RecallResult) GetResult() interface{} {
+	return p.Success
+}
+
+func pullOfflineHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	switch s := arg.(type) {
+	case *streaming.Args:
+		st := s.Stream
+		req := new(api.OfflineRequest)
+		if err := st.RecvMsg(req); err != nil {
+			return err
+		}
+		resp, err := handler.(api.RouterService).PullOffline(ctx, req)
+		if err != nil {
+			return err
+		}
+		return st.SendMsg(resp)
+	case *PullOfflineArgs:
+		success, err := handler.(api.RouterService).PullOffline(ctx, s.Req)
+		if err != nil {
+			return err
+		}
+		realResult := result.(*PullOfflineResult)
+		realResult.Success = success
+		return nil
+	default:
+		return errInvalidMessageType
+	}
+}
+func newPullOfflineArgs() interface{} {
+	return &PullOfflineArgs{}
+}
+
+func newPullOfflineResult() interface{} {
+	return &PullOfflineResult{}
+}
+
+type PullOfflineArgs struct {
+	Req *api.OfflineRequest
+}
+
+func (p *PullOfflineArgs) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetReq() {
+		p.Req = new(api.OfflineRequest)
+	}
+	return p.Req.FastRead(buf, _type, number)
+}
+
+func (p *PullOfflineArgs) FastWrite(buf []byte) (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.FastWrite(buf)
+}
+
+func (p *PullOfflineArgs) Size() (n int) {
+	if !p.IsSetReq() {
+		return 0
+	}
+	return p.Req.Size()
+}
+
+func (p *PullOfflineArgs) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetReq() {
+		return out, nil
+	}
+	return proto.Marshal(p.Req)
+}
+
+func (p *PullOfflineArgs) Unmarshal(in []byte) error {
+	msg := new(api.OfflineRequest)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Req = msg
+	return nil
+}
+
+var PullOfflineArgs_Req_DEFAULT *api.OfflineRequest
+
+func (p *PullOfflineArgs) GetReq() *api.OfflineRequest {
+	if !p.IsSetReq() {
+		return PullOfflineArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+
+func (p *PullOfflineArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *PullOfflineArgs) GetFirstArgument() interface{} {
+	return p.Req
+}
+
+type PullOfflineResult struct {
+	Success *api.OfflineReply
+}
+
+var PullOfflineResult_Success_DEFAULT *api.OfflineReply
+
+func (p *PullOfflineResult) FastRead(buf []byte, _type int8, number int32) (n int, err error) {
+	if !p.IsSetSuccess() {
+		p.Success = new(api.OfflineReply)
+	}
+	return p.Success.FastRead(buf, _type, number)
+}
+
+func (p *PullOfflineResult) FastWrite(buf []byte) (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.FastWrite(buf)
+}
+
+func (p *PullOfflineResult) Size() (n int) {
+	if !p.IsSetSuccess() {
+		return 0
+	}
+	return p.Success.Size()
+}
+
+func (p *PullOfflineResult) Marshal(out []byte) ([]byte, error) {
+	if !p.IsSetSuccess() {
+		return out, nil
+	}
+	return proto.Marshal(p.Success)
+}
+
+func (p *PullOfflineResult) Unmarshal(in []byte) error {
+	msg := new(api.OfflineReply)
+	if err := proto.Unmarshal(in, msg); err != nil {
+		return err
+	}
+	p.Success = msg
+	return nil
+}
+
+func (p *PullOfflineResult) GetSuccess() *api.OfflineReply {
+	if !p.IsSetSuccess() {
+		return PullOfflineResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+func (p *PullOfflineResult) SetSuccess(x interface{}) {
+	p.Success = x.(*api.OfflineReply)
+}
+
+func (p *PullOfflineResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *PullOfflineResult) GetResult() interface{} {
+	return p.Success
+}
+
+type kClient struct {
+	c client.Client
+}
+
+func newServiceClient(c client.Client) *kClient {
+	return &kClient{
+		c: c,
+	}
+}
+
+func (p *kClient) Route(ctx context.Context, Req *api.Message) (r *api.RouteReply, err error) {
+	var _args RouteArgs
+	_args.Req = Req
+	var _result RouteResult
+	if err = p.c.Call(ctx, "Route", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Read(ctx context.Context, Req *api.ReadRequest) (r *api.ReadReply, err error) {
+	var _args ReadArgs
+	_args.Req = Req
+	var _result ReadResult
+	if err = p.c.Call(ctx, "Read", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) QueryReceipt(ctx context.Context, Req *api.ReceiptRequest) (r *api.ReceiptReply, err error) {
+	var _args QueryReceiptArgs
+	_args.Req = Req
+	var _result QueryReceiptResult
+	if err = p.c.Call(ctx, "QueryReceipt", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) RegisterKeys(ctx context.Context, Req *api.KeyRegisterRequest) (r *api.KeyRegisterReply, err error) {
+	var _args RegisterKeysArgs
+	_args.Req = Req
+	var _result RegisterKeysResult
+	if err = p.c.Call(ctx, "RegisterKeys", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) FetchKeys(ctx context.Context, Req *api.KeyFetchRequest) (r *api.KeyFetchReply, err error) {
+	var _args FetchKeysArgs
+	_args.Req = Req
+	var _result FetchKeysResult
+	if err = p.c.Call(ctx, "FetchKeys", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Delivered(ctx context.Context, Req *api.DeliveredRequest) (r *api.DeliveredReply, err error) {
+	var _args DeliveredArgs
+	_args.Req = Req
+	var _result DeliveredResult
+	if err = p.c.Call(ctx, "Delivered", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) RegisterPush(ctx context.Context, Req *api.PushRegisterRequest) (r *api.PushRegisterReply, err error) {
+	var _args RegisterPushArgs
+	_args.Req = Req
+	var _result RegisterPushResult
+	if err = p.c.Call(ctx, "RegisterPush", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) SetNotify(ctx context.Context, Req *api.NotifySettingRequest) (r *api.NotifySettingReply, err error) {
+	var _args SetNotifyArgs
+	_args.Req = Req
+	var _result SetNotifyResult
+	if err = p.c.Call(ctx, "SetNotify", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Schedule(ctx context.Context, Req *api.ScheduleRequest) (r *api.ScheduleReply, err error) {
+	var _args ScheduleArgs
+	_args.Req = Req
+	var _result ScheduleResult
+	if err = p.c.Call(ctx, "Schedule", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListScheduled(ctx context.Context, Req *api.ScheduleListRequest) (r *api.ScheduleListReply, err error) {
+	var _args ListScheduledArgs
+	_args.Req = Req
+	var _result ListScheduledResult
+	if err = p.c.Call(ctx, "ListScheduled", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) CancelScheduled(ctx context.Context, Req *api.ScheduleCancelRequest) (r *api.ScheduleCancelReply, err error) {
+	var _args CancelScheduledArgs
+	_args.Req = Req
+	var _result CancelScheduledResult
+	if err = p.c.Call(ctx, "CancelScheduled", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Recall(ctx context.Context, Req *api.RecallRequest) (r *api.RecallReply, err error) {
+	var _args RecallArgs
+	_args.Req = Req
+	var _result RecallResult
+	if err = p.c.Call(ctx, "Recall", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) PullOffline(ctx context.Context, Req *api.OfflineRequest) (r *api.OfflineReply, err error) {
+	var _args PullOfflineArgs
+	_args.Req = Req
+	var _result PullOfflineResult
+	if err = p.c.Call(ctx, "PullOffline", &_args, &_result); err != nil {
 		return
 	}
 	return _result.GetSuccess(), nil
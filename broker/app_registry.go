@@ -0,0 +1,152 @@
+package broker
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"go.uber.org/fx"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefAppRegistryRefresh 从app注册表刷新限流配置的间隔
+	DefAppRegistryRefresh = 30 * time.Second
+)
+
+// noAppRule 注册表中没有该app的限流配置
+var noAppRule = &global.RateLimitRule{}
+
+// AppRegistry app注册表中的限流配置，保存在 im:{appId}:app:ratelimit 的hash中，
+// 字段为 packets/bytes/messages/commands/burst，burst为 time.Duration 格式。
+// 第一次用到某个app时在后台加载，之后按Refresh定期刷新，加载完成前使用配置文件的规则
+type AppRegistry struct {
+	rds     *redis.Client
+	refresh time.Duration
+	rules   sync.Map // appId -> *global.RateLimitRule
+	loading sync.Map // appId -> struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	logger  *Logger
+}
+
+// NewAppRegistry 未配置限流时返回nil
+func NewAppRegistry(g *global.Config, rds *redis.Client, lc fx.Lifecycle) *AppRegistry {
+	c := getOrDefaultRateLimitConfig(getOrDefaultTCPConfig(g))
+	if c == nil {
+		return nil
+	}
+
+	ar := newAppRegistry(rds, c.Refresh)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go ar.loop()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			ar.cancel()
+			return nil
+		},
+	})
+	return ar
+}
+
+func newAppRegistry(rds *redis.Client, refresh time.Duration) *AppRegistry {
+	if refresh <= 0 {
+		refresh = DefAppRegistryRefresh
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AppRegistry{
+		rds:     rds,
+		refresh: refresh,
+		ctx:     ctx,
+		cancel:  cancel,
+		logger:  NewLogger("app"),
+	}
+}
+
+// Rule 注册表中app的限流配置，没有或尚未加载时返回nil。
+// 在事件循环中调用，不访问redis；规则变化时返回新的指针
+func (r *AppRegistry) Rule(appId string) *global.RateLimitRule {
+	if r == nil || appId == "" {
+		return nil
+	}
+
+	if v, ok := r.rules.Load(appId); ok {
+		if rule := v.(*global.RateLimitRule); rule != noAppRule {
+			return rule
+		}
+		return nil
+	}
+
+	if _, loading := r.loading.LoadOrStore(appId, struct{}{}); !loading {
+		go func() {
+			defer r.loading.Delete(appId)
+			r.load(r.ctx, appId)
+		}()
+	}
+	return nil
+}
+
+func (r *AppRegistry) loop() {
+	ticker := time.NewTicker(r.refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.rules.Range(func(key, _ any) bool {
+			r.load(r.ctx, key.(string))
+			return r.ctx.Err() == nil
+		})
+	}
+}
+
+// load 规则没有变化时保留原来的指针，限流器据此判断是否需要重建令牌桶
+func (r *AppRegistry) load(ctx context.Context, appId string) {
+	kv, err := r.rds.HGetAll(ctx, infra.KeyAppRateLimit(appId)).Result()
+	if err != nil {
+		r.logger.SrvInfo("load app rate limit failed", SrvLifecycle, err)
+		return
+	}
+
+	rule := parseAppRule(kv)
+	if old, ok := r.rules.Load(appId); ok && *old.(*global.RateLimitRule) == *rule {
+		return
+	}
+	r.rules.Store(appId, rule)
+}
+
+// parseAppRule 无法解析的字段忽略，使用配置文件的值
+func parseAppRule(kv map[string]string) *global.RateLimitRule {
+	if len(kv) == 0 {
+		return noAppRule
+	}
+
+	rule := &global.RateLimitRule{}
+	fields := map[string]*float64{
+		"packets":  &rule.Packets,
+		"bytes":    &rule.Bytes,
+		"messages": &rule.Messages,
+		"commands": &rule.Commands,
+	}
+	for name, v := range fields {
+		if f, err := strconv.ParseFloat(kv[name], 64); err == nil {
+			*v = f
+		}
+	}
+	if d, err := time.ParseDuration(kv["burst"]); err == nil {
+		rule.Burst = d
+	}
+
+	if *rule == *noAppRule {
+		return noAppRule
+	}
+	return rule
+}
@@ -0,0 +1,92 @@
+package broker
+
+import (
+	"errors"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/global"
+	"github.com/panjf2000/gnet/v2"
+	"time"
+)
+
+const (
+	// DefOutboundHighWater 每个连接待发送字节的默认高水位
+	DefOutboundHighWater = 4 << 20
+
+	// DefSlowConsumerTimeout 持续超过高水位的时间超过该值时断开连接
+	DefSlowConsumerTimeout = 30 * time.Second
+)
+
+var errSlowConsumer = errors.New("outbound over high water")
+
+func getOrDefaultBackpressureConfig(g *global.Config) *global.BackpressureConfig {
+	c := &global.BackpressureConfig{}
+	if g != nil && g.TCP != nil && g.TCP.Backpressure != nil {
+		*c = *g.TCP.Backpressure
+	}
+
+	if c.HighWater <= 0 {
+		c.HighWater = DefOutboundHighWater
+	}
+
+	if c.SlowTimeout <= 0 {
+		c.SlowTimeout = DefSlowConsumerTimeout
+	}
+
+	return c
+}
+
+// Backpressure 写背压。待发送字节 = 已提交AsyncWrite未写出的 + gnet写缓冲中的，
+// 超过高水位时不再写入新的投递，由调用方转离线；持续超过SlowTimeout的连接视为慢消费者，由心跳检查断开。
+// 回复客户端请求的packet不受高水位限制，其数量由限流约束
+type Backpressure struct {
+	cfg *global.BackpressureConfig
+}
+
+func NewBackpressure(g *global.Config) *Backpressure {
+	return &Backpressure{cfg: getOrDefaultBackpressureConfig(g)}
+}
+
+// Congested 是否超过高水位，并记录开始超过的时间
+func (b *Backpressure) Congested(uc *domain.UserConn) bool {
+	if uc.OutboundDepth() <= b.cfg.HighWater {
+		uc.SlowSince.Store(time.Time{})
+		return false
+	}
+
+	if uc.SlowSince.Load().IsZero() {
+		uc.SlowSince.Store(time.Now())
+	}
+	return true
+}
+
+// Stalled 持续超过高水位的时间是否超过SlowTimeout
+func (b *Backpressure) Stalled(uc *domain.UserConn, now time.Time) bool {
+	if !b.Congested(uc) {
+		return false
+	}
+	return now.Sub(uc.SlowSince.Load()) > b.cfg.SlowTimeout
+}
+
+// asyncWrite 异步写入并统计待发送字节
+func asyncWrite(uc *domain.UserConn, bs []byte, callback gnet.AsyncCallback) error {
+	n := int64(len(bs))
+	uc.Pending.Add(n)
+
+	err := uc.Conn.AsyncWrite(bs, func(c gnet.Conn, err error) error {
+		uc.Pending.Sub(n)
+		if callback != nil {
+			return callback(c, err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		uc.Pending.Sub(n)
+	}
+	return err
+}
+
+// sampleOutbound gnet的写缓冲只能在event loop中读取
+func sampleOutbound(c gnet.Conn, uc *domain.UserConn) {
+	uc.Buffered.Store(int64(c.OutboundBuffered()))
+}
@@ -0,0 +1,95 @@
+package broker
+
+import (
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/panjf2000/gnet/v2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// stuckConn 模拟不读取数据的客户端，AsyncWrite的回调在flush时才执行
+type stuckConn struct {
+	gnet.Conn
+	callbacks []func()
+	buffered  int
+}
+
+func (c *stuckConn) AsyncWrite(buf []byte, callback gnet.AsyncCallback) error {
+	n := len(buf)
+	c.callbacks = append(c.callbacks, func() {
+		c.buffered += n
+		_ = callback(c, nil)
+	})
+	return nil
+}
+
+func (c *stuckConn) OutboundBuffered() int {
+	return c.buffered
+}
+
+// flush event loop执行已提交的写入，数据进入写缓冲
+func (c *stuckConn) flush() {
+	for _, f := range c.callbacks {
+		f()
+	}
+	c.callbacks = nil
+}
+
+func TestBackpressureDepth(t *testing.T) {
+	c := &stuckConn{}
+	uc := &domain.UserConn{Conn: c}
+
+	assert.NoError(t, asyncWrite(uc, make([]byte, 100), nil))
+	assert.NoError(t, asyncWrite(uc, make([]byte, 50), nil))
+	assert.Equal(t, int64(150), uc.Pending.Load())
+
+	c.flush()
+	sampleOutbound(c, uc)
+	assert.Equal(t, int64(0), uc.Pending.Load())
+	assert.Equal(t, int64(150), uc.Buffered.Load())
+	assert.Equal(t, int64(150), uc.OutboundDepth())
+}
+
+func TestBackpressureWriter(t *testing.T) {
+	c := &stuckConn{}
+	uc := &domain.UserConn{Conn: c}
+	w := NewPacketWriter(NewCodec(), newFixture(t).backpressure(1024), NewLogger("test"))
+
+	// 客户端不读取，超过高水位后拒绝新的投递
+	var err error
+	for i := 0; i < 10 && err == nil; i++ {
+		err = w.Write(largeText(), uc)
+		c.flush()
+		sampleOutbound(c, uc)
+	}
+	assert.ErrorIs(t, err, errSlowConsumer)
+	assert.Greater(t, uc.OutboundDepth(), int64(1024))
+	assert.False(t, uc.SlowSince.Load().IsZero())
+
+	// 写缓冲排空后恢复
+	c.buffered = 0
+	sampleOutbound(c, uc)
+	assert.NoError(t, w.Write(api.NewHeartbeat(1).Wrap(), uc))
+	assert.True(t, uc.SlowSince.Load().IsZero())
+}
+
+func TestBackpressureStalled(t *testing.T) {
+	bp := newFixture(t).backpressure(1024)
+	uc := &domain.UserConn{}
+
+	now := time.Now()
+	assert.False(t, bp.Stalled(uc, now))
+
+	uc.Buffered.Store(2048)
+	assert.False(t, bp.Stalled(uc, now))
+	assert.True(t, bp.Stalled(uc, now.Add(2*time.Second)))
+
+	uc.Buffered.Store(0)
+	assert.False(t, bp.Stalled(uc, now.Add(2*time.Second)))
+
+	c := getOrDefaultBackpressureConfig(nil)
+	assert.Equal(t, int64(DefOutboundHighWater), c.HighWater)
+	assert.Equal(t, DefSlowConsumerTimeout, c.SlowTimeout)
+}
@@ -0,0 +1,66 @@
+package cmd_service
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	brokerctx "github.com/magicnana999/im/broker/ctx"
+	"github.com/magicnana999/im/errors"
+	"go.uber.org/fx"
+)
+
+type ConvService struct {
+	routerCli routerservice.Client
+}
+
+func NewConvService(rc routerservice.Client, lf fx.Lifecycle) (*ConvService, error) {
+	return &ConvService{routerCli: rc}, nil
+}
+
+// Read 上报会话已读sequence，appId/userId以当前连接为准
+func (s *ConvService) Read(ctx context.Context, request *api.ReadRequest) (*api.ReadReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.Read(ctx, request)
+}
+
+// QueryReceipt 查询群消息的已读/未读成员，仅发送者可查
+func (s *ConvService) QueryReceipt(ctx context.Context, request *api.ReceiptRequest) (*api.ReceiptReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.QueryReceipt(ctx, request)
+}
+
+// SetNotify 修改或查询通知设置，appId/userId以当前连接为准
+func (s *ConvService) SetNotify(ctx context.Context, request *api.NotifySettingRequest) (*api.NotifySettingReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.SetNotify(ctx, request)
+}
+
+// Recall 撤回当前用户发送的消息
+func (s *ConvService) Recall(ctx context.Context, request *api.RecallRequest) (*api.RecallReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.Recall(ctx, request)
+}
@@ -0,0 +1,42 @@
+package cmd_service
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	brokerctx "github.com/magicnana999/im/broker/ctx"
+	"github.com/magicnana999/im/errors"
+	"go.uber.org/fx"
+)
+
+type KeyService struct {
+	routerCli routerservice.Client
+}
+
+func NewKeyService(rc routerservice.Client, lf fx.Lifecycle) (*KeyService, error) {
+	return &KeyService{routerCli: rc}, nil
+}
+
+// Register 注册当前设备的公钥，appId/userId以当前连接为准
+func (s *KeyService) Register(ctx context.Context, request *api.KeyRegisterRequest) (*api.KeyRegisterReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.RegisterKeys(ctx, request)
+}
+
+// Fetch 拉取目标用户的设备公钥，用于建立加密会话
+func (s *KeyService) Fetch(ctx context.Context, request *api.KeyFetchRequest) (*api.KeyFetchReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.FetchKeys(ctx, request)
+}
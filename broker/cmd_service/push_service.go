@@ -0,0 +1,34 @@
+package cmd_service
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	brokerctx "github.com/magicnana999/im/broker/ctx"
+	"github.com/magicnana999/im/errors"
+	"go.uber.org/fx"
+)
+
+type PushService struct {
+	routerCli routerservice.Client
+}
+
+func NewPushService(rc routerservice.Client, lf fx.Lifecycle) (*PushService, error) {
+	return &PushService{routerCli: rc}, nil
+}
+
+// Register 注册当前连接的推送token，appId/userId/label以当前连接为准
+func (s *PushService) Register(ctx context.Context, request *api.PushRegisterRequest) (*api.PushRegisterReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	request.Label = uc.Label()
+	if request.Vendor == "" {
+		request.Vendor = uc.OS.Load()
+	}
+	return s.routerCli.RegisterPush(ctx, request)
+}
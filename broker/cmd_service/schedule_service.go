@@ -0,0 +1,54 @@
+package cmd_service
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	brokerctx "github.com/magicnana999/im/broker/ctx"
+	"github.com/magicnana999/im/errors"
+	"go.uber.org/fx"
+)
+
+type ScheduleService struct {
+	routerCli routerservice.Client
+}
+
+func NewScheduleService(rc routerservice.Client, lf fx.Lifecycle) (*ScheduleService, error) {
+	return &ScheduleService{routerCli: rc}, nil
+}
+
+// Send 提交定时发送的消息，appId/userId以当前连接为准
+func (s *ScheduleService) Send(ctx context.Context, request *api.ScheduleRequest) (*api.ScheduleReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.Schedule(ctx, request)
+}
+
+// List 查询当前用户未发送的定时消息
+func (s *ScheduleService) List(ctx context.Context, request *api.ScheduleListRequest) (*api.ScheduleListReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.ListScheduled(ctx, request)
+}
+
+// Cancel 取消当前用户未发送的定时消息
+func (s *ScheduleService) Cancel(ctx context.Context, request *api.ScheduleCancelRequest) (*api.ScheduleCancelReply, error) {
+	uc, err := brokerctx.GetCurUserConn(ctx)
+	if err != nil {
+		return nil, errors.CurUserNotFound.SetDetail(err.Error())
+	}
+
+	request.AppId = uc.AppId.Load()
+	request.UserId = uc.UserId.Load()
+	return s.routerCli.CancelScheduled(ctx, request)
+}
@@ -1,6 +1,13 @@
 package domain
 
+import "fmt"
+
 type BrokerInfo struct {
 	Addr    string `json:"addr"`
 	StartAt int64  `json:"startAt"`
 }
+
+// Id broker实例，同一地址重启后是新的实例 addr@startAt
+func (b BrokerInfo) Id() string {
+	return fmt.Sprintf("%s@%d", b.Addr, b.StartAt)
+}
@@ -9,6 +9,11 @@ import (
 	"time"
 )
 
+const (
+	ProtocolTCP = "tcp"
+	ProtocolWS  = "ws"
+)
+
 type UserConn struct {
 	Fd            int           `json:"fd"`
 	AppId         atomic.String `json:"appId"`
@@ -16,7 +21,13 @@ type UserConn struct {
 	OS            atomic.String `json:"os"`
 	ClientAddr    string        `json:"clientAddr"`
 	BrokerAddr    string        `json:"brokerAddr"`
-	ConnectTime   int64         `json:"connectTime"` //首次连接时间 毫秒
+	ConnectTime   int64         `json:"connectTime"`  //首次连接时间 毫秒
+	Protocol      string        `json:"protocol"`     //接入协议 tcp/ws
+	FrameVersion  atomic.Int32  `json:"frameVersion"` //tcp帧版本，首帧协商，0为未协商
+	Compression   atomic.String `json:"compression"`  //登录时协商的压缩算法，为空不压缩
+	Pending       atomic.Int64  `json:"pending"`      //已提交AsyncWrite、event loop还未写出的字节
+	Buffered      atomic.Int64  `json:"buffered"`     //gnet写缓冲中的字节，在event loop中采样
+	SlowSince     atomic.Time   `json:"-"`            //待发送字节开始超过高水位的时间，未超过时为零值
 	IsLogin       atomic.Bool   `json:"-"`
 	IsClosed      atomic.Bool   `json:"-"`
 	LastHeartbeat atomic.Time   `json:"-"` //上次心跳 毫秒
@@ -28,8 +39,8 @@ func NewUserConn(c gnet.Conn) *UserConn {
 	uc := &UserConn{
 		Fd:          c.Fd(),
 		ClientAddr:  c.RemoteAddr().String(),
-		BrokerAddr:  c.LocalAddr().String(),
 		ConnectTime: time.Now().UnixMilli(),
+		Protocol:    ProtocolTCP,
 		Reader:      c,
 		Conn:        c,
 	}
@@ -76,6 +87,11 @@ func (u *UserConn) Label() string {
 	return fmt.Sprintf("%s#%s#%s", u.AppId.Load(), u.UserId.String(), u.OS.Load())
 }
 
+// OutboundDepth 待发送给客户端的字节数
+func (u *UserConn) OutboundDepth() int64 {
+	return u.Pending.Load() + u.Buffered.Load()
+}
+
 // Refresh 刷新上次心跳时间
 func (u *UserConn) Refresh(t time.Time) {
 	u.LastHeartbeat.Store(t)
@@ -0,0 +1,121 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/pkg/jsonext"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefDrainTimeout 下线时等待重发任务的默认时间，略大于重发的超时时间
+const DefDrainTimeout = DefRetryTimeout + 5*time.Second
+
+var errDraining = errors.New("broker is draining")
+
+func getOrDefaultDrainConfig(g *global.Config) *global.DrainConfig {
+	c := &global.DrainConfig{}
+	if g != nil && g.Drain != nil {
+		*c = *g.Drain
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = DefDrainTimeout
+	}
+
+	return c
+}
+
+// Drainer broker优雅下线，由信号或管理接口触发：
+// 从etcd注销，停止接受新连接，通知已有连接重连到其他broker，
+// 等待重发任务被ack或转离线，超过Timeout后剩余的立即转离线，之后才停止各个server
+type Drainer struct {
+	cfg    *global.DrainConfig
+	tcp    *TcpServer
+	rbs    *RpcBrokerServer
+	mrs    *MessageRetryServer
+	admin  *http.Server
+	once   sync.Once
+	done   chan struct{}
+	logger *Logger
+}
+
+func NewDrainer(g *global.Config, tcp *TcpServer, rbs *RpcBrokerServer, mrs *MessageRetryServer, lc fx.Lifecycle) (*Drainer, error) {
+	c := getOrDefaultDrainConfig(g)
+
+	log := NewLogger("drain")
+	log.SrvInfo(string(jsonext.MarshalNoErr(c)), SrvLifecycle, nil)
+
+	d := &Drainer{
+		cfg:    c,
+		tcp:    tcp,
+		rbs:    rbs,
+		mrs:    mrs,
+		done:   make(chan struct{}),
+		logger: log,
+	}
+
+	if c.AdminAddr == "" {
+		return d, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/drain", d.handleDrain)
+	d.admin = &http.Server{Addr: c.AdminAddr, Handler: mux}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				err := d.admin.ListenAndServe()
+				d.logger.SrvInfo("admin server stopped", SrvLifecycle, err)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return d.admin.Shutdown(ctx)
+		},
+	})
+
+	return d, nil
+}
+
+// Done 下线完成后关闭
+func (d *Drainer) Done() <-chan struct{} {
+	return d.done
+}
+
+// Drain 执行下线，重复调用时等待第一次完成；剩余的重发任务保存到离线后才完成，ctx结束时不再重试
+func (d *Drainer) Drain(ctx context.Context) {
+	d.once.Do(func() {
+		defer close(d.done)
+
+		d.logger.SrvInfo("drain started", SrvLifecycle, nil)
+
+		if err := d.rbs.Deregister(); err != nil {
+			d.logger.SrvInfo("deregister failed, continue draining", SrvLifecycle, err)
+		}
+
+		n := d.tcp.Reconnect(&api.ReconnectRequest{Addrs: d.cfg.Alternatives, Reason: errDraining.Error()})
+		d.logger.SrvInfo("reconnect sent", SrvLifecycle, nil, zap.Int("conns", n))
+
+		resaved, lost := d.mrs.Drain(ctx, d.cfg.Timeout)
+		d.logger.SrvInfo("drain completed", SrvLifecycle, nil, zap.Int("resaved", resaved), zap.Int("lost", lost))
+	})
+	<-d.done
+}
+
+// handleDrain POST /drain 异步触发下线，下线完成后进程退出
+func (d *Drainer) handleDrain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	go d.Drain(context.Background())
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,34 @@
+package broker
+
+import (
+	"github.com/magicnana999/im/global"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDrainConfig(t *testing.T) {
+	c := getOrDefaultDrainConfig(nil)
+	assert.Equal(t, DefDrainTimeout, c.Timeout)
+	assert.Empty(t, c.AdminAddr)
+
+	c = getOrDefaultDrainConfig(&global.Config{Drain: &global.DrainConfig{Timeout: time.Second, Alternatives: []string{"127.0.0.1:5076"}}})
+	assert.Equal(t, time.Second, c.Timeout)
+	assert.Equal(t, []string{"127.0.0.1:5076"}, c.Alternatives)
+}
+
+func TestDrainAdminMethod(t *testing.T) {
+	d := &Drainer{done: make(chan struct{})}
+
+	w := httptest.NewRecorder()
+	d.handleDrain(w, httptest.NewRequest(http.MethodGet, "/drain", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	select {
+	case <-d.Done():
+		t.Fatal("drain should not start")
+	default:
+	}
+}
@@ -0,0 +1,75 @@
+package broker
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/global"
+	"testing"
+	"time"
+)
+
+// fixture broker各组件测试共用的环境，miniredis在测试结束时关闭
+type fixture struct {
+	tb  testing.TB
+	mr  *miniredis.Miniredis
+	rds *redis.Client
+}
+
+func newFixture(tb testing.TB) *fixture {
+	mr := miniredis.RunT(tb)
+	rds := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	tb.Cleanup(func() { rds.Close() })
+	return &fixture{tb: tb, mr: mr, rds: rds}
+}
+
+// rateLimiter app注册表使用fixture的redis
+func (f *fixture) rateLimiter(rule global.RateLimitRule, apps map[string]*global.RateLimitRule) *RateLimiter {
+	registry := newAppRegistry(f.rds, time.Minute)
+	f.tb.Cleanup(registry.cancel)
+
+	return NewRateLimiter(getOrDefaultRateLimitConfig(&global.TCPConfig{
+		RateLimit: &global.RateLimitConfig{RateLimitRule: rule, MaxViolations: 3, Apps: apps},
+	}), registry)
+}
+
+func (f *fixture) backpressure(highWater int64) *Backpressure {
+	return NewBackpressure(&global.Config{TCP: &global.TCPConfig{
+		Backpressure: &global.BackpressureConfig{HighWater: highWater, SlowTimeout: time.Second},
+	}})
+}
+
+func (f *fixture) resaver(c *global.OfflineConfig) *MessageResaver {
+	return NewMessageResaver(&global.Config{MRS: &global.MRSConfig{Offline: c}}, f.rds, nil)
+}
+
+func (f *fixture) retryServer(c *global.MRSConfig) *MessageRetryServer {
+	return &MessageRetryServer{
+		cfg:    getOrDefaultMRSConfig(&global.Config{MRS: c}),
+		mr:     f.resaver(nil),
+		logger: NewLogger("mrs"),
+	}
+}
+
+// sendServer 未启动发送循环，发送的消息留在队列中
+func (f *fixture) sendServer(size int, bp *Backpressure) *MessageSendServer {
+	mss := &MessageSendServer{
+		ch:     make(chan *messageSending, size),
+		logger: NewLogger("test"),
+		mr:     f.resaver(nil),
+		mw:     NewPacketWriter(NewCodec(), bp, NewLogger("test")),
+	}
+	mss.isRunning.Store(true)
+	return mss
+}
+
+func newLoginConn(appId string, userId int64) *domain.UserConn {
+	uc := &domain.UserConn{}
+	uc.Login(appId, userId, "iOS")
+	return uc
+}
+
+func newTextPacket() *api.Packet {
+	return api.NewMessage(1, 2, 0, 1, "19860220", "1:2", &api.Text{Text: "hi"}).Wrap()
+}
@@ -12,12 +12,20 @@ import (
 type CommandHandler struct {
 	userHolder  *holder.UserHolder
 	userService *cmd_service.UserService
+	convService *cmd_service.ConvService
+	keyService  *cmd_service.KeyService
+	pushService *cmd_service.PushService
+	schService  *cmd_service.ScheduleService
 }
 
-func NewCommandHandler(uh *holder.UserHolder, us *cmd_service.UserService) (*CommandHandler, error) {
+func NewCommandHandler(uh *holder.UserHolder, us *cmd_service.UserService, cs *cmd_service.ConvService, ks *cmd_service.KeyService, ps *cmd_service.PushService, ss *cmd_service.ScheduleService) (*CommandHandler, error) {
 	return &CommandHandler{
 		userHolder:  uh,
 		userService: us,
+		convService: cs,
+		keyService:  ks,
+		pushService: ps,
+		schService:  ss,
 	}, nil
 
 }
@@ -35,6 +43,26 @@ func (c *CommandHandler) HandlePacket(ctx context.Context, packet *api.Packet) (
 		reply, err = c.userService.Login(ctx, mb.GetLoginRequest())
 	case api.CommandTypeUserLogout:
 		reply, err = c.userService.Logout(ctx, mb.GetLogoutRequest())
+	case api.CommandTypeConvRead:
+		reply, err = c.convService.Read(ctx, mb.GetReadRequest())
+	case api.CommandTypeMessageReceipt:
+		reply, err = c.convService.QueryReceipt(ctx, mb.GetReceiptRequest())
+	case api.CommandTypeNotifySetting:
+		reply, err = c.convService.SetNotify(ctx, mb.GetNotifySettingRequest())
+	case api.CommandTypeKeyRegister:
+		reply, err = c.keyService.Register(ctx, mb.GetKeyRegisterRequest())
+	case api.CommandTypeKeyFetch:
+		reply, err = c.keyService.Fetch(ctx, mb.GetKeyFetchRequest())
+	case api.CommandTypePushRegister:
+		reply, err = c.pushService.Register(ctx, mb.GetPushRegisterRequest())
+	case api.CommandTypeScheduleSend:
+		reply, err = c.schService.Send(ctx, mb.GetScheduleRequest())
+	case api.CommandTypeScheduleList:
+		reply, err = c.schService.List(ctx, mb.GetScheduleListRequest())
+	case api.CommandTypeScheduleCancel:
+		reply, err = c.schService.Cancel(ctx, mb.GetScheduleCancelRequest())
+	case api.CommandTypeRecall:
+		reply, err = c.convService.Recall(ctx, mb.GetRecallRequest())
 	default:
 		err = errors.CmdUnknownType
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/magicnana999/im/api/kitex_gen/api"
 	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	brokerctx "github.com/magicnana999/im/broker/ctx"
+	"github.com/magicnana999/im/errors"
 	"go.uber.org/fx"
 )
 
@@ -24,8 +26,25 @@ func (m *MessageHandler) HandlePacket(ctx context.Context, p *api.Packet) (*api.
 
 	mb := p.GetMessage()
 	if mb.IsRequest() {
-		_, err := m.routerClient.Route(ctx, mb)
-		return mb.Response(nil, err).Wrap(), err
+		// 发送者以当前连接为准，router据此判断会话
+		uc, err := brokerctx.GetCurUserConn(ctx)
+		if err != nil {
+			err = errors.CurUserNotFound.SetDetail(err.Error())
+			return mb.Response(nil, err).Wrap(), err
+		}
+		mb.AppId = uc.AppId.Load()
+		mb.UserId = uc.UserId.Load()
+
+		reply, err := m.routerClient.Route(ctx, mb)
+		res := mb.Response(nil, err)
+
+		// router分配的sequence和服务端时间，重复发送时为首次发送的messageId和sequence
+		if reply != nil && reply.MessageId != "" {
+			res.MessageId = reply.MessageId
+			res.Sequence = reply.Sequence
+			res.STime = reply.STime
+		}
+		return res.Wrap(), err
 	}
 
 	return nil, nil
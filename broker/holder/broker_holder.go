@@ -18,23 +18,79 @@ func NewBrokerHolder(rds *redis.Client, lf fx.Lifecycle) (*BrokerHolder, error)
 	return &BrokerHolder{rds: rds}, nil
 }
 
-func (s *BrokerHolder) StoreBroker(ctx context.Context, broker domain.BrokerInfo) (string, error) {
+// StoreBroker 登记broker，租约到期前需RefreshBroker续约
+func (s *BrokerHolder) StoreBroker(ctx context.Context, broker domain.BrokerInfo, lease time.Duration) (string, error) {
 	json, err := json.Marshal(broker)
 	if err != nil {
 		return "", err
 	}
 
 	key := infra.KeyBroker(broker.Addr)
+	now, err := s.rds.Time(ctx).Result()
+	if err != nil {
+		return "", err
+	}
 
-	ret := s.rds.Set(ctx, key, json, time.Minute)
+	pipe := s.rds.TxPipeline()
+	ret := pipe.Set(ctx, key, json, lease)
+	pipe.ZAdd(ctx, infra.KeyBrokers(), &redis.Z{Score: leaseScore(now, lease), Member: broker.Id()})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
 	return ret.Val(), ret.Err()
 }
 
-func (s *BrokerHolder) RefreshBroker(ctx context.Context, broker domain.BrokerInfo) (bool, error) {
+// refreshScript KEYS[1]为broker信息，KEYS[2]为所有broker实例；
+// 实例租约已过期或已被清理时不再续约，返回0
+var refreshScript = redis.NewScript(`
+	local score = redis.call("ZSCORE", KEYS[2], ARGV[1])
+	if not score or tonumber(score) < tonumber(ARGV[2]) then
+		return 0
+	end
+	redis.call("ZADD", KEYS[2], ARGV[3], ARGV[1])
+	return redis.call("PEXPIRE", KEYS[1], ARGV[4])
+`)
 
-	key := infra.KeyBroker(broker.Addr)
+// RefreshBroker 续约，返回false时租约已丢失，需以新的实例重新StoreBroker
+func (s *BrokerHolder) RefreshBroker(ctx context.Context, broker domain.BrokerInfo, lease time.Duration) (bool, error) {
 
-	ret := s.rds.Expire(ctx, key, time.Minute)
+	keys := []string{infra.KeyBroker(broker.Addr), infra.KeyBrokers()}
+	now, err := s.rds.Time(ctx).Result()
+	if err != nil {
+		return false, err
+	}
+
+	ret, err := refreshScript.Run(ctx, s.rds, keys, broker.Id(), now.UnixMilli(), leaseScore(now, lease), lease.Milliseconds()).Int64()
+	return ret == 1, err
+}
 
+// ExpireBroker 停止时立即过期，剩余的连接由router清理
+func (s *BrokerHolder) ExpireBroker(ctx context.Context, broker domain.BrokerInfo) error {
+	pipe := s.rds.TxPipeline()
+	pipe.Del(ctx, infra.KeyBroker(broker.Addr))
+	pipe.ZAddXX(ctx, infra.KeyBrokers(), &redis.Z{Score: 0, Member: broker.Id()})
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// StoreBrokerClient 登记broker实例上登录的连接
+func (s *BrokerHolder) StoreBrokerClient(ctx context.Context, broker domain.BrokerInfo, uc *domain.UserConn) (int64, error) {
+	key := infra.KeyBrokerClients(broker.Id())
+	js, err := json.Marshal(uc)
+	if err != nil {
+		return 0, err
+	}
+	ret := s.rds.HSet(ctx, key, uc.Label(), string(js))
 	return ret.Val(), ret.Err()
 }
+
+func (s *BrokerHolder) DeleteBrokerClient(ctx context.Context, broker domain.BrokerInfo, uc *domain.UserConn) (int64, error) {
+	key := infra.KeyBrokerClients(broker.Id())
+	ret := s.rds.HDel(ctx, key, uc.Label())
+	return ret.Val(), ret.Err()
+}
+
+// leaseScore 租约到期时间 毫秒，now取redis的时间，与router清理时使用同一个时钟
+func leaseScore(now time.Time, lease time.Duration) float64 {
+	return float64(now.Add(lease).UnixMilli())
+}
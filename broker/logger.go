@@ -52,7 +52,7 @@ func (s *Logger) Printf(format string, args ...any) {
 	if !s.Logger.IsDebugEnabled() {
 		return
 	}
-	s.Debug(fmt.Sprintf(format, args))
+	s.Debug(fmt.Sprintf(format, args...))
 }
 
 func (s *Logger) SrvInfo(msg string, ezf EventZapField, err error, field ...zap.Field) {
@@ -1,16 +1,120 @@
 package broker
 
 import (
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v8"
 	"github.com/magicnana999/im/api/kitex_gen/api"
 	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/jsonext"
+	"go.uber.org/fx"
+	"time"
 )
 
+// 转离线的原因
+const (
+	ResaveTimeout        = "timeout"
+	ResaveMaxAttempts    = "max attempts"
+	ResaveWriteFailed    = "write failed"
+	ResaveScheduleFailed = "schedule failed"
+	ResaveShutdown       = "shutdown"
+)
+
+const (
+	// DefResaveExpire 离线收件箱的默认保留时间，与router一致
+	DefResaveExpire = 7 * 24 * time.Hour
+
+	// DefResaveMaxMessages 每个用户离线收件箱默认保留的消息数，与router一致
+	DefResaveMaxMessages = 1000
+
+	// resaveTimeout 写入redis的超时，停服时也需要在该时间内完成
+	resaveTimeout = 3 * time.Second
+)
+
+// DeliveryAttempt 投递记录，随消息一起转离线
+type DeliveryAttempt struct {
+	Attempts  int32  `json:"attempts"`  //发送次数，包括首次发送
+	FirstSend int64  `json:"firstSend"` //首次发送时间 毫秒
+	LastSend  int64  `json:"lastSend"`  //最后一次发送时间 毫秒
+	Reason    string `json:"reason"`
+}
+
+func getOrDefaultResaveConfig(g *global.Config) *global.OfflineConfig {
+	c := &global.OfflineConfig{}
+	if g != nil && g.MRS != nil && g.MRS.Offline != nil {
+		*c = *g.MRS.Offline
+	}
+
+	if c.Expire <= 0 {
+		c.Expire = DefResaveExpire
+	}
+
+	if c.MaxMessages <= 0 {
+		c.MaxMessages = DefResaveMaxMessages
+	}
+
+	return c
+}
+
+// MessageResaver 投递失败的消息转离线
+//
+// 消息id写入接收者的离线收件箱 im:{appId}:offline:{userId}，与router的离线兜底是同一个收件箱，
+// 消息内容在消息历史中；投递记录按消息id保存在 im:{appId}:offline:attempt:{userId}
 type MessageResaver struct {
+	cfg    *global.OfflineConfig
+	rds    *redis.Client
+	logger *Logger
+}
+
+func NewMessageResaver(g *global.Config, rds *redis.Client, lc fx.Lifecycle) *MessageResaver {
+	c := getOrDefaultResaveConfig(g)
+
+	log := NewLogger("resaver")
+	log.SrvInfo(string(jsonext.MarshalNoErr(c)), SrvLifecycle, nil)
+
+	return &MessageResaver{cfg: c, rds: rds, logger: log}
 }
 
-func NewMessageResaver() *MessageResaver {
-	return &MessageResaver{}
+// Resave 写入接收者的离线收件箱，返回nil时已保存
+func (mr *MessageResaver) Resave(m *api.Message, uc *domain.UserConn, a *DeliveryAttempt) error {
+	bs, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resaveTimeout)
+	defer cancel()
+
+	appId, userId := uc.AppId.Load(), uc.UserId.Load()
+	key, attemptKey := infra.KeyOffline(appId, userId), infra.KeyOfflineAttempt(appId, userId)
+	_, err = mr.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, &redis.Z{Score: float64(m.STime), Member: m.MessageId})
+		pipe.ZRemRangeByRank(ctx, key, 0, -mr.cfg.MaxMessages-1)
+		pipe.PExpire(ctx, key, mr.cfg.Expire)
+		pipe.HSet(ctx, attemptKey, m.MessageId, bs)
+		pipe.PExpire(ctx, attemptKey, mr.cfg.Expire)
+		return nil
+	})
+
+	mr.logger.PktDebug("resave message", uc.Desc(), m.MessageId, nil, PacketTracking, err)
+	return err
 }
 
-func (mr *MessageResaver) Resave(m *api.Message, uc *domain.UserConn) {
+// Attempt 转离线时的投递记录，没有时返回nil
+func (mr *MessageResaver) Attempt(ctx context.Context, appId string, userId int64, messageId string) (*DeliveryAttempt, error) {
+	bs, err := mr.rds.HGet(ctx, infra.KeyOfflineAttempt(appId, userId), messageId).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a := &DeliveryAttempt{}
+	if err := json.Unmarshal(bs, a); err != nil {
+		return nil, err
+	}
+	return a, nil
 }
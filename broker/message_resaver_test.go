@@ -0,0 +1,50 @@
+package broker
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestResave(t *testing.T) {
+	ctx := context.Background()
+	r := newFixture(t).resaver(&global.OfflineConfig{MaxMessages: 2})
+	assert.Equal(t, DefResaveExpire, r.cfg.Expire)
+
+	uc := &domain.UserConn{}
+	uc.Login("19860220", 2, "iOS")
+
+	var ids []string
+	for seq := int64(1); seq <= 3; seq++ {
+		m := api.NewMessage(1, 2, 0, seq, "19860220", "1:2", &api.Text{Text: "hi"})
+		m.STime = seq
+		a := &DeliveryAttempt{Attempts: int32(seq), FirstSend: 1000, LastSend: 2000, Reason: ResaveMaxAttempts}
+		assert.NoError(t, r.Resave(m, uc, a))
+		ids = append(ids, m.MessageId)
+	}
+
+	// 收件箱按sTime排序，超过上限删除最早的
+	inbox, err := r.rds.ZRange(ctx, infra.KeyOffline("19860220", 2), 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, ids[1:], inbox)
+
+	ttl := r.rds.PTTL(ctx, infra.KeyOfflineAttempt("19860220", 2)).Val()
+	assert.InDelta(t, float64(DefResaveExpire), float64(ttl), float64(time.Second))
+
+	a, err := r.Attempt(ctx, "19860220", 2, ids[2])
+	assert.NoError(t, err)
+	assert.Equal(t, &DeliveryAttempt{Attempts: 3, FirstSend: 1000, LastSend: 2000, Reason: ResaveMaxAttempts}, a)
+
+	a, err = r.Attempt(ctx, "19860220", 3, ids[2])
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+
+	// redis不可用时返回错误
+	r.rds.Close()
+	assert.Error(t, r.Resave(api.NewMessage(1, 2, 0, 4, "19860220", "1:2", &api.Text{Text: "hi"}), uc, &DeliveryAttempt{}))
+}
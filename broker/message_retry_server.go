@@ -6,11 +6,15 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"math"
+	"math/rand"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
 	"github.com/magicnana999/im/broker/domain"
 	"github.com/magicnana999/im/global"
 	"github.com/magicnana999/im/pkg/jsonext"
@@ -18,6 +22,35 @@ import (
 	"github.com/magicnana999/im/pkg/timewheel"
 )
 
+const (
+	// DefRetryTimeout 首次发送后超过此时间不再重发
+	DefRetryTimeout = time.Second * 10
+
+	// DefRetryInitialInterval 首次发送到第一次重发的间隔
+	DefRetryInitialInterval = time.Second
+
+	// DefRetryMultiplier 每次重发后间隔翻倍
+	DefRetryMultiplier = 2.0
+
+	// DefRetryMaxAttempts 最多发送次数，包括首次发送
+	DefRetryMaxAttempts = 4
+
+	// retrySlotTick 重发时间轮的精度
+	retrySlotTick = time.Millisecond * 100
+
+	// deliveredTimeout 通知router已送达的超时时间
+	deliveredTimeout = time.Second * 3
+
+	// drainPollInterval 下线时检查剩余重发任务的间隔
+	drainPollInterval = time.Millisecond * 100
+
+	// resaveRetryInterval 转离线失败后再次保存的间隔
+	resaveRetryInterval = time.Second
+
+	// resaveMaxAttempts 重发结束后最多保存次数，仍失败时放弃该消息
+	resaveMaxAttempts = 3
+)
+
 func getOrDefaultMRSConfig(g *global.Config) *global.MRSConfig {
 	c := &global.MRSConfig{}
 	if g != nil && g.MRS != nil {
@@ -25,30 +58,59 @@ func getOrDefaultMRSConfig(g *global.Config) *global.MRSConfig {
 	}
 
 	if c.Timeout <= 0 {
-		c.Timeout = time.Second * 3
+		c.Timeout = DefRetryTimeout
+	}
+
+	if c.InitialInterval <= 0 {
+		c.InitialInterval = DefRetryInitialInterval
+	}
+
+	if c.Multiplier < 1 {
+		c.Multiplier = DefRetryMultiplier
+	}
+
+	if c.Jitter < 0 || c.Jitter > 1 {
+		c.Jitter = 0
+	}
+
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefRetryMaxAttempts
 	}
 
 	return c
 }
 
+// retryBackoff 第attempt次发送后到下一次执行的间隔，r为[0,1)的随机数，间隔在 ±Jitter 内浮动
+func retryBackoff(c *global.MRSConfig, attempt int, r float64) time.Duration {
+	d := float64(c.InitialInterval) * math.Pow(c.Multiplier, float64(attempt-1))
+	d *= 1 + c.Jitter*(2*r-1)
+	return time.Duration(d)
+}
+
+// errResaveDropped 多次转离线失败后放弃消息
+var errResaveDropped = errors.New("resave failed, message dropped")
+
 type MessageRetryServer struct {
-	tasks  sync.Map             // tasks stores message retry tasks by message ID.
+	tasks  sync.Map             // tasks stores message retry tasks by connection label and message ID.
+	convs  sync.Map             // convs indexes tasks by connection and conversation for cumulative acks.
 	tw     *timewheel.Timewheel // tw schedules retry tasks.
 	cfg    *global.MRSConfig    // cfg holds retry configuration.
 	logger *Logger              // logger records server events.
 	mw     *PacketWriter
 	mr     *MessageResaver
+	rc     routerservice.Client // rc reports delivered messages to the router.
 }
 
-func NewMessageRetryServer(g *global.Config, lc fx.Lifecycle) (*MessageRetryServer, error) {
+func NewMessageRetryServer(g *global.Config, rc routerservice.Client, mr *MessageResaver, lc fx.Lifecycle) (*MessageRetryServer, error) {
 	c := getOrDefaultMRSConfig(g)
 
 	log := NewLogger("mrs")
 	log.SrvInfo(string(jsonext.MarshalNoErr(c)), SrvLifecycle, nil)
 
+	// 任务按到期时间放入槽中，到期时间不晚于Timeout，时间轮转一圈需要覆盖Timeout
 	twc := &timewheel.Config{
-		SlotTick:      time.Millisecond * 100,
-		SlotCount:     30,
+		SlotTick:      retrySlotTick,
+		SlotCount:     int(c.Timeout/retrySlotTick) + 2,
 		SlotMaxLength: 100_0000,
 	}
 	log.SrvInfo(string(jsonext.MarshalNoErr(twc)), SrvLifecycle, nil)
@@ -65,8 +127,9 @@ func NewMessageRetryServer(g *global.Config, lc fx.Lifecycle) (*MessageRetryServ
 		tw:     tw,
 		cfg:    c,
 		logger: log,
-		mw:     NewPacketWriter(NewCodec(), log),
-		mr:     NewMessageResaver(),
+		mw:     NewPacketWriter(NewCodec(), NewBackpressure(g), log),
+		mr:     mr,
+		rc:     rc,
 	}
 
 	lc.Append(fx.Hook{
@@ -89,26 +152,77 @@ func (s *MessageRetryServer) Start(ctx context.Context) error {
 	return nil
 }
 
+// Stop 剩余的任务在返回前转离线
 func (s *MessageRetryServer) Stop(ctx context.Context) error {
 	s.tw.Stop()
 	s.logger.SrvInfo("timewheel-mrs stopped", SrvLifecycle, nil)
-	s.logger.SrvInfo("resave the remaining message", SrvLifecycle, nil)
-	go s.resaveMessages()
+	n, failed := s.resaveMessages()
+	s.logger.SrvInfo("resave the remaining message", SrvLifecycle, nil, zap.Int("count", n), zap.Int("failed", failed))
 	return nil
 }
 
-func (s *MessageRetryServer) resaveMessages() {
-
+// resaveMessages 剩余的任务立即转离线，返回保存成功和失败的数量
+func (s *MessageRetryServer) resaveMessages() (n, failed int) {
 	s.tasks.Range(func(key, value interface{}) bool {
 		task, ok := value.(*messageRetryTask)
-		if ok && task != nil {
-			s.mr.Resave(task.m, task.uc)
+		if !ok || task == nil {
+			return true
+		}
+		if s.resave(task, ResaveShutdown) {
+			n++
+		} else {
+			failed++
 		}
 		return true
 	})
+	return n, failed
+}
+
+// Inflight 等待ack的任务数
+func (s *MessageRetryServer) Inflight() int {
+	n := 0
+	s.tasks.Range(func(key, value interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Drain 等待任务被ack或按重发策略转离线，超过timeout后剩余的立即转离线。
+// 转离线失败的任务保留并继续重试，全部保存后才返回；ctx结束时不再重试，
+// 返回立即转离线的数量和未能保存的数量
+func (s *MessageRetryServer) Drain(ctx context.Context, timeout time.Duration) (resaved, lost int) {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	wait, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for s.Inflight() > 0 {
+		select {
+		case <-wait.Done():
+		case <-ticker.C:
+			continue
+		}
+
+		n, failed := s.resaveMessages()
+		resaved += n
+		if failed == 0 {
+			return resaved, 0
+		}
+
+		s.logger.SrvInfo("resave failed, retry", SrvLifecycle, nil, zap.Int("failed", failed))
+		select {
+		case <-ctx.Done():
+			return resaved, failed
+		case <-ticker.C:
+		}
+	}
+	return resaved, 0
 }
 
-func (s *MessageRetryServer) Submit(m *api.Message, uc *domain.UserConn, firstSend int64) error {
+// Submit 首次发送成功后提交，按退避策略安排重发
+func (s *MessageRetryServer) Submit(m *api.Message, uc *domain.UserConn, firstSend time.Time) error {
 	if m == nil || m.MessageId == "" {
 		return errors.New("invalid message")
 	}
@@ -116,62 +230,322 @@ func (s *MessageRetryServer) Submit(m *api.Message, uc *domain.UserConn, firstSe
 		return errors.New("invalid or closed connection")
 	}
 
+	task := s.newTask(m, uc, firstSend)
+
+	s.tasks.Store(task.key, task)
+	s.index(task)
+	if err := s.schedule(task); err != nil {
+		s.tasks.Delete(task.key)
+		s.unindex(task)
+		return err
+	}
+	return nil
+}
+
+func (s *MessageRetryServer) newTask(m *api.Message, uc *domain.UserConn, firstSend time.Time) *messageRetryTask {
 	task := &messageRetryTask{
-		uc:         uc,
-		m:          m,
-		timeout:    s.cfg.Timeout,
-		writeFunc:  s.write,
-		resaveFunc: s.resave,
+		key:          retryTaskKey{label: uc.Label(), messageId: m.MessageId},
+		uc:           uc,
+		m:            m,
+		firstSend:    firstSend,
+		deadline:     firstSend.Add(s.cfg.Timeout),
+		maxAttempts:  s.cfg.MaxAttempts,
+		writeFunc:    s.write,
+		resaveFunc:   s.resaveOrRetry,
+		scheduleFunc: s.schedule,
 	}
-	task.firstSendSecond.Store(firstSend)
+	task.attempts.Store(1)
+	task.lastSend.Store(firstSend.UnixMilli())
+	return task
+}
 
-	s.tasks.Store(m.MessageId, task)
-	_, _, err := s.tw.Submit(task)
-	return err
+// Ack 单条ack只清除该连接上的任务，同一消息投递到用户的多个设备时各自等待ack
+func (s *MessageRetryServer) Ack(uc *domain.UserConn, messageID string) {
+	if task := s.ack(retryTaskKey{label: uc.Label(), messageId: messageID}); task != nil {
+		s.delivered([]*messageRetryTask{task})
+	}
+}
+
+// AckBatch 批量ack，只清除该连接上的任务
+func (s *MessageRetryServer) AckBatch(uc *domain.UserConn, ack *api.Ack) int {
+	var acked []*messageRetryTask
+	label := uc.Label()
+	for _, id := range ack.GetMessageIds() {
+		if task := s.ack(retryTaskKey{label: label, messageId: id}); task != nil {
+			acked = append(acked, task)
+		}
+	}
+
+	for _, c := range ack.GetConvs() {
+		acked = append(acked, s.ackConv(uc, c.ConvId, c.From, c.Sequence)...)
+	}
+
+	s.delivered(acked)
+	return len(acked)
+}
+
+func (s *MessageRetryServer) ack(key retryTaskKey) *messageRetryTask {
+	value, ok := s.tasks.LoadAndDelete(key)
+	if !ok {
+		return nil
+	}
+
+	task, ok := value.(*messageRetryTask)
+	if !ok {
+		return nil
+	}
+	task.isAckOK.Store(true)
+	s.unindex(task)
+	return task
+}
+
+// delivered 异步通知router消息已送达，由router推送给发送者，并取消接收者这些会话待发的推送
+func (s *MessageRetryServer) delivered(tasks []*messageRetryTask) {
+	if s.rc == nil || len(tasks) == 0 {
+		return
+	}
+
+	type receiver struct {
+		appId  string
+		userId int64
+	}
+
+	reqs := make(map[receiver]*api.DeliveredRequest)
+	for _, t := range tasks {
+		if t.m.IsSystem() {
+			continue
+		}
+		r := receiver{appId: t.m.AppId, userId: t.uc.UserId.Load()}
+		req, ok := reqs[r]
+		if !ok {
+			req = &api.DeliveredRequest{AppId: r.appId, UserId: r.userId}
+			reqs[r] = req
+		}
+		req.MessageIds = append(req.MessageIds, t.m.MessageId)
+		if !slices.Contains(req.ConvIds, t.m.ConvId) {
+			req.ConvIds = append(req.ConvIds, t.m.ConvId)
+		}
+	}
+
+	for _, req := range reqs {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), deliveredTimeout)
+			defer cancel()
+			if _, err := s.rc.Delivered(ctx, req); err != nil {
+				s.logger.PktDebug("report delivered failed", "", "", nil, PacketTracking, err,
+					zap.String("appId", req.AppId), zap.Int("messages", len(req.MessageIds)))
+			}
+		}()
+	}
 }
 
-func (s *MessageRetryServer) Ack(messageID string) {
-	value, ok := s.tasks.LoadAndDelete(messageID)
-	if ok {
-		if task, ok := value.(*messageRetryTask); ok {
-			task.isAckOK.Store(true)
+// retryTaskKey 同一消息投递到多个设备时每个连接一个任务
+type retryTaskKey struct {
+	label     string
+	messageId string
+}
+
+// convTaskKey 累计ack的索引，sequence只在会话内有序
+type convTaskKey struct {
+	uc     *domain.UserConn
+	convId string
+}
+
+type convTasks struct {
+	mu    sync.Mutex
+	tasks map[string]*messageRetryTask
+}
+
+func (s *MessageRetryServer) index(t *messageRetryTask) {
+	if t.m.Sequence <= 0 || t.m.ConvId == "" {
+		return
+	}
+
+	key := convTaskKey{uc: t.uc, convId: t.m.ConvId}
+	for {
+		v, _ := s.convs.LoadOrStore(key, &convTasks{tasks: map[string]*messageRetryTask{}})
+		ct := v.(*convTasks)
+
+		ct.mu.Lock()
+		// 已被删除的索引不能再写入，重新创建
+		if ct.tasks == nil {
+			ct.mu.Unlock()
+			continue
 		}
+		ct.tasks[t.m.MessageId] = t
+		ct.mu.Unlock()
+		return
+	}
+}
+
+func (s *MessageRetryServer) unindex(t *messageRetryTask) {
+	key := convTaskKey{uc: t.uc, convId: t.m.ConvId}
+	v, ok := s.convs.Load(key)
+	if !ok {
+		return
+	}
+
+	ct := v.(*convTasks)
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	delete(ct.tasks, t.m.MessageId)
+	if len(ct.tasks) == 0 && ct.tasks != nil {
+		ct.tasks = nil
+		s.convs.CompareAndDelete(key, ct)
+	}
+}
+
+// ackConv 清除会话中sequence在[from, seq]内的任务
+func (s *MessageRetryServer) ackConv(uc *domain.UserConn, convId string, from, seq int64) []*messageRetryTask {
+	key := convTaskKey{uc: uc, convId: convId}
+	v, ok := s.convs.Load(key)
+	if !ok {
+		return nil
+	}
+
+	ct := v.(*convTasks)
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	var acked []*messageRetryTask
+	for id, t := range ct.tasks {
+		if t.m.Sequence < from || t.m.Sequence > seq {
+			continue
+		}
+		t.isAckOK.Store(true)
+		s.tasks.CompareAndDelete(t.key, t)
+		delete(ct.tasks, id)
+		acked = append(acked, t)
+	}
+
+	if len(ct.tasks) == 0 && ct.tasks != nil {
+		ct.tasks = nil
+		s.convs.CompareAndDelete(key, ct)
+	}
+	return acked
+}
+
+// due 下一次执行的时间，不晚于deadline
+func (s *MessageRetryServer) due(t *messageRetryTask) time.Time {
+	due := time.UnixMilli(t.lastSend.Load()).Add(retryBackoff(s.cfg, int(t.attempts.Load()), rand.Float64()))
+	if due.After(t.deadline) {
+		due = t.deadline
 	}
+	return due
+}
+
+func (s *MessageRetryServer) schedule(t *messageRetryTask) error {
+	_, _, err := s.tw.SubmitAt(t, s.due(t))
+	return err
 }
 
 type messageRetryTask struct {
-	uc              *domain.UserConn
-	m               *api.Message
-	isAckOK         atomic.Bool                                //是否已收到ACK
-	firstSendSecond atomic.Int64                               //首次发送时间
-	interval        time.Duration                              //重发间隔
-	timeout         time.Duration                              //重发超时时间，超多此时间将不在重发
-	writeFunc       func(*api.Message, *domain.UserConn) error //消息写入方法
-	resaveFunc      func(*api.Message, *domain.UserConn)       //消息保存方法
+	key          retryTaskKey
+	uc           *domain.UserConn
+	m            *api.Message
+	isAckOK      atomic.Bool                                //是否已收到ACK
+	attempts     atomic.Int32                               //已发送次数，包括首次发送
+	firstSend    time.Time                                  //首次发送时间
+	lastSend     atomic.Int64                               //上次发送时间 毫秒
+	deadline     time.Time                                  //超过此时间不再重发
+	maxAttempts  int                                        //最多发送次数
+	writeFunc    func(*api.Message, *domain.UserConn) error //消息写入方法
+	resaveFunc   func(*messageRetryTask, string) bool       //消息保存方法，保存成功返回true
+	scheduleFunc func(*messageRetryTask) error              //按下一次的到期时间重新提交
+	resaves      atomic.Int32                               //重发结束后保存失败的次数
 }
 
+// Execute 到期时执行一次，需要继续重发时按新的到期时间重新提交，不在原槽中等待
 func (t *messageRetryTask) Execute(now time.Time) timewheel.TaskResult {
 	if t.isAckOK.Load() {
 		return timewheel.Break
 	}
 
-	if time.Since(time.Unix(t.firstSendSecond.Load(), 0)) >= t.timeout {
-		t.resaveFunc(t.m, t.uc)
+	if int(t.attempts.Load()) >= t.maxAttempts {
+		t.resaveFunc(t, ResaveMaxAttempts)
+		return timewheel.Break
+	}
+
+	if !now.Before(t.deadline) {
+		t.resaveFunc(t, ResaveTimeout)
 		return timewheel.Break
 	}
 
 	if err := t.writeFunc(t.m, t.uc); err != nil {
-		t.resaveFunc(t.m, t.uc)
+		t.resaveFunc(t, ResaveWriteFailed)
 		return timewheel.Break
 	}
 
-	return timewheel.Retry
+	t.attempts.Add(1)
+	t.lastSend.Store(now.UnixMilli())
+
+	if err := t.scheduleFunc(t); err != nil {
+		t.resaveFunc(t, ResaveScheduleFailed)
+	}
+	return timewheel.Break
+}
+
+// attempt 转离线时携带的投递记录
+func (t *messageRetryTask) attempt(reason string) *DeliveryAttempt {
+	return &DeliveryAttempt{
+		Attempts:  t.attempts.Load(),
+		FirstSend: t.firstSend.UnixMilli(),
+		LastSend:  t.lastSend.Load(),
+		Reason:    reason,
+	}
 }
 
 func (s *MessageRetryServer) write(m *api.Message, uc *domain.UserConn) error {
 	return s.mw.Write(m.Wrap(), uc)
 }
 
-func (s *MessageRetryServer) resave(m *api.Message, uc *domain.UserConn) {
-	s.mr.Resave(m, uc)
+// resave 保存成功后才删除任务，失败的任务保留，下线时再次转离线
+func (s *MessageRetryServer) resave(t *messageRetryTask, reason string) bool {
+	a := t.attempt(reason)
+	err := s.mr.Resave(t.m, t.uc, a)
+	s.logger.PktDebug("resave message", t.uc.Desc(), t.m.MessageId, nil, PacketTracking, err,
+		zap.Int32("attempts", a.Attempts), zap.String("reason", reason))
+	if err != nil {
+		return false
+	}
+
+	s.tasks.CompareAndDelete(t.key, t)
+	s.unindex(t)
+	return true
+}
+
+// resaveOrRetry 重发结束时转离线，失败后间隔resaveRetryInterval再次保存，
+// 共resaveMaxAttempts次仍失败时放弃，删除任务，之后的ack不再接受
+func (s *MessageRetryServer) resaveOrRetry(t *messageRetryTask, reason string) bool {
+	if s.resave(t, reason) {
+		return true
+	}
+
+	if t.resaves.Add(1) < resaveMaxAttempts {
+		_, _, err := s.tw.SubmitAt(&resaveRetryTask{task: t, reason: reason, resaveFunc: s.resaveOrRetry}, time.Now().Add(resaveRetryInterval))
+		if err == nil {
+			return false
+		}
+	}
+
+	s.logger.PktDebug("drop message", t.uc.Desc(), t.m.MessageId, nil, PacketTracking, errResaveDropped,
+		zap.Int32("resaves", t.resaves.Load()), zap.String("reason", reason))
+	s.tasks.CompareAndDelete(t.key, t)
+	s.unindex(t)
+	return false
+}
+
+// resaveRetryTask 转离线失败后再次保存，期间已被ack的不再保存
+type resaveRetryTask struct {
+	task       *messageRetryTask
+	reason     string
+	resaveFunc func(*messageRetryTask, string) bool
+}
+
+func (r *resaveRetryTask) Execute(now time.Time) timewheel.TaskResult {
+	if !r.task.isAckOK.Load() {
+		r.resaveFunc(r.task, r.reason)
+	}
+	return timewheel.Break
 }
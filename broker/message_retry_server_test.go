@@ -0,0 +1,308 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/timewheel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	c := getOrDefaultMRSConfig(&global.Config{MRS: &global.MRSConfig{InitialInterval: time.Second, Multiplier: 2, Jitter: 0.2}})
+
+	assert.Equal(t, time.Second, retryBackoff(c, 1, 0.5))
+	assert.Equal(t, 2*time.Second, retryBackoff(c, 2, 0.5))
+	assert.Equal(t, 4*time.Second, retryBackoff(c, 3, 0.5))
+
+	// 在 ±20% 内浮动
+	assert.Equal(t, 800*time.Millisecond, retryBackoff(c, 1, 0))
+	assert.InDelta(t, float64(1200*time.Millisecond), float64(retryBackoff(c, 1, 0.9999999)), float64(time.Millisecond))
+
+	c = getOrDefaultMRSConfig(nil)
+	assert.Equal(t, DefRetryTimeout, c.Timeout)
+	assert.Equal(t, DefRetryMaxAttempts, c.MaxAttempts)
+	assert.Equal(t, DefRetryMultiplier, c.Multiplier)
+}
+
+// retryRecorder 记录task的写入、调度和转离线
+type retryRecorder struct {
+	writes   int
+	writeErr error
+	dues     []time.Time
+	reason   string
+	attempt  *DeliveryAttempt
+}
+
+func (f *fixture) retryTask(r *retryRecorder, cfg *global.MRSConfig, firstSend time.Time) *messageRetryTask {
+	s := f.retryServer(cfg)
+	task := s.newTask(api.NewMessage(1, 2, 0, 1, "19860220", "1:2", &api.Text{Text: "hi"}), &domain.UserConn{}, firstSend)
+	task.writeFunc = func(m *api.Message, uc *domain.UserConn) error {
+		r.writes++
+		return r.writeErr
+	}
+	task.scheduleFunc = func(t *messageRetryTask) error {
+		r.dues = append(r.dues, s.due(t))
+		return nil
+	}
+	task.resaveFunc = func(t *messageRetryTask, reason string) bool {
+		r.reason = reason
+		r.attempt = t.attempt(reason)
+		return true
+	}
+	return task
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	r := &retryRecorder{}
+	start := time.UnixMilli(1_000_000)
+	task := newFixture(t).retryTask(r, &global.MRSConfig{Timeout: time.Minute, InitialInterval: time.Second, Multiplier: 2, MaxAttempts: 3}, start)
+
+	// 每次按退避后的到期时间执行
+	now := start.Add(time.Second)
+	assert.Equal(t, timewheel.Break, task.Execute(now))
+	assert.Equal(t, start.Add(3*time.Second), r.dues[0])
+
+	now = r.dues[0]
+	assert.Equal(t, timewheel.Break, task.Execute(now))
+	assert.Equal(t, start.Add(7*time.Second), r.dues[1])
+	assert.Equal(t, 2, r.writes)
+
+	// 第3次发送后等待ack超时，转离线
+	task.Execute(r.dues[1])
+	assert.Equal(t, 2, r.writes)
+	assert.Equal(t, ResaveMaxAttempts, r.reason)
+	assert.Equal(t, &DeliveryAttempt{Attempts: 3, FirstSend: start.UnixMilli(), LastSend: start.Add(3 * time.Second).UnixMilli(), Reason: ResaveMaxAttempts}, r.attempt)
+}
+
+func TestRetryTimeout(t *testing.T) {
+	r := &retryRecorder{}
+	start := time.UnixMilli(1_000_000)
+	task := newFixture(t).retryTask(r, &global.MRSConfig{Timeout: 5 * time.Second, InitialInterval: 2 * time.Second, Multiplier: 4, MaxAttempts: 10}, start)
+
+	task.Execute(start.Add(2 * time.Second))
+	// 下一次应在10秒后，不超过Timeout
+	assert.Equal(t, start.Add(5*time.Second), r.dues[0])
+
+	task.Execute(r.dues[0])
+	assert.Equal(t, 1, r.writes)
+	assert.Equal(t, ResaveTimeout, r.reason)
+	assert.Equal(t, int32(2), r.attempt.Attempts)
+}
+
+func TestRetryAckAndWriteFailed(t *testing.T) {
+	r := &retryRecorder{}
+	start := time.Now()
+	task := newFixture(t).retryTask(r, nil, start)
+
+	task.isAckOK.Store(true)
+	task.Execute(start.Add(time.Second))
+	assert.Zero(t, r.writes)
+	assert.Empty(t, r.reason)
+
+	r = &retryRecorder{writeErr: errors.New("closed")}
+	task = newFixture(t).retryTask(r, nil, start)
+	task.Execute(start.Add(time.Second))
+	assert.Equal(t, ResaveWriteFailed, r.reason)
+	assert.Equal(t, int32(1), r.attempt.Attempts)
+}
+
+func TestRetryAckBatch(t *testing.T) {
+	s := newFixture(t).retryServer(nil)
+	uc, other := newLoginConn("19860220", 2), &domain.UserConn{}
+	other.Login("19860220", 2, "Android")
+
+	submit := func(uc *domain.UserConn, seq int64, convId string) *messageRetryTask {
+		task := s.newTask(api.NewMessage(1, 2, 0, seq, "19860220", convId, &api.Text{Text: "hi"}), uc, time.Now())
+		s.tasks.Store(task.key, task)
+		s.index(task)
+		return task
+	}
+
+	var conv []*messageRetryTask
+	for seq := int64(1); seq <= 5; seq++ {
+		conv = append(conv, submit(uc, seq, "1:2"))
+	}
+	single := submit(uc, 1, "1:3")
+	otherConn := submit(other, 2, "1:2")
+
+	// 累计ack只清除该连接上sequence在[2, 3]内的任务
+	ack := api.NewAck(single.m.MessageId).AckConv("1:2", 2, 2).AckConv("1:2", 3, 3)
+	assert.Equal(t, 3, s.AckBatch(uc, ack))
+
+	for i, task := range conv {
+		_, ok := s.tasks.Load(task.key)
+		assert.Equal(t, i == 0 || i >= 3, ok)
+		assert.Equal(t, i == 1 || i == 2, task.isAckOK.Load())
+	}
+	assert.True(t, single.isAckOK.Load())
+	assert.False(t, otherConn.isAckOK.Load())
+
+	// 单条ack同样更新索引，索引清空后删除
+	assert.Equal(t, 1, s.AckBatch(uc, api.NewAck(conv[3].m.MessageId)))
+	assert.Equal(t, 2, s.AckBatch(uc, api.NewAck().AckConv("1:2", 1, 10)))
+	assert.Equal(t, 0, s.AckBatch(uc, api.NewAck().AckConv("1:2", 1, 10)))
+
+	_, ok := s.convs.Load(convTaskKey{uc: uc, convId: "1:2"})
+	assert.False(t, ok)
+	_, ok = s.convs.Load(convTaskKey{uc: uc, convId: "1:3"})
+	assert.False(t, ok)
+	_, ok = s.convs.Load(convTaskKey{uc: other, convId: "1:2"})
+	assert.True(t, ok)
+
+	// 同一消息投递到多个设备，只清除ack的连接上的任务
+	m := api.NewMessage(1, 2, 0, 6, "19860220", "1:2", &api.Text{Text: "hi"})
+	phone, pc := s.newTask(m, uc, time.Now()), s.newTask(m, other, time.Now())
+	s.tasks.Store(phone.key, phone)
+	s.tasks.Store(pc.key, pc)
+	s.Ack(other, m.MessageId)
+	assert.False(t, phone.isAckOK.Load())
+	assert.True(t, pc.isAckOK.Load())
+	_, ok = s.tasks.Load(phone.key)
+	assert.True(t, ok)
+}
+
+// fakeRouter 记录broker上报的送达
+type fakeRouter struct {
+	routerservice.Client
+	delivered chan *api.DeliveredRequest
+}
+
+func (r *fakeRouter) Delivered(ctx context.Context, req *api.DeliveredRequest, callOptions ...callopt.Option) (*api.DeliveredReply, error) {
+	r.delivered <- req
+	return &api.DeliveredReply{}, nil
+}
+
+func TestRetryAckReportsDelivered(t *testing.T) {
+	rc := &fakeRouter{delivered: make(chan *api.DeliveredRequest, 1)}
+	s := newFixture(t).retryServer(nil)
+	s.rc = rc
+	uc := &domain.UserConn{}
+	uc.UserId.Store(2)
+
+	m := api.NewMessage(1, 2, 0, 1, "19860220", "1:2", &api.Text{Text: "hi"})
+	status := api.NewMessage(2, 2, 0, 0, "19860220", "", m.NewStatus(api.MessageStatusDelivered, 0))
+	for _, msg := range []*api.Message{m, status} {
+		task := s.newTask(msg, uc, time.Now())
+		s.tasks.Store(task.key, task)
+	}
+
+	// 状态消息本身不上报
+	assert.Equal(t, 2, s.AckBatch(uc, api.NewAck(m.MessageId, status.MessageId)))
+	select {
+	case req := <-rc.delivered:
+		assert.Equal(t, &api.DeliveredRequest{AppId: "19860220", UserId: 2, MessageIds: []string{m.MessageId}, ConvIds: []string{"1:2"}}, req)
+	case <-time.After(time.Second):
+		t.Fatal("delivered not reported")
+	}
+}
+
+func TestRetryDrain(t *testing.T) {
+	f := newFixture(t)
+	s := f.retryServer(nil)
+	uc := &domain.UserConn{}
+
+	store := func(seqs ...int64) []*messageRetryTask {
+		var tasks []*messageRetryTask
+		for _, seq := range seqs {
+			task := s.newTask(api.NewMessage(1, 2, 0, seq, "19860220", "1:2", &api.Text{Text: "hi"}), uc, time.Now())
+			s.tasks.Store(task.key, task)
+			s.index(task)
+			tasks = append(tasks, task)
+		}
+		return tasks
+	}
+	offline := func() int64 {
+		return f.rds.ZCard(context.Background(), infra.KeyOffline("", 0)).Val()
+	}
+
+	tasks := store(1, 2, 3)
+	assert.Equal(t, 3, s.Inflight())
+
+	// 等待期间被ack的不再转离线
+	go func() {
+		time.Sleep(drainPollInterval)
+		s.AckBatch(uc, api.NewAck(tasks[0].m.MessageId))
+	}()
+
+	resaved, lost := s.Drain(context.Background(), 3*drainPollInterval)
+	assert.Equal(t, 2, resaved)
+	assert.Equal(t, 0, lost)
+	assert.Equal(t, 0, s.Inflight())
+	assert.Equal(t, int64(2), offline())
+
+	// 没有任务时立即返回
+	resaved, lost = s.Drain(context.Background(), time.Minute)
+	assert.Equal(t, 0, resaved)
+	assert.Equal(t, 0, lost)
+
+	// redis不可用时保留任务并重试，恢复后保存
+	store(4)
+	f.mr.SetError("LOADING")
+	go func() {
+		time.Sleep(3 * drainPollInterval)
+		f.mr.SetError("")
+	}()
+	resaved, lost = s.Drain(context.Background(), 0)
+	assert.Equal(t, 1, resaved)
+	assert.Equal(t, 0, lost)
+	assert.Equal(t, 0, s.Inflight())
+	assert.Equal(t, int64(3), offline())
+
+	// ctx结束时不再重试，返回未保存的数量
+	store(5)
+	f.mr.SetError("LOADING")
+	ctx, cancel := context.WithTimeout(context.Background(), 3*drainPollInterval)
+	defer cancel()
+	resaved, lost = s.Drain(ctx, 0)
+	assert.Equal(t, 0, resaved)
+	assert.Equal(t, 1, lost)
+	assert.Equal(t, 1, s.Inflight())
+}
+
+func TestRetryResaveFailed(t *testing.T) {
+	f := newFixture(t)
+	s := f.retryServer(nil)
+	tw, err := timewheel.NewTimewheel(&timewheel.Config{SlotTick: retrySlotTick, SlotCount: 100, SlotMaxLength: 100}, nil, nil)
+	assert.NoError(t, err)
+	tw.Start(context.Background())
+	t.Cleanup(tw.Stop)
+	s.tw = tw
+
+	uc := &domain.UserConn{}
+	submit := func(seq int64) *messageRetryTask {
+		task := s.newTask(api.NewMessage(1, 2, 0, seq, "19860220", "1:2", &api.Text{Text: "hi"}), uc, time.Now())
+		s.tasks.Store(task.key, task)
+		s.index(task)
+		return task
+	}
+	offline := func() int64 {
+		return f.rds.ZCard(context.Background(), infra.KeyOffline("", 0)).Val()
+	}
+
+	// 保存失败时保留任务并稍后再次保存
+	f.mr.SetError("LOADING")
+	task := submit(1)
+	assert.False(t, task.resaveFunc(task, ResaveTimeout))
+	assert.Equal(t, 1, s.Inflight())
+
+	f.mr.SetError("")
+	assert.Eventually(t, func() bool { return s.Inflight() == 0 }, 3*resaveRetryInterval, drainPollInterval)
+	assert.Equal(t, int64(1), offline())
+
+	// 一直失败时放弃，任务和会话索引都删除
+	f.mr.SetError("LOADING")
+	task = submit(2)
+	assert.False(t, task.resaveFunc(task, ResaveTimeout))
+	assert.Eventually(t, func() bool { return s.Inflight() == 0 }, (resaveMaxAttempts+1)*resaveRetryInterval, drainPollInterval)
+	assert.Equal(t, int32(resaveMaxAttempts), task.resaves.Load())
+	_, ok := s.convs.Load(convTaskKey{uc: uc, convId: "1:2"})
+	assert.False(t, ok)
+}
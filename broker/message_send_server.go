@@ -3,7 +3,7 @@ package broker
 import (
 	"context"
 	"errors"
-	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -12,6 +12,7 @@ import (
 	"github.com/magicnana999/im/global"
 	"github.com/magicnana999/im/pkg/jsonext"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 var (
@@ -30,10 +31,13 @@ type messageSending struct {
 // MessageSendServer 消息投递服务，用于主动给客户端投递消息，不用于收到客户端消息后的ack
 type MessageSendServer struct {
 	isRunning atomic.Bool
+	mu        sync.RWMutex //Send持读锁入队，Stop持写锁停止，停止后不再有消息入队
 	cancel    context.CancelFunc
+	done      chan struct{}        //发送循环退出
 	ch        chan *messageSending //消息投递队列
 	logger    *Logger
 	mrs       *MessageRetryServer //消息重发服务
+	mr        *MessageResaver     //转离线
 	mw        *PacketWriter       //消息写入服务
 }
 
@@ -50,7 +54,7 @@ func getOrDefaultMSSConfig(g *global.Config) *global.MSSConfig {
 	return c
 }
 
-func NewMessageSendServer(g *global.Config, mrs *MessageRetryServer, lc fx.Lifecycle) (*MessageSendServer, error) {
+func NewMessageSendServer(g *global.Config, mrs *MessageRetryServer, mr *MessageResaver, lc fx.Lifecycle) (*MessageSendServer, error) {
 	c := getOrDefaultMSSConfig(g)
 
 	log := NewLogger("mss")
@@ -60,7 +64,8 @@ func NewMessageSendServer(g *global.Config, mrs *MessageRetryServer, lc fx.Lifec
 		ch:     make(chan *messageSending, c.MaxRemaining),
 		logger: log,
 		mrs:    mrs,
-		mw:     NewPacketWriter(NewCodec(), log),
+		mr:     mr,
+		mw:     NewPacketWriter(NewCodec(), NewBackpressure(g), log),
 	}
 
 	lc.Append(fx.Hook{
@@ -78,7 +83,9 @@ func (mss *MessageSendServer) Start(ctx context.Context) error {
 	if mss.isRunning.CompareAndSwap(false, true) {
 		ctx, cancel := context.WithCancel(context.Background())
 		mss.cancel = cancel
+		mss.done = make(chan struct{})
 		go func() {
+			defer close(mss.done)
 			mss.logger.SrvInfo("message send loop started", SrvLifecycle, nil)
 
 			for {
@@ -94,26 +101,61 @@ func (mss *MessageSendServer) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop 停服后，把没有收到ack的消息保存到离线
+// Stop 停止发送循环，队列中剩余的消息在返回前转离线
 func (mss *MessageSendServer) Stop(ctx context.Context) error {
-	if mss.isRunning.CompareAndSwap(true, false) {
-		mss.cancel()
-		close(mss.ch)
-		mss.logger.SrvInfo("message send loop stopped", SrvLifecycle, nil)
+	mss.mu.Lock()
+	stopped := mss.isRunning.CompareAndSwap(true, false)
+	mss.mu.Unlock()
+	if !stopped {
+		return nil
+	}
 
-		mss.logger.SrvInfo("resave the remaining message", SrvLifecycle, nil)
-		go mss.resaveMessages()
+	if mss.cancel != nil {
+		mss.cancel()
+		<-mss.done
 	}
+	mss.logger.SrvInfo("message send loop stopped", SrvLifecycle, nil)
+
+	n, lost := mss.resaveMessages(ctx)
+	mss.logger.SrvInfo("resave the remaining message", SrvLifecycle, nil, zap.Int("count", n), zap.Int("lost", lost))
 	return nil
 }
 
-func (mss *MessageSendServer) resaveMessages() {
-	for ms := range mss.ch {
-		mss.resave(ms.message, ms.uc)
+// resaveMessages 发送循环已退出，队列中的消息转离线，失败的按drainPollInterval重试，
+// ctx结束时不再重试，返回保存成功和未能保存的数量
+func (mss *MessageSendServer) resaveMessages(ctx context.Context) (n, lost int) {
+	pending := make([]*messageSending, 0, len(mss.ch))
+	for len(mss.ch) > 0 {
+		pending = append(pending, <-mss.ch)
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		failed := pending[:0]
+		for _, ms := range pending {
+			if mss.resave(ms.message, ms.uc, ResaveShutdown) {
+				n++
+			} else {
+				failed = append(failed, ms)
+			}
+		}
+		pending = failed
+
+		if len(pending) == 0 {
+			return n, 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return n, len(pending)
+		case <-ticker.C:
+		}
 	}
 }
 
-// Send 外部调用
+// Send 外部调用，连接超过写高水位时返回 errSlowConsumer
 func (mss *MessageSendServer) Send(m *api.Message, uc *domain.UserConn) error {
 	if m == nil {
 		return invalidMessage
@@ -124,9 +166,16 @@ func (mss *MessageSendServer) Send(m *api.Message, uc *domain.UserConn) error {
 	if uc.IsClosed.Load() {
 		return userConnClosed
 	}
+
+	mss.mu.RLock()
+	defer mss.mu.RUnlock()
 	if !mss.isRunning.Load() {
 		return mssNotRunning
 	}
+	// 客户端读得太慢，不再进入发送队列，由调用方转离线
+	if mss.mw.bp.Congested(uc) {
+		return errSlowConsumer
+	}
 
 	select {
 	case mss.ch <- &messageSending{message: m, uc: uc}:
@@ -139,20 +188,30 @@ func (mss *MessageSendServer) Send(m *api.Message, uc *domain.UserConn) error {
 // write 成功后开始消息重发逻辑，失败后直接写入离线
 func (mss *MessageSendServer) write(m *api.Message, uc *domain.UserConn) {
 	if err := mss.mw.Write(m.Wrap(), uc); err != nil {
-		mss.resave(m, uc)
+		mss.resave(m, uc, ResaveWriteFailed)
 	} else {
 		mss.submit(m, uc)
 	}
 }
 
 func (mss *MessageSendServer) submit(ms *api.Message, uc *domain.UserConn) {
-	if err := mss.mrs.Submit(ms, uc, time.Now().Unix()); err != nil {
+	if err := mss.mrs.Submit(ms, uc, time.Now()); err != nil {
 		mss.logger.PktDebug("failed to submit mrs,resave it", uc.Desc(), ms.MessageId, nil, PacketTracking, nil)
-		mss.resave(ms, uc)
+		mss.resave(ms, uc, ResaveScheduleFailed)
 	}
 }
 
-func (mss *MessageSendServer) resave(ms *api.Message, uc *domain.UserConn) {
-	mss.logger.PktDebug("resave message", uc.Desc(), ms.MessageId, nil, PacketTracking, nil)
-	fmt.Print(jsonext.PbMarshalNoErr(ms))
+// resave 没有进入重发的消息，停服时未发送过，其他情况已发送一次，保存成功返回true
+func (mss *MessageSendServer) resave(ms *api.Message, uc *domain.UserConn, reason string) bool {
+	a := &DeliveryAttempt{Reason: reason}
+	if reason != ResaveShutdown {
+		now := time.Now().UnixMilli()
+		a.Attempts, a.FirstSend, a.LastSend = 1, now, now
+	}
+
+	if err := mss.mr.Resave(ms, uc, a); err != nil {
+		mss.logger.PktDebug("failed to resave message", uc.Desc(), ms.MessageId, nil, PacketTracking, err)
+		return false
+	}
+	return true
 }
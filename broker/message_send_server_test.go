@@ -0,0 +1,77 @@
+package broker
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/infra"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSendStop(t *testing.T) {
+	f := newFixture(t)
+	mss := f.sendServer(10, f.backpressure(1024))
+	uc := newLoginConn("19860220", 2)
+
+	send := func(seq int64) {
+		m := api.NewMessage(1, 2, 0, seq, "19860220", "1:2", &api.Text{Text: "hi"})
+		assert.NoError(t, mss.Send(m, uc))
+	}
+	offline := func() int64 {
+		return f.rds.ZCard(context.Background(), infra.KeyOffline("19860220", 2)).Val()
+	}
+
+	// redis恢复前重试，队列中的消息在Stop返回前全部转离线
+	send(1)
+	send(2)
+	f.mr.SetError("LOADING")
+	go func() {
+		time.Sleep(3 * drainPollInterval)
+		f.mr.SetError("")
+	}()
+	assert.NoError(t, mss.Stop(context.Background()))
+	assert.Equal(t, int64(2), offline())
+	assert.Zero(t, len(mss.ch))
+
+	// 停止后不再入队
+	err := mss.Send(api.NewMessage(1, 2, 0, 3, "19860220", "1:2", &api.Text{Text: "hi"}), uc)
+	assert.Equal(t, mssNotRunning, err)
+}
+
+func TestSendStopDeadline(t *testing.T) {
+	f := newFixture(t)
+	mss := f.sendServer(10, f.backpressure(1024))
+	uc := newLoginConn("19860220", 2)
+	assert.NoError(t, mss.Send(api.NewMessage(1, 2, 0, 1, "19860220", "1:2", &api.Text{Text: "hi"}), uc))
+
+	// ctx结束时不再重试
+	f.mr.SetError("LOADING")
+	ctx, cancel := context.WithTimeout(context.Background(), 3*drainPollInterval)
+	defer cancel()
+	n, lost := mss.resaveMessages(ctx)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 1, lost)
+}
+
+func TestSendDuringStop(t *testing.T) {
+	f := newFixture(t)
+	mss := f.sendServer(1000, f.backpressure(1024))
+	uc := newLoginConn("19860220", 2)
+
+	// 并发的Send不会写入已停止的队列
+	var wg sync.WaitGroup
+	for i := int64(1); i <= 10; i++ {
+		wg.Add(1)
+		go func(seq int64) {
+			defer wg.Done()
+			for j := int64(0); j < 50; j++ {
+				_ = mss.Send(api.NewMessage(1, 2, 0, seq*100+j, "19860220", "1:2", &api.Text{Text: "hi"}), uc)
+			}
+		}(i)
+	}
+	assert.NoError(t, mss.Stop(context.Background()))
+	wg.Wait()
+	assert.Zero(t, len(mss.ch))
+}
@@ -0,0 +1,88 @@
+package broker
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	"github.com/magicnana999/im/broker/domain"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	// offlinePullLimit 每次从router拉取的消息数
+	offlinePullLimit = 100
+
+	// offlinePullTimeout 每次拉取的超时
+	offlinePullTimeout = 3 * time.Second
+
+	// offlineSendInterval 发送队列满或连接拥塞时，等待后重试
+	offlineSendInterval = 100 * time.Millisecond
+
+	// offlineSendRetries 同一条消息最多重试的次数，超过后停止拉取，剩余的下次登录再拉取
+	offlineSendRetries = 50
+)
+
+// OfflinePuller 登录后拉取离线收件箱，包括router兜底和本机转离线的消息，
+// 按在线消息一样经发送队列下发并重发，ack后router从收件箱删除
+type OfflinePuller struct {
+	rc     routerservice.Client
+	mss    *MessageSendServer
+	logger *Logger
+}
+
+func NewOfflinePuller(rc routerservice.Client, mss *MessageSendServer, lc fx.Lifecycle) *OfflinePuller {
+	return &OfflinePuller{
+		rc:     rc,
+		mss:    mss,
+		logger: NewLogger("offline"),
+	}
+}
+
+// Pull 异步拉取，不阻塞登录
+func (p *OfflinePuller) Pull(uc *domain.UserConn) {
+	go func() {
+		n, err := p.pull(uc)
+		p.logger.ConnDebug("pull offline", uc.Desc(), ConnLifecycle, err, zap.Int("count", n))
+	}()
+}
+
+// pull 返回下发的消息数，出错时停止，未下发的仍在收件箱中
+func (p *OfflinePuller) pull(uc *domain.UserConn) (int, error) {
+	req := &api.OfflineRequest{AppId: uc.AppId.Load(), UserId: uc.UserId.Load(), Limit: offlinePullLimit}
+
+	n := 0
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), offlinePullTimeout)
+		reply, err := p.rc.PullOffline(ctx, req)
+		cancel()
+		if err != nil {
+			return n, err
+		}
+
+		for _, m := range reply.Messages {
+			if err := p.send(mentionedFor(m, req.UserId), uc); err != nil {
+				return n, err
+			}
+			n++
+		}
+
+		if !reply.More {
+			return n, nil
+		}
+		req.Since = reply.Next
+	}
+}
+
+// send 发送队列满或连接拥塞时等待后重试
+func (p *OfflinePuller) send(m *api.Message, uc *domain.UserConn) error {
+	var err error
+	for i := 0; i < offlineSendRetries; i++ {
+		if err = p.mss.Send(m, uc); err != channelFull && err != errSlowConsumer {
+			return err
+		}
+		time.Sleep(offlineSendInterval)
+	}
+	return err
+}
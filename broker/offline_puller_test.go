@@ -0,0 +1,52 @@
+package broker
+
+import (
+	"context"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// fakeOfflineRouter 按since分页返回离线消息
+type fakeOfflineRouter struct {
+	routerservice.Client
+	pages map[int64]*api.OfflineReply
+	reqs  []*api.OfflineRequest
+}
+
+func (r *fakeOfflineRouter) PullOffline(ctx context.Context, req *api.OfflineRequest, callOptions ...callopt.Option) (*api.OfflineReply, error) {
+	r.reqs = append(r.reqs, req)
+	return r.pages[req.Since], nil
+}
+
+func TestOfflinePull(t *testing.T) {
+	f := newFixture(t)
+	mss := f.sendServer(10, f.backpressure(1024))
+
+	m1 := api.NewMessage(1, 2, 0, 1, "19860220", "1:2", &api.Text{Text: "hi"})
+	m2 := api.NewMessage(3, 0, 9, 1, "19860220", "g9", &api.Text{Text: "hi"})
+	m2.At = []*api.At{{UserId: 2}}
+	m3 := api.NewMessage(1, 2, 0, 2, "19860220", "1:2", &api.Text{Text: "hi"})
+	rc := &fakeOfflineRouter{pages: map[int64]*api.OfflineReply{
+		0:  {Messages: []*api.Message{m1, m2}, Next: 10, More: true},
+		10: {Messages: []*api.Message{m3}, Next: 20},
+	}}
+
+	p := NewOfflinePuller(rc, mss, nil)
+	uc := newLoginConn("19860220", 2)
+
+	n, err := p.pull(uc)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	assert.Len(t, rc.reqs, 2)
+	assert.Equal(t, &api.OfflineRequest{AppId: "19860220", UserId: 2, Since: 10, Limit: offlinePullLimit}, rc.reqs[1])
+
+	// 按顺序进入发送队列，群消息按接收者设置mentioned
+	assert.Len(t, mss.ch, 3)
+	assert.Equal(t, m1.MessageId, (<-mss.ch).message.MessageId)
+	assert.True(t, (<-mss.ch).message.Mentioned)
+	assert.Equal(t, m3.MessageId, (<-mss.ch).message.MessageId)
+}
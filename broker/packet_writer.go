@@ -17,14 +17,16 @@ var (
 // PacketWriter 消息写入服务
 type PacketWriter struct {
 	codec     *Codec
+	bp        *Backpressure
 	logger    *Logger
 	IsClose   atomic.Bool
 	IsWriting atomic.Bool
 }
 
-func NewPacketWriter(codec *Codec, logger *Logger) *PacketWriter {
+func NewPacketWriter(codec *Codec, bp *Backpressure, logger *Logger) *PacketWriter {
 	return &PacketWriter{
 		codec:  codec,
+		bp:     bp,
 		logger: logger,
 	}
 }
@@ -43,14 +45,20 @@ func (s *PacketWriter) Write(packet *api.Packet, uc *domain.UserConn) error {
 		return errUcIsClosed
 	}
 
-	buffer, err := s.codec.Encode(packet)
+	// 客户端读得太慢，不再堆积到写缓冲，由调用方转离线
+	if s.bp.Congested(uc) {
+		s.logger.PktDebug("failed to write message,outbound over high water", uc.Desc(), packet.GetPacketId(), nil, PacketTracking, errSlowConsumer)
+		return errSlowConsumer
+	}
+
+	buffer, err := encoderOf(uc, s.codec).Encode(packet)
 	defer bb.Put(buffer)
 	if err != nil {
 		s.logger.PktDebug("failed to decode message", uc.Desc(), packet.GetPacketId(), nil, PacketTracking, err)
 		return err
 	}
 
-	err = uc.Conn.AsyncWrite(buffer.Bytes(), func(c gnet.Conn, err error) error {
+	err = asyncWrite(uc, buffer.Bytes(), func(c gnet.Conn, err error) error {
 		s.logger.PktDebug("write completed", uc.Desc(), packet.GetPacketId(), nil, PacketTracking, err)
 		s.IsWriting.Store(false)
 		return nil
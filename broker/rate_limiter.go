@@ -0,0 +1,363 @@
+package broker
+
+import (
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"sync"
+	"time"
+)
+
+const (
+	// DefRateLimitBurst 令牌桶默认允许突发1秒的量
+	DefRateLimitBurst = time.Second
+
+	// DefRateLimitMaxViolations 窗口内超限次数达到该值时断开连接
+	DefRateLimitMaxViolations = 50
+
+	// DefRateLimitViolationWindow 统计超限次数的窗口
+	DefRateLimitViolationWindow = 10 * time.Second
+)
+
+// getOrDefaultRateLimitConfig 未配置时不限流，返回nil
+func getOrDefaultRateLimitConfig(tc *global.TCPConfig) *global.RateLimitConfig {
+	if tc == nil || tc.RateLimit == nil {
+		return nil
+	}
+
+	c := &global.RateLimitConfig{}
+	*c = *tc.RateLimit
+
+	if c.Burst <= 0 {
+		c.Burst = DefRateLimitBurst
+	}
+
+	if c.MaxViolations <= 0 {
+		c.MaxViolations = DefRateLimitMaxViolations
+	}
+
+	if c.ViolationWindow <= 0 {
+		c.ViolationWindow = DefRateLimitViolationWindow
+	}
+
+	apps := make(map[string]*global.RateLimitRule, len(c.Apps))
+	for appId, r := range c.Apps {
+		rule := c.RateLimitRule
+		if r != nil {
+			rule = mergeRateLimitRule(*r, c.RateLimitRule)
+		}
+		apps[appId] = &rule
+	}
+	c.Apps = apps
+
+	return c
+}
+
+func mergeRateLimitRule(r, def global.RateLimitRule) global.RateLimitRule {
+	if r.Packets <= 0 {
+		r.Packets = def.Packets
+	}
+	if r.Bytes <= 0 {
+		r.Bytes = def.Bytes
+	}
+	if r.Messages <= 0 {
+		r.Messages = def.Messages
+	}
+	if r.Commands <= 0 {
+		r.Commands = def.Commands
+	}
+	if r.Burst <= 0 {
+		r.Burst = def.Burst
+	}
+	return r
+}
+
+// tokenBucket 令牌桶，rate为每秒补充的令牌数；nil表示不限制
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst time.Duration, now time.Time) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := max(rate*burst.Seconds(), 1)
+	return &tokenBucket{rate: rate, burst: b, tokens: b, last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// allow 令牌足够时扣除n个
+func (b *tokenBucket) allow(n float64, now time.Time) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	if b.tokens < n {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+// charge 扣除n个，可以透支，透支期间 allow 都返回false
+func (b *tokenBucket) charge(n float64, now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	b.tokens -= n
+}
+
+// overdrawn 是否处于透支状态
+func (b *tokenBucket) overdrawn(now time.Time) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	return b.tokens < 0
+}
+
+// idle 令牌已补满，删除后重建不影响限流结果
+func (b *tokenBucket) idle(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// connLimit 单个连接的packet和字节限流，以及超限计数
+type connLimit struct {
+	appId   string
+	src     *global.RateLimitRule
+	packets *tokenBucket
+	bytes   *tokenBucket
+
+	mu          sync.Mutex
+	violations  int
+	windowStart time.Time
+}
+
+// userKey 用户限流的key
+type userKey struct {
+	appId  string
+	userId int64
+}
+
+// userLimit 同一用户所有设备共享的message和command限流
+type userLimit struct {
+	src      *global.RateLimitRule
+	messages *tokenBucket
+	commands *tokenBucket
+}
+
+// RateLimiter 长连接限流
+//
+// 连接维度限制packet数和读取的字节数，字节在解码前按实际读取量扣除，可以透支，透支期间该连接的packet都被限流；
+// 用户维度（appId+userId）限制message和command，登录前只受连接维度限制。
+// 规则优先使用app注册表中的配置，其次是配置文件中按appId的配置，未设置的项使用默认值。
+// 被限流的message/command回复 errors.RateLimited，心跳和ack直接丢弃；
+// 窗口内超限次数达到MaxViolations时断开连接
+type RateLimiter struct {
+	cfg   *global.RateLimitConfig
+	apps  *AppRegistry
+	conns sync.Map // *domain.UserConn -> *connLimit
+	users sync.Map // userKey -> *userLimit
+}
+
+// NewRateLimiter cfg为nil时返回nil，不限流；apps为nil时只使用配置文件的规则
+func NewRateLimiter(cfg *global.RateLimitConfig, apps *AppRegistry) *RateLimiter {
+	if cfg == nil {
+		return nil
+	}
+	return &RateLimiter{cfg: cfg, apps: apps}
+}
+
+// rule app的规则，src为注册表中的配置，注册表变化时src随之变化
+func (l *RateLimiter) rule(appId string, src *global.RateLimitRule) global.RateLimitRule {
+	def := l.cfg.RateLimitRule
+	if r, ok := l.cfg.Apps[appId]; ok {
+		def = *r
+	}
+	if src == nil {
+		return def
+	}
+	return mergeRateLimitRule(*src, def)
+}
+
+// connOf 登录后appId变化或注册表中的规则变化时重建
+func (l *RateLimiter) connOf(uc *domain.UserConn, now time.Time) *connLimit {
+	appId := uc.AppId.Load()
+	src := l.apps.Rule(appId)
+	if v, ok := l.conns.Load(uc); ok {
+		if cl := v.(*connLimit); cl.appId == appId && cl.src == src {
+			return cl
+		}
+	}
+
+	r := l.rule(appId, src)
+	cl := &connLimit{
+		appId:       appId,
+		src:         src,
+		packets:     newTokenBucket(r.Packets, r.Burst, now),
+		bytes:       newTokenBucket(r.Bytes, r.Burst, now),
+		windowStart: now,
+	}
+	l.conns.Store(uc, cl)
+	return cl
+}
+
+func (l *RateLimiter) userOf(uc *domain.UserConn, now time.Time) *userLimit {
+	if !uc.IsLogin.Load() {
+		return nil
+	}
+
+	key := userKey{appId: uc.AppId.Load(), userId: uc.UserId.Load()}
+	src := l.apps.Rule(key.appId)
+	v, ok := l.users.Load(key)
+	if ok && v.(*userLimit).src == src {
+		return v.(*userLimit)
+	}
+
+	r := l.rule(key.appId, src)
+	ul := &userLimit{
+		src:      src,
+		messages: newTokenBucket(r.Messages, r.Burst, now),
+		commands: newTokenBucket(r.Commands, r.Burst, now),
+	}
+	if ok {
+		// 规则变化，直接替换
+		l.users.Store(key, ul)
+		return ul
+	}
+	v, _ = l.users.LoadOrStore(key, ul)
+	return v.(*userLimit)
+}
+
+// Charge 扣除连接读取的字节数
+func (l *RateLimiter) Charge(uc *domain.UserConn, n int) {
+	if l == nil || n <= 0 {
+		return
+	}
+	now := time.Now()
+	l.connOf(uc, now).bytes.charge(float64(n), now)
+}
+
+// Allow 检查一个packet，被限流时返回 errors.RateLimited 并计入超限次数
+func (l *RateLimiter) Allow(uc *domain.UserConn, p *api.Packet) error {
+	if l == nil {
+		return nil
+	}
+
+	now := time.Now()
+	cl := l.connOf(uc, now)
+
+	ok := !cl.bytes.overdrawn(now) && cl.packets.allow(1, now)
+	if ok {
+		if ul := l.userOf(uc, now); ul != nil {
+			switch {
+			case p.IsCommand():
+				ok = ul.commands.allow(1, now)
+			case p.IsMessage() && p.GetMessage().IsRequest():
+				ok = ul.messages.allow(1, now)
+			}
+		}
+	}
+
+	if ok {
+		return nil
+	}
+
+	cl.violate(now, l.cfg.ViolationWindow)
+	return errors.RateLimited
+}
+
+// Exceeded 窗口内超限次数是否达到上限
+func (l *RateLimiter) Exceeded(uc *domain.UserConn) bool {
+	if l == nil {
+		return false
+	}
+
+	v, ok := l.conns.Load(uc)
+	if !ok {
+		return false
+	}
+
+	cl := v.(*connLimit)
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return cl.violations >= l.cfg.MaxViolations
+}
+
+func (cl *connLimit) violate(now time.Time, window time.Duration) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	if now.Sub(cl.windowStart) > window {
+		cl.windowStart = now
+		cl.violations = 0
+	}
+	cl.violations++
+}
+
+// Remove 连接关闭时删除
+func (l *RateLimiter) Remove(uc *domain.UserConn) {
+	if l == nil {
+		return
+	}
+	l.conns.Delete(uc)
+}
+
+// Sweep 删除已补满的用户限流，避免下线用户一直占用内存
+func (l *RateLimiter) Sweep(now time.Time) {
+	if l == nil {
+		return
+	}
+
+	l.users.Range(func(key, value any) bool {
+		ul := value.(*userLimit)
+		if ul.messages.idle(now) && ul.commands.idle(now) {
+			l.users.Delete(key)
+		}
+		return true
+	})
+}
+
+// throttled 被限流时回复的packet，心跳和ack不回复
+func throttled(p *api.Packet, err error) *api.Packet {
+	switch {
+	case p.IsCommand():
+		return p.GetCommand().Response(nil, err).Wrap()
+	case p.IsMessage() && p.GetMessage().IsRequest():
+		return p.GetMessage().Response(nil, err).Wrap()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package broker
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newCommandPacket() *api.Packet {
+	return (&api.Command{CommandId: "c1", CommandType: api.CommandTypeConvRead}).Wrap()
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(2, time.Second, now)
+
+	assert.True(t, b.allow(1, now))
+	assert.True(t, b.allow(1, now))
+	assert.False(t, b.allow(1, now))
+
+	// 0.5秒补充1个
+	assert.True(t, b.allow(1, now.Add(500*time.Millisecond)))
+
+	// 透支后需要补回才能继续
+	b.charge(3, now.Add(500*time.Millisecond))
+	assert.True(t, b.overdrawn(now.Add(time.Second)))
+	assert.False(t, b.overdrawn(now.Add(2*time.Second)))
+	assert.True(t, b.idle(now.Add(3*time.Second)))
+
+	// 速率为0不限制
+	unlimited := newTokenBucket(0, time.Second, now)
+	assert.Nil(t, unlimited)
+	assert.True(t, unlimited.allow(100, now))
+}
+
+func TestRateLimitConn(t *testing.T) {
+	l := newFixture(t).rateLimiter(global.RateLimitRule{Packets: 2}, nil)
+	uc := &domain.UserConn{}
+
+	assert.NoError(t, l.Allow(uc, api.NewHeartbeat(1).Wrap()))
+	assert.NoError(t, l.Allow(uc, newCommandPacket()))
+	assert.ErrorIs(t, l.Allow(uc, newCommandPacket()), errors.RateLimited)
+
+	// 其他连接不受影响
+	assert.NoError(t, l.Allow(&domain.UserConn{}, newCommandPacket()))
+}
+
+func TestRateLimitBytes(t *testing.T) {
+	l := newFixture(t).rateLimiter(global.RateLimitRule{Bytes: 1024}, nil)
+	uc := &domain.UserConn{}
+
+	l.Charge(uc, 1024)
+	assert.NoError(t, l.Allow(uc, newTextPacket()))
+
+	l.Charge(uc, 4096)
+	assert.ErrorIs(t, l.Allow(uc, newTextPacket()), errors.RateLimited)
+}
+
+func TestRateLimitUser(t *testing.T) {
+	l := newFixture(t).rateLimiter(global.RateLimitRule{Messages: 2, Commands: 1}, nil)
+
+	// 同一用户的多个设备共享额度
+	phone, pc := newLoginConn("19860220", 1), newLoginConn("19860220", 1)
+	assert.NoError(t, l.Allow(phone, newTextPacket()))
+	assert.NoError(t, l.Allow(pc, newTextPacket()))
+	assert.ErrorIs(t, l.Allow(phone, newTextPacket()), errors.RateLimited)
+
+	// message和command分开计算
+	assert.NoError(t, l.Allow(pc, newCommandPacket()))
+	assert.ErrorIs(t, l.Allow(phone, newCommandPacket()), errors.RateLimited)
+
+	// ack不计入发送额度
+	ack := newTextPacket()
+	ack.GetMessage().Flow = api.FlowResponse
+	assert.NoError(t, l.Allow(phone, ack))
+
+	assert.NoError(t, l.Allow(newLoginConn("19860220", 2), newTextPacket()))
+
+	l.Sweep(time.Now().Add(time.Minute))
+	count := 0
+	l.users.Range(func(key, value any) bool { count++; return true })
+	assert.Zero(t, count)
+}
+
+func TestRateLimitApps(t *testing.T) {
+	l := newFixture(t).rateLimiter(global.RateLimitRule{Messages: 1, Commands: 1}, map[string]*global.RateLimitRule{
+		"vip": {Messages: 3},
+	})
+
+	// 覆盖的项使用app的配置，未配置的项使用默认值
+	r := l.rule("vip", nil)
+	assert.Equal(t, float64(3), r.Messages)
+	assert.Equal(t, float64(1), r.Commands)
+	assert.Equal(t, DefRateLimitBurst, r.Burst)
+
+	uc := newLoginConn("vip", 1)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, l.Allow(uc, newTextPacket()))
+	}
+	assert.ErrorIs(t, l.Allow(uc, newTextPacket()), errors.RateLimited)
+}
+
+func TestRateLimitAppRegistry(t *testing.T) {
+	f := newFixture(t)
+	f.mr.HSet(infra.KeyAppRateLimit("vip"), "messages", "2", "burst", "1s")
+	l := f.rateLimiter(global.RateLimitRule{Messages: 1, Commands: 1}, nil)
+	apps := l.apps
+
+	// 加载完成前使用配置文件的规则
+	uc := newLoginConn("vip", 1)
+	assert.NoError(t, l.Allow(uc, newTextPacket()))
+	assert.ErrorIs(t, l.Allow(uc, newTextPacket()), errors.RateLimited)
+
+	assert.Eventually(t, func() bool { return apps.Rule("vip") != nil }, time.Second, 10*time.Millisecond)
+	r := l.rule("vip", apps.Rule("vip"))
+	assert.Equal(t, float64(2), r.Messages)
+	assert.Equal(t, float64(1), r.Commands)
+
+	// 规则变化后重建令牌桶
+	assert.NoError(t, l.Allow(uc, newTextPacket()))
+	assert.NoError(t, l.Allow(uc, newTextPacket()))
+	assert.ErrorIs(t, l.Allow(uc, newTextPacket()), errors.RateLimited)
+
+	// 刷新时规则未变化保留原来的指针
+	old := apps.Rule("vip")
+	apps.load(context.Background(), "vip")
+	assert.Same(t, old, apps.Rule("vip"))
+
+	f.mr.HSet(infra.KeyAppRateLimit("vip"), "messages", "5")
+	apps.load(context.Background(), "vip")
+	assert.Equal(t, float64(5), apps.Rule("vip").Messages)
+
+	// 注册表中没有的app
+	assert.Nil(t, apps.Rule("other"))
+	assert.Eventually(t, func() bool {
+		v, ok := apps.rules.Load("other")
+		return ok && v == noAppRule
+	}, time.Second, 10*time.Millisecond)
+	assert.Nil(t, apps.Rule("other"))
+}
+
+func TestParseAppRule(t *testing.T) {
+	assert.Same(t, noAppRule, parseAppRule(nil))
+	assert.Same(t, noAppRule, parseAppRule(map[string]string{"messages": "x"}))
+
+	r := parseAppRule(map[string]string{"packets": "10", "bytes": "1024", "commands": "3", "burst": "2s"})
+	assert.Equal(t, global.RateLimitRule{Packets: 10, Bytes: 1024, Commands: 3, Burst: 2 * time.Second}, *r)
+}
+
+func TestRateLimitExceeded(t *testing.T) {
+	l := newFixture(t).rateLimiter(global.RateLimitRule{Packets: 1}, nil)
+	uc := &domain.UserConn{}
+
+	assert.NoError(t, l.Allow(uc, newTextPacket()))
+	for i := 0; i < 3; i++ {
+		assert.False(t, l.Exceeded(uc))
+		assert.Error(t, l.Allow(uc, newTextPacket()))
+	}
+	assert.True(t, l.Exceeded(uc))
+
+	l.Remove(uc)
+	assert.False(t, l.Exceeded(uc))
+
+	disabled := NewRateLimiter(getOrDefaultRateLimitConfig(&global.TCPConfig{}), nil)
+	assert.Nil(t, disabled)
+	assert.NoError(t, disabled.Allow(uc, newTextPacket()))
+	assert.False(t, disabled.Exceeded(uc))
+}
+
+func TestRateLimitThrottled(t *testing.T) {
+	p := throttled(newTextPacket(), errors.RateLimited)
+	assert.Equal(t, int32(errors.RateLimited.Code), p.GetMessage().Code)
+	assert.Equal(t, api.FlowResponse, p.GetMessage().Flow)
+
+	p = throttled(newCommandPacket(), errors.RateLimited)
+	assert.Equal(t, int32(errors.RateLimited.Code), p.GetCommand().Code)
+
+	assert.Nil(t, throttled(api.NewHeartbeat(1).Wrap(), errors.RateLimited))
+}
@@ -0,0 +1,186 @@
+package broker
+
+import (
+	"context"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/broker/holder"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/pkg/ip"
+	"github.com/magicnana999/im/pkg/jsonext"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// DefRegistryLease broker租约时长，宕机后最晚在该时间后被router清理
+	DefRegistryLease = 30 * time.Second
+
+	// DefRegistryRefresh 续约间隔
+	DefRegistryRefresh = 10 * time.Second
+)
+
+func getOrDefaultRegistryConfig(g *global.Config) *global.RegistryConfig {
+	c := &global.RegistryConfig{}
+	if g != nil && g.Registry != nil {
+		*c = *g.Registry
+	}
+
+	if c.Lease <= 0 {
+		c.Lease = DefRegistryLease
+	}
+
+	if c.Refresh <= 0 || c.Refresh >= c.Lease {
+		c.Refresh = c.Lease / 3
+	}
+
+	return c
+}
+
+// RegistryServer 在redis中登记broker实例并定时续约，同时登记本实例上登录的连接。
+// broker宕机后租约过期，router按实例清理这些连接
+type RegistryServer struct {
+	cfg    *global.RegistryConfig
+	bh     *holder.BrokerHolder
+	uh     *holder.UserHolder
+	mu     sync.RWMutex
+	info   domain.BrokerInfo
+	cancel context.CancelFunc
+	logger *Logger
+}
+
+func NewRegistryServer(g *global.Config, bh *holder.BrokerHolder, uh *holder.UserHolder, lc fx.Lifecycle) (*RegistryServer, error) {
+	c := getOrDefaultRegistryConfig(g)
+
+	log := NewLogger("registry")
+	log.SrvInfo(string(jsonext.MarshalNoErr(c)), SrvLifecycle, nil)
+
+	addr, err := registryAddr(getOrDefaultTCPConfig(g).Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &RegistryServer{
+		cfg:    c,
+		bh:     bh,
+		uh:     uh,
+		info:   domain.BrokerInfo{Addr: addr, StartAt: time.Now().UnixMilli()},
+		logger: log,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return rs.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return rs.Stop(ctx)
+		},
+	})
+
+	return rs, nil
+}
+
+// registryAddr 监听地址未指定ip时使用本机ip
+func registryAddr(listen string) (string, error) {
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return "", err
+	}
+
+	if h := net.ParseIP(host); host == "" || (h != nil && h.IsUnspecified()) {
+		if host, err = ip.GetLocalIP(); err != nil {
+			return "", err
+		}
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// Info 当前实例
+func (s *RegistryServer) Info() domain.BrokerInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.info
+}
+
+func (s *RegistryServer) Start(ctx context.Context) error {
+	if _, err := s.bh.StoreBroker(ctx, s.Info(), s.cfg.Lease); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.keepalive(ctx)
+
+	s.logger.SrvInfo("broker registered", SrvLifecycle, nil, zap.String("id", s.Info().Id()))
+	return nil
+}
+
+func (s *RegistryServer) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return s.bh.ExpireBroker(ctx, s.Info())
+}
+
+// StoreClient 登录后登记到当前实例
+func (s *RegistryServer) StoreClient(ctx context.Context, uc *domain.UserConn) {
+	if _, err := s.bh.StoreBrokerClient(ctx, s.Info(), uc); err != nil {
+		s.logger.ConnDebug("store broker client failed", uc.Desc(), ConnLifecycle, err)
+	}
+}
+
+// DeleteClient 断开后从当前实例删除
+func (s *RegistryServer) DeleteClient(ctx context.Context, uc *domain.UserConn) {
+	if _, err := s.bh.DeleteBrokerClient(ctx, s.Info(), uc); err != nil {
+		s.logger.ConnDebug("delete broker client failed", uc.Desc(), ConnLifecycle, err)
+	}
+}
+
+func (s *RegistryServer) keepalive(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ok, err := s.bh.RefreshBroker(ctx, s.Info(), s.cfg.Lease)
+		if err != nil {
+			s.logger.SrvInfo("refresh broker failed", SrvLifecycle, err)
+			continue
+		}
+		if !ok {
+			s.renew(ctx)
+		}
+	}
+}
+
+// renew 租约丢失时本实例的连接可能已被router清理，以新的实例重新登记所有连接
+func (s *RegistryServer) renew(ctx context.Context) {
+	s.mu.Lock()
+	s.info = domain.BrokerInfo{Addr: s.info.Addr, StartAt: time.Now().UnixMilli()}
+	s.mu.Unlock()
+
+	info := s.Info()
+	if _, err := s.bh.StoreBroker(ctx, info, s.cfg.Lease); err != nil {
+		s.logger.SrvInfo("renew broker failed", SrvLifecycle, err)
+		return
+	}
+
+	n := 0
+	s.uh.RangeAllUserConn(func(uc *domain.UserConn) bool {
+		if uc.IsLogin.Load() && !uc.IsClosed.Load() {
+			s.uh.StoreUserClients(ctx, uc)
+			s.StoreClient(ctx, uc)
+			n++
+		}
+		return true
+	})
+
+	s.logger.SrvInfo("broker lease lost, renewed", SrvLifecycle, nil, zap.String("id", info.Id()), zap.Int("clients", n))
+}
@@ -0,0 +1,109 @@
+package broker
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/broker/holder"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRegistryConfig(t *testing.T) {
+	c := getOrDefaultRegistryConfig(nil)
+	assert.Equal(t, DefRegistryLease, c.Lease)
+	assert.Equal(t, DefRegistryRefresh, c.Refresh)
+
+	// 续约间隔不小于租约时无法续约
+	c = getOrDefaultRegistryConfig(&global.Config{Registry: &global.RegistryConfig{Lease: 6 * time.Second, Refresh: 6 * time.Second}})
+	assert.Equal(t, 2*time.Second, c.Refresh)
+
+	addr, err := registryAddr("127.0.0.1:5075")
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:5075", addr)
+
+	if addr, err = registryAddr("0.0.0.0:5075"); err == nil {
+		host, port, _ := net.SplitHostPort(addr)
+		assert.False(t, net.ParseIP(host).IsUnspecified())
+		assert.Equal(t, "5075", port)
+	}
+}
+
+func TestRegistryRenew(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	bh, _ := holder.NewBrokerHolder(f.rds, nil)
+	uh, _ := holder.NewUserHolder(f.rds, nil)
+	s := &RegistryServer{
+		cfg:    getOrDefaultRegistryConfig(nil),
+		bh:     bh,
+		uh:     uh,
+		info:   domain.BrokerInfo{Addr: "127.0.0.1:5075", StartAt: 1},
+		logger: NewLogger("registry"),
+	}
+
+	uc := &domain.UserConn{ClientAddr: "1.1.1.1:1", ConnectTime: 100}
+	uc.Login("19860220", 1, "iOS")
+	uh.HoldUserConn(uc)
+	uh.StoreUserClients(ctx, uc)
+
+	old := s.Info()
+	_, err := bh.StoreBroker(ctx, old, s.cfg.Lease)
+	assert.NoError(t, err)
+	s.StoreClient(ctx, uc)
+
+	ok, err := bh.RefreshBroker(ctx, old, s.cfg.Lease)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// 租约过期后router清理了本实例的连接
+	f.rds.ZAdd(ctx, infra.KeyBrokers(), &redis.Z{Score: 0, Member: old.Id()})
+	ok, err = bh.RefreshBroker(ctx, old, s.cfg.Lease)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	f.rds.ZRem(ctx, infra.KeyBrokers(), old.Id())
+	f.rds.Del(ctx, infra.KeyBrokerClients(old.Id()), infra.KeyUserClients("19860220", 1))
+
+	// 以新的实例重新登记连接
+	time.Sleep(time.Millisecond)
+	s.renew(ctx)
+	cur := s.Info()
+	assert.NotEqual(t, old.Id(), cur.Id())
+	assert.Equal(t, old.Addr, cur.Addr)
+
+	ids, _ := f.rds.ZRange(ctx, infra.KeyBrokers(), 0, -1).Result()
+	assert.Equal(t, []string{cur.Id()}, ids)
+	assert.True(t, f.mr.Exists(infra.KeyBroker(cur.Addr)))
+	assert.Equal(t, int64(1), f.rds.HLen(ctx, infra.KeyBrokerClients(cur.Id())).Val())
+	assert.Equal(t, int64(1), f.rds.HLen(ctx, infra.KeyUserClients("19860220", 1)).Val())
+
+	// 停止后立即过期
+	assert.NoError(t, bh.ExpireBroker(ctx, cur))
+	assert.Equal(t, float64(0), f.rds.ZScore(ctx, infra.KeyBrokers(), cur.Id()).Val())
+	assert.False(t, f.mr.Exists(infra.KeyBroker(cur.Addr)))
+}
+
+func TestRegistryLeaseClock(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	bh, _ := holder.NewBrokerHolder(f.rds, nil)
+	info := domain.BrokerInfo{Addr: "127.0.0.1:5075", StartAt: 1}
+
+	// 租约按redis的时间计算，与broker本地时钟无关
+	redisNow := time.UnixMilli(1_000_000)
+	f.mr.SetTime(redisNow)
+
+	_, err := bh.StoreBroker(ctx, info, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(redisNow.Add(time.Minute).UnixMilli()), f.rds.ZScore(ctx, infra.KeyBrokers(), info.Id()).Val())
+
+	f.mr.SetTime(redisNow.Add(time.Second))
+	ok, err := bh.RefreshBroker(ctx, info, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, float64(redisNow.Add(time.Second+time.Minute).UnixMilli()), f.rds.ZScore(ctx, infra.KeyBrokers(), info.Id()).Val())
+}
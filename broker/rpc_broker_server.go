@@ -8,8 +8,11 @@ import (
 	"github.com/magicnana999/im/api/kitex_gen/api"
 	"github.com/magicnana999/im/api/kitex_gen/api/brokerservice"
 	"github.com/magicnana999/im/broker/holder"
+	"github.com/magicnana999/im/errors"
 	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
 	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
 	"net"
 )
 
@@ -17,6 +20,7 @@ type RpcBrokerServer struct {
 	cfg        *global.RBSConfig
 	registry   registry.Registry
 	server     server.Server
+	addr       net.Addr
 	mss        *MessageSendServer
 	userHolder *holder.UserHolder
 	logger     *Logger
@@ -42,6 +46,7 @@ func NewRpcBrokerServer(
 	registry registry.Registry,
 	mss *MessageSendServer,
 	userHolder *holder.UserHolder,
+	rs *RegistryServer,
 	g *global.Config,
 	lc fx.Lifecycle) (*RpcBrokerServer, error) {
 
@@ -61,12 +66,15 @@ func NewRpcBrokerServer(
 	}
 
 	addr, _ := net.ResolveTCPAddr(c.Network, c.Addr)
+	s.addr = addr
 	svr := brokerservice.NewServer(s,
 		server.WithServiceAddr(addr),
 		server.WithRegistry(registry),
 		server.WithServerBasicInfo(
 			&rpcinfo.EndpointBasicInfo{
-				ServiceName: "im.broker",
+				ServiceName: infra.BrokerServiceName,
+				// router按用户连接的broker地址找到本机的rpc地址
+				Tags: map[string]string{infra.BrokerConnTag: rs.Info().Addr},
 			},
 		),
 	)
@@ -100,15 +108,65 @@ func (s *RpcBrokerServer) Stop(ctx context.Context) error {
 	return err
 }
 
+// Deregister 下线时从etcd注销，router不再发现本机，已有的投递仍然处理
+func (s *RpcBrokerServer) Deregister() error {
+	err := s.registry.Deregister(&registry.Info{
+		ServiceName: infra.BrokerServiceName,
+		Addr:        s.addr,
+	})
+	s.logger.SrvInfo("rpc server deregister", SrvLifecycle, err)
+	return err
+}
+
+// Deliver 逐个label投递，结果中code不为0的label由router转离线
 func (s *RpcBrokerServer) Deliver(ctx context.Context, req *api.DeliverRequest) (res *api.DeliverReply, err error) {
+	if req.Message == nil {
+		e := errors.MsgDeliverTaskError.SetDetail("message is required")
+		return &api.DeliverReply{MessageId: req.MessageId, Code: int32(e.Code), Message: e.Error()}, nil
+	}
+
+	results := make([]*api.DeliverResult, 0, len(req.UserLabels))
 	for _, label := range req.UserLabels {
-		uc := s.userHolder.GetUserConn(label)
-		s.mss.Send(req.Message, uc)
+		results = append(results, s.deliver(req.Message, label))
 	}
 
 	return &api.DeliverReply{
 		MessageId: req.MessageId,
 		Code:      0,
 		Message:   "",
+		Results:   results,
 	}, nil
 }
+
+// deliver 连接不在本机、超过写高水位或进入发送队列失败时返回失败
+func (s *RpcBrokerServer) deliver(m *api.Message, label string) *api.DeliverResult {
+	uc := s.userHolder.GetUserConn(label)
+	if uc == nil {
+		e := errors.UserConnNotFound.SetDetail(label)
+		return &api.DeliverResult{Label: label, Code: int32(e.Code), Message: e.Error()}
+	}
+
+	m = mentionedFor(m, uc.UserId.Load())
+	if err := s.mss.Send(m, uc); err != nil {
+		e := errors.MsgDeliverTaskError.SetDetail(err.Error())
+		if err == errSlowConsumer {
+			e = errors.UserConnCongested.SetDetail(label)
+		}
+		s.logger.PktDebug("deliver failed", uc.Desc(), m.MessageId, nil, PacketTracking, err)
+		return &api.DeliverResult{Label: label, Code: int32(e.Code), Message: e.Error()}
+	}
+
+	return &api.DeliverResult{Label: label}
+}
+
+// mentionedFor 按接收者设置mentioned，和原消息不同时复制一份，不修改其他接收者共用的消息
+func mentionedFor(m *api.Message, userId int64) *api.Message {
+	mentioned := m.IsToGroup() && m.IsAt(userId)
+	if m.Mentioned == mentioned {
+		return m
+	}
+
+	c := proto.Clone(m).(*api.Message)
+	c.Mentioned = mentioned
+	return c
+}
@@ -0,0 +1,49 @@
+package broker
+
+import (
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/broker/holder"
+	"github.com/magicnana999/im/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMentionedFor(t *testing.T) {
+	m := api.NewMessage(1, 0, 1001, 1, "app", "conv", &api.Text{Text: "hi"})
+	m.At = []*api.At{{UserId: 3}}
+
+	mentioned := mentionedFor(m, 3)
+	assert.True(t, mentioned.Mentioned)
+	assert.False(t, m.Mentioned)
+
+	assert.Same(t, m, mentionedFor(m, 4))
+
+	// 客户端填写的mentioned不转发给其他接收者
+	m.Mentioned = true
+	assert.False(t, mentionedFor(m, 4).Mentioned)
+}
+
+func TestDeliverCongested(t *testing.T) {
+	f := newFixture(t)
+	bp := f.backpressure(1024)
+	mss := f.sendServer(10, bp)
+
+	uh, _ := holder.NewUserHolder(nil, nil)
+	s := &RpcBrokerServer{mss: mss, userHolder: uh, logger: NewLogger("test")}
+
+	uc := &domain.UserConn{Conn: &stuckConn{}}
+	uc.Login("19860220", 1, "iOS")
+	uh.HoldUserConn(uc)
+	m := api.NewMessage(2, 1, 0, 1, "19860220", "1:2", &api.Text{Text: "hi"})
+
+	ret := s.deliver(m, uc.Label())
+	assert.Zero(t, ret.Code)
+	assert.Len(t, mss.ch, 1)
+
+	// 超过高水位时不进入发送队列，返回失败由router转离线
+	uc.Buffered.Store(2048)
+	ret = s.deliver(m, uc.Label())
+	assert.Equal(t, int32(errors.UserConnCongested.Code), ret.Code)
+	assert.Len(t, mss.ch, 1)
+}
@@ -4,24 +4,45 @@ import (
 	"encoding/binary"
 	"errors"
 	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
 	"github.com/panjf2000/gnet/v2"
 	bb "github.com/panjf2000/gnet/v2/pkg/pool/bytebuffer"
 	"google.golang.org/protobuf/proto"
 )
 
+const (
+	// DefMaxFrameSize 单帧body的默认最大字节数，压缩帧按解压后的大小计算
+	DefMaxFrameSize = 1 << 20
+
+	// v1帧长度前缀的字节数
+	lengthFieldSize = 4
+)
+
 var (
-	lengthError = errors.New("read length is less than field")
+	errFrameLength   = errors.New("frame length is negative")
+	errFrameTooLarge = errors.New("frame too large")
 )
 
-var defaultCodec = &Codec{}
+var defaultCodec = &Codec{maxFrameSize: DefMaxFrameSize}
 
+// Codec tcp编解码，v1为 int32长度+body，长度为4时是心跳；v2见 FrameCodec。
+// 解码时先Peek帧头，数据完整后才消费，半包留在gnet的缓冲区中等待下一次OnTraffic
 type Codec struct {
+	maxFrameSize int
 }
 
 func NewCodec() *Codec {
 	return defaultCodec
 }
 
+// NewCodecWithMaxFrameSize 超过maxFrameSize的帧直接返回错误，由调用方断开连接
+func NewCodecWithMaxFrameSize(maxFrameSize int) *Codec {
+	if maxFrameSize <= 0 {
+		return defaultCodec
+	}
+	return &Codec{maxFrameSize: maxFrameSize}
+}
+
 func (l *Codec) Encode(p *api.Packet) (*bb.ByteBuffer, error) {
 
 	buffer := bb.Get()
@@ -48,50 +69,94 @@ func (l *Codec) Encode(p *api.Packet) (*bb.ByteBuffer, error) {
 	}
 }
 
+// Decode 同一端口兼容v1和v2，按每一帧的前缀区分
 func (l *Codec) Decode(c gnet.Conn) ([]*api.Packet, error) {
+	return l.decode(c, nil)
+}
+
+// DecodeNegotiate 解码，并以该连接的首帧协商回写使用的帧版本
+func (l *Codec) DecodeNegotiate(c gnet.Conn, uc *domain.UserConn) ([]*api.Packet, error) {
+	return l.decode(c, func(version uint8) {
+		uc.FrameVersion.CompareAndSwap(0, int32(min(version, FrameVersion)))
+	})
+}
+
+func (l *Codec) decode(c gnet.Conn, negotiate func(version uint8)) ([]*api.Packet, error) {
 
 	result := make([]*api.Packet, 0)
 
-	for c.InboundBuffered() >= 4 {
+	for c.InboundBuffered() >= 2 {
 
-		var length int32
-		if err := binary.Read(c, binary.BigEndian, &length); err != nil {
+		head, err := c.Peek(2)
+		if err != nil {
 			return nil, err
 		}
 
-		if length == 4 && c.InboundBuffered() >= int(length) {
+		var packet *api.Packet
+		version := FrameV1
+		if isFrameV2(head) {
+			packet, version, err = l.decodeFrame(c)
+		} else {
+			packet, err = l.decodeV1(c)
+		}
 
-			var heartbeat int32
-			if err := binary.Read(c, binary.BigEndian, &heartbeat); err != nil {
-				return nil, err
-			}
+		if err != nil {
+			return nil, err
+		}
+
+		if packet == nil {
+			break
+		}
 
-			packet := api.NewHeartbeat(int32(heartbeat)).Wrap()
-			result = append(result, packet)
+		if negotiate != nil {
+			negotiate(version)
 		}
+		result = append(result, packet)
+	}
 
-		if length > 4 && c.InboundBuffered() >= int(length) {
+	return result, nil
+}
 
-			bs := make([]byte, int(length))
-			n, e := c.Read(bs)
-			if e != nil {
-				return nil, e
-			}
+// decodeV1 解码一个v1帧，数据不完整时返回nil且不消费
+func (l *Codec) decodeV1(c gnet.Conn) (*api.Packet, error) {
+	if c.InboundBuffered() < lengthFieldSize {
+		return nil, nil
+	}
 
-			if n != int(length) {
-				return nil, lengthError
-			}
+	head, err := c.Peek(lengthFieldSize)
+	if err != nil {
+		return nil, err
+	}
 
-			var p api.Packet
-			if e4 := proto.Unmarshal(bs, &p); e4 != nil {
-				return nil, e4
-			}
+	length := int32(binary.BigEndian.Uint32(head))
+	if length < 0 {
+		return nil, errFrameLength
+	}
 
-			result = append(result, &p)
+	if int(length) > l.maxFrameSize {
+		return nil, errFrameTooLarge
+	}
 
-		}
+	if c.InboundBuffered() < lengthFieldSize+int(length) {
+		return nil, nil
+	}
 
+	if _, err := c.Discard(lengthFieldSize); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	body, err := c.Next(int(length))
+	if err != nil {
+		return nil, err
+	}
+
+	if length == 4 {
+		return api.NewHeartbeat(int32(binary.BigEndian.Uint32(body))).Wrap(), nil
+	}
+
+	var p api.Packet
+	if err := proto.Unmarshal(body, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
 }
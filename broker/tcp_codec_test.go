@@ -0,0 +1,148 @@
+package broker
+
+import (
+	"encoding/binary"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"math/rand"
+	"testing"
+)
+
+func TestCodecV1Partial(t *testing.T) {
+	p := largeText()
+	data := encodeV1(t, p)
+
+	// 每次只到达一部分，半包不能被消费
+	c := &bufConn{}
+	var packets []*api.Packet
+	for i := 0; i < len(data); i += 7 {
+		c.buf = append(c.buf, data[i:min(i+7, len(data))]...)
+		ps, err := NewCodec().Decode(c)
+		assert.NoError(t, err)
+		packets = append(packets, ps...)
+	}
+
+	assert.Len(t, packets, 1)
+	assert.True(t, proto.Equal(p, packets[0]))
+	assert.Zero(t, c.InboundBuffered())
+}
+
+func TestCodecInvalidLength(t *testing.T) {
+	// 负数长度
+	_, err := NewCodec().Decode(&bufConn{buf: []byte{0xff, 0xff, 0xff, 0xfc, 1, 2, 3, 4}})
+	assert.ErrorIs(t, err, errFrameLength)
+
+	// 超过最大帧，只需要帧头就能判断
+	codec := NewCodecWithMaxFrameSize(1024)
+	_, err = codec.Decode(&bufConn{buf: binary.BigEndian.AppendUint32(nil, 1025)})
+	assert.ErrorIs(t, err, errFrameTooLarge)
+
+	_, err = codec.Decode(&bufConn{buf: appendFrameHeader(nil, FrameV2, 0, FrameTypePacket, 1025)})
+	assert.ErrorIs(t, err, errFrameTooLarge)
+
+	// 压缩帧按解压后的大小计算
+	_, err = codec.Decode(&bufConn{buf: encodeFrameWith(t, FrameCodecOf(CompressionZstd), largeText())})
+	assert.ErrorIs(t, err, errDecompressTooLarge)
+
+	assert.Same(t, defaultCodec, NewCodecWithMaxFrameSize(0))
+}
+
+// randomFrames 随机生成v1、v2、压缩帧混合的数据
+func randomFrames(t testing.TB, r *rand.Rand) ([]byte, []*api.Packet) {
+	var data []byte
+	var packets []*api.Packet
+
+	for i := 0; i < 1+r.Intn(20); i++ {
+		var p *api.Packet
+		switch r.Intn(3) {
+		case 0:
+			p = api.NewHeartbeat(r.Int31()).Wrap()
+		case 1:
+			p = largeText()
+		default:
+			p = api.NewMessage(r.Int63(), r.Int63(), 0, r.Int63(), "19860220", "1:2", &api.Text{Text: "hi"}).Wrap()
+		}
+
+		switch r.Intn(4) {
+		case 0:
+			data = append(data, encodeV1(t, p)...)
+		case 1:
+			data = append(data, encodeFrame(t, p)...)
+		case 2:
+			data = append(data, encodeFrameWith(t, FrameCodecOf(CompressionZstd), p)...)
+		default:
+			data = append(data, encodeFrameWith(t, FrameCodecOf(CompressionGzip), p)...)
+		}
+		packets = append(packets, p)
+	}
+	return data, packets
+}
+
+// decodeSplit 按随机大小分段喂给Codec，模拟拆包和粘包
+func decodeSplit(codec *Codec, data []byte, r *rand.Rand) ([]*api.Packet, int, error) {
+	c := &bufConn{}
+	var result []*api.Packet
+	for len(data) > 0 {
+		n := 1 + r.Intn(min(len(data), 64))
+		c.buf = append(c.buf, data[:n]...)
+		data = data[n:]
+
+		packets, err := codec.Decode(c)
+		if err != nil {
+			return nil, 0, err
+		}
+		result = append(result, packets...)
+	}
+	return result, c.InboundBuffered(), nil
+}
+
+func TestCodecRandomSplit(t *testing.T) {
+	r := rand.New(rand.NewSource(20250419))
+
+	for i := 0; i < 200; i++ {
+		data, expected := randomFrames(t, r)
+
+		packets, remaining, err := decodeSplit(NewCodec(), data, r)
+		assert.NoError(t, err)
+		assert.Zero(t, remaining)
+		if assert.Len(t, packets, len(expected)) {
+			for j := range expected {
+				assert.True(t, proto.Equal(expected[j], packets[j]))
+			}
+		}
+	}
+}
+
+// FuzzCodecDecode 任意输入不能panic；分段解码和一次解码的结果一致
+func FuzzCodecDecode(f *testing.F) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 8; i++ {
+		data, _ := randomFrames(f, r)
+		f.Add(data, int64(i))
+	}
+	f.Add([]byte{0, 0, 0, 4, 0, 0, 0, 1}, int64(0))
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff}, int64(0))
+	f.Add(appendFrameHeader(nil, FrameV2, FlagCompressed|1<<compressionShift, FrameTypePacket, 3), int64(0))
+
+	f.Fuzz(func(t *testing.T, data []byte, seed int64) {
+		codec := NewCodecWithMaxFrameSize(64 << 10)
+
+		whole := &bufConn{buf: append([]byte(nil), data...)}
+		expected, err := codec.Decode(whole)
+
+		packets, remaining, splitErr := decodeSplit(codec, data, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			assert.Error(t, splitErr)
+			return
+		}
+
+		assert.NoError(t, splitErr)
+		assert.Equal(t, whole.InboundBuffered(), remaining)
+		if assert.Len(t, packets, len(expected)) {
+			for i := range expected {
+				assert.True(t, proto.Equal(expected[i], packets[i]))
+			}
+		}
+	})
+}
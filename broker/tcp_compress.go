@@ -0,0 +1,152 @@
+package broker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"sync"
+)
+
+const (
+	CompressionZstd = "zstd"
+	CompressionGzip = "gzip"
+
+	// DefCompressThreshold body超过该字节数才压缩，小包压缩收益不抵cpu开销
+	DefCompressThreshold = 512
+
+	// maxDecoderMemory zstd解码的内存上限，实际限制为 Codec 的maxFrameSize
+	maxDecoderMemory = 64 << 20
+)
+
+var (
+	errFrameCompression   = errors.New("frame compression not supported")
+	errDecompressTooLarge = errors.New("frame decompressed too large")
+)
+
+// compressor 压缩算法，id写入帧flags，在帧内自描述，解码不依赖连接状态
+type compressor interface {
+	id() uint8
+	name() string
+	compress(src []byte) ([]byte, error)
+	decompress(src []byte, limit int) ([]byte, error)
+}
+
+var (
+	compressors = []compressor{newZstdCompressor(), &gzipCompressor{}}
+
+	frameCodecs = func() map[string]*FrameCodec {
+		m := map[string]*FrameCodec{"": defaultFrameCodec}
+		for _, c := range compressors {
+			m[c.name()] = &FrameCodec{compressor: c, threshold: DefCompressThreshold}
+		}
+		return m
+	}()
+)
+
+// NegotiateCompression 按客户端的优先级选择第一个支持的算法，都不支持时返回空
+func NegotiateCompression(offered []string) string {
+	for _, name := range offered {
+		if _, ok := frameCodecs[name]; ok && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// FrameCodecOf 按协商的算法返回编码，未知算法不压缩
+func FrameCodecOf(compression string) *FrameCodec {
+	if c, ok := frameCodecs[compression]; ok {
+		return c
+	}
+	return defaultFrameCodec
+}
+
+func compressorOf(id uint8) compressor {
+	for _, c := range compressors {
+		if c.id() == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// zstdCompressor EncodeAll/DecodeAll可并发调用，全局共用一个
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCompressor() *zstdCompressor {
+	encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+	decoder, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(maxDecoderMemory))
+	return &zstdCompressor{encoder: encoder, decoder: decoder}
+}
+
+func (z *zstdCompressor) id() uint8    { return 1 }
+func (z *zstdCompressor) name() string { return CompressionZstd }
+
+func (z *zstdCompressor) compress(src []byte) ([]byte, error) {
+	return z.encoder.EncodeAll(src, nil), nil
+}
+
+func (z *zstdCompressor) decompress(src []byte, limit int) ([]byte, error) {
+	bs, err := z.decoder.DecodeAll(src, nil)
+	if errors.Is(err, zstd.ErrDecoderSizeExceeded) || len(bs) > limit {
+		return nil, errDecompressTooLarge
+	}
+	return bs, err
+}
+
+// gzipCompressor writer和reader放在pool中复用
+type gzipCompressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+func (g *gzipCompressor) id() uint8    { return 2 }
+func (g *gzipCompressor) name() string { return CompressionGzip }
+
+func (g *gzipCompressor) compress(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, ok := g.writers.Get().(*gzip.Writer)
+	if ok {
+		w.Reset(&buf)
+	} else {
+		w = gzip.NewWriter(&buf)
+	}
+	defer g.writers.Put(w)
+
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (g *gzipCompressor) decompress(src []byte, limit int) ([]byte, error) {
+	r, ok := g.readers.Get().(*gzip.Reader)
+	if ok {
+		if err := r.Reset(bytes.NewReader(src)); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if r, err = gzip.NewReader(bytes.NewReader(src)); err != nil {
+			return nil, err
+		}
+	}
+	defer g.readers.Put(r)
+
+	bs, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(bs) > limit {
+		return nil, errDecompressTooLarge
+	}
+	return bs, nil
+}
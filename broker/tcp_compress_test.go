@@ -0,0 +1,156 @@
+package broker
+
+import (
+	"fmt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+func largeText() *api.Packet {
+	text := strings.Repeat("今天下午三点在会议室讨论 v2 协议的压缩方案，请大家准时参加。", 40)
+	return api.NewMessage(1, 2, 0, 1, "19860220", "1:2", &api.Text{Text: text}).Wrap()
+}
+
+func largeMerged() *api.Packet {
+	merged := &api.Merged{Title: "群聊的聊天记录"}
+	for i := 0; i < 50; i++ {
+		merged.Items = append(merged.Items, &api.MergedItem{
+			MessageId:   fmt.Sprintf("cvq8ka2vlsq1m6ggk%03d", i),
+			UserId:      int64(10000 + i%5),
+			Name:        fmt.Sprintf("user-%d", i%5),
+			MessageType: api.MessageTypeText,
+			Summary:     "收到，我这边已经处理完了",
+			CTime:       1745000000000 + int64(i)*1000,
+		})
+	}
+	return api.NewMessage(1, 0, 1001, 1, "19860220", "g:1001", merged).Wrap()
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	for _, name := range []string{CompressionZstd, CompressionGzip} {
+		for _, p := range []*api.Packet{largeText(), largeMerged()} {
+			raw, err := proto.Marshal(p)
+			assert.NoError(t, err)
+
+			data := encodeFrameWith(t, FrameCodecOf(name), p)
+			assert.NotZero(t, data[3]&FlagCompressed, name)
+			assert.Less(t, len(data), len(raw), name)
+
+			packets, err := NewCodec().Decode(&bufConn{buf: data})
+			assert.NoError(t, err)
+			assert.Len(t, packets, 1)
+			assert.True(t, proto.Equal(p, packets[0]), name)
+		}
+	}
+}
+
+func TestCompressionThreshold(t *testing.T) {
+	data := encodeFrameWith(t, FrameCodecOf(CompressionZstd), api.NewHeartbeat(1).Wrap())
+	assert.Zero(t, data[3])
+
+	small := api.NewMessage(1, 2, 0, 1, "19860220", "1:2", &api.Text{Text: "hi"}).Wrap()
+	data = encodeFrameWith(t, FrameCodecOf(CompressionZstd), small)
+	assert.Zero(t, data[3])
+}
+
+func TestCompressionNegotiate(t *testing.T) {
+	assert.Equal(t, CompressionGzip, NegotiateCompression([]string{"br", CompressionGzip, CompressionZstd}))
+	assert.Equal(t, CompressionZstd, NegotiateCompression([]string{CompressionZstd, CompressionGzip}))
+	assert.Equal(t, "", NegotiateCompression([]string{"br", ""}))
+	assert.Equal(t, "", NegotiateCompression(nil))
+
+	uc := &domain.UserConn{}
+	uc.FrameVersion.Store(int32(FrameV2))
+	uc.Compression.Store(CompressionGzip)
+	assert.Same(t, FrameCodecOf(CompressionGzip), encoderOf(uc, NewCodec()))
+	assert.Same(t, defaultFrameCodec, FrameCodecOf("br"))
+}
+
+func TestCompressionInvalid(t *testing.T) {
+	body := []byte("not compressed")
+
+	// 未知算法
+	data := append(appendFrameHeader(nil, FrameV2, FlagCompressed|3<<compressionShift, FrameTypePacket, len(body)), body...)
+	_, err := NewCodec().Decode(&bufConn{buf: data})
+	assert.ErrorIs(t, err, errFrameCompression)
+
+	// 有算法id但没有压缩标记
+	data = append(appendFrameHeader(nil, FrameV2, 1<<compressionShift, FrameTypePacket, len(body)), body...)
+	_, err = NewCodec().Decode(&bufConn{buf: data})
+	assert.ErrorIs(t, err, errFrameFlags)
+
+	// 压缩炸弹
+	for _, c := range compressors {
+		bomb, err := c.compress(make([]byte, DefMaxFrameSize+1))
+		assert.NoError(t, err)
+		data = append(appendFrameHeader(nil, FrameV2, FlagCompressed|c.id()<<compressionShift, FrameTypePacket, len(bomb)), bomb...)
+		_, err = NewCodec().Decode(&bufConn{buf: data})
+		assert.ErrorIs(t, err, errDecompressTooLarge, c.name())
+	}
+}
+
+func encodeFrameWith(tb testing.TB, codec *FrameCodec, p *api.Packet) []byte {
+	buffer, err := codec.Encode(p)
+	assert.NoError(tb, err)
+	return append([]byte(nil), buffer.Bytes()...)
+}
+
+var benchPackets = map[string]func() *api.Packet{
+	"text":   largeText,
+	"merged": largeMerged,
+}
+
+// BenchmarkFrameEncode 对比各算法的编码耗时，wire-bytes为实际发送的字节数，saved为节省的比例
+func BenchmarkFrameEncode(b *testing.B) {
+	for _, name := range []string{"", CompressionZstd, CompressionGzip} {
+		for kind, newPacket := range benchPackets {
+			p := newPacket()
+			raw := len(encodeFrameWith(b, defaultFrameCodec, p))
+			codec := FrameCodecOf(name)
+
+			b.Run(fmt.Sprintf("%s/%s", algorithmName(name), kind), func(b *testing.B) {
+				var n int
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					buffer, err := codec.Encode(p)
+					if err != nil {
+						b.Fatal(err)
+					}
+					n = buffer.Len()
+				}
+				b.ReportMetric(float64(n), "wire-bytes")
+				b.ReportMetric(1-float64(n)/float64(raw), "saved")
+			})
+		}
+	}
+}
+
+// BenchmarkFrameDecode 对比各算法的解码耗时
+func BenchmarkFrameDecode(b *testing.B) {
+	for _, name := range []string{"", CompressionZstd, CompressionGzip} {
+		for kind, newPacket := range benchPackets {
+			data := encodeFrameWith(b, FrameCodecOf(name), newPacket())
+
+			b.Run(fmt.Sprintf("%s/%s", algorithmName(name), kind), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(data)))
+				for i := 0; i < b.N; i++ {
+					if _, err := NewCodec().Decode(&bufConn{buf: data}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func algorithmName(name string) string {
+	if name == "" {
+		return "none"
+	}
+	return name
+}
@@ -0,0 +1,183 @@
+package broker
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/panjf2000/gnet/v2"
+	bb "github.com/panjf2000/gnet/v2/pkg/pool/bytebuffer"
+	"google.golang.org/protobuf/proto"
+)
+
+// v2帧格式，头部固定9字节，大端：
+//
+//	| magic 2 | version 1 | flags 1 | type 1 | length 4 | body length |
+//
+// magic为 "IM"，作为v1的长度前缀时超过1GB，不会与v1混淆。
+// 连接上的首帧决定回写使用的版本：v1客户端一直使用v1；
+// v2及以上的客户端协商为 min(客户端版本, FrameVersion)，客户端按服务端回写的版本降级。
+// 之后的版本保持头部布局不变
+const (
+	FrameV1 uint8 = 1
+	FrameV2 uint8 = 2
+
+	// FrameVersion 服务端支持的最高版本
+	FrameVersion = FrameV2
+
+	// FrameHeaderLength v2帧头长度
+	FrameHeaderLength = 9
+
+	// FlagCompressed body已压缩，算法id在flags的4-5位
+	FlagCompressed uint8 = 1 << 0
+
+	// FlagEncrypted body已加密
+	FlagEncrypted uint8 = 1 << 1
+
+	// compressionShift 压缩算法id在flags中的位置
+	compressionShift = 4
+
+	compressionMask uint8 = 0x3 << compressionShift
+
+	// FrameTypeHeartbeat body为4字节int32
+	FrameTypeHeartbeat uint8 = 1
+
+	// FrameTypePacket body为protobuf编码的Packet
+	FrameTypePacket uint8 = 2
+)
+
+var (
+	frameMagic = [2]byte{'I', 'M'}
+
+	// supportedFlags 目前支持的flags，其余位出现时断开连接
+	supportedFlags = FlagCompressed | compressionMask
+
+	errFrameVersion   = errors.New("frame version not supported")
+	errFrameFlags     = errors.New("frame flags not supported")
+	errFrameType      = errors.New("frame type not supported")
+	errFrameHeartbeat = errors.New("frame heartbeat length must be 4")
+)
+
+var defaultFrameCodec = &FrameCodec{}
+
+// FrameCodec v2编码，协商了压缩算法时body超过threshold才压缩
+type FrameCodec struct {
+	compressor compressor
+	threshold  int
+}
+
+// NewFrameCodec 不压缩的v2编码，压缩见 FrameCodecOf
+func NewFrameCodec() *FrameCodec {
+	return defaultFrameCodec
+}
+
+func (l *FrameCodec) Encode(p *api.Packet) (*bb.ByteBuffer, error) {
+
+	frameType := FrameTypePacket
+	var body []byte
+	if p.IsHeartbeat() {
+		frameType = FrameTypeHeartbeat
+		body = binary.BigEndian.AppendUint32(nil, uint32(p.GetHeartbeat().Value))
+	} else {
+		bs, err := proto.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		body = bs
+	}
+
+	var flags uint8
+	if l.compressor != nil && len(body) >= l.threshold {
+		z, err := l.compressor.compress(body)
+		if err != nil {
+			return nil, err
+		}
+		// 压缩后反而变大时原样发送
+		if len(z) < len(body) {
+			body = z
+			flags = FlagCompressed | l.compressor.id()<<compressionShift
+		}
+	}
+
+	buffer := bb.Get()
+	buffer.Write(appendFrameHeader(make([]byte, 0, FrameHeaderLength), FrameVersion, flags, frameType, len(body)))
+	buffer.Write(body)
+	return buffer, nil
+}
+
+func appendFrameHeader(bs []byte, version, flags, frameType uint8, length int) []byte {
+	bs = append(bs, frameMagic[0], frameMagic[1], version, flags, frameType)
+	return binary.BigEndian.AppendUint32(bs, uint32(length))
+}
+
+func isFrameV2(head []byte) bool {
+	return len(head) >= 2 && head[0] == frameMagic[0] && head[1] == frameMagic[1]
+}
+
+// decodeFrame 解码一个v2帧，数据不完整时返回nil且不消费
+func (l *Codec) decodeFrame(c gnet.Conn) (*api.Packet, uint8, error) {
+	if c.InboundBuffered() < FrameHeaderLength {
+		return nil, 0, nil
+	}
+
+	header, err := c.Peek(FrameHeaderLength)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	version, flags, frameType := header[2], header[3], header[4]
+	length := int(binary.BigEndian.Uint32(header[5:]))
+
+	if version < FrameV2 {
+		return nil, 0, errFrameVersion
+	}
+
+	if length > l.maxFrameSize {
+		return nil, 0, errFrameTooLarge
+	}
+
+	if flags&^supportedFlags != 0 || (flags&FlagCompressed == 0 && flags&compressionMask != 0) {
+		return nil, 0, errFrameFlags
+	}
+
+	var comp compressor
+	if flags&FlagCompressed != 0 {
+		if comp = compressorOf(flags & compressionMask >> compressionShift); comp == nil {
+			return nil, 0, errFrameCompression
+		}
+	}
+
+	if c.InboundBuffered() < FrameHeaderLength+length {
+		return nil, 0, nil
+	}
+
+	if _, err := c.Discard(FrameHeaderLength); err != nil {
+		return nil, 0, err
+	}
+
+	body, err := c.Next(length)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if comp != nil {
+		if body, err = comp.decompress(body, l.maxFrameSize); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	switch frameType {
+	case FrameTypeHeartbeat:
+		if len(body) != 4 {
+			return nil, 0, errFrameHeartbeat
+		}
+		return api.NewHeartbeat(int32(binary.BigEndian.Uint32(body))).Wrap(), version, nil
+	case FrameTypePacket:
+		var p api.Packet
+		if err := proto.Unmarshal(body, &p); err != nil {
+			return nil, 0, err
+		}
+		return &p, version, nil
+	default:
+		return nil, 0, errFrameType
+	}
+}
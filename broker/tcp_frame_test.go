@@ -0,0 +1,169 @@
+package broker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/panjf2000/gnet/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"testing"
+)
+
+// bufConn 模拟gnet.Conn的读缓冲
+type bufConn struct {
+	gnet.Conn
+	buf []byte
+}
+
+func (c *bufConn) Read(p []byte) (int, error) {
+	if len(c.buf) == 0 {
+		return 0, io.ErrShortBuffer
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *bufConn) Next(n int) ([]byte, error) {
+	if n < 0 || n > len(c.buf) {
+		n = len(c.buf)
+	}
+	bs := c.buf[:n]
+	c.buf = c.buf[n:]
+	return bs, nil
+}
+
+func (c *bufConn) Peek(n int) ([]byte, error) {
+	if n > len(c.buf) {
+		return c.buf, io.ErrShortBuffer
+	}
+	return c.buf[:n], nil
+}
+
+func (c *bufConn) Discard(n int) (int, error) {
+	bs, err := c.Next(n)
+	return len(bs), err
+}
+
+func (c *bufConn) InboundBuffered() int {
+	return len(c.buf)
+}
+
+func encodeFrame(t testing.TB, p *api.Packet) []byte {
+	buffer, err := NewFrameCodec().Encode(p)
+	assert.NoError(t, err)
+	return append([]byte(nil), buffer.Bytes()...)
+}
+
+func encodeV1(t testing.TB, p *api.Packet) []byte {
+	buffer, err := NewCodec().Encode(p)
+	assert.NoError(t, err)
+	return append([]byte(nil), buffer.Bytes()...)
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	// v1中4字节的Packet会被当作心跳，v2用type区分
+	small := &api.Packet{Type: api.TypeCommand, Body: &api.Packet_Command{Command: &api.Command{}}}
+	bs, err := proto.Marshal(small)
+	assert.NoError(t, err)
+	assert.Len(t, bs, 4)
+
+	var data []byte
+	data = append(data, encodeFrame(t, api.NewHeartbeat(7).Wrap())...)
+	data = append(data, encodeFrame(t, small)...)
+	assert.Equal(t, []byte{'I', 'M', FrameV2, 0, FrameTypeHeartbeat, 0, 0, 0, 4, 0, 0, 0, 7}, data[:13])
+
+	packets, err := NewCodec().Decode(&bufConn{buf: data})
+	assert.NoError(t, err)
+	assert.Len(t, packets, 2)
+	assert.True(t, packets[0].IsHeartbeat())
+	assert.Equal(t, int32(7), packets[0].GetHeartbeat().Value)
+	assert.True(t, packets[1].IsCommand())
+}
+
+func TestFrameMixedWithV1(t *testing.T) {
+	var data []byte
+	data = append(data, encodeV1(t, api.NewHeartbeat(1).Wrap())...)
+	data = append(data, encodeFrame(t, api.NewHeartbeat(2).Wrap())...)
+	data = append(data, encodeV1(t, api.NewHeartbeat(3).Wrap())...)
+
+	packets, err := NewCodec().Decode(&bufConn{buf: data})
+	assert.NoError(t, err)
+	assert.Len(t, packets, 3)
+	for i, p := range packets {
+		assert.Equal(t, int32(i+1), p.GetHeartbeat().Value)
+	}
+}
+
+func TestFramePartial(t *testing.T) {
+	data := encodeFrame(t, api.NewHeartbeat(1).Wrap())
+
+	for _, n := range []int{2, FrameHeaderLength, len(data) - 1} {
+		c := &bufConn{buf: append([]byte(nil), data[:n]...)}
+		packets, err := NewCodec().Decode(c)
+		assert.NoError(t, err)
+		assert.Empty(t, packets)
+		assert.Equal(t, n, c.InboundBuffered(), "incomplete frame must not be consumed")
+
+		c.buf = append(c.buf, data[n:]...)
+		packets, err = NewCodec().Decode(c)
+		assert.NoError(t, err)
+		assert.Len(t, packets, 1)
+	}
+}
+
+func TestFrameInvalid(t *testing.T) {
+	frame := func(version, flags, frameType uint8, body []byte) []byte {
+		return append(appendFrameHeader(nil, version, flags, frameType, len(body)), body...)
+	}
+	hb := binary.BigEndian.AppendUint32(nil, 1)
+
+	cases := map[string]struct {
+		data []byte
+		err  error
+	}{
+		"version":   {frame(FrameV1, 0, FrameTypeHeartbeat, hb), errFrameVersion},
+		"flags":     {frame(FrameV2, 1<<7, FrameTypeHeartbeat, hb), errFrameFlags},
+		"type":      {frame(FrameV2, 0, 9, hb), errFrameType},
+		"heartbeat": {frame(FrameV2, 0, FrameTypeHeartbeat, []byte{1}), errFrameHeartbeat},
+	}
+
+	for name, tc := range cases {
+		_, err := NewCodec().Decode(&bufConn{buf: tc.data})
+		assert.ErrorIs(t, err, tc.err, name)
+	}
+}
+
+func TestFrameNegotiate(t *testing.T) {
+	codec := NewCodec()
+
+	// v1客户端
+	uc := &domain.UserConn{}
+	data := append(encodeV1(t, api.NewHeartbeat(1).Wrap()), encodeFrame(t, api.NewHeartbeat(2).Wrap())...)
+	_, err := codec.DecodeNegotiate(&bufConn{buf: data}, uc)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(FrameV1), uc.FrameVersion.Load())
+	assert.Same(t, codec, encoderOf(uc, codec))
+
+	// v2客户端
+	uc = &domain.UserConn{}
+	_, err = codec.DecodeNegotiate(&bufConn{buf: encodeFrame(t, api.NewHeartbeat(1).Wrap())}, uc)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(FrameV2), uc.FrameVersion.Load())
+	assert.Equal(t, PacketEncoder(defaultFrameCodec), encoderOf(uc, codec))
+
+	// 更高版本的客户端降级到服务端支持的版本
+	uc = &domain.UserConn{}
+	future := append(appendFrameHeader(nil, FrameVersion+1, 0, FrameTypeHeartbeat, 4), 0, 0, 0, 1)
+	packets, err := codec.DecodeNegotiate(&bufConn{buf: future}, uc)
+	assert.NoError(t, err)
+	assert.Len(t, packets, 1)
+	assert.Equal(t, int32(FrameVersion), uc.FrameVersion.Load())
+
+	buffer, err := encoderOf(uc, codec).Encode(api.HeartbeatACK)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buffer.Bytes(), []byte{'I', 'M', FrameVersion}))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/magicnana999/im/broker/domain"
 	"github.com/magicnana999/im/broker/handler"
 	"github.com/magicnana999/im/broker/holder"
+	"github.com/magicnana999/im/errors"
 	"github.com/magicnana999/im/global"
 	"github.com/magicnana999/im/pkg/jsonext"
 	"github.com/magicnana999/im/pkg/timewheel"
@@ -18,6 +19,7 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"runtime"
+	"sync/atomic"
 	"time"
 )
 
@@ -138,10 +140,16 @@ type TcpServer struct {
 	messageHandler *handler.MessageHandler
 	brokerHolder   *holder.BrokerHolder
 	userHolder     *holder.UserHolder
+	registry       *RegistryServer
+	offline        *OfflinePuller
 	codec          *Codec
+	certs          *certStore
+	limiter        *RateLimiter
+	bp             *Backpressure
 	ctx            context.Context
 	worker         *ants.Pool
 	logger         *Logger
+	draining       atomic.Bool // draining 下线中，不再接受新连接
 }
 
 func getOrDefaultTCPConfig(g *global.Config) *global.TCPConfig {
@@ -170,6 +178,10 @@ func getOrDefaultTCPConfig(g *global.Config) *global.TCPConfig {
 		c.Worker.MaxBlockingTasks = DefWorkerMaxTask
 	}
 
+	if c.MaxFrameSize <= 0 {
+		c.MaxFrameSize = DefMaxFrameSize
+	}
+
 	return c
 }
 
@@ -203,6 +215,9 @@ func NewTcpServer(
 	mh *handler.MessageHandler,
 	bh *holder.BrokerHolder,
 	uh *holder.UserHolder,
+	rs *RegistryServer,
+	op *OfflinePuller,
+	apps *AppRegistry,
 	lc fx.Lifecycle) (*TcpServer, error) {
 
 	logger := NewLogger("tcp")
@@ -214,6 +229,14 @@ func NewTcpServer(
 		return nil, err
 	}
 
+	var certs *certStore
+	if tc := getOrDefaultTLSConfig(c); tc != nil {
+		certs, err = newCertStore(tc, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ts := &TcpServer{
 		cfg:            c,
 		hts:            hts,
@@ -222,7 +245,12 @@ func NewTcpServer(
 		messageHandler: mh,
 		brokerHolder:   bh,
 		userHolder:     uh,
-		codec:          NewCodec(),
+		registry:       rs,
+		offline:        op,
+		codec:          NewCodecWithMaxFrameSize(c.MaxFrameSize),
+		certs:          certs,
+		limiter:        NewRateLimiter(getOrDefaultRateLimitConfig(c), apps),
+		bp:             NewBackpressure(conf),
 		logger:         logger,
 		worker:         worker,
 	}
@@ -232,6 +260,9 @@ func NewTcpServer(
 			return ts.Start(context.Background())
 		},
 		OnStop: func(ctx context.Context) error {
+			if ts.certs != nil {
+				ts.certs.stop()
+			}
 			return ts.eng.Stop(ctx)
 		},
 	})
@@ -256,6 +287,9 @@ func (s *TcpServer) Stop(ctx context.Context) error {
 // Start 启动
 func (s *TcpServer) Start(ctx context.Context) error {
 	s.ctx = ctx
+	if s.certs != nil {
+		go s.certs.watch()
+	}
 	go func() {
 		err := gnet.Run(s,
 			fmt.Sprintf("tcp://%s", s.cfg.Addr),
@@ -301,6 +335,10 @@ func (s *TcpServer) OnShutdown(eng gnet.Engine) {
 
 // OnOpen 新链接后回调
 func (s *TcpServer) OnOpen(c gnet.Conn) (out []byte, action gnet.Action) {
+	if s.certs != nil {
+		return s.openTLS(c)
+	}
+
 	uc := domain.NewUserConn(c)
 	err := s.openConn(c, uc)
 	s.logger.ConnDebug("connect", uc.Desc(), ConnLifecycle, err, zap.String("uc", string(jsonext.MarshalNoErr(uc))))
@@ -311,12 +349,43 @@ func (s *TcpServer) OnOpen(c gnet.Conn) (out []byte, action gnet.Action) {
 	return nil, gnet.None
 }
 
+// openTLS 开启TLS时uc.Conn为TLSConn，写入自动加密；握手在单独的goroutine中进行，解密在event loop中进行
+func (s *TcpServer) openTLS(c gnet.Conn) (out []byte, action gnet.Action) {
+	tc := newTLSConn(c, s.certs.serverConfig())
+	uc := domain.NewUserConn(tc)
+
+	err := s.openConn(c, uc)
+	s.logger.ConnDebug("connect", uc.Desc(), ConnLifecycle, err, zap.String("uc", string(jsonext.MarshalNoErr(uc))))
+	if err != nil {
+		return nil, gnet.Close
+	}
+
+	c.SetContext(context.WithValue(s.getContext(c), tlsConnKey{}, tc))
+
+	go func() {
+		// 握手期间可能已经收到应用数据，唤醒event loop解密
+		err := tc.handshake(func() { _ = c.Wake(nil) })
+		if err != nil {
+			s.logger.ConnDebug("tls handshake", uc.Desc(), ConnLifecycle, err)
+		}
+	}()
+
+	return nil, gnet.None
+}
+
 // OnClose 关闭时回调
 func (s *TcpServer) OnClose(c gnet.Conn, err error) (action gnet.Action) {
 
 	ctx := s.getContext(c)
+	if tc := s.getTLSConn(c); tc != nil {
+		tc.release()
+	}
+
 	uc, err := brokerctx.GetCurUserConn(ctx)
-	if err != nil {
+	if uc != nil {
+		s.limiter.Remove(uc)
+	}
+	if err == nil && uc != nil {
 		s.closeConn(ctx, c, uc)
 	}
 	s.logger.ConnDebug("close", uc.Desc(), ConnLifecycle, err)
@@ -325,8 +394,43 @@ func (s *TcpServer) OnClose(c gnet.Conn, err error) (action gnet.Action) {
 	return gnet.None
 }
 
-// OnTraffic 收到消息
+// OnTraffic 收到消息，TLS连接先解密再解码
 func (s *TcpServer) OnTraffic(c gnet.Conn) (action gnet.Action) {
+	if s.certs != nil {
+		return s.traffic(c, s.decodeTLS)
+	}
+	return s.traffic(c, s.decode)
+}
+
+// decode tcp解码，首帧协商帧版本
+func (s *TcpServer) decode(c gnet.Conn) ([]*api.Packet, error) {
+	uc, err := brokerctx.GetCurUserConn(s.getContext(c))
+	if err != nil {
+		return nil, err
+	}
+	return s.codec.DecodeNegotiate(c, uc)
+}
+
+// decodeTLS 密文交给TLSConn解密，再从明文中解码，首帧协商帧版本
+func (s *TcpServer) decodeTLS(c gnet.Conn) ([]*api.Packet, error) {
+	tc := s.getTLSConn(c)
+	if tc == nil {
+		return nil, errTLSNoConn
+	}
+
+	uc, err := brokerctx.GetCurUserConn(s.getContext(c))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tc.Feed(c); err != nil {
+		return nil, err
+	}
+	return s.codec.DecodeNegotiate(tc, uc)
+}
+
+// traffic 解码后提交worker处理，tcp和websocket共用
+func (s *TcpServer) traffic(c gnet.Conn, decode func(gnet.Conn) ([]*api.Packet, error)) gnet.Action {
 
 	ctx := s.getContext(c)
 	uc, err := brokerctx.GetCurUserConn(ctx)
@@ -336,15 +440,38 @@ func (s *TcpServer) OnTraffic(c gnet.Conn) (action gnet.Action) {
 	}
 
 	s.RefreshUser(ctx, uc)
+	sampleOutbound(c, uc)
 
-	packets, err := s.codec.Decode(c)
+	buffered := c.InboundBuffered()
+	packets, err := decode(c)
+	s.limiter.Charge(uc, buffered-c.InboundBuffered())
 
+	// 非法帧直接断开，不再读取后续数据
 	if err != nil {
 		s.logger.ConnDebug("decode", uc.Desc(), ConnLifecycle, err)
+		s.closeConnFD(c, uc, "decode: "+err.Error())
+		return gnet.None
+	}
+
+	if err := s.dispatch(ctx, c, uc, packets); err != nil {
+		s.logger.ConnDebug("submit decode failed", uc.Desc(), ConnLifecycle, err)
 		return gnet.Close
 	}
+	return gnet.None
+}
+
+// dispatch 限流后提交worker处理
+func (s *TcpServer) dispatch(ctx context.Context, c gnet.Conn, uc *domain.UserConn, packets []*api.Packet) error {
+	packets, err := s.throttle(uc, packets)
+	if err != nil {
+		return err
+	}
+
+	if len(packets) == 0 {
+		return nil
+	}
 
-	err = s.worker.Submit(func() {
+	return s.worker.Submit(func() {
 		for _, packet := range packets {
 			resp := s.processPacket(ctx, c, uc, packet)
 			err := s.response(resp, uc)
@@ -354,12 +481,30 @@ func (s *TcpServer) OnTraffic(c gnet.Conn) (action gnet.Action) {
 			}
 		}
 	})
+}
 
-	if err != nil {
-		s.logger.ConnDebug("submit decode failed", uc.Desc(), ConnLifecycle, err)
-		return gnet.Close
+// throttle 被限流的packet直接回复，不提交worker；超限次数过多时返回错误，由调用方断开连接
+func (s *TcpServer) throttle(uc *domain.UserConn, packets []*api.Packet) ([]*api.Packet, error) {
+	if s.limiter == nil {
+		return packets, nil
 	}
-	return gnet.None
+
+	allowed := packets[:0]
+	for _, packet := range packets {
+		if err := s.limiter.Allow(uc, packet); err != nil {
+			s.logger.PktDebug("rate limited", uc.Desc(), packet.GetPacketId(), packet, PacketTracking, err)
+			if err := s.response(throttled(packet, err), uc); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		allowed = append(allowed, packet)
+	}
+
+	if s.limiter.Exceeded(uc) {
+		return nil, errors.RateLimited
+	}
+	return allowed, nil
 }
 
 // processPacket 处理客户端发来的Packet，heartbeat；command；message
@@ -378,11 +523,17 @@ func (s *TcpServer) processPacket(ctx context.Context, c gnet.Conn, uc *domain.U
 	}
 
 	//message
+	if packet.IsAck() {
+		n := s.mrs.AckBatch(uc, packet.GetAck())
+		s.logger.PktDebug("ack batch process", uc.Desc(), packet.GetPacketId(), packet, PacketTracking, nil, zap.Int("acked", n))
+		return nil
+	}
+
 	if packet.IsMessage() {
 		if packet.GetMessage().IsRequest() {
 			return s.processMessage(ctx, c, uc, packet)
 		} else {
-			s.mrs.Ack(packet.GetMessage().MessageId)
+			s.mrs.Ack(uc, packet.GetMessage().MessageId)
 			s.logger.PktDebug("ack process", uc.Desc(), packet.GetPacketId(), packet, PacketTracking, nil)
 			return nil
 		}
@@ -415,6 +566,7 @@ func (s *TcpServer) processCommand(ctx context.Context, c gnet.Conn, uc *domain.
 
 // OnTick gnet ticker
 func (s *TcpServer) OnTick() (delay time.Duration, action gnet.Action) {
+	s.limiter.Sweep(time.Now())
 	return s.cfg.Interval, gnet.None
 }
 
@@ -431,7 +583,7 @@ func (s *TcpServer) RefreshUser(ctx context.Context, uc *domain.UserConn) {
 	}
 }
 
-// OnUserLogin 登录成功后处理本地map和redis
+// OnUserLogin 登录成功后处理本地map和redis，之后拉取离线消息
 func (s *TcpServer) OnUserLogin(
 	ctx context.Context,
 	uc *domain.UserConn,
@@ -442,9 +594,17 @@ func (s *TcpServer) OnUserLogin(
 		return
 	}
 
+	// 只有v2帧能标记压缩，登录回复本身已按协商结果编码
+	if rep != nil && uc.Protocol == domain.ProtocolTCP && uc.FrameVersion.Load() >= int32(FrameV2) {
+		rep.Compression = NegotiateCompression(req.GetCompressions())
+		uc.Compression.Store(rep.Compression)
+	}
+
 	s.userHolder.HoldUserConn(uc)
 	s.userHolder.StoreUserConn(ctx, uc)
 	s.userHolder.StoreUserClients(ctx, uc)
+	s.registry.StoreClient(ctx, uc)
+	s.offline.Pull(uc)
 }
 
 // initContext 新连接到来时，初始化ctx
@@ -471,9 +631,25 @@ func (s *TcpServer) getContext(c gnet.Conn) context.Context {
 	return nil
 }
 
+// 获取TLS连接，未开启TLS时为nil
+func (s *TcpServer) getTLSConn(c gnet.Conn) *TLSConn {
+	ctx := s.getContext(c)
+	if ctx == nil {
+		return nil
+	}
+
+	tc, _ := ctx.Value(tlsConnKey{}).(*TLSConn)
+	return tc
+}
+
 // 打开链接：初始化ctx、保存uc到本地、启动心跳
 func (s *TcpServer) openConn(c gnet.Conn, uc *domain.UserConn) error {
+	if s.draining.Load() {
+		return errDraining
+	}
 
+	// 与注册的地址一致，router按该地址找到本实例
+	uc.BrokerAddr = s.registry.Info().Addr
 	s.initContext(c, uc)
 
 	fun := func(now time.Time) timewheel.TaskResult {
@@ -496,6 +672,12 @@ func (s *TcpServer) openConn(c gnet.Conn, uc *domain.UserConn) error {
 			return timewheel.Break
 		}
 
+		if s.bp.Stalled(uc, now) {
+			s.logger.ConnDebug("slow consumer", uc.Desc(), ConnLifecycle, nil, zap.Int64("outbound", uc.OutboundDepth()))
+			s.closeConnFD(c, uc, "slow consumer")
+			return timewheel.Break
+		}
+
 		return timewheel.Retry
 	}
 
@@ -512,6 +694,7 @@ func (s *TcpServer) closeConn(ctx context.Context, c gnet.Conn, uc *domain.UserC
 	s.userHolder.RemoveUserConn(uc)
 	s.userHolder.DeleteUserConn(ctx, uc)
 	s.userHolder.DeleteUserClient(ctx, uc)
+	s.registry.DeleteClient(ctx, uc)
 	s.delContext(c)
 }
 
@@ -528,6 +711,25 @@ func (s *TcpServer) closeConnFD(c gnet.Conn, uc *domain.UserConn, reason string)
 	}
 }
 
+// Reconnect 下线时停止接受新连接，通知已有连接重连到其他broker，返回通知的连接数
+func (s *TcpServer) Reconnect(req *api.ReconnectRequest) int {
+	s.draining.Store(true)
+
+	n := 0
+	s.userHolder.RangeAllUserConn(func(uc *domain.UserConn) bool {
+		if uc.IsClosed.Load() {
+			return true
+		}
+		err := s.response(api.NewCommand(req), uc)
+		s.logger.ConnDebug("reconnect", uc.Desc(), ConnLifecycle, err)
+		if err == nil {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
 // 回复消息给客户端
 func (s *TcpServer) response(packet *api.Packet, uc *domain.UserConn) error {
 
@@ -535,7 +737,7 @@ func (s *TcpServer) response(packet *api.Packet, uc *domain.UserConn) error {
 		return nil
 	}
 
-	buffer, err := s.codec.Encode(packet)
+	buffer, err := encoderOf(uc, s.codec).Encode(packet)
 	if err != nil {
 		return err
 	}
@@ -546,7 +748,7 @@ func (s *TcpServer) response(packet *api.Packet, uc *domain.UserConn) error {
 		return err
 	}
 
-	err = uc.Conn.AsyncWrite(buffer.Bytes(), func(c gnet.Conn, err error) error {
+	err = asyncWrite(uc, buffer.Bytes(), func(c gnet.Conn, err error) error {
 		s.logger.PktDebug("write completed", uc.Desc(), packet.GetPacketId(), nil, PacketTracking, err)
 		return nil
 	})
@@ -0,0 +1,205 @@
+package broker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/magicnana999/im/global"
+	"go.uber.org/zap"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefTLSReloadInterval 检查证书文件是否变化的默认间隔
+	DefTLSReloadInterval = 30 * time.Second
+)
+
+var (
+	errTLSNoCert = errors.New("tls cert not configured")
+	errTLSNoConn = errors.New("tls conn not found")
+)
+
+// certStore 保存当前的TLS配置，证书文件变化后重新加载。
+// 新连接握手时读取最新配置，已建立的连接不受影响
+type certStore struct {
+	cfg      *global.TcpTLSConfig
+	current  atomic.Pointer[tls.Config]
+	modTimes map[string]time.Time
+	done     chan struct{}
+	once     sync.Once
+	logger   *Logger
+}
+
+func getOrDefaultTLSConfig(c *global.TCPConfig) *global.TcpTLSConfig {
+	if c == nil || c.TLS == nil {
+		return nil
+	}
+
+	t := &global.TcpTLSConfig{}
+	*t = *c.TLS
+
+	if t.ReloadInterval <= 0 {
+		t.ReloadInterval = DefTLSReloadInterval
+	}
+
+	return t
+}
+
+func newCertStore(c *global.TcpTLSConfig, logger *Logger) (*certStore, error) {
+	s := &certStore{
+		cfg:    c,
+		done:   make(chan struct{}),
+		logger: logger,
+	}
+
+	if _, err := s.reloadIfChanged(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// serverConfig 交给tls.Server的配置，每次握手取最新的快照
+func (s *certStore) serverConfig() *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return s.current.Load(), nil
+		},
+	}
+}
+
+// files 需要监听变化的文件
+func (s *certStore) files() []string {
+	files := []string{s.cfg.CertFile, s.cfg.KeyFile, s.cfg.ClientCAFile}
+	for _, c := range s.cfg.Certs {
+		files = append(files, c.CertFile, c.KeyFile)
+	}
+
+	result := make([]string, 0, len(files))
+	for _, f := range files {
+		if f != "" {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// reloadIfChanged 任一文件修改时间变化时重新加载，加载失败时保留旧配置
+func (s *certStore) reloadIfChanged() (bool, error) {
+	modTimes := make(map[string]time.Time)
+	changed := s.modTimes == nil
+	for _, f := range s.files() {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return false, err
+		}
+		modTimes[f] = fi.ModTime()
+		if !fi.ModTime().Equal(s.modTimes[f]) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	c, err := buildTLSConfig(s.cfg)
+	if err != nil {
+		return false, err
+	}
+
+	s.current.Store(c)
+	s.modTimes = modTimes
+	return true, nil
+}
+
+// watch 定时检查证书文件
+func (s *certStore) watch() {
+	ticker := time.NewTicker(s.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			ok, err := s.reloadIfChanged()
+			if err != nil {
+				s.logger.Error("tls reload failed", zap.Error(err))
+			} else if ok {
+				s.logger.SrvInfo("tls reloaded", SrvLifecycle, nil)
+			}
+		}
+	}
+}
+
+func (s *certStore) stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// buildTLSConfig 按SNI选择证书：先精确匹配，再匹配通配符，最后使用默认证书
+func buildTLSConfig(c *global.TcpTLSConfig) (*tls.Config, error) {
+
+	var def *tls.Certificate
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		def = &cert
+	}
+
+	named := make(map[string]*tls.Certificate)
+	for _, nc := range c.Certs {
+		cert, err := tls.LoadX509KeyPair(nc.CertFile, nc.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", nc.ServerName, err)
+		}
+		named[strings.ToLower(nc.ServerName)] = &cert
+		if def == nil {
+			def = &cert
+		}
+	}
+
+	if def == nil {
+		return nil, errTLSNoCert
+	}
+
+	t := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			name := strings.ToLower(hello.ServerName)
+			if cert, ok := named[name]; ok {
+				return cert, nil
+			}
+			if _, domain, ok := strings.Cut(name, "."); ok {
+				if cert, ok := named["*."+domain]; ok {
+					return cert, nil
+				}
+			}
+			return def, nil
+		},
+	}
+
+	if c.ClientCAFile != "" {
+		pem, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no client ca found in %s", c.ClientCAFile)
+		}
+		t.ClientCAs = pool
+		t.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return t, nil
+}
@@ -0,0 +1,296 @@
+package broker
+
+import (
+	"crypto/tls"
+	"github.com/panjf2000/gnet/v2"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type tlsConnKey struct{}
+
+// maxTLSPlaintext 一个TLS记录最多解出的明文长度
+const maxTLSPlaintext = 16 << 10
+
+// tlsReadPool 解密时使用的临时缓冲，解密结束后归还，连接不单独持有
+var tlsReadPool = sync.Pool{
+	New: func() any {
+		bs := make([]byte, maxTLSPlaintext)
+		return &bs
+	},
+}
+
+// errWouldBlock 握手完成后没有更多密文，tls.Conn遇到临时错误时保留已读取的部分记录，下次继续
+var errWouldBlock net.Error = wouldBlockError{}
+
+type wouldBlockError struct{}
+
+func (wouldBlockError) Error() string   { return "tls: would block" }
+func (wouldBlockError) Timeout() bool   { return true }
+func (wouldBlockError) Temporary() bool { return true }
+
+// memConn tls.Conn底层的net.Conn：读取event loop喂进来的密文，写入时交给gnet异步发送。
+// 握手期间读取会阻塞等待密文，握手完成后切换为非阻塞
+type memConn struct {
+	raw      gnet.Conn
+	mu       sync.Mutex
+	cond     *sync.Cond
+	buf      []byte
+	closed   bool
+	nonblock bool
+}
+
+func newMemConn(raw gnet.Conn) *memConn {
+	m := &memConn{raw: raw}
+	m.cond = sync.NewCond(&m.mu)
+	return m
+}
+
+// feed 在event loop中调用，不阻塞
+func (m *memConn) feed(data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return
+	}
+	m.buf = append(m.buf, data...)
+	m.cond.Signal()
+}
+
+// setNonblock 握手完成后调用，之后没有密文时立即返回errWouldBlock
+func (m *memConn) setNonblock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nonblock = true
+}
+
+func (m *memConn) Read(b []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for len(m.buf) == 0 && !m.closed {
+		if m.nonblock {
+			return 0, errWouldBlock
+		}
+		m.cond.Wait()
+	}
+	if len(m.buf) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(b, m.buf)
+	m.buf = m.buf[n:]
+	if len(m.buf) == 0 {
+		m.buf = nil
+	}
+	return n, nil
+}
+
+// Write tls.Conn会复用写缓冲，需要拷贝后再异步发送
+func (m *memConn) Write(b []byte) (int, error) {
+	bs := append([]byte(nil), b...)
+	if err := m.raw.AsyncWrite(bs, nil); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (m *memConn) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	m.cond.Broadcast()
+	return nil
+}
+
+func (m *memConn) LocalAddr() net.Addr                { return m.raw.LocalAddr() }
+func (m *memConn) RemoteAddr() net.Addr               { return m.raw.RemoteAddr() }
+func (m *memConn) SetDeadline(t time.Time) error      { return nil }
+func (m *memConn) SetReadDeadline(t time.Time) error  { return nil }
+func (m *memConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// TLSConn 在gnet.Conn前面加一层TLS。crypto/tls的握手不能中断后继续，
+// 只有握手在单独的goroutine中进行，完成后goroutine退出；
+// 之后event loop把密文交给 Feed，在loop中解密到buf，明文通过Reader接口交给 Codec.Decode。
+// 写入时加密后异步发送，因此写方法都可以在event loop之外调用
+type TLSConn struct {
+	gnet.Conn
+	mem        *memConn
+	tls        *tls.Conn
+	handshaked atomic.Bool
+	mu         sync.Mutex
+	buf        []byte
+}
+
+func newTLSConn(raw gnet.Conn, cfg *tls.Config) *TLSConn {
+	mem := newMemConn(raw)
+	return &TLSConn{
+		Conn: raw,
+		mem:  mem,
+		tls:  tls.Server(mem, cfg),
+	}
+}
+
+// handshake 握手，成功后回调onDone唤醒event loop处理握手期间已收到的数据，失败时关闭连接
+func (c *TLSConn) handshake(onDone func()) error {
+	if err := c.tls.Handshake(); err != nil {
+		_ = c.Conn.Close()
+		return err
+	}
+
+	c.mem.setNonblock()
+	c.handshaked.Store(true)
+	onDone()
+	return nil
+}
+
+// Feed 在OnTraffic中调用，转交密文；握手完成后在当前goroutine中解密出全部完整记录
+func (c *TLSConn) Feed(raw gnet.Conn) error {
+	bs, err := raw.Next(raw.InboundBuffered())
+	if err != nil {
+		return err
+	}
+	c.mem.feed(bs)
+
+	if !c.handshaked.Load() {
+		return nil
+	}
+	return c.decrypt()
+}
+
+// decrypt 解密到buf，没有完整记录时返回nil
+func (c *TLSConn) decrypt() error {
+	p := tlsReadPool.Get().(*[]byte)
+	defer tlsReadPool.Put(p)
+
+	for {
+		n, err := c.tls.Read(*p)
+		if n > 0 {
+			c.mu.Lock()
+			c.buf = append(c.buf, (*p)[:n]...)
+			c.mu.Unlock()
+		}
+		if err == errWouldBlock {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// release 连接关闭后释放握手中的goroutine
+func (c *TLSConn) release() {
+	_ = c.mem.Close()
+}
+
+// ConnectionState 握手完成后可取得客户端证书等信息
+func (c *TLSConn) ConnectionState() tls.ConnectionState {
+	return c.tls.ConnectionState()
+}
+
+func (c *TLSConn) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.buf) == 0 {
+		return 0, io.ErrShortBuffer
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *TLSConn) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, err := w.Write(c.buf)
+	c.buf = c.buf[n:]
+	return int64(n), err
+}
+
+func (c *TLSConn) Next(n int) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n < 0 || n > len(c.buf) {
+		n = len(c.buf)
+	}
+	bs := c.buf[:n]
+	c.buf = c.buf[n:]
+	return bs, nil
+}
+
+func (c *TLSConn) Peek(n int) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n > len(c.buf) {
+		return c.buf, io.ErrShortBuffer
+	}
+	if n < 0 {
+		n = len(c.buf)
+	}
+	return c.buf[:n], nil
+}
+
+func (c *TLSConn) Discard(n int) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n < 0 || n > len(c.buf) {
+		n = len(c.buf)
+	}
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *TLSConn) InboundBuffered() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.buf)
+}
+
+func (c *TLSConn) Write(p []byte) (int, error) {
+	return c.tls.Write(p)
+}
+
+func (c *TLSConn) ReadFrom(r io.Reader) (int64, error) {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	n, err := c.tls.Write(bs)
+	return int64(n), err
+}
+
+func (c *TLSConn) Writev(bs [][]byte) (int, error) {
+	var total int
+	for _, b := range bs {
+		n, err := c.tls.Write(b)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (c *TLSConn) Flush() error {
+	return nil
+}
+
+// AsyncWrite 加密后交给gnet异步发送，callback在当前goroutine中回调
+func (c *TLSConn) AsyncWrite(buf []byte, callback gnet.AsyncCallback) error {
+	_, err := c.tls.Write(buf)
+	if callback != nil {
+		_ = callback(c, err)
+	}
+	return err
+}
+
+func (c *TLSConn) AsyncWritev(bs [][]byte, callback gnet.AsyncCallback) error {
+	_, err := c.Writev(bs)
+	if callback != nil {
+		_ = callback(c, err)
+	}
+	return err
+}
@@ -0,0 +1,320 @@
+package broker
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/global"
+	"github.com/panjf2000/gnet/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeCert 生成自签名证书，同时可作为客户端证书的CA
+func writeCert(t *testing.T, dir, name string, dnsNames ...string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              dnsNames,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, c *tls.Config, serverName string) string {
+	cert, err := c.GetCertificate(&tls.ClientHelloInfo{ServerName: serverName})
+	assert.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestTLSServerNameSelection(t *testing.T) {
+	dir := t.TempDir()
+	defCert, defKey := writeCert(t, dir, "default")
+	imCert, imKey := writeCert(t, dir, "im", "im.example.com")
+	wildCert, wildKey := writeCert(t, dir, "wildcard", "*.example.com")
+
+	c, err := buildTLSConfig(&global.TcpTLSConfig{
+		CertFile: defCert,
+		KeyFile:  defKey,
+		Certs: []*global.TcpTLSCert{
+			{ServerName: "im.example.com", CertFile: imCert, KeyFile: imKey},
+			{ServerName: "*.example.com", CertFile: wildCert, KeyFile: wildKey},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "im", commonName(t, c, "IM.example.com"))
+	assert.Equal(t, "wildcard", commonName(t, c, "push.example.com"))
+	assert.Equal(t, "default", commonName(t, c, "example.org"))
+	assert.Equal(t, "default", commonName(t, c, ""))
+
+	_, err = buildTLSConfig(&global.TcpTLSConfig{})
+	assert.ErrorIs(t, err, errTLSNoCert)
+}
+
+func TestTLSReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "old")
+
+	s, err := newCertStore(&global.TcpTLSConfig{CertFile: certFile, KeyFile: keyFile, ReloadInterval: time.Second}, nil)
+	assert.NoError(t, err)
+	before := s.current.Load()
+	assert.Equal(t, "old", commonName(t, before, ""))
+
+	ok, err := s.reloadIfChanged()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// 证书写坏时保留旧配置
+	assert.NoError(t, os.WriteFile(certFile, []byte("broken"), 0600))
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certFile, future, future))
+	_, err = s.reloadIfChanged()
+	assert.Error(t, err)
+	assert.Same(t, before, s.current.Load())
+
+	newCert, newKey := writeCert(t, dir, "new")
+	assert.NoError(t, os.Rename(newCert, certFile))
+	assert.NoError(t, os.Rename(newKey, keyFile))
+	future = future.Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certFile, future, future))
+
+	ok, err = s.reloadIfChanged()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "new", commonName(t, s.current.Load(), ""))
+
+	// 已经拿到旧快照的连接不受影响
+	assert.Equal(t, "old", commonName(t, before, ""))
+}
+
+// pipeConn 模拟gnet.Conn，与gnet一样异步写入，不会因为对端未读而阻塞
+type pipeConn struct {
+	gnet.Conn
+	out chan []byte
+}
+
+func newPipeConn(w io.WriteCloser) *pipeConn {
+	p := &pipeConn{out: make(chan []byte, 64)}
+	go func() {
+		for bs := range p.out {
+			if _, err := w.Write(bs); err != nil {
+				break
+			}
+		}
+		_ = w.Close()
+	}()
+	return p
+}
+
+func (p *pipeConn) AsyncWrite(buf []byte, callback gnet.AsyncCallback) error {
+	p.out <- buf
+	if callback != nil {
+		_ = callback(p, nil)
+	}
+	return nil
+}
+
+func (p *pipeConn) LocalAddr() net.Addr { return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5075} }
+func (p *pipeConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50000}
+}
+func (p *pipeConn) Close() error { return nil }
+
+func newTLSPair(t *testing.T, cfg *global.TcpTLSConfig, onData func(tc *TLSConn)) (*TLSConn, net.Conn) {
+	s, err := newCertStore(cfg, nil)
+	assert.NoError(t, err)
+
+	server, client := net.Pipe()
+	tc := newTLSConn(newPipeConn(server), s.serverConfig())
+
+	data := make(chan []byte)
+	go func() {
+		defer close(data)
+		for {
+			bs := make([]byte, 1024)
+			n, err := server.Read(bs)
+			if err != nil {
+				return
+			}
+			data <- bs[:n]
+		}
+	}()
+
+	// event loop的角色：握手期间只转交密文，握手完成后在同一个goroutine中解密
+	wake := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case bs, ok := <-data:
+				if !ok {
+					tc.release()
+					return
+				}
+				tc.mem.feed(bs)
+			case <-wake:
+			}
+
+			if tc.handshaked.Load() {
+				assert.NoError(t, tc.decrypt())
+				onData(tc)
+			}
+		}
+	}()
+
+	go func() {
+		_ = tc.handshake(func() { wake <- struct{}{} })
+	}()
+
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+	return tc, client
+}
+
+func TestTLSConnRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "im", "im.example.com")
+
+	received := make(chan *api.Packet, 2)
+	tc, raw := newTLSPair(t, &global.TcpTLSConfig{CertFile: certFile, KeyFile: keyFile}, func(tc *TLSConn) {
+		packets, err := defaultCodec.Decode(tc)
+		assert.NoError(t, err)
+		for _, p := range packets {
+			received <- p
+		}
+	})
+
+	ca, err := os.ReadFile(certFile)
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+
+	client := tls.Client(raw, &tls.Config{ServerName: "im.example.com", RootCAs: pool})
+
+	hb, err := defaultCodec.Encode(api.NewHeartbeat(1).Wrap())
+	assert.NoError(t, err)
+	_, err = client.Write(hb.Bytes())
+	assert.NoError(t, err)
+
+	select {
+	case p := <-received:
+		assert.True(t, p.IsHeartbeat())
+	case <-time.After(5 * time.Second):
+		t.Fatal("packet not received")
+	}
+
+	// 服务端写入的数据由客户端解密
+	ack, err := defaultCodec.Encode(api.HeartbeatACK)
+	assert.NoError(t, err)
+	assert.NoError(t, tc.AsyncWrite(ack.Bytes(), nil))
+
+	bs := make([]byte, len(ack.Bytes()))
+	_, err = io.ReadFull(client, bs)
+	assert.NoError(t, err)
+	assert.Equal(t, ack.Bytes(), bs)
+}
+
+func TestTLSConnPartialRecord(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "im", "im.example.com")
+
+	received := make(chan *api.Packet, 1)
+	_, raw := newTLSPair(t, &global.TcpTLSConfig{CertFile: certFile, KeyFile: keyFile}, func(tc *TLSConn) {
+		packets, err := defaultCodec.Decode(tc)
+		assert.NoError(t, err)
+		for _, p := range packets {
+			received <- p
+		}
+	})
+
+	ca, err := os.ReadFile(certFile)
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+	client := tls.Client(raw, &tls.Config{ServerName: "im.example.com", RootCAs: pool})
+
+	// 密文按1024字节分段到达，记录不完整时等待后续密文
+	text := strings.Repeat("a", 3*maxTLSPlaintext)
+	m := api.NewMessage(1, 2, 0, 1, "app", "conv", &api.Text{Text: text})
+	bs, err := defaultCodec.Encode(m.Wrap())
+	assert.NoError(t, err)
+	_, err = client.Write(bs.Bytes())
+	assert.NoError(t, err)
+
+	select {
+	case p := <-received:
+		assert.Equal(t, text, p.GetMessage().GetText().Text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("packet not received")
+	}
+}
+
+func TestTLSConnClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "im", "im.example.com")
+	clientCert, clientKey := writeCert(t, dir, "client")
+
+	cfg := &global.TcpTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: clientCert}
+
+	ca, err := os.ReadFile(certFile)
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+
+	// 没有客户端证书，握手失败
+	_, raw := newTLSPair(t, cfg, func(*TLSConn) {})
+	client := tls.Client(raw, &tls.Config{ServerName: "im.example.com", RootCAs: pool})
+	_, err = client.Write([]byte{0, 0, 0, 4, 0, 0, 0, 1})
+	if err == nil {
+		_, err = client.Read(make([]byte, 1))
+	}
+	assert.Error(t, err)
+
+	// 携带客户端证书
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	assert.NoError(t, err)
+
+	tc, raw := newTLSPair(t, cfg, func(*TLSConn) {})
+	client = tls.Client(raw, &tls.Config{ServerName: "im.example.com", RootCAs: pool, Certificates: []tls.Certificate{cert}})
+	assert.NoError(t, client.Handshake())
+
+	// 服务端写入会等待握手完成
+	assert.NoError(t, tc.AsyncWrite([]byte{1}, nil))
+	_, err = io.ReadFull(client, make([]byte, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "client", tc.ConnectionState().PeerCertificates[0].Subject.CommonName)
+}
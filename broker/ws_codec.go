@@ -0,0 +1,253 @@
+package broker
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gobwas/ws"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/panjf2000/gnet/v2"
+	bb "github.com/panjf2000/gnet/v2/pkg/pool/bytebuffer"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxHandshakeSize 握手请求的最大字节数
+	maxHandshakeSize = 8192
+)
+
+var (
+	errWsHandshakeTooLarge = errors.New("ws handshake too large")
+	errWsMessageTooLarge   = errors.New("ws message too large")
+	errWsUnmasked          = errors.New("ws client frame not masked")
+	errWsTextFrame         = errors.New("ws text frame not supported")
+	errWsFragment          = errors.New("ws unexpected fragment")
+	errWsClosed            = errors.New("ws closed by client")
+
+	crlfcrlf = []byte("\r\n\r\n")
+)
+
+// PacketEncoder Packet编码，tcp为长度前缀或v2帧，websocket为二进制帧
+type PacketEncoder interface {
+	Encode(p *api.Packet) (*bb.ByteBuffer, error)
+}
+
+var defaultWsCodec = &WsCodec{}
+
+// encoderOf 按连接的协议和协商的帧版本选择编码
+func encoderOf(uc *domain.UserConn, def PacketEncoder) PacketEncoder {
+	if uc == nil {
+		return def
+	}
+	if uc.Protocol == domain.ProtocolWS {
+		return defaultWsCodec
+	}
+	if uc.FrameVersion.Load() >= int32(FrameV2) {
+		return FrameCodecOf(uc.Compression.Load())
+	}
+	return def
+}
+
+// WsCodec 每个Packet编码为一个二进制帧，心跳也编码为Packet，不按长度区分
+type WsCodec struct {
+}
+
+func (l *WsCodec) Encode(p *api.Packet) (*bb.ByteBuffer, error) {
+	payload, err := proto.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bb.Get()
+	header := ws.Header{Fin: true, OpCode: ws.OpBinary, Length: int64(len(payload))}
+	if err := ws.WriteHeader(buffer, header); err != nil {
+		bb.Put(buffer)
+		return nil, err
+	}
+	buffer.Write(payload)
+	return buffer, nil
+}
+
+// wsDecoder 每个websocket连接一个，保存握手状态和未读完的帧
+type wsDecoder struct {
+	path           string
+	maxMessageSize int
+	upgraded       bool
+	buf            []byte
+	frag           []byte
+	fragmented     bool
+}
+
+func newWsDecoder(path string, maxMessageSize int) *wsDecoder {
+	return &wsDecoder{
+		path:           path,
+		maxMessageSize: maxMessageSize,
+	}
+}
+
+// Decode 在event loop中调用，握手响应、pong和close直接写回
+func (d *wsDecoder) Decode(c gnet.Conn) ([]*api.Packet, error) {
+	bs, err := c.Next(c.InboundBuffered())
+	if err != nil {
+		return nil, err
+	}
+	return d.feed(bs, c)
+}
+
+func (d *wsDecoder) feed(data []byte, w io.Writer) ([]*api.Packet, error) {
+	d.buf = append(d.buf, data...)
+
+	if !d.upgraded {
+		ok, err := d.upgrade(w)
+		if err != nil || !ok {
+			return nil, err
+		}
+	}
+
+	packets, err := d.readFrames(w)
+
+	// 剩余的半帧拷贝出来，避免buf无限增长
+	if len(d.buf) == 0 {
+		d.buf = nil
+	} else {
+		d.buf = append([]byte(nil), d.buf...)
+	}
+	return packets, err
+}
+
+// upgrade 收到完整的握手请求后升级，path不匹配时返回404
+func (d *wsDecoder) upgrade(w io.Writer) (bool, error) {
+	idx := bytes.Index(d.buf, crlfcrlf)
+	if idx < 0 {
+		if len(d.buf) > maxHandshakeSize {
+			return false, errWsHandshakeTooLarge
+		}
+		return false, nil
+	}
+
+	u := ws.Upgrader{
+		OnRequest: func(uri []byte) error {
+			path, _, _ := strings.Cut(string(uri), "?")
+			if path != d.path {
+				return ws.RejectConnectionError(ws.RejectionStatus(http.StatusNotFound))
+			}
+			return nil
+		},
+	}
+
+	var resp bytes.Buffer
+	_, err := u.Upgrade(struct {
+		io.Reader
+		io.Writer
+	}{bytes.NewReader(d.buf[:idx+len(crlfcrlf)]), &resp})
+
+	if resp.Len() > 0 {
+		if _, werr := w.Write(resp.Bytes()); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	d.buf = d.buf[idx+len(crlfcrlf):]
+	d.upgraded = true
+	return true, nil
+}
+
+func (d *wsDecoder) readFrames(w io.Writer) ([]*api.Packet, error) {
+	result := make([]*api.Packet, 0)
+
+	for len(d.buf) >= ws.MinHeaderSize {
+		r := bytes.NewReader(d.buf)
+		h, err := ws.ReadHeader(r)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if h.Length > int64(d.maxMessageSize) {
+			return nil, errWsMessageTooLarge
+		}
+
+		hl := len(d.buf) - r.Len()
+		if int64(len(d.buf)-hl) < h.Length {
+			break
+		}
+
+		payload := d.buf[hl : hl+int(h.Length)]
+		d.buf = d.buf[hl+int(h.Length):]
+
+		if !h.Masked {
+			return nil, errWsUnmasked
+		}
+		ws.Cipher(payload, h.Mask, 0)
+
+		switch h.OpCode {
+		case ws.OpPing:
+			if err := writeFrame(w, ws.NewPongFrame(payload)); err != nil {
+				return nil, err
+			}
+		case ws.OpPong:
+		case ws.OpClose:
+			_ = writeFrame(w, ws.NewCloseFrame(ws.NewCloseFrameBody(ws.StatusNormalClosure, "")))
+			return nil, errWsClosed
+		case ws.OpText:
+			return nil, errWsTextFrame
+		case ws.OpBinary, ws.OpContinuation:
+			if (h.OpCode == ws.OpBinary) == d.fragmented {
+				return nil, errWsFragment
+			}
+
+			if h.Fin && !d.fragmented {
+				p, err := decodeWsPayload(payload)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, p)
+				continue
+			}
+
+			d.fragmented = true
+			d.frag = append(d.frag, payload...)
+			if len(d.frag) > d.maxMessageSize {
+				return nil, errWsMessageTooLarge
+			}
+
+			if h.Fin {
+				p, err := decodeWsPayload(d.frag)
+				d.frag = nil
+				d.fragmented = false
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, p)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func writeFrame(w io.Writer, f ws.Frame) error {
+	bs, err := ws.CompileFrame(f)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bs)
+	return err
+}
+
+// decodeWsPayload payload为protobuf编码的Packet，包括心跳
+func decodeWsPayload(payload []byte) (*api.Packet, error) {
+	var p api.Packet
+	if err := proto.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,133 @@
+package broker
+
+import (
+	"bytes"
+	"github.com/gobwas/ws"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+const wsHandshake = "GET /ws?token=abc HTTP/1.1\r\n" +
+	"Host: 127.0.0.1:5076\r\n" +
+	"Upgrade: websocket\r\n" +
+	"Connection: Upgrade\r\n" +
+	"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+	"Sec-WebSocket-Version: 13\r\n\r\n"
+
+func clientFrame(op ws.OpCode, fin bool, payload []byte) []byte {
+	return ws.MustCompileFrame(ws.MaskFrame(ws.NewFrame(op, fin, payload)))
+}
+
+func upgradedDecoder(t *testing.T) *wsDecoder {
+	d := newWsDecoder(DefaultWSPath, DefWsMaxMessageSize)
+	var out bytes.Buffer
+	packets, err := d.feed([]byte(wsHandshake), &out)
+	assert.NoError(t, err)
+	assert.Empty(t, packets)
+	assert.True(t, d.upgraded)
+	assert.True(t, strings.HasPrefix(out.String(), "HTTP/1.1 101"))
+	assert.Contains(t, out.String(), "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=")
+	return d
+}
+
+func TestWsUpgradeSplit(t *testing.T) {
+	d := newWsDecoder(DefaultWSPath, DefWsMaxMessageSize)
+	var out bytes.Buffer
+
+	// 握手分两次到达
+	packets, err := d.feed([]byte(wsHandshake[:20]), &out)
+	assert.NoError(t, err)
+	assert.Empty(t, packets)
+	assert.False(t, d.upgraded)
+
+	bs, _ := proto.Marshal(api.NewHeartbeat(1).Wrap())
+	hb := clientFrame(ws.OpBinary, true, bs)
+	packets, err = d.feed(append([]byte(wsHandshake[20:]), hb...), &out)
+	assert.NoError(t, err)
+	assert.True(t, d.upgraded)
+	assert.Len(t, packets, 1)
+	assert.True(t, packets[0].IsHeartbeat())
+	assert.Equal(t, int32(1), packets[0].GetHeartbeat().Value)
+}
+
+func TestWsUpgradeWrongPath(t *testing.T) {
+	d := newWsDecoder("/im", DefWsMaxMessageSize)
+	var out bytes.Buffer
+	_, err := d.feed([]byte(wsHandshake), &out)
+	assert.Error(t, err)
+	assert.True(t, strings.HasPrefix(out.String(), "HTTP/1.1 404"))
+}
+
+func TestWsDecodePackets(t *testing.T) {
+	d := upgradedDecoder(t)
+	var out bytes.Buffer
+
+	m := api.NewMessage(1, 2, 0, 1, "app", "conv", &api.Text{Text: "hello"}).Wrap()
+	bs, _ := proto.Marshal(m)
+
+	// 一个完整帧 + 一条分片消息，分片中间夹一个ping
+	data := clientFrame(ws.OpBinary, true, bs)
+	data = append(data, clientFrame(ws.OpBinary, false, bs[:5])...)
+	data = append(data, clientFrame(ws.OpPing, true, []byte("p"))...)
+	data = append(data, clientFrame(ws.OpContinuation, true, bs[5:])...)
+
+	// 最后一帧拆开到达
+	packets, err := d.feed(data[:len(data)-3], &out)
+	assert.NoError(t, err)
+	assert.Len(t, packets, 1)
+	assert.Equal(t, m.GetMessage().MessageId, packets[0].GetMessage().MessageId)
+
+	pong := ws.MustCompileFrame(ws.NewPongFrame([]byte("p")))
+	assert.Equal(t, pong, out.Bytes())
+
+	packets, err = d.feed(data[len(data)-3:], &out)
+	assert.NoError(t, err)
+	assert.Len(t, packets, 1)
+	assert.Equal(t, "hello", packets[0].GetMessage().GetText().Text)
+}
+
+func TestWsDecodeErrors(t *testing.T) {
+	var out bytes.Buffer
+
+	d := upgradedDecoder(t)
+	_, err := d.feed(ws.MustCompileFrame(ws.NewBinaryFrame([]byte("x"))), &out)
+	assert.Equal(t, errWsUnmasked, err)
+
+	d = upgradedDecoder(t)
+	_, err = d.feed(clientFrame(ws.OpText, true, []byte("x")), &out)
+	assert.Equal(t, errWsTextFrame, err)
+
+	d = upgradedDecoder(t)
+	_, err = d.feed(clientFrame(ws.OpContinuation, true, []byte("x")), &out)
+	assert.Equal(t, errWsFragment, err)
+
+	d = upgradedDecoder(t)
+	d.maxMessageSize = 8
+	_, err = d.feed(clientFrame(ws.OpBinary, true, make([]byte, 9)), &out)
+	assert.Equal(t, errWsMessageTooLarge, err)
+
+	d = upgradedDecoder(t)
+	_, err = d.feed(clientFrame(ws.OpClose, true, nil), &out)
+	assert.Equal(t, errWsClosed, err)
+}
+
+func TestWsEncode(t *testing.T) {
+	buffer, err := defaultWsCodec.Encode(api.HeartbeatACK)
+	assert.NoError(t, err)
+
+	h, err := ws.ReadHeader(bytes.NewReader(buffer.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, ws.OpBinary, h.OpCode)
+	assert.True(t, h.Fin)
+	assert.False(t, h.Masked)
+
+	// 心跳编码为Packet，4字节的Packet不会被当作心跳值
+	payload := buffer.Bytes()[len(buffer.Bytes())-int(h.Length):]
+	p, err := decodeWsPayload(payload)
+	assert.NoError(t, err)
+	assert.True(t, p.IsHeartbeat())
+	assert.Equal(t, api.HeartbeatACK.GetHeartbeat().Value, p.GetHeartbeat().Value)
+}
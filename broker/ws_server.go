@@ -0,0 +1,183 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"github.com/magicnana999/im/broker/domain"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/pkg/jsonext"
+	"github.com/panjf2000/gnet/v2"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"runtime"
+	"time"
+)
+
+const (
+	// DefaultWSAddress websocket默认监听地址
+	DefaultWSAddress = "0.0.0.0:5076"
+
+	// DefaultWSPath websocket默认握手路径
+	DefaultWSPath = "/ws"
+
+	// DefWsMaxMessageSize 单条websocket消息的默认最大字节数
+	DefWsMaxMessageSize = 1 << 20
+)
+
+type wsDecoderKey struct{}
+
+// WsServer websocket接入，连接建立后与tcp共用UserConn、心跳、UserHolder和Packet处理流程，
+// 路由上与tcp用户没有区别。仅在配置了ws时启动
+type WsServer struct {
+	*gnet.BuiltinEventEngine
+	eng    gnet.Engine
+	cfg    *global.WSConfig
+	tcp    *TcpServer
+	logger *Logger
+}
+
+func getOrDefaultWSConfig(g *global.Config) *global.WSConfig {
+	c := &global.WSConfig{}
+	if g != nil && g.WS != nil {
+		*c = *g.WS
+	}
+
+	if c.Addr == "" {
+		c.Addr = DefaultWSAddress
+	}
+
+	if c.Path == "" {
+		c.Path = DefaultWSPath
+	}
+
+	if c.Interval <= 0 {
+		c.Interval = DefaultTickInterval
+	}
+
+	if c.MaxMessageSize <= 0 {
+		c.MaxMessageSize = DefWsMaxMessageSize
+	}
+
+	return c
+}
+
+func NewWsServer(conf *global.Config, tcp *TcpServer, lc fx.Lifecycle) (*WsServer, error) {
+
+	logger := NewLogger("ws")
+
+	c := getOrDefaultWSConfig(conf)
+
+	s := &WsServer{
+		cfg:    c,
+		tcp:    tcp,
+		logger: logger,
+	}
+
+	if conf == nil || conf.WS == nil {
+		return s, nil
+	}
+
+	logger.SrvInfo(string(jsonext.MarshalNoErr(c)), SrvLifecycle, nil)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return s.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+
+	return s, nil
+}
+
+// Start 启动，需在tcp之后启动
+func (s *WsServer) Start(ctx context.Context) error {
+	go func() {
+		err := gnet.Run(s,
+			fmt.Sprintf("tcp://%s", s.cfg.Addr),
+			gnet.WithMulticore(DefMulticore),
+			gnet.WithLockOSThread(DefLockOSThread),
+			gnet.WithReadBufferCap(DefReadBufferCap),
+			gnet.WithWriteBufferCap(DefWriteBufferCap),
+			gnet.WithLoadBalancing(DefLoadBalancing),
+			gnet.WithNumEventLoop(runtime.NumCPU()),
+			gnet.WithReuseAddr(DefReuseAddr),
+			gnet.WithReusePort(DefReusePort),
+			gnet.WithTCPKeepAlive(DefTcpKeepAlive),
+			gnet.WithTCPNoDelay(DefTcpNoDelay),
+			gnet.WithSocketRecvBuffer(DefSocketRecvBuffer),
+			gnet.WithSocketSendBuffer(DefSocketSendBuffer),
+			gnet.WithTicker(DefTicker),
+			gnet.WithLogger(s.logger),
+			gnet.WithLogLevel(DefLogLevel),
+			gnet.WithEdgeTriggeredIO(DefEdgeTriggeredIO),
+			gnet.WithEdgeTriggeredIOChunk(DefEdgeTriggeredIOChunk))
+
+		s.logger.SrvInfo("ws starting", SrvLifecycle, err)
+
+		if err != nil {
+			s.logger.Fatal("ws start failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Stop 停止，连接由tcp统一关闭
+func (s *WsServer) Stop(ctx context.Context) error {
+	return s.eng.Stop(ctx)
+}
+
+// OnBoot 启动回调
+func (s *WsServer) OnBoot(eng gnet.Engine) (action gnet.Action) {
+	s.eng = eng
+	s.logger.SrvInfo("ws started", SrvLifecycle, nil)
+	return gnet.None
+}
+
+// OnShutdown 停止回调
+func (s *WsServer) OnShutdown(eng gnet.Engine) {
+	s.logger.SrvInfo("ws shutdown", SrvLifecycle, nil)
+}
+
+// OnOpen 新链接，和tcp一样初始化ctx、启动心跳，另外绑定该连接的wsDecoder
+func (s *WsServer) OnOpen(c gnet.Conn) (out []byte, action gnet.Action) {
+	uc := domain.NewUserConn(c)
+	uc.Protocol = domain.ProtocolWS
+
+	err := s.tcp.openConn(c, uc)
+	s.logger.ConnDebug("connect", uc.Desc(), ConnLifecycle, err, zap.String("uc", string(jsonext.MarshalNoErr(uc))))
+	if err != nil {
+		return nil, gnet.Close
+	}
+
+	ctx := context.WithValue(s.tcp.getContext(c), wsDecoderKey{}, newWsDecoder(s.cfg.Path, s.cfg.MaxMessageSize))
+	c.SetContext(ctx)
+	return nil, gnet.None
+}
+
+// OnClose 关闭时回调
+func (s *WsServer) OnClose(c gnet.Conn, err error) (action gnet.Action) {
+	return s.tcp.OnClose(c, err)
+}
+
+// OnTraffic 收到数据，握手完成前只处理握手
+func (s *WsServer) OnTraffic(c gnet.Conn) (action gnet.Action) {
+	ctx := s.tcp.getContext(c)
+	if ctx == nil {
+		return gnet.Close
+	}
+
+	decoder, ok := ctx.Value(wsDecoderKey{}).(*wsDecoder)
+	if !ok {
+		s.logger.ConnDebug("ws decoder not found", c.RemoteAddr().String(), ConnLifecycle, nil)
+		return gnet.Close
+	}
+
+	return s.tcp.traffic(c, decoder.Decode)
+}
+
+// OnTick gnet ticker
+func (s *WsServer) OnTick() (delay time.Duration, action gnet.Action) {
+	return s.cfg.Interval, gnet.None
+}
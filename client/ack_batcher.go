@@ -0,0 +1,166 @@
+package main
+
+import (
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"sync"
+	"time"
+)
+
+const (
+	// DefAckWindow 收到消息后等待合并ack的时间
+	DefAckWindow = time.Millisecond * 50
+
+	// DefAckMaxIds 待ack的消息达到该数量时立即发送
+	DefAckMaxIds = 64
+)
+
+// convMark 会话中连续收到的sequence范围[from, seq]，从登录后收到的第一条消息开始，
+// 之前的消息不一定收到，不在范围内
+type convMark struct {
+	from  int64
+	seq   int64
+	above map[int64]struct{} //已收到但不连续的sequence
+}
+
+func newConvMark(seq int64) *convMark {
+	return &convMark{from: seq, seq: seq, above: map[int64]struct{}{}}
+}
+
+func (m *convMark) receive(seq int64) {
+	if seq >= m.from && seq <= m.seq {
+		return
+	}
+	// 重发的更早的消息，与范围相邻时向前扩展，否则按messageId ack
+	if seq < m.from {
+		if seq == m.from-1 {
+			m.from = seq
+		}
+		return
+	}
+	m.above[seq] = struct{}{}
+	for {
+		if _, ok := m.above[m.seq+1]; !ok {
+			return
+		}
+		m.seq++
+		delete(m.above, m.seq)
+	}
+}
+
+// AckBatcher 合并一个连接在窗口内收到的消息的ack，
+// 会话中已连续收到的消息合并为累计ack，其余按messageId
+type AckBatcher struct {
+	mu      sync.Mutex
+	window  time.Duration
+	maxIds  int
+	pending []*api.Message
+	marks   map[string]*convMark
+	timer   *time.Timer
+	flush   func(*api.Ack)
+}
+
+func NewAckBatcher(window time.Duration, maxIds int, flush func(*api.Ack)) *AckBatcher {
+	if window <= 0 {
+		window = DefAckWindow
+	}
+	if maxIds <= 0 {
+		maxIds = DefAckMaxIds
+	}
+	return &AckBatcher{
+		window: window,
+		maxIds: maxIds,
+		marks:  make(map[string]*convMark),
+		flush:  flush,
+	}
+}
+
+// Add 收到一条消息
+func (b *AckBatcher) Add(m *api.Message) {
+	b.mu.Lock()
+
+	if m.ConvId != "" && m.Sequence > 0 {
+		mark, ok := b.marks[m.ConvId]
+		if !ok {
+			mark = newConvMark(m.Sequence)
+			b.marks[m.ConvId] = mark
+		}
+		mark.receive(m.Sequence)
+	}
+
+	b.pending = append(b.pending, m)
+
+	var ack *api.Ack
+	if len(b.pending) >= b.maxIds {
+		ack = b.take()
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.Flush)
+	}
+	b.mu.Unlock()
+
+	if ack != nil {
+		b.flush(ack)
+	}
+}
+
+// Flush 立即发送待ack的消息
+func (b *AckBatcher) Flush() {
+	b.mu.Lock()
+	ack := b.take()
+	b.mu.Unlock()
+
+	if ack != nil {
+		b.flush(ack)
+	}
+}
+
+// Stop 连接关闭后不再发送
+func (b *AckBatcher) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.pending = nil
+}
+
+// take 取出待ack的消息并生成ack，需持有锁
+func (b *AckBatcher) take() *api.Ack {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	covered := make(map[string]int)
+	for _, m := range b.pending {
+		if b.covered(m) {
+			covered[m.ConvId]++
+		}
+	}
+
+	ack := api.NewAck()
+	for _, m := range b.pending {
+		// 只有一条时累计ack不能减少数据量
+		if b.covered(m) && covered[m.ConvId] > 1 {
+			mark := b.marks[m.ConvId]
+			ack.AckConv(m.ConvId, mark.from, mark.seq)
+			continue
+		}
+		ack.MessageIds = append(ack.MessageIds, m.MessageId)
+	}
+
+	b.pending = nil
+	return ack
+}
+
+func (b *AckBatcher) covered(m *api.Message) bool {
+	if m.ConvId == "" || m.Sequence <= 0 {
+		return false
+	}
+	mark, ok := b.marks[m.ConvId]
+	return ok && m.Sequence >= mark.from && m.Sequence <= mark.seq
+}
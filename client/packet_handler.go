@@ -11,14 +11,11 @@ import (
 )
 
 type PacketHandler struct {
-	codec *broker.Codec
-	mm    *sync.Map
+	mm *sync.Map
 }
 
 func NewPacketHandler() *PacketHandler {
-	return &PacketHandler{
-		codec: broker.NewCodec(),
-	}
+	return &PacketHandler{}
 }
 
 func (s *PacketHandler) GetMessageSent(msgID string) *api.Message {
@@ -52,6 +49,10 @@ func (s *PacketHandler) Send(request *api.Packet, user *User) {
 func (s *PacketHandler) Handle(p *api.Packet, user *User) *api.Packet {
 	if p.IsMessage() {
 		if p.GetMessage().IsRequest() {
+			if user.Acks != nil {
+				user.Acks.Add(p.GetMessage())
+				return nil
+			}
 			return p.GetMessage().Wrap()
 		}
 
@@ -67,6 +68,7 @@ func (s *PacketHandler) Handle(p *api.Packet, user *User) *api.Packet {
 		if cmd.GetLoginReply() != nil {
 			user.UserID = cmd.GetLoginReply().GetUserId()
 			user.AppID = cmd.GetLoginReply().GetAppId()
+			user.Compression.Store(cmd.GetLoginReply().GetCompression())
 			user.IsLogin.Store(true)
 		}
 	}
@@ -78,7 +80,8 @@ func (s *PacketHandler) Write(ret *api.Packet, user *User) error {
 		logging.Infof("%d write: %s", user.UserID, toJson(ret))
 	}
 
-	buffer, err := s.codec.Encode(ret)
+	// v2帧，登录后按协商的算法压缩
+	buffer, err := broker.FrameCodecOf(user.GetCompression()).Encode(ret)
 	defer bb.Put(buffer)
 	if err != nil {
 		return err
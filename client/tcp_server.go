@@ -12,6 +12,7 @@ import (
 	"github.com/panjf2000/gnet/v2"
 	"github.com/panjf2000/gnet/v2/pkg/logging"
 	"go.uber.org/zap"
+	"math/rand"
 	"strconv"
 	"strings"
 	"sync"
@@ -70,6 +71,11 @@ func (h *TcpServer) OnOpen(c gnet.Conn) (out []byte, action gnet.Action) {
 		Writer: c,
 		Reader: c,
 	}
+	user.Acks = NewAckBatcher(DefAckWindow, DefAckMaxIds, func(ack *api.Ack) {
+		if !user.IsClosed.Load() {
+			h.handler.Write(ack.Wrap(), user)
+		}
+	})
 
 	atomic.StorePointer(&h.curUser, unsafe.Pointer(user))
 
@@ -80,10 +86,11 @@ func (h *TcpServer) OnOpen(c gnet.Conn) (out []byte, action gnet.Action) {
 
 	//登录
 	req := &api.LoginRequest{
-		AppId:    "1201",
-		UserSig:  "",
-		Os:       "iOS",
-		DeviceId: "",
+		AppId:        "1201",
+		UserSig:      "",
+		Os:           "iOS",
+		DeviceId:     "",
+		Compressions: []string{broker.CompressionZstd, broker.CompressionGzip},
 	}
 	packet := api.NewCommand(req)
 	h.write(packet, user)
@@ -121,6 +128,10 @@ func (h *TcpServer) OnTraffic(c gnet.Conn) gnet.Action {
 		if packet.IsCommand() && packet.GetCommand().CommandType == api.CommandTypeUserLogin {
 			h.curUsers.Store(user.UserID, user)
 		}
+
+		if packet.IsCommand() && packet.GetCommand().CommandType == api.CommandTypeReconnect {
+			h.reconnect(c, user, packet.GetCommand().GetReconnectRequest())
+		}
 	}
 
 	return gnet.None
@@ -131,6 +142,7 @@ func (h *TcpServer) OnClose(c gnet.Conn, err error) gnet.Action {
 	user := GetUser(c)
 	if user != nil {
 		user.IsClosed.Store(true)
+		user.Acks.Stop()
 		h.curUsers.Delete(user.UserID)
 
 		loginUser := h.GetLoginUser()
@@ -169,16 +181,37 @@ func (h *TcpServer) login(size int) {
 		h.connect(1)
 
 		req := &api.LoginRequest{
-			AppId:    "1201",
-			UserSig:  "",
-			Os:       "iOS",
-			DeviceId: "",
+			AppId:        "1201",
+			UserSig:      "",
+			Os:           "iOS",
+			DeviceId:     "",
+			Compressions: []string{broker.CompressionZstd, broker.CompressionGzip},
 		}
 		packet := api.NewCommand(req)
 		h.write(packet, h.GetLoginUser())
 	}
 }
 
+// reconnect broker下线前通知重连，连上其中一个地址后再关闭当前连接
+func (h *TcpServer) reconnect(c gnet.Conn, user *User, req *api.ReconnectRequest) {
+	addrs := req.GetAddrs()
+	if len(addrs) == 0 {
+		logging.Infof("%d reconnect without addrs: %s", user.UserID, req.GetReason())
+		return
+	}
+
+	addr := addrs[rand.Intn(len(addrs))]
+	go func() {
+		if _, err := h.agent.Dial("tcp", addr); err != nil {
+			logging.Errorf("%d reconnect to %s failed: %v", user.UserID, addr, err)
+			return
+		}
+		user.Acks.Flush()
+		c.Close()
+		logging.Infof("%d reconnected to %s", user.UserID, addr)
+	}()
+}
+
 func (h *TcpServer) write(ht *api.Packet, user *User) {
 	h.handler.Write(ht, user)
 }
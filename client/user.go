@@ -14,4 +14,12 @@ type User struct {
 	IsClosed atomic.Bool  `json:"-"`
 	IsLogin  atomic.Bool  `json:"-"`
 	LastHTS  atomic.Int64 `json:"-"`
+
+	Compression atomic.Value `json:"-"` //登录时协商的压缩算法
+	Acks        *AckBatcher  `json:"-"` //合并收到消息的ack
+}
+
+func (u *User) GetCompression() string {
+	c, _ := u.Compression.Load().(string)
+	return c
 }
@@ -5,6 +5,7 @@ import "time"
 type Conv struct {
 	ConvId      string    `gorm:"primaryKey",json:"convId"`
 	AppId       string    `gorm:"primaryKey",json:"appId"`
+	UserId      int64     `gorm:"primaryKey",json:"userId"`
 	ConvType    string    `gorm:"conv_type",json:"convType"`
 	Sequence    int64     `gorm:"sequence",json:"sequence"`
 	ReadSeq     int64     `gorm:"read_seq",json:"readSeq"`
@@ -13,6 +14,8 @@ type Conv struct {
 	IsHide      int       `gorm:"is_hide",json:"isHide"`
 	IsTop       int       `gorm:"is_top",json:"isTop"`
 	IsDisturb   int       `gorm:"is_disturb",json:"isDisturb"`
+	MuteUntil   int64     `gorm:"mute_until",json:"muteUntil"`
+	MentionOnly int       `gorm:"mention_only",json:"mentionOnly"`
 	CustomType  string    `gorm:"custom_type",json:"customType"`
 	Custom1     string    `gorm:"custom_1",json:"custom1"`
 	Custom2     string    `gorm:"custom_2",json:"custom2"`
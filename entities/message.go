@@ -8,9 +8,9 @@ type Message struct {
 	GroupId   int64  `gorm:"group_id",json:"groupId"`
 	ConvId    string `gorm:"conv_id",json:"convId"`
 	Sequence  int64  `gorm:"sequence",json:"sequence"`
-	cTime     int64  `gorm:"c_time",json:"cTime"`
-	sTime     int64  `gorm:"s_time",json:"sTime"`
-	cType     string `gorm:"c_type",json:"cType"`
+	CTime     int64  `gorm:"c_time",json:"cTime"`
+	STime     int64  `gorm:"s_time",json:"sTime"`
+	CType     string `gorm:"c_type",json:"cType"`
 	At        string `gorm:"at",json:"at"`
 	Refer     string `gorm:"refer",json:"refer"`
 	Content   string `gorm:"content",json:"content"`
@@ -13,9 +13,39 @@ var (
 	MsgMQProduceError   = errext.New(1106, "message produce failed")
 	MsgDeliverTaskError = errext.New(1107, "message deliver task failed")
 	CurUserNotFound     = errext.New(1108, "current user not found")
+	RateLimited         = errext.New(1109, "rate limited")
+	UserConnNotFound    = errext.New(1110, "user conn not found")
+	UserConnCongested   = errext.New(1111, "user conn congested")
 
 	LoginErr       = errext.New(1201, "cmd_service failed")
 	CmdUnknownType = errext.New(1202, "unknown cmd_service type")
 
-	RouteErr = errext.New(1301, "route failed")
+	RouteErr         = errext.New(1301, "route failed")
+	ReadErr          = errext.New(1302, "read failed")
+	ReceiptErr       = errext.New(1303, "receipt query failed")
+	ReceiptForbidden = errext.New(1304, "receipt only available to sender")
+	MentionErr       = errext.New(1305, "mention failed")
+	MentionForbidden = errext.New(1306, "@all only available to group admins")
+	ReferErr         = errext.New(1307, "refer failed")
+	ReferNotFound    = errext.New(1308, "referenced message not found")
+	ReferForbidden   = errext.New(1309, "referenced message outside conversation")
+	KeyErr           = errext.New(1310, "key directory failed")
+	KeyInvalid       = errext.New(1311, "invalid device keys")
+	KeyNotFound      = errext.New(1312, "device keys not found")
+	DedupErr         = errext.New(1313, "dedup failed")
+	StatusErr        = errext.New(1314, "status failed")
+	OfflineErr       = errext.New(1315, "offline fallback failed")
+	PushErr          = errext.New(1316, "push failed")
+	PushInvalid      = errext.New(1317, "invalid push token")
+	PushTokenExpired = errext.New(1318, "push token expired")
+	NotifyErr        = errext.New(1319, "notify setting failed")
+	NotifyInvalid    = errext.New(1320, "invalid notify setting")
+	ScheduleErr      = errext.New(1321, "schedule failed")
+	ScheduleInvalid  = errext.New(1322, "invalid scheduled message")
+	ScheduleLimited  = errext.New(1323, "too many scheduled messages")
+	ReceiptNotFound  = errext.New(1324, "receipt not found")
+	ReadForbidden    = errext.New(1325, "read only available to group members")
+	RecallErr        = errext.New(1326, "recall failed")
+	RecallForbidden  = errext.New(1327, "recall only available to sender")
+	KeyLimited       = errext.New(1328, "too many key fetches")
 )
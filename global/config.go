@@ -11,22 +11,100 @@ import (
 )
 
 type Config struct {
-	TCP   *TCPConfig   `yaml:"tcp,omitempty" json:"tcp,omitempty"`
-	Gorm  *GormConfig  `yaml:"gorm" json:"gorm"`
-	Redis *RedisConfig `yaml:"redis" json:"redis"`
-	Kafka *KafkaConfig `yaml:"kafka" json:"kafka"`
-	Etcd  *EtcdConfig  `yaml:"etcd" json:"etcd"`
-	MRS   *MRSConfig   `yaml:"mrs" json:"mrs"`
-	MSS   *MSSConfig   `yaml:"mss" json:"mss"`
-	RBS   *RBSConfig   `yaml:"rbs" json:"rbs"`
-	RRS   *RRSConfig   `yaml:"rrs,omitempty" json:"rrs,omitempty"`
+	TCP      *TCPConfig      `yaml:"tcp,omitempty" json:"tcp,omitempty"`
+	WS       *WSConfig       `yaml:"ws,omitempty" json:"ws,omitempty"`
+	Gorm     *GormConfig     `yaml:"gorm" json:"gorm"`
+	Redis    *RedisConfig    `yaml:"redis" json:"redis"`
+	Kafka    *KafkaConfig    `yaml:"kafka" json:"kafka"`
+	Etcd     *EtcdConfig     `yaml:"etcd" json:"etcd"`
+	MRS      *MRSConfig      `yaml:"mrs" json:"mrs"`
+	MSS      *MSSConfig      `yaml:"mss" json:"mss"`
+	RBS      *RBSConfig      `yaml:"rbs" json:"rbs"`
+	RRS      *RRSConfig      `yaml:"rrs,omitempty" json:"rrs,omitempty"`
+	Drain    *DrainConfig    `yaml:"drain,omitempty" json:"drain,omitempty"`
+	Registry *RegistryConfig `yaml:"registry,omitempty" json:"registry,omitempty"`
+
+	BrokerClient *BrokerClientConfig `yaml:"brokerClient,omitempty" json:"brokerClient,omitempty"`
+}
+
+// BrokerClientConfig router访问broker的客户端配置
+type BrokerClientConfig struct {
+	RPCTimeout       time.Duration `yaml:"rpcTimeout" json:"rpcTimeout"`             //rpc超时
+	HealthInterval   time.Duration `yaml:"healthInterval" json:"healthInterval"`     //健康检查间隔
+	HealthTimeout    time.Duration `yaml:"healthTimeout" json:"healthTimeout"`       //健康检查连接超时
+	FailureThreshold int           `yaml:"failureThreshold" json:"failureThreshold"` //连续失败该次数后熔断
+	Cooldown         time.Duration `yaml:"cooldown" json:"cooldown"`                 //熔断后经过该时间放行一次试探请求
+}
+
+// RegistryConfig broker在redis中登记的租约
+type RegistryConfig struct {
+	Lease   time.Duration `yaml:"lease" json:"lease"`     //租约时长，超过该时间未续约视为broker已宕机
+	Refresh time.Duration `yaml:"refresh" json:"refresh"` //续约间隔，需小于Lease
+}
+
+// DrainConfig broker优雅下线配置
+type DrainConfig struct {
+	Timeout      time.Duration `yaml:"timeout" json:"timeout"`           //等待重发任务ack的最长时间，超时后剩余的转离线
+	Alternatives []string      `yaml:"alternatives" json:"alternatives"` //通知客户端重连的broker地址，不包括本机
+	AdminAddr    string        `yaml:"adminAddr" json:"adminAddr"`       //管理接口地址，POST /drain 触发下线，为空时不启动
 }
 
 type TCPConfig struct {
-	Addr      string              `yaml:"addr" json:"addr"`
-	Interval  time.Duration       `yaml:"interval" json:"interval"`
-	Heartbeat *TcpHeartbeatConfig `yaml:"heartbeat" json:"heartbeat"`
-	Worker    *TcpWorkerConfig    `yaml:"worker" json:"worker"`
+	Addr         string              `yaml:"addr" json:"addr"`
+	Interval     time.Duration       `yaml:"interval" json:"interval"`
+	Heartbeat    *TcpHeartbeatConfig `yaml:"heartbeat" json:"heartbeat"`
+	Worker       *TcpWorkerConfig    `yaml:"worker" json:"worker"`
+	TLS          *TcpTLSConfig       `yaml:"tls,omitempty" json:"tls,omitempty"`
+	MaxFrameSize int                 `yaml:"maxFrameSize" json:"maxFrameSize"` //单帧最大字节数，超过时断开连接
+	RateLimit    *RateLimitConfig    `yaml:"rateLimit,omitempty" json:"rateLimit,omitempty"`
+	Backpressure *BackpressureConfig `yaml:"backpressure,omitempty" json:"backpressure,omitempty"`
+}
+
+// BackpressureConfig 写背压，待发送字节超过高水位时新的投递转离线
+type BackpressureConfig struct {
+	HighWater   int64         `yaml:"highWater" json:"highWater"`     //每个连接待发送字节的高水位
+	SlowTimeout time.Duration `yaml:"slowTimeout" json:"slowTimeout"` //持续超过高水位的时间超过该值时断开连接
+}
+
+// RateLimitConfig 长连接限流，tcp和websocket共用
+type RateLimitConfig struct {
+	RateLimitRule   `yaml:",inline"`
+	MaxViolations   int                       `yaml:"maxViolations" json:"maxViolations"`     //窗口内超限次数达到该值时断开连接
+	ViolationWindow time.Duration             `yaml:"violationWindow" json:"violationWindow"` //统计超限次数的窗口
+	Apps            map[string]*RateLimitRule `yaml:"apps" json:"apps"`                       //按appId覆盖，未配置的项使用默认值
+	Refresh         time.Duration             `yaml:"refresh" json:"refresh"`                 //从app注册表刷新限流配置的间隔
+}
+
+// RateLimitRule 令牌桶速率，为0时不限制
+type RateLimitRule struct {
+	Packets  float64       `yaml:"packets" json:"packets"`   //每个连接每秒的packet数，包括心跳
+	Bytes    float64       `yaml:"bytes" json:"bytes"`       //每个连接每秒读取的字节数
+	Messages float64       `yaml:"messages" json:"messages"` //每个用户所有设备合计每秒发送的消息数
+	Commands float64       `yaml:"commands" json:"commands"` //每个用户所有设备合计每秒的command数
+	Burst    time.Duration `yaml:"burst" json:"burst"`       //允许突发的时长，桶容量为 速率*burst
+}
+
+// TcpTLSConfig 长连接TLS，配置后tcp只接受TLS连接
+type TcpTLSConfig struct {
+	CertFile       string        `yaml:"certFile" json:"certFile"`             //默认证书，SNI未匹配时使用
+	KeyFile        string        `yaml:"keyFile" json:"keyFile"`               //默认证书私钥
+	ClientCAFile   string        `yaml:"clientCAFile" json:"clientCAFile"`     //配置后开启双向认证
+	Certs          []*TcpTLSCert `yaml:"certs" json:"certs"`                   //按SNI选择的证书
+	ReloadInterval time.Duration `yaml:"reloadInterval" json:"reloadInterval"` //检查证书文件变化的间隔
+}
+
+type TcpTLSCert struct {
+	ServerName string `yaml:"serverName" json:"serverName"` //支持 *.example.com
+	CertFile   string `yaml:"certFile" json:"certFile"`
+	KeyFile    string `yaml:"keyFile" json:"keyFile"`
+}
+
+// WSConfig websocket接入，心跳和worker与tcp共用
+type WSConfig struct {
+	Addr           string        `yaml:"addr" json:"addr"`
+	Path           string        `yaml:"path" json:"path"`
+	Interval       time.Duration `yaml:"interval" json:"interval"`
+	MaxMessageSize int           `yaml:"maxMessageSize" json:"maxMessageSize"` //单条消息（含分片）最大字节数
 }
 
 type TcpHeartbeatConfig struct {
@@ -56,9 +134,109 @@ type RBSConfig struct {
 }
 
 type RRSConfig struct {
-	Network   string `yaml:"network" json:"network"`
-	Addr      string `yaml:"addr" json:"addr"`
-	DebugMode bool   `yaml:"debugMode" json:"debugMode"`
+	Network    string            `yaml:"network" json:"network"`
+	Addr       string            `yaml:"addr" json:"addr"`
+	DebugMode  bool              `yaml:"debugMode" json:"debugMode"`
+	LargeGroup *LargeGroupConfig `yaml:"largeGroup" json:"largeGroup"`
+	Receipt    *ReceiptConfig    `yaml:"receipt" json:"receipt"`
+	History    *HistoryConfig    `yaml:"history" json:"history"`
+	Dedup      *DedupConfig      `yaml:"dedup" json:"dedup"`
+	Janitor    *JanitorConfig    `yaml:"janitor" json:"janitor"`
+	Offline    *OfflineConfig    `yaml:"offline" json:"offline"`
+	Push       *PushConfig       `yaml:"push" json:"push"`
+	Schedule   *ScheduleConfig   `yaml:"schedule" json:"schedule"`
+	Keys       *KeysConfig       `yaml:"keys" json:"keys"`
+}
+
+// KeysConfig 公钥目录配置
+type KeysConfig struct {
+	FetchLimit  int64         `yaml:"fetchLimit" json:"fetchLimit"`   //每个用户在窗口内拉取公钥的次数上限
+	FetchWindow time.Duration `yaml:"fetchWindow" json:"fetchWindow"` //拉取限流的窗口
+}
+
+// DedupConfig 客户端重发去重配置
+type DedupConfig struct {
+	Window time.Duration `yaml:"window" json:"window"` //clientMsgId的保留时间，窗口内的重发返回首次发送的结果
+}
+
+// OfflineConfig 在线投递失败的消息保存到离线收件箱
+type OfflineConfig struct {
+	Expire      time.Duration `yaml:"expire" json:"expire"`           //收件箱最后一次写入后的保留时间
+	MaxMessages int64         `yaml:"maxMessages" json:"maxMessages"` //每个用户保留的最多消息数，超过时删除最早的
+}
+
+// PushConfig 离线推送配置
+type PushConfig struct {
+	Locale   string            `yaml:"locale" json:"locale"`     //token没有指定语言时通知文案的语言
+	Timeout  time.Duration     `yaml:"timeout" json:"timeout"`   //调用推送通道的超时
+	Preview  int               `yaml:"preview" json:"preview"`   //文本消息在通知中显示的最大字数
+	Vendors  map[string]string `yaml:"vendors" json:"vendors"`   //按vendor配置推送通道的http地址，未配置的vendor不推送
+	Collapse time.Duration     `yaml:"collapse" json:"collapse"` //同一会话的推送在该时间内合并为一条
+
+	MaxAttempts   int           `yaml:"maxAttempts" json:"maxAttempts"`     //发送失败的推送最多发送的次数，超过后写入死信
+	RetryInterval time.Duration `yaml:"retryInterval" json:"retryInterval"` //发送失败后第一次重试的间隔，之后每次加倍
+
+	APNs   *APNsConfig       `yaml:"apns,omitempty" json:"apns,omitempty"`
+	FCM    *FCMConfig        `yaml:"fcm,omitempty" json:"fcm,omitempty"`
+	Huawei *HuaweiPushConfig `yaml:"huawei,omitempty" json:"huawei,omitempty"`
+}
+
+// APNsConfig 苹果推送，使用token认证
+type APNsConfig struct {
+	KeyFile string `yaml:"keyFile" json:"keyFile"` //.p8私钥文件
+	KeyId   string `yaml:"keyId" json:"keyId"`
+	TeamId  string `yaml:"teamId" json:"teamId"`
+	Topic   string `yaml:"topic" json:"topic"`     //app的bundle id
+	Sandbox bool   `yaml:"sandbox" json:"sandbox"` //使用开发环境
+}
+
+// FCMConfig Firebase推送，使用HTTP v1接口
+type FCMConfig struct {
+	CredentialsFile string   `yaml:"credentialsFile" json:"credentialsFile"` //服务账号的json密钥文件
+	Vendors         []string `yaml:"vendors" json:"vendors"`                 //通过FCM推送的vendor，默认Samsung
+}
+
+// HuaweiPushConfig 华为推送服务
+type HuaweiPushConfig struct {
+	AppId        string   `yaml:"appId" json:"appId"`
+	ClientSecret string   `yaml:"clientSecret" json:"-"`
+	Vendors      []string `yaml:"vendors" json:"vendors"` //通过华为推送的vendor，默认Huawei
+}
+
+// ScheduleConfig 定时发送消息配置
+type ScheduleConfig struct {
+	Interval   time.Duration `yaml:"interval" json:"interval"`     //检查到期消息的间隔
+	Batch      int           `yaml:"batch" json:"batch"`           //每次发送的到期消息上限
+	MaxPending int64         `yaml:"maxPending" json:"maxPending"` //每个用户未发送的定时消息上限
+	MaxDelay   time.Duration `yaml:"maxDelay" json:"maxDelay"`     //发送时间距提交时间的最大间隔
+	Timeout    time.Duration `yaml:"timeout" json:"timeout"`       //发送中的消息超过该时间未完成时重新发送
+}
+
+// JanitorConfig 宕机broker的连接清理配置
+type JanitorConfig struct {
+	Interval time.Duration `yaml:"interval" json:"interval"` //检查租约过期的间隔
+	Batch    int           `yaml:"batch" json:"batch"`       //每次处理的过期broker数量上限
+}
+
+// HistoryConfig 消息历史配置，引用消息时从历史中校验
+type HistoryConfig struct {
+	Expire        time.Duration `yaml:"expire" json:"expire"`               //消息历史的保存时间
+	SnippetLength int           `yaml:"snippetLength" json:"snippetLength"` //引用文本的最大字数
+}
+
+// ReceiptConfig 群消息已读回执配置
+type ReceiptConfig struct {
+	FlushInterval time.Duration `yaml:"flushInterval" json:"flushInterval"` //已读数推送给发送者的节流间隔
+	MaxTracked    int64         `yaml:"maxTracked" json:"maxTracked"`       //每个群最多跟踪的消息数
+	Expire        time.Duration `yaml:"expire" json:"expire"`               //回执的保存时间
+}
+
+// LargeGroupConfig 大群投递配置
+type LargeGroupConfig struct {
+	Threshold   int64 `yaml:"threshold" json:"threshold"`     //成员数超过此值走大群模式
+	ShardSize   int64 `yaml:"shardSize" json:"shardSize"`     //每个分片的成员数
+	MaxLabels   int   `yaml:"maxLabels" json:"maxLabels"`     //单个DeliverRequest最多携带的label数
+	Concurrency int   `yaml:"concurrency" json:"concurrency"` //并发处理的分片/broker数
 }
 
 type MSSConfig struct {
@@ -66,9 +244,15 @@ type MSSConfig struct {
 	DebugMode    bool `yaml:"debugMode" json:"debugMode"`
 }
 
+// MRSConfig 消息重发，按指数退避重发，直到收到ack、达到MaxAttempts或超过Timeout
 type MRSConfig struct {
-	Timeout   time.Duration `yaml:"timeout" json:"timeout"`
-	DebugMode bool          `yaml:"debugMode" json:"debugMode"`
+	Timeout         time.Duration  `yaml:"timeout" json:"timeout"`                 //首次发送后超过此时间不再重发
+	InitialInterval time.Duration  `yaml:"initialInterval" json:"initialInterval"` //首次发送到第一次重发的间隔
+	Multiplier      float64        `yaml:"multiplier" json:"multiplier"`           //每次重发后间隔的倍数
+	Jitter          float64        `yaml:"jitter" json:"jitter"`                   //间隔随机浮动的比例，0~1
+	MaxAttempts     int            `yaml:"maxAttempts" json:"maxAttempts"`         //最多发送次数，包括首次发送
+	DebugMode       bool           `yaml:"debugMode" json:"debugMode"`
+	Offline         *OfflineConfig `yaml:"offline,omitempty" json:"offline,omitempty"` //转离线写入的收件箱，与router的rrs.offline一致
 }
 
 type GormConfig struct {
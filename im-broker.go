@@ -31,6 +31,7 @@ func main() {
 	}
 
 	log := logger.Named("main")
+	var drainer *broker.Drainer
 	app := fx.New(
 		fx.NopLogger,
 		fx.Provide(
@@ -45,16 +46,27 @@ func main() {
 			infra.NewBrokerClientResolver,
 			holder.NewBrokerHolder,
 			holder.NewUserHolder,
+			broker.NewRegistryServer,
+			broker.NewAppRegistry,
 			broker.NewHeartbeatServer,
+			broker.NewMessageResaver,
 			broker.NewMessageRetryServer,
 			broker.NewMessageSendServer,
+			broker.NewOfflinePuller,
 			cmd_service.NewUserService,
+			cmd_service.NewConvService,
+			cmd_service.NewKeyService,
+			cmd_service.NewPushService,
+			cmd_service.NewScheduleService,
 			handler.NewCommandHandler,
 			handler.NewMessageHandler,
 			broker.NewRpcBrokerServer,
 			broker.NewTcpServer,
+			broker.NewWsServer,
+			broker.NewDrainer,
 		),
-		fx.Invoke(func(tcp *broker.TcpServer, rpc *broker.RpcBrokerServer, delivery *broker.MessageSendServer) {
+		fx.Populate(&drainer),
+		fx.Invoke(func(tcp *broker.TcpServer, ws *broker.WsServer, rpc *broker.RpcBrokerServer, delivery *broker.MessageSendServer) {
 			go func() {
 			}()
 		}),
@@ -71,7 +83,23 @@ func main() {
 		log.Fatal("Failed to start app", zap.Error(err))
 	}
 
-	<-sigs
+	// 收到信号或管理接口触发时先下线，下线期间再次收到信号时不再等待重发任务
+	select {
+	case <-sigs:
+		log.Info("draining...")
+		drainCtx, force := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-sigs:
+				log.Info("force shutdown")
+				force()
+			case <-drainer.Done():
+			}
+		}()
+		drainer.Drain(drainCtx)
+		force()
+	case <-drainer.Done():
+	}
 	log.Info("shutdown...")
 
 	// 停止 Fx
@@ -3,11 +3,10 @@ package main
 import (
 	"context"
 	"flag"
-	"github.com/magicnana999/im/broker"
-	"github.com/magicnana999/im/broker/holder"
 	"github.com/magicnana999/im/global"
 	"github.com/magicnana999/im/infra"
 	"github.com/magicnana999/im/pkg/logger"
+	"github.com/magicnana999/im/router"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"os"
@@ -17,11 +16,11 @@ import (
 )
 
 func main() {
-	logger.Init(nil)
+	logger.Init(&logger.Config{Dir: "./logs/im-router/"})
 	defer logger.Close()
 
 	var confFile string
-	flag.StringVar(&confFile, "conf", "conf/im-broker.yaml", "config file path")
+	flag.StringVar(&confFile, "conf", "conf/im-router.yaml", "config file path")
 	flag.Parse()
 
 	f := func() (*global.Config, error) {
@@ -38,11 +37,27 @@ func main() {
 			infra.NewKafkaProducer,
 			infra.NewEtcdRegistry,
 			infra.NewEtcdResolver,
-			infra.NewBusinessClient,
-			infra.NewRouterClient,
-			holder.NewBrokerHolder,
+			infra.NewBrokerClientResolver,
+			router.NewUserService,
+			router.NewGroupService,
+			router.NewDeliveryService,
+			router.NewReceiptService,
+			router.NewConvService,
+			router.NewMessageStore,
+			router.NewReferService,
+			router.NewKeyService,
+			router.NewDedupService,
+			router.NewStatusService,
+			router.NewOfflineService,
+			router.NewOfflineServer,
+			router.NewPushService,
+			router.NewPushServer,
+			router.NewBrokerJanitor,
+			router.NewScheduleService,
+			router.NewRpcRouterServer,
+			router.NewScheduleServer,
 		),
-		fx.Invoke(func(tcp *broker.TcpServer, rpc *broker.RpcBrokerServer, delivery *broker.MessageSendServer) {
+		fx.Invoke(func(rpc *router.RpcRouterServer, janitor *router.BrokerJanitor, push *router.PushServer, offline *router.OfflineServer, schedule *router.ScheduleServer) {
 			go func() {
 			}()
 		}),
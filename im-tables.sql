@@ -77,3 +77,51 @@ CREATE TABLE IF NOT EXISTS im_friend_request
     UNIQUE INDEX idx_app_from_to (app_id, from_user_id, to_user_id) COMMENT '租户内防止重复请求'
     ) ENGINE = InnoDB
     DEFAULT CHARSET = utf8mb4 COMMENT ='好友请求表';
+
+-- 会话表，每个用户一条
+CREATE TABLE IF NOT EXISTS im_conv
+(
+    app_id        VARCHAR(50)     NOT NULL COMMENT '租户 ID',
+    user_id       BIGINT UNSIGNED NOT NULL COMMENT '用户 ID',
+    conv_id       VARCHAR(64)     NOT NULL COMMENT '会话 ID',
+    conv_type     VARCHAR(20) COMMENT '会话类型',
+    sequence      BIGINT          NOT NULL DEFAULT 0 COMMENT '最新消息序号',
+    read_seq      BIGINT          NOT NULL DEFAULT 0 COMMENT '已读消息序号',
+    last_msg_id   VARCHAR(64) COMMENT '最新消息 ID',
+    last_msg_body TEXT COMMENT '最新消息内容',
+    is_hide       TINYINT         NOT NULL DEFAULT 0 COMMENT '是否隐藏',
+    is_top        TINYINT         NOT NULL DEFAULT 0 COMMENT '是否置顶',
+    is_disturb    TINYINT         NOT NULL DEFAULT 0 COMMENT '是否静音（免打扰），被@时仍然通知',
+    mute_until    BIGINT          NOT NULL DEFAULT 0 COMMENT '静音截止时间（毫秒），0 表示一直静音',
+    mention_only  TINYINT         NOT NULL DEFAULT 0 COMMENT '群会话只在被@时通知',
+    custom_type   VARCHAR(50) COMMENT '自定义类型',
+    custom_1      VARCHAR(256) COMMENT '自定义字段 1',
+    custom_2      VARCHAR(256) COMMENT '自定义字段 2',
+    created_at    TIMESTAMP                DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at    TIMESTAMP                DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (app_id, user_id, conv_id) COMMENT '复合主键，支持多租户',
+    INDEX idx_app_user_updated (app_id, user_id, updated_at) COMMENT '租户内最近会话查询索引'
+    ) ENGINE = InnoDB
+    DEFAULT CHARSET = utf8mb4 COMMENT ='会话表';
+
+-- 离线消息表，转离线的消息每条一行，消息历史过期后从这里读取
+CREATE TABLE IF NOT EXISTS im_message_offline
+(
+    message_id VARCHAR(64)     NOT NULL COMMENT '消息 ID',
+    app_id     VARCHAR(50)     NOT NULL COMMENT '租户 ID',
+    user_id    BIGINT UNSIGNED NOT NULL COMMENT '发送者 ID',
+    `to`       BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '接收者 ID，群消息为 0',
+    group_id   BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '群 ID',
+    conv_id    VARCHAR(64)     NOT NULL COMMENT '会话 ID',
+    sequence   BIGINT          NOT NULL DEFAULT 0 COMMENT '消息序号',
+    c_time     BIGINT          NOT NULL DEFAULT 0 COMMENT '客户端时间（毫秒）',
+    s_time     BIGINT          NOT NULL DEFAULT 0 COMMENT '服务端时间（毫秒）',
+    c_type     VARCHAR(20) COMMENT '消息类型',
+    `at`       TEXT COMMENT '@的用户，json',
+    refer      TEXT COMMENT '引用的消息，json',
+    content    MEDIUMTEXT COMMENT '消息，json',
+    created_at TIMESTAMP                DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP                DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (app_id, message_id) COMMENT '复合主键，支持多租户'
+    ) ENGINE = InnoDB
+    DEFAULT CHARSET = utf8mb4 COMMENT ='离线消息表';
@@ -0,0 +1,453 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/brokerservice"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/pkg/logger"
+	etcdclient "go.etcd.io/etcd/client/v3"
+	"go.uber.org/atomic"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// DefBrokerRPCTimeout 访问broker的rpc超时
+	DefBrokerRPCTimeout = 3 * time.Second
+
+	// DefBrokerHealthInterval 健康检查间隔
+	DefBrokerHealthInterval = 5 * time.Second
+
+	// DefBrokerHealthTimeout 健康检查连接超时
+	DefBrokerHealthTimeout = time.Second
+
+	// DefBrokerFailureThreshold 连续失败该次数后熔断
+	DefBrokerFailureThreshold = 3
+
+	// DefBrokerCooldown 熔断后放行试探请求的等待时间
+	DefBrokerCooldown = 10 * time.Second
+)
+
+var (
+	BrokerIsDown      = errors.New("broker is down")
+	BrokerNotFound    = errors.New("broker not found")
+	BrokerUnavailable = errors.New("broker unavailable")
+)
+
+func getOrDefaultBrokerClientConfig(g *global.Config) *global.BrokerClientConfig {
+	c := &global.BrokerClientConfig{}
+	if g != nil && g.BrokerClient != nil {
+		*c = *g.BrokerClient
+	}
+
+	if c.RPCTimeout <= 0 {
+		c.RPCTimeout = DefBrokerRPCTimeout
+	}
+
+	if c.HealthInterval <= 0 {
+		c.HealthInterval = DefBrokerHealthInterval
+	}
+
+	if c.HealthTimeout <= 0 {
+		c.HealthTimeout = DefBrokerHealthTimeout
+	}
+
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = DefBrokerFailureThreshold
+	}
+
+	if c.Cooldown <= 0 {
+		c.Cooldown = DefBrokerCooldown
+	}
+
+	return c
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker 连续失败threshold次后熔断，cooldown后或健康检查恢复后放行一次试探请求，成功后恢复
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	trial     bool //半开时试探请求已放行
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// ready 是否可以发送请求，不占用试探请求
+func (b *circuitBreaker) ready(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		return now.Sub(b.openedAt) >= b.cooldown
+	case breakerHalfOpen:
+		return !b.trial
+	}
+	return true
+}
+
+// allow 发送请求前调用，半开时只放行一次
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state, b.trial = breakerHalfOpen, true
+		return true
+	case breakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	}
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state, b.failures, b.trial = breakerClosed, 0, false
+}
+
+// failure 返回true时由此熔断
+func (b *circuitBreaker) failure(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	switch b.state {
+	case breakerOpen:
+		b.openedAt = now
+		return false
+	case breakerHalfOpen:
+	default:
+		if b.failures < b.threshold {
+			return false
+		}
+	}
+	b.state, b.openedAt, b.trial = breakerOpen, now, false
+	return true
+}
+
+// recover 健康检查通过，熔断中的放行一次试探请求
+func (b *circuitBreaker) recover() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerOpen {
+		b.state, b.trial = breakerHalfOpen, false
+	}
+}
+
+// LockableBrokerClient 一个broker的rpc客户端，broker注销后不可用，失败时熔断
+type LockableBrokerClient struct {
+	isShutdown   atomic.Bool
+	brokerClient brokerservice.Client
+	breaker      *circuitBreaker
+}
+
+func (l *LockableBrokerClient) Deliver(ctx context.Context, req *api.DeliverRequest, callOptions ...callopt.Option) (res *api.DeliverReply, err error) {
+	if l.isShutdown.Load() {
+		return nil, BrokerIsDown
+	}
+	if !l.breaker.allow(time.Now()) {
+		return nil, BrokerUnavailable
+	}
+
+	res, err = l.brokerClient.Deliver(ctx, req, callOptions...)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		l.breaker.failure(time.Now())
+	} else if err == nil {
+		l.breaker.success()
+	}
+	return res, err
+}
+
+// brokerEntry 一个注册的broker，客户端在第一次使用时创建
+type brokerEntry struct {
+	ep      BrokerEndpoint
+	breaker *circuitBreaker
+	mu      sync.Mutex
+	client  *LockableBrokerClient
+}
+
+// BrokerClientResolver 按broker地址获取rpc客户端
+//
+// broker由BrokerDiscovery发现，按注册的rpc地址管理，也可以用注册时携带的连接地址（UserClient.BrokerAddr）获取。
+// 客户端在第一次使用时创建；定时检查连接，rpc或健康检查连续失败时熔断；broker注销后删除
+type BrokerClientResolver struct {
+	cfg       *global.BrokerClientConfig
+	discovery BrokerDiscovery
+	endpoints map[string]*brokerEntry
+	aliases   map[string]string //连接地址 -> 注册地址
+	newClient func(addr string) (brokerservice.Client, error)
+	probe     func(ctx context.Context, addr string) error
+	cancel    context.CancelFunc
+	logger    *logger.Logger
+	lock      sync.RWMutex
+}
+
+func NewBrokerClientResolver(g *global.Config, lc fx.Lifecycle) (*BrokerClientResolver, error) {
+	c := getOrDefaultEtcdConfig(g)
+	cli, err := etcdclient.New(etcdclient.Config{
+		Endpoints:   c.Endpoints,
+		DialTimeout: c.DialTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	srv := NewBrokerClientResolverWithDiscovery(g, newEtcdBrokerDiscovery(cli))
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return srv.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return srv.Stop(ctx)
+		},
+	})
+
+	return srv, nil
+}
+
+// NewBrokerClientResolverWithDiscovery 使用指定的发现方式，需调用Start
+func NewBrokerClientResolverWithDiscovery(g *global.Config, d BrokerDiscovery) *BrokerClientResolver {
+	c := getOrDefaultBrokerClientConfig(g)
+
+	return &BrokerClientResolver{
+		cfg:       c,
+		discovery: d,
+		endpoints: make(map[string]*brokerEntry),
+		aliases:   make(map[string]string),
+		newClient: func(addr string) (brokerservice.Client, error) {
+			return brokerservice.NewClient(BrokerServiceName,
+				client.WithHostPorts(addr),
+				client.WithRPCTimeout(c.RPCTimeout),
+			)
+		},
+		probe: func(ctx context.Context, addr string) error {
+			conn, err := (&net.Dialer{Timeout: c.HealthTimeout}).DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+		logger: logger.Named("kitex"),
+	}
+}
+
+func (s *BrokerClientResolver) Start(ctx context.Context) error {
+	c, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	eps, err := s.discovery.Watch(c, s.onEvent)
+	if err != nil {
+		cancel()
+		s.logger.Error("Failed to get initial brokers", zap.Error(err))
+		return err
+	}
+	for _, ep := range eps {
+		s.put(ep, "INIT")
+	}
+
+	go s.checkHealth(c)
+	return nil
+}
+
+func (s *BrokerClientResolver) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if err := s.discovery.Close(); err != nil {
+		s.logger.Error("Failed to close discovery", zap.Error(err))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for k, e := range s.endpoints {
+		e.shutdown()
+		delete(s.endpoints, k)
+	}
+	clear(s.aliases)
+	return nil
+}
+
+// Client 按注册地址或连接地址获取客户端，broker未注册或熔断中时返回错误
+func (s *BrokerClientResolver) Client(ctx context.Context, addr string) (brokerservice.Client, error) {
+	s.lock.RLock()
+	e := s.lookup(addr)
+	s.lock.RUnlock()
+
+	if e == nil {
+		return nil, BrokerNotFound
+	}
+	if !e.breaker.ready(time.Now()) {
+		return nil, BrokerUnavailable
+	}
+
+	cli, err := e.get(s.newClient)
+	if err != nil {
+		return nil, err
+	}
+	return cli, nil
+}
+
+// Deliver 投递到指定地址的broker
+func (s *BrokerClientResolver) Deliver(ctx context.Context, addr string, req *api.DeliverRequest) (res *api.DeliverReply, err error) {
+	cli, err := s.Client(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Deliver(ctx, req)
+}
+
+// lookup 需持有锁
+func (s *BrokerClientResolver) lookup(addr string) *brokerEntry {
+	if e, ok := s.endpoints[addr]; ok {
+		return e
+	}
+	if a, ok := s.aliases[addr]; ok {
+		return s.endpoints[a]
+	}
+	return nil
+}
+
+func (s *BrokerClientResolver) onEvent(ev BrokerEvent) {
+	if ev.Deleted {
+		s.remove(ev.Addr)
+		return
+	}
+	s.put(ev.BrokerEndpoint, "PUT")
+}
+
+func (s *BrokerClientResolver) put(ep BrokerEndpoint, event string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.endpoints[ep.Addr]
+	if !ok {
+		e = &brokerEntry{ep: ep, breaker: newCircuitBreaker(s.cfg.FailureThreshold, s.cfg.Cooldown)}
+		s.endpoints[ep.Addr] = e
+	} else if e.ep.ConnAddr != ep.ConnAddr {
+		s.unalias(e.ep)
+		e.ep = ep
+	}
+	if ep.ConnAddr != "" && ep.ConnAddr != ep.Addr {
+		s.aliases[ep.ConnAddr] = ep.Addr
+	}
+
+	s.logger.Info("broker client,ok", zap.String("addr", ep.Addr), zap.String("conn", ep.ConnAddr), zap.String("event", event))
+}
+
+func (s *BrokerClientResolver) remove(addr string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.endpoints[addr]
+	if !ok {
+		return
+	}
+	e.shutdown()
+	s.unalias(e.ep)
+	delete(s.endpoints, addr)
+
+	s.logger.Info("broker client,deleted", zap.String("addr", addr), zap.String("event", "DELETE"))
+}
+
+// unalias 需持有锁，别名已指向其他broker时不删除
+func (s *BrokerClientResolver) unalias(ep BrokerEndpoint) {
+	if a, ok := s.aliases[ep.ConnAddr]; ok && a == ep.Addr {
+		delete(s.aliases, ep.ConnAddr)
+	}
+}
+
+// checkHealth 定时检查所有注册的broker，失败计入熔断，熔断中的broker恢复后放行试探请求
+func (s *BrokerClientResolver) checkHealth(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.lock.RLock()
+		entries := make([]*brokerEntry, 0, len(s.endpoints))
+		for _, e := range s.endpoints {
+			entries = append(entries, e)
+		}
+		s.lock.RUnlock()
+
+		for _, e := range entries {
+			s.checkEntry(ctx, e)
+		}
+	}
+}
+
+func (s *BrokerClientResolver) checkEntry(ctx context.Context, e *brokerEntry) {
+	c, cancel := context.WithTimeout(ctx, s.cfg.HealthTimeout)
+	defer cancel()
+
+	if err := s.probe(c, e.ep.Addr); err != nil {
+		if e.breaker.failure(time.Now()) {
+			s.logger.Warn("broker client,open", zap.String("addr", e.ep.Addr), zap.Error(err))
+		}
+		return
+	}
+	e.breaker.recover()
+}
+
+// get 第一次使用时创建客户端
+func (e *brokerEntry) get(newClient func(addr string) (brokerservice.Client, error)) (*LockableBrokerClient, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		return e.client, nil
+	}
+
+	cli, err := newClient(e.ep.Addr)
+	if err != nil {
+		return nil, err
+	}
+	e.client = &LockableBrokerClient{brokerClient: cli, breaker: e.breaker}
+	return e.client, nil
+}
+
+func (e *brokerEntry) shutdown() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		e.client.isShutdown.Store(true)
+	}
+}
@@ -0,0 +1,138 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBrokerClientLazyAndAlias(t *testing.T) {
+	ctx := context.Background()
+	d := NewMemoryBrokerDiscovery(BrokerEndpoint{Addr: "10.0.0.1:7539", ConnAddr: "10.0.0.1:5075"})
+	f := newFixture(t, d)
+	s, created := f.resolver, f.created
+
+	assert.Empty(t, created)
+
+	// 连接地址和注册地址得到同一个客户端，只创建一次
+	c1, err := s.Client(ctx, "10.0.0.1:5075")
+	assert.NoError(t, err)
+	c2, err := s.Client(ctx, "10.0.0.1:7539")
+	assert.NoError(t, err)
+	assert.Same(t, c1, c2)
+	assert.Equal(t, map[string]int{"10.0.0.1:7539": 1}, created)
+
+	rep, err := s.Deliver(ctx, "10.0.0.1:5075", &api.DeliverRequest{MessageId: "m1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "m1", rep.MessageId)
+
+	_, err = s.Client(ctx, "10.0.0.2:5075")
+	assert.ErrorIs(t, err, BrokerNotFound)
+
+	// 注销后已获取的客户端也不可用
+	d.Delete("10.0.0.1:7539")
+	_, err = s.Client(ctx, "10.0.0.1:5075")
+	assert.ErrorIs(t, err, BrokerNotFound)
+	_, err = c1.Deliver(ctx, &api.DeliverRequest{MessageId: "m2"})
+	assert.ErrorIs(t, err, BrokerIsDown)
+
+	// 重新注册后创建新的客户端
+	d.Put(BrokerEndpoint{Addr: "10.0.0.1:7539", ConnAddr: "10.0.0.1:5075"})
+	c3, err := s.Client(ctx, "10.0.0.1:5075")
+	assert.NoError(t, err)
+	assert.NotSame(t, c1, c3)
+	assert.Equal(t, 2, created["10.0.0.1:7539"])
+}
+
+func TestBrokerClientCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	d := NewMemoryBrokerDiscovery(BrokerEndpoint{Addr: "10.0.0.1:7539"})
+	f := newFixture(t, d)
+	s, errs := f.resolver, f.errs
+
+	cli, err := s.Client(ctx, "10.0.0.1:7539")
+	assert.NoError(t, err)
+
+	// 连续失败两次后熔断
+	down := errors.New("connection refused")
+	errs.Store("10.0.0.1:7539", down)
+	for i := 0; i < 2; i++ {
+		_, err = cli.Deliver(ctx, &api.DeliverRequest{})
+		assert.ErrorIs(t, err, down)
+	}
+	_, err = cli.Deliver(ctx, &api.DeliverRequest{})
+	assert.ErrorIs(t, err, BrokerUnavailable)
+	_, err = s.Client(ctx, "10.0.0.1:7539")
+	assert.ErrorIs(t, err, BrokerUnavailable)
+
+	// 健康检查仍失败时保持熔断
+	e := s.endpoints["10.0.0.1:7539"]
+	s.checkEntry(ctx, e)
+	_, err = s.Client(ctx, "10.0.0.1:7539")
+	assert.ErrorIs(t, err, BrokerUnavailable)
+
+	// 健康检查恢复后放行一次试探请求，成功后恢复
+	errs.Delete("10.0.0.1:7539")
+	s.checkEntry(ctx, e)
+	cli, err = s.Client(ctx, "10.0.0.1:7539")
+	assert.NoError(t, err)
+	_, err = cli.Deliver(ctx, &api.DeliverRequest{})
+	assert.NoError(t, err)
+	_, err = cli.Deliver(ctx, &api.DeliverRequest{})
+	assert.NoError(t, err)
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(1, time.Second)
+
+	assert.True(t, b.failure(now))
+	assert.False(t, b.allow(now))
+	assert.False(t, b.ready(now))
+
+	// 冷却后只放行一次，试探失败重新熔断
+	later := now.Add(time.Second)
+	assert.True(t, b.ready(later))
+	assert.True(t, b.allow(later))
+	assert.False(t, b.allow(later))
+	assert.True(t, b.failure(later))
+	assert.False(t, b.allow(later))
+
+	b.success()
+	assert.True(t, b.allow(later))
+	assert.True(t, b.allow(later))
+}
+
+func TestParseBrokerEndpoint(t *testing.T) {
+	value := []byte(`{"network":"tcp","address":"10.0.0.1:7539","weight":10,"tags":{"conn":"10.0.0.1:5075"}}`)
+	ep, ok := parseBrokerEndpoint([]byte(brokerRegistryPrefix+"10.0.0.1:7539"), value)
+	assert.True(t, ok)
+	assert.Equal(t, BrokerEndpoint{Addr: "10.0.0.1:7539", ConnAddr: "10.0.0.1:5075"}, ep)
+
+	// DELETE事件没有value
+	ep, ok = parseBrokerEndpoint([]byte(brokerRegistryPrefix+"10.0.0.1:7539"), nil)
+	assert.True(t, ok)
+	assert.Equal(t, BrokerEndpoint{Addr: "10.0.0.1:7539"}, ep)
+
+	_, ok = parseBrokerEndpoint([]byte("kitex/registry-etcd/im.router/10.0.0.1:7540"), value)
+	assert.False(t, ok)
+}
+
+func TestResyncBrokers(t *testing.T) {
+	known := map[string]struct{}{"10.0.0.1:7539": {}, "10.0.0.2:7539": {}}
+	var events []BrokerEvent
+
+	// 压缩期间10.0.0.2注销、10.0.0.3注册
+	eps := []BrokerEndpoint{{Addr: "10.0.0.1:7539"}, {Addr: "10.0.0.3:7539", ConnAddr: "10.0.0.3:5075"}}
+	resync(eps, known, func(ev BrokerEvent) { events = append(events, ev) })
+
+	assert.Equal(t, []BrokerEvent{
+		{BrokerEndpoint: BrokerEndpoint{Addr: "10.0.0.2:7539"}, Deleted: true},
+		{BrokerEndpoint: eps[0]},
+		{BrokerEndpoint: eps[1]},
+	}, events)
+	assert.Equal(t, map[string]struct{}{"10.0.0.1:7539": {}, "10.0.0.3:7539": {}}, known)
+}
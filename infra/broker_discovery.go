@@ -0,0 +1,251 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/magicnana999/im/pkg/logger"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	etcdclient "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// BrokerServiceName broker在etcd中注册的服务名
+	BrokerServiceName = "im.broker"
+
+	// BrokerConnTag broker注册时携带的tag，值为客户端连接的地址，与UserClient.BrokerAddr一致
+	BrokerConnTag = "conn"
+
+	brokerRegistryPrefix = "kitex/registry-etcd/" + BrokerServiceName + "/"
+
+	// watchRetryInterval watch出错后重新watch的间隔
+	watchRetryInterval = time.Second
+)
+
+// BrokerEndpoint 一个注册的broker，Addr为注册的rpc地址，ConnAddr为客户端连接的地址
+type BrokerEndpoint struct {
+	Addr     string
+	ConnAddr string
+}
+
+// BrokerEvent broker注册或注销，注销时只有Addr
+type BrokerEvent struct {
+	BrokerEndpoint
+	Deleted bool
+}
+
+// BrokerDiscovery 发现broker的注册和注销
+type BrokerDiscovery interface {
+	// Watch 返回当前注册的broker，之后的变化依次回调onEvent，直到ctx结束
+	Watch(ctx context.Context, onEvent func(BrokerEvent)) ([]BrokerEndpoint, error)
+	Close() error
+}
+
+// etcdBrokerDiscovery 读取kitex registry-etcd注册的broker
+type etcdBrokerDiscovery struct {
+	client *etcdclient.Client
+	logger *logger.Logger
+}
+
+func newEtcdBrokerDiscovery(client *etcdclient.Client) *etcdBrokerDiscovery {
+	return &etcdBrokerDiscovery{client: client, logger: logger.Named("kitex")}
+}
+
+func (d *etcdBrokerDiscovery) Watch(ctx context.Context, onEvent func(BrokerEvent)) ([]BrokerEndpoint, error) {
+	eps, rev, err := d.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]struct{}, len(eps))
+	for _, ep := range eps {
+		known[ep.Addr] = struct{}{}
+	}
+
+	go d.watch(ctx, rev, known, onEvent)
+	return eps, nil
+}
+
+// list 当前注册的broker，返回之后开始watch的版本
+func (d *etcdBrokerDiscovery) list(ctx context.Context) ([]BrokerEndpoint, int64, error) {
+	resp, err := d.client.Get(ctx, brokerRegistryPrefix, etcdclient.WithPrefix())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	eps := make([]BrokerEndpoint, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		if ep, ok := parseBrokerEndpoint(kv.Key, kv.Value); ok {
+			eps = append(eps, ep)
+		}
+	}
+	return eps, resp.Header.Revision + 1, nil
+}
+
+// watch 出错或watch被关闭时从上次处理的版本之后重新watch；
+// 该版本已被压缩时重新读取全部broker，与known比较后补发变化
+func (d *etcdBrokerDiscovery) watch(ctx context.Context, rev int64, known map[string]struct{}, onEvent func(BrokerEvent)) {
+	for ctx.Err() == nil {
+		if rev == 0 {
+			eps, next, err := d.list(ctx)
+			if err != nil {
+				d.logger.Error("list brokers error", zap.Error(err))
+			} else {
+				resync(eps, known, onEvent)
+				rev = next
+			}
+		}
+
+		if rev > 0 {
+			rev = d.watchFrom(ctx, rev, known, onEvent)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(watchRetryInterval):
+		}
+	}
+	d.logger.Info("watch stopped", zap.Error(ctx.Err()))
+}
+
+// watchFrom 从rev开始watch，返回下次开始的版本，版本已被压缩时返回0
+func (d *etcdBrokerDiscovery) watchFrom(ctx context.Context, rev int64, known map[string]struct{}, onEvent func(BrokerEvent)) int64 {
+	wctx, cancel := context.WithCancel(etcdclient.WithRequireLeader(ctx))
+	defer cancel()
+
+	for wr := range d.client.Watch(wctx, brokerRegistryPrefix, etcdclient.WithPrefix(), etcdclient.WithRev(rev)) {
+		if wr.CompactRevision != 0 {
+			d.logger.Warn("watch compacted, relist", zap.Int64("rev", rev), zap.Int64("compact", wr.CompactRevision))
+			return 0
+		}
+		if err := wr.Err(); err != nil {
+			d.logger.Error("watch error, rewatch", zap.Int64("rev", rev), zap.Error(err))
+			return rev
+		}
+
+		for _, event := range wr.Events {
+			rev = event.Kv.ModRevision + 1
+
+			ep, ok := parseBrokerEndpoint(event.Kv.Key, event.Kv.Value)
+			if !ok {
+				d.logger.Warn("invalid broker key", zap.String("key", string(event.Kv.Key)))
+				continue
+			}
+
+			deleted := event.Type == mvccpb.DELETE
+			if deleted {
+				delete(known, ep.Addr)
+			} else {
+				known[ep.Addr] = struct{}{}
+			}
+			onEvent(BrokerEvent{BrokerEndpoint: ep, Deleted: deleted})
+		}
+	}
+	return rev
+}
+
+// resync 重新读取后补发变化：不在eps中的已注销，eps中的全部按注册回调
+func resync(eps []BrokerEndpoint, known map[string]struct{}, onEvent func(BrokerEvent)) {
+	cur := make(map[string]struct{}, len(eps))
+	for _, ep := range eps {
+		cur[ep.Addr] = struct{}{}
+	}
+
+	for addr := range known {
+		if _, ok := cur[addr]; !ok {
+			delete(known, addr)
+			onEvent(BrokerEvent{BrokerEndpoint: BrokerEndpoint{Addr: addr}, Deleted: true})
+		}
+	}
+
+	for _, ep := range eps {
+		known[ep.Addr] = struct{}{}
+		onEvent(BrokerEvent{BrokerEndpoint: ep})
+	}
+}
+
+func (d *etcdBrokerDiscovery) Close() error {
+	return d.client.Close()
+}
+
+// parseBrokerEndpoint key为前缀加注册地址，value为registry-etcd的instanceInfo，DELETE时为空
+func parseBrokerEndpoint(key, value []byte) (BrokerEndpoint, bool) {
+	k := string(key)
+	if !strings.HasPrefix(k, brokerRegistryPrefix) || len(k) == len(brokerRegistryPrefix) {
+		return BrokerEndpoint{}, false
+	}
+
+	ep := BrokerEndpoint{Addr: k[len(brokerRegistryPrefix):]}
+	if len(value) > 0 {
+		ep.ConnAddr = jsoniter.Get(value, "tags", BrokerConnTag).ToString()
+	}
+	return ep, true
+}
+
+// MemoryBrokerDiscovery 内存中的注册表，用于测试
+type MemoryBrokerDiscovery struct {
+	mu        sync.Mutex
+	endpoints map[string]BrokerEndpoint
+	watchers  []func(BrokerEvent)
+	closed    bool
+}
+
+func NewMemoryBrokerDiscovery(eps ...BrokerEndpoint) *MemoryBrokerDiscovery {
+	d := &MemoryBrokerDiscovery{endpoints: make(map[string]BrokerEndpoint)}
+	for _, ep := range eps {
+		d.endpoints[ep.Addr] = ep
+	}
+	return d
+}
+
+func (d *MemoryBrokerDiscovery) Watch(ctx context.Context, onEvent func(BrokerEvent)) ([]BrokerEndpoint, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil, errors.New("discovery closed")
+	}
+
+	eps := make([]BrokerEndpoint, 0, len(d.endpoints))
+	for _, ep := range d.endpoints {
+		eps = append(eps, ep)
+	}
+	d.watchers = append(d.watchers, onEvent)
+	return eps, nil
+}
+
+// Put 注册broker，同步回调
+func (d *MemoryBrokerDiscovery) Put(ep BrokerEndpoint) {
+	d.mu.Lock()
+	d.endpoints[ep.Addr] = ep
+	watchers := d.watchers
+	d.mu.Unlock()
+
+	for _, w := range watchers {
+		w(BrokerEvent{BrokerEndpoint: ep})
+	}
+}
+
+// Delete 注销broker，同步回调
+func (d *MemoryBrokerDiscovery) Delete(addr string) {
+	d.mu.Lock()
+	delete(d.endpoints, addr)
+	watchers := d.watchers
+	d.mu.Unlock()
+
+	for _, w := range watchers {
+		w(BrokerEvent{BrokerEndpoint: BrokerEndpoint{Addr: addr}, Deleted: true})
+	}
+}
+
+func (d *MemoryBrokerDiscovery) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closed = true
+	d.watchers = nil
+	return nil
+}
@@ -0,0 +1,64 @@
+package infra
+
+import (
+	"context"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/brokerservice"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBrokerClient 按地址返回预设的错误
+type fakeBrokerClient struct {
+	addr string
+	errs *sync.Map
+}
+
+func (c *fakeBrokerClient) Deliver(ctx context.Context, req *api.DeliverRequest, callOptions ...callopt.Option) (*api.DeliverReply, error) {
+	if err, ok := c.errs.Load(c.addr); ok {
+		return nil, err.(error)
+	}
+	return &api.DeliverReply{MessageId: req.MessageId}, nil
+}
+
+// fixture infra测试共用的环境，broker客户端按地址返回errs中预设的错误
+type fixture struct {
+	resolver *BrokerClientResolver
+	errs     *sync.Map      //addr -> error
+	created  map[string]int //addr -> 创建客户端的次数
+}
+
+func newFixture(t *testing.T, d BrokerDiscovery) *fixture {
+	// resolver使用全局logger，只初始化一次
+	logger.Init(&logger.Config{Dir: filepath.Join(os.TempDir(), "im-infra-test")})
+
+	errs := &sync.Map{}
+	created := map[string]int{}
+
+	s := NewBrokerClientResolverWithDiscovery(&global.Config{BrokerClient: &global.BrokerClientConfig{
+		FailureThreshold: 2,
+		Cooldown:         time.Hour,
+		HealthInterval:   time.Hour,
+	}}, d)
+	s.newClient = func(addr string) (brokerservice.Client, error) {
+		created[addr]++
+		return &fakeBrokerClient{addr: addr, errs: errs}, nil
+	}
+	s.probe = func(ctx context.Context, addr string) error {
+		if err, ok := errs.Load(addr); ok {
+			return err.(error)
+		}
+		return nil
+	}
+
+	assert.NoError(t, s.Start(context.Background()))
+	t.Cleanup(func() { s.Stop(context.Background()) })
+	return &fixture{resolver: s, errs: errs, created: created}
+}
@@ -18,6 +18,8 @@ var (
 	Store    = TopicInfo{"msg-store", "msg-store-group"}
 	Offline  = TopicInfo{"msg-offline", "msg-offline-group"}
 	Push     = TopicInfo{"msg-push", "msg-push-group"}
+	PushDLQ  = TopicInfo{"msg-push-dlq", "msg-push-dlq-group"}
+	Presence = TopicInfo{"user-presence", "user-presence-group"}
 )
 
 type TopicInfo struct {
@@ -90,6 +92,25 @@ func NewKafkaProducer(g *global.Config, lc fx.Lifecycle) (*kafka.Writer, error)
 	return kw, nil
 }
 
+// NewKafkaConsumer 初始化指定topic的 Kafka 消费者，使用TopicInfo中的消费组。
+// 调用方负责在停止时关闭返回的 kafka.Reader。
+func NewKafkaConsumer(g *global.Config, ti TopicInfo) *kafka.Reader {
+
+	c := getOrDefaultKafkaConfig(g)
+
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        c.Brokers,
+		Topic:          ti.Topic,
+		GroupID:        ti.Group,
+		MinBytes:       1,
+		MaxBytes:       10 << 20,               // 10MB
+		MaxWait:        100 * time.Millisecond, // 最长等待时间
+		CommitInterval: time.Second,            // 定时提交offset
+		Logger:         newKafkaLogger(zapcore.DebugLevel),
+		ErrorLogger:    newKafkaLogger(zapcore.ErrorLevel),
+	})
+}
+
 type KafkaLogger struct {
 	*log.Logger
 	level zapcore.Level
@@ -4,6 +4,8 @@ import "fmt"
 
 const (
 	broker           = "im:broker:%s"
+	brokers          = "im:brokers"
+	brokerClients    = "im:broker:clients:%s"
 	userSig          = "im:%s:user:sig:%s"
 	user             = "im:%s:user:%d"
 	userLock         = "im:%s:user:%d:lock"
@@ -12,8 +14,31 @@ const (
 	userConn         = "im:%s:user:connect:%s"
 	userClients      = "im:%s:user:clients:%d"
 	userConnLock     = "im:%s:user:connect:%s:lock"
+	group            = "im:%s:group:%d"
 	groupMembers     = "im:%s:group:members:%d"
 	groupMembersLock = "im:%s:group:members:%d:lock"
+	groupRead        = "im:%s:group:read:%d"
+	groupReceipts    = "im:%s:group:receipts:%d"
+	receipt          = "im:%s:receipt:%s"
+	groupAdmins      = "im:%s:group:admins:%d"
+	groupAtAll       = "im:%s:group:atall:%d"
+	convMention      = "im:%s:conv:mention:%d:%s"
+	notify           = "im:%s:notify:%d"
+	message          = "im:%s:message:%s"
+	deviceKeys       = "im:%s:keys:devices:%d"
+	oneTimePreKeys   = "im:%s:keys:prekeys:%d:%s"
+	keyFetchLimit    = "im:%s:keys:fetch:%d"
+	clientMsg        = "im:%s:user:%d:clientmsg:%s"
+	offline          = "im:%s:offline:%d"
+	pushTokens       = "im:%s:push:tokens:%d"
+	pushBadge        = "im:%s:push:badge:%d"
+	pushPending      = "im:%s:push:pending:%d"
+	pushDue          = "im:push:due"
+	schedule         = "im:%s:schedule:%d"
+	scheduleDue      = "im:schedule:due"
+	scheduleSending  = "im:schedule:sending"
+	appRateLimit     = "im:%s:app:ratelimit"
+	offlineAttempt   = "im:%s:offline:attempt:%d"
 )
 
 func KeyUserSig(appId, sig string) string {
@@ -32,6 +57,16 @@ func KeyBroker(addr string) string {
 	return fmt.Sprintf(broker, addr)
 }
 
+// KeyBrokers 所有broker实例，score为租约到期时间
+func KeyBrokers() string {
+	return brokers
+}
+
+// KeyBrokerClients broker实例上登录的连接，宕机后按此清理用户的连接
+func KeyBrokerClients(brokerId string) string {
+	return fmt.Sprintf(brokerClients, brokerId)
+}
+
 func KeyUserConn(appId, ucLabel string) string {
 	return fmt.Sprintf(userConn, appId, ucLabel)
 }
@@ -44,6 +79,10 @@ func KeyUserConnLock(appId, ucLabel string) string {
 	return fmt.Sprintf(userConnLock, appId, ucLabel)
 }
 
+func KeyGroup(appId string, groupId int64) string {
+	return fmt.Sprintf(group, appId, groupId)
+}
+
 func KeyGroupMembers(appId string, groupId int64) string {
 	return fmt.Sprintf(groupMembers, appId, groupId)
 }
@@ -59,3 +98,91 @@ func KeyUser(appId string, userId int64) string {
 func KeyUserLock(appId string, userId int64) string {
 	return fmt.Sprintf(userLock, appId, userId)
 }
+
+func KeyGroupRead(appId string, groupId int64) string {
+	return fmt.Sprintf(groupRead, appId, groupId)
+}
+
+func KeyGroupReceipts(appId string, groupId int64) string {
+	return fmt.Sprintf(groupReceipts, appId, groupId)
+}
+
+func KeyReceipt(appId, messageId string) string {
+	return fmt.Sprintf(receipt, appId, messageId)
+}
+
+func KeyGroupAdmins(appId string, groupId int64) string {
+	return fmt.Sprintf(groupAdmins, appId, groupId)
+}
+
+func KeyGroupAtAll(appId string, groupId int64) string {
+	return fmt.Sprintf(groupAtAll, appId, groupId)
+}
+
+func KeyConvMention(appId string, userId int64, convId string) string {
+	return fmt.Sprintf(convMention, appId, userId, convId)
+}
+
+func KeyNotify(appId string, userId int64) string {
+	return fmt.Sprintf(notify, appId, userId)
+}
+
+func KeyMessage(appId, messageId string) string {
+	return fmt.Sprintf(message, appId, messageId)
+}
+
+func KeyDeviceKeys(appId string, userId int64) string {
+	return fmt.Sprintf(deviceKeys, appId, userId)
+}
+
+func KeyOneTimePreKeys(appId string, userId int64, deviceId string) string {
+	return fmt.Sprintf(oneTimePreKeys, appId, userId, deviceId)
+}
+
+func KeyFetchLimit(appId string, userId int64) string {
+	return fmt.Sprintf(keyFetchLimit, appId, userId)
+}
+
+func KeyClientMsg(appId string, userId int64, clientMsgId string) string {
+	return fmt.Sprintf(clientMsg, appId, userId, clientMsgId)
+}
+
+func KeyOffline(appId string, userId int64) string {
+	return fmt.Sprintf(offline, appId, userId)
+}
+
+func KeyPushTokens(appId string, userId int64) string {
+	return fmt.Sprintf(pushTokens, appId, userId)
+}
+
+func KeyPushBadge(appId string, userId int64) string {
+	return fmt.Sprintf(pushBadge, appId, userId)
+}
+
+func KeyPushPending(appId string, userId int64) string {
+	return fmt.Sprintf(pushPending, appId, userId)
+}
+
+func KeyPushDue() string {
+	return pushDue
+}
+
+func KeySchedule(appId string, userId int64) string {
+	return fmt.Sprintf(schedule, appId, userId)
+}
+
+func KeyScheduleDue() string {
+	return scheduleDue
+}
+
+func KeyScheduleSending() string {
+	return scheduleSending
+}
+
+func KeyOfflineAttempt(appId string, userId int64) string {
+	return fmt.Sprintf(offlineAttempt, appId, userId)
+}
+
+func KeyAppRateLimit(appId string) string {
+	return fmt.Sprintf(appRateLimit, appId)
+}
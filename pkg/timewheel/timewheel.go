@@ -261,6 +261,25 @@ func (tw *Timewheel) Put(task Task, index int) error {
 	return tw.slots[index].Enqueue(task)
 }
 
+// SubmitAt 按到期时间选择槽，已过期或不足一个tick时放入下一个槽；
+// 到期时间超过一圈时会提前执行，由调用方保证不超过 SlotTick*SlotCount
+func (tw *Timewheel) SubmitAt(task Task, due time.Time) (int, int64, error) {
+	tickMs := int64(tw.cfg.SlotTick / time.Millisecond)
+	currentMilli := atomic.LoadInt64(&tw.currentMilli)
+	if currentMilli == 0 {
+		currentMilli = time.Now().UnixMilli()
+	}
+
+	targetMilli := max(due.UnixMilli(), currentMilli+tickMs)
+	slot := int((targetMilli / tickMs) % int64(tw.cfg.SlotCount))
+
+	err := tw.slots[slot].Enqueue(task)
+	if tw.logger != nil && err != nil {
+		tw.logger.Error("failed to submit", zap.Error(err))
+	}
+	return slot, tw.slots[slot].Len(), err
+}
+
 func (tw *Timewheel) Submit(task Task) (int, int64, error) {
 
 	// 计算目标槽基于当前时间
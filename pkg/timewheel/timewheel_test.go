@@ -76,6 +76,26 @@ func TestNewTimewheel(t *testing.T) {
 	assert.False(t, tw.IsRunning.Load())
 }
 
+func TestSubmitAt(t *testing.T) {
+	tw, err := NewTimewheel(&Config{SlotTick: 100 * time.Millisecond, SlotCount: 30}, nil, nil)
+	assert.NoError(t, err)
+
+	now := time.UnixMilli(1_000_000)
+	tw.currentMilli = now.UnixMilli()
+	var noop task = func(now time.Time) TaskResult { return Break }
+
+	slot, _, err := tw.SubmitAt(noop, now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 20, slot)
+
+	// 已过期的放入下一个槽
+	slot, _, _ = tw.SubmitAt(noop, now.Add(-time.Second))
+	assert.Equal(t, 11, slot)
+
+	slot, _, _ = tw.SubmitAt(noop, now.Add(2500*time.Millisecond))
+	assert.Equal(t, 5, slot)
+}
+
 func TestMain(m *testing.M) {
 	redisConfig := global.RedisConfig{
 		Addr:    "127.0.0.1:6379",
@@ -0,0 +1,279 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefJanitorInterval 检查broker租约过期的默认间隔
+	DefJanitorInterval = 5 * time.Second
+
+	// DefJanitorBatch 每次处理的过期broker数量上限
+	DefJanitorBatch = 16
+
+	// janitorScanCount 每次HSCAN的连接数
+	janitorScanCount = 100
+
+	// PresenceReasonBrokerExpired broker租约过期，连接被清理
+	PresenceReasonBrokerExpired = "broker expired"
+)
+
+// purgeScript KEYS[1]为用户的在线连接，KEYS[2]为broker租约，ARGV为label、connectTime、clientAddr、broker、now；
+// 只删除同一个连接，用户已在其他broker用同一label重新登录时不删除。
+// broker已续约时返回-2，删除时返回用户剩余的连接数，否则返回-1
+var purgeScript = redis.NewScript(`
+	local lease = redis.call("ZSCORE", KEYS[2], ARGV[4])
+	if lease and tonumber(lease) >= tonumber(ARGV[5]) then
+		return -2
+	end
+	local v = redis.call("HGET", KEYS[1], ARGV[1])
+	if not v then
+		return -1
+	end
+	local c = cjson.decode(v)
+	if c.connectTime ~= tonumber(ARGV[2]) or c.clientAddr ~= ARGV[3] then
+		return -1
+	end
+	redis.call("HDEL", KEYS[1], ARGV[1])
+	return redis.call("HLEN", KEYS[1])
+`)
+
+// removeBrokerScript KEYS[1]为broker实例的连接，KEYS[2]为broker租约，ARGV为broker、now；
+// broker已续约时不删除，返回0
+var removeBrokerScript = redis.NewScript(`
+	local lease = redis.call("ZSCORE", KEYS[2], ARGV[1])
+	if lease and tonumber(lease) >= tonumber(ARGV[2]) then
+		return 0
+	end
+	redis.call("DEL", KEYS[1])
+	redis.call("ZREM", KEYS[2], ARGV[1])
+	return 1
+`)
+
+// purgeRenewed purgeScript的返回值，broker已续约
+const purgeRenewed = -2
+
+// errLeaseRenewed 清理过程中broker续约了
+var errLeaseRenewed = errors.New("broker lease renewed")
+
+// kafkaWriter 写入kafka，kafka.Writer实现，测试中替换
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// BrokerJanitor 清理租约过期的broker上登记的连接
+//
+// broker宕机后不会删除用户的在线连接，这些连接按broker实例登记在 im:broker:clients:{id}。
+// 租约过期后从每个用户的在线连接中删除，并发布离线事件。
+// 多个router同时清理时，只有删除成功的一方发布事件
+type BrokerJanitor struct {
+	cfg    *global.JanitorConfig
+	rds    *redis.Client
+	pub    kafkaWriter
+	cancel context.CancelFunc
+	logger *logger.Logger
+}
+
+func getOrDefaultJanitorConfig(g *global.Config) *global.JanitorConfig {
+	c := &global.JanitorConfig{}
+	if g != nil && g.RRS != nil && g.RRS.Janitor != nil {
+		*c = *g.RRS.Janitor
+	}
+
+	if c.Interval <= 0 {
+		c.Interval = DefJanitorInterval
+	}
+
+	if c.Batch <= 0 {
+		c.Batch = DefJanitorBatch
+	}
+
+	return c
+}
+
+func NewBrokerJanitor(g *global.Config, rds *redis.Client, kw *kafka.Writer, lc fx.Lifecycle) *BrokerJanitor {
+	s := newBrokerJanitor(getOrDefaultJanitorConfig(g), rds, kw)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return s.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+	return s
+}
+
+func newBrokerJanitor(c *global.JanitorConfig, rds *redis.Client, pub kafkaWriter) *BrokerJanitor {
+	return &BrokerJanitor{
+		cfg:    c,
+		rds:    rds,
+		pub:    pub,
+		logger: logger.Named("janitor"),
+	}
+}
+
+func (s *BrokerJanitor) Start(ctx context.Context) error {
+	c, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.Done():
+				return
+			case <-ticker.C:
+				// 租约按redis的时间写入，清理时使用同一个时钟
+				now, err := s.rds.Time(c).Result()
+				if err != nil {
+					s.logger.Error("failed to get redis time", zap.Error(err))
+					continue
+				}
+				if _, err := s.Sweep(c, now); err != nil {
+					s.logger.Error("failed to sweep brokers", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *BrokerJanitor) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
+
+// Sweep 清理租约在now之前过期的broker，返回清理的连接数
+func (s *BrokerJanitor) Sweep(ctx context.Context, now time.Time) (int, error) {
+	ids, err := s.rds.ZRangeByScore(ctx, infra.KeyBrokers(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   "(" + strconv.FormatInt(now.UnixMilli(), 10),
+		Count: int64(s.cfg.Batch),
+	}).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, id := range ids {
+		n, purged, err := s.purge(ctx, id, now)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if purged {
+			s.logger.Info("expired broker purged", zap.String("broker", id), zap.Int("clients", n))
+		} else {
+			s.logger.Warn("broker lease renewed, purge aborted", zap.String("broker", id), zap.Int("clients", n))
+		}
+	}
+	return total, nil
+}
+
+// purge 删除一个broker实例的所有连接，全部处理后才删除实例，失败时下次重试。
+// 每次删除前检查租约，broker在清理过程中续约时停止，不删除实例，返回false
+func (s *BrokerJanitor) purge(ctx context.Context, id string, now time.Time) (int, bool, error) {
+	key := infra.KeyBrokerClients(id)
+
+	total := 0
+	renewed := false
+	var cursor uint64
+	for !renewed {
+		kvs, next, err := s.rds.HScan(ctx, key, cursor, "*", janitorScanCount).Result()
+		if err != nil {
+			return total, false, err
+		}
+
+		events := make([]kafka.Message, 0, len(kvs)/2)
+		for i := 0; i+1 < len(kvs); i += 2 {
+			ev, err := s.purgeClient(ctx, id, kvs[i], kvs[i+1], now)
+			if err == errLeaseRenewed {
+				renewed = true
+				break
+			}
+			if err != nil {
+				return total, false, err
+			}
+			if ev != nil {
+				events = append(events, *ev)
+			}
+		}
+
+		if len(events) > 0 && s.pub != nil {
+			if err := s.pub.WriteMessages(ctx, events...); err != nil {
+				s.logger.Error("failed to publish presence", zap.String("broker", id), zap.Error(err))
+			}
+		}
+		total += len(events)
+
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+	if renewed {
+		return total, false, nil
+	}
+
+	removed, err := removeBrokerScript.Run(ctx, s.rds, []string{key, infra.KeyBrokers()}, id, now.UnixMilli()).Int()
+	return total, removed == 1, err
+}
+
+// purgeClient 从用户的在线连接中删除，删除成功时返回离线事件
+func (s *BrokerJanitor) purgeClient(ctx context.Context, id, label, value string, now time.Time) (*kafka.Message, error) {
+	var client vo.UserClient
+	if err := json.Unmarshal([]byte(value), &client); err != nil {
+		s.logger.Error("invalid broker client", zap.String("label", label), zap.Error(err))
+		return nil, nil
+	}
+
+	key := infra.KeyUserClients(client.AppId, client.UserId)
+	keys := []string{key, infra.KeyBrokers()}
+	left, err := purgeScript.Run(ctx, s.rds, keys, label, client.ConnectTime, client.ClientAddr, id, now.UnixMilli()).Int64()
+	if err != nil {
+		return nil, err
+	}
+	if left == purgeRenewed {
+		return nil, errLeaseRenewed
+	}
+	if left < 0 {
+		return nil, nil
+	}
+
+	ev, err := json.Marshal(&vo.PresenceEvent{
+		AppId:   client.AppId,
+		UserId:  client.UserId,
+		Label:   label,
+		OS:      client.OS,
+		Status:  vo.PresenceOffline,
+		Reason:  PresenceReasonBrokerExpired,
+		Clients: left,
+		Time:    now.UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafka.Message{
+		Topic: infra.Presence.Topic,
+		Key:   []byte(strconv.FormatInt(client.UserId, 10)),
+		Value: ev,
+	}, nil
+}
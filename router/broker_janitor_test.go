@@ -0,0 +1,131 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// fakePresence 记录发布的在线状态事件
+type fakePresence struct {
+	events []vo.PresenceEvent
+}
+
+func (p *fakePresence) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	for _, km := range msgs {
+		var ev vo.PresenceEvent
+		if err := json.Unmarshal(km.Value, &ev); err != nil {
+			return err
+		}
+		p.events = append(p.events, ev)
+	}
+	return nil
+}
+
+// storeClient 按broker的方式登记连接：用户的在线连接和broker实例的连接
+func storeClient(tb testing.TB, rds *redis.Client, brokerId string, c vo.UserClient) {
+	ctx := context.Background()
+	js, _ := json.Marshal(c)
+	assert.NoError(tb, rds.HSet(ctx, infra.KeyUserClients(c.AppId, c.UserId), c.Label, js).Err())
+	if brokerId != "" {
+		assert.NoError(tb, rds.HSet(ctx, infra.KeyBrokerClients(brokerId), c.Label, js).Err())
+	}
+}
+
+func TestJanitorSweep(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	pub := &fakePresence{}
+	s := newBrokerJanitor(getOrDefaultJanitorConfig(nil), f.rds, pub)
+	now := time.UnixMilli(10000)
+
+	dead, alive := "10.0.0.1:5075@1", "10.0.0.2:5075@1"
+	f.rds.ZAdd(ctx, infra.KeyBrokers(), &redis.Z{Score: 9000, Member: dead}, &redis.Z{Score: 20000, Member: alive})
+
+	// 用户1在宕机的broker上有两个连接，其中web端已在另一个broker重新登录
+	storeClient(t, f.rds, dead, vo.UserClient{AppId: testAppId, UserId: 1, OS: "iOS", ClientAddr: "1.1.1.1:1", ConnectTime: 100, Label: testAppId + "#1#iOS"})
+	storeClient(t, f.rds, dead, vo.UserClient{AppId: testAppId, UserId: 1, OS: "web", ClientAddr: "1.1.1.1:2", ConnectTime: 100, Label: testAppId + "#1#web"})
+	storeClient(t, f.rds, alive, vo.UserClient{AppId: testAppId, UserId: 1, OS: "web", ClientAddr: "1.1.1.1:3", ConnectTime: 200, Label: testAppId + "#1#web"})
+	// 用户2只在宕机的broker上
+	storeClient(t, f.rds, dead, vo.UserClient{AppId: testAppId, UserId: 2, OS: "android", ClientAddr: "2.2.2.2:1", ConnectTime: 100, Label: testAppId + "#2#android"})
+
+	n, err := s.Sweep(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	clients, err := f.rds.HGetAll(ctx, infra.KeyUserClients(testAppId, 1)).Result()
+	assert.NoError(t, err)
+	assert.Len(t, clients, 1)
+	assert.Contains(t, clients[testAppId+"#1#web"], "1.1.1.1:3")
+	assert.False(t, f.mr.Exists(infra.KeyUserClients(testAppId, 2)))
+
+	if assert.Len(t, pub.events, 2) {
+		byUser := map[int64]vo.PresenceEvent{}
+		for _, ev := range pub.events {
+			byUser[ev.UserId] = ev
+		}
+		assert.Equal(t, vo.PresenceEvent{AppId: testAppId, UserId: 1, Label: testAppId + "#1#iOS", OS: "iOS", Status: vo.PresenceOffline, Reason: PresenceReasonBrokerExpired, Clients: 1, Time: 10000}, byUser[1])
+		assert.Equal(t, int64(0), byUser[2].Clients)
+	}
+
+	// 宕机的实例已删除，存活的不受影响
+	ids, _ := f.rds.ZRange(ctx, infra.KeyBrokers(), 0, -1).Result()
+	assert.Equal(t, []string{alive}, ids)
+	assert.False(t, f.mr.Exists(infra.KeyBrokerClients(dead)))
+
+	// 再次清理不会重复发布
+	n, err = s.Sweep(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Len(t, pub.events, 2)
+}
+
+// renewingPresence 发布事件时broker续约，模拟清理过程中恢复的broker
+type renewingPresence struct {
+	fakePresence
+	rds    *redis.Client
+	broker string
+}
+
+func (p *renewingPresence) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	p.rds.ZAdd(ctx, infra.KeyBrokers(), &redis.Z{Score: 20000, Member: p.broker})
+	return p.fakePresence.WriteMessages(ctx, msgs...)
+}
+
+func TestJanitorLeaseRenewed(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	now := time.UnixMilli(10000)
+	broker := "10.0.0.1:5075@1"
+
+	storeClient(t, f.rds, broker, vo.UserClient{AppId: testAppId, UserId: 1, OS: "iOS", ClientAddr: "1.1.1.1:1", ConnectTime: 100, Label: testAppId + "#1#iOS"})
+	storeClient(t, f.rds, broker, vo.UserClient{AppId: testAppId, UserId: 2, OS: "iOS", ClientAddr: "2.2.2.2:1", ConnectTime: 100, Label: testAppId + "#2#iOS"})
+
+	// 开始清理前已续约，不删除任何连接
+	f.rds.ZAdd(ctx, infra.KeyBrokers(), &redis.Z{Score: 20000, Member: broker})
+	s := newBrokerJanitor(getOrDefaultJanitorConfig(nil), f.rds, &fakePresence{})
+	n, purged, err := s.purge(ctx, broker, now)
+	assert.NoError(t, err)
+	assert.False(t, purged)
+	assert.Zero(t, n)
+	assert.True(t, f.mr.Exists(infra.KeyUserClients(testAppId, 1)))
+	assert.True(t, f.mr.Exists(infra.KeyUserClients(testAppId, 2)))
+
+	// 清理过程中续约，不删除实例和连接索引
+	f.rds.ZAdd(ctx, infra.KeyBrokers(), &redis.Z{Score: 9000, Member: broker})
+	s = newBrokerJanitor(getOrDefaultJanitorConfig(nil), f.rds, &renewingPresence{rds: f.rds, broker: broker})
+	_, err = s.Sweep(ctx, now)
+	assert.NoError(t, err)
+
+	score, err := f.rds.ZScore(ctx, infra.KeyBrokers(), broker).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(20000), score)
+	assert.True(t, f.mr.Exists(infra.KeyBrokerClients(broker)))
+}
@@ -0,0 +1,374 @@
+package router
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/router/vo"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// quietHoursLayout 免打扰时段的时间格式
+	quietHoursLayout = "15:04"
+
+	notifyQuietField   = "quiet"
+	notifyVersionField = "version"
+)
+
+const (
+	// maxAtAll 每个群保留的@所有人标记数量
+	maxAtAll = 100
+
+	// maxMention 每个成员每个会话保留的@标记数量
+	maxMention = 100
+
+	// mentionExpire 成员的@标记在最后一次被@后保留的时间
+	mentionExpire = 30 * 24 * time.Hour
+)
+
+// ConvService 会话状态，包括"有人@我"标记和通知设置
+//
+// 普通@按成员和会话记录在 conv:mention zset 中（score 为 sequence），已读时清除；
+// @所有人只记录在群上（group:atall zset），查询时和成员的已读sequence比较，避免大群逐个写入。
+// 会话的通知设置（静音即 Conv.IsDisturb）保存在 im_conv 中；
+// 免打扰时段和版本号按用户保存在 notify hash 中
+type ConvService struct {
+	rds   *redis.Client
+	gs    *GroupService
+	store convStore
+}
+
+func NewConvService(rds *redis.Client, db *gorm.DB, gs *GroupService, lc fx.Lifecycle) *ConvService {
+	return &ConvService{
+		rds:   rds,
+		gs:    gs,
+		store: &gormConvStore{db: db},
+	}
+}
+
+// CheckMention 校验群消息中的@，@所有人仅限群管理员，@的不是群成员时从消息中去掉；
+// 在消息保存前调用，保存的@列表为过滤后的
+func (s *ConvService) CheckMention(ctx context.Context, m *api.Message) error {
+	if len(m.At) == 0 || !m.IsToGroup() {
+		return nil
+	}
+
+	if m.IsAtAll() {
+		ok, err := s.gs.IsGroupAdmin(ctx, m.AppId, m.GroupId, m.UserId)
+		if err != nil {
+			return errors.MentionErr.SetDetail(err.Error())
+		}
+		if !ok {
+			return errors.MentionForbidden
+		}
+		return nil
+	}
+
+	userIds := make([]int64, 0, len(m.At))
+	for _, at := range m.At {
+		if at.UserId > 0 && at.UserId != m.UserId {
+			userIds = append(userIds, at.UserId)
+		}
+	}
+
+	members, err := s.gs.FilterGroupMembers(ctx, m.AppId, m.GroupId, userIds)
+	if err != nil {
+		return errors.MentionErr.SetDetail(err.Error())
+	}
+
+	at := make([]*api.At, 0, len(members))
+	for _, a := range m.At {
+		if slices.Contains(members, a.UserId) {
+			at = append(at, a)
+		}
+	}
+	m.At = at
+	return nil
+}
+
+// Mention 记录已校验的@，消息保存后调用。每个成员每个会话保留最近maxMention条，
+// 长期不读时整个标记过期
+func (s *ConvService) Mention(ctx context.Context, m *api.Message) error {
+	if len(m.At) == 0 || !m.IsToGroup() {
+		return nil
+	}
+
+	if m.IsAtAll() {
+		key := infra.KeyGroupAtAll(m.AppId, m.GroupId)
+		pipe := s.rds.TxPipeline()
+		pipe.ZAdd(ctx, key, &redis.Z{Score: float64(m.Sequence), Member: m.Sequence})
+		pipe.ZRemRangeByRank(ctx, key, 0, -maxAtAll-1)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return errors.MentionErr.SetDetail(err.Error())
+		}
+		return nil
+	}
+
+	pipe := s.rds.Pipeline()
+	for _, at := range m.At {
+		if at.UserId <= 0 || at.UserId == m.UserId {
+			continue
+		}
+		key := infra.KeyConvMention(m.AppId, at.UserId, m.ConvId)
+		pipe.ZAdd(ctx, key, &redis.Z{Score: float64(m.Sequence), Member: m.Sequence})
+		pipe.ZRemRangeByRank(ctx, key, 0, -maxMention-1)
+		pipe.Expire(ctx, key, mentionExpire)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.MentionErr.SetDetail(err.Error())
+	}
+	return nil
+}
+
+// GetMention 返回会话中最早一条未读@的sequence，没有时返回0
+//
+// 普通@已读时清除；@所有人和成员在群里的已读sequence比较
+func (s *ConvService) GetMention(ctx context.Context, appId string, userId int64, convId string, groupId int64) (int64, error) {
+	mention, err := s.rds.ZRange(ctx, infra.KeyConvMention(appId, userId, convId), 0, 0).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	var seq int64
+	if len(mention) > 0 {
+		seq, _ = strconv.ParseInt(mention[0], 10, 64)
+	}
+	if groupId == 0 {
+		return seq, nil
+	}
+
+	read, err := s.rds.HGet(ctx, infra.KeyGroupRead(appId, groupId), strconv.FormatInt(userId, 10)).Int64()
+	if err != nil && err != redis.Nil {
+		return 0, err
+	}
+
+	all, err := s.rds.ZRangeByScore(ctx, infra.KeyGroupAtAll(appId, groupId), &redis.ZRangeBy{
+		Min:   "(" + strconv.FormatInt(read, 10),
+		Max:   "+inf",
+		Count: 1,
+	}).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(all) > 0 {
+		a, _ := strconv.ParseInt(all[0], 10, 64)
+		if seq == 0 || a < seq {
+			seq = a
+		}
+	}
+	return seq, nil
+}
+
+// ClearMention 会话已读到sequence后清除不晚于sequence的@标记
+func (s *ConvService) ClearMention(ctx context.Context, appId string, userId int64, convId string, sequence int64) error {
+	key := infra.KeyConvMention(appId, userId, convId)
+	return s.rds.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(sequence, 10)).Err()
+}
+
+// SetDisturb 设置会话静音，不设截止时间
+func (s *ConvService) SetDisturb(ctx context.Context, appId string, userId int64, convId string, disturb bool) error {
+	_, _, err := s.SetNotify(ctx, &api.NotifySettingRequest{
+		AppId:  appId,
+		UserId: userId,
+		Convs:  []*api.ConvNotify{{ConvId: convId, Mute: disturb}},
+	})
+	return err
+}
+
+// IsDisturb 会话当前是否静音
+func (s *ConvService) IsDisturb(ctx context.Context, appId string, userId int64, convId string) (bool, error) {
+	_, conv, err := s.notifyOf(ctx, appId, userId, convId)
+	if err != nil {
+		return false, err
+	}
+	return isMuted(conv, time.Now()), nil
+}
+
+// ShouldNotify 投递失败后是否需要通知（推送）
+func (s *ConvService) ShouldNotify(ctx context.Context, f vo.DeliverFail) (bool, error) {
+	_, notify, err := s.Decide(ctx, f, time.Now())
+	return notify, err
+}
+
+// Decide 按接收者的通知设置判断消息是否计入未读数，以及是否推送
+//
+// 静音的会话和只在@时通知的群，只有被@的消息计入未读数；被@时忽略静音。
+// 免打扰时段内计入未读数但不推送
+func (s *ConvService) Decide(ctx context.Context, f vo.DeliverFail, now time.Time) (bool, bool, error) {
+	quiet, conv, err := s.notifyOf(ctx, f.M.AppId, f.UserId, f.M.ConvId)
+	if err != nil {
+		return false, false, err
+	}
+
+	unread := f.Mentioned
+	if !unread {
+		unread = !isMuted(conv, now) && !(conv.GetMentionOnly() && f.M.IsToGroup())
+	}
+	return unread, unread && !isQuiet(quiet, now), nil
+}
+
+// SetNotify 修改通知设置，返回修改后的设置以及是否有修改
+func (s *ConvService) SetNotify(ctx context.Context, req *api.NotifySettingRequest) (*api.NotifySettings, bool, error) {
+	if err := validateNotify(req); err != nil {
+		return nil, false, err
+	}
+
+	changed := req.QuietHours != nil || len(req.Convs) > 0
+	if changed {
+		if len(req.Convs) > 0 {
+			if err := s.store.SaveNotify(ctx, req.AppId, req.UserId, req.Convs); err != nil {
+				return nil, false, errors.NotifyErr.SetDetail(err.Error())
+			}
+		}
+
+		key := infra.KeyNotify(req.AppId, req.UserId)
+		pipe := s.rds.TxPipeline()
+		if q := req.QuietHours; q != nil {
+			if q.Start == "" && q.End == "" {
+				pipe.HDel(ctx, key, notifyQuietField)
+			} else {
+				bs, err := proto.Marshal(q)
+				if err != nil {
+					return nil, false, errors.NotifyErr.SetDetail(err.Error())
+				}
+				pipe.HSet(ctx, key, notifyQuietField, bs)
+			}
+		}
+		pipe.HIncrBy(ctx, key, notifyVersionField, 1)
+
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, false, errors.NotifyErr.SetDetail(err.Error())
+		}
+	}
+
+	settings, err := s.GetNotify(ctx, req.AppId, req.UserId)
+	if err != nil {
+		return nil, false, err
+	}
+	return settings, changed, nil
+}
+
+// GetNotify 用户的通知设置，会话按convId排序
+func (s *ConvService) GetNotify(ctx context.Context, appId string, userId int64) (*api.NotifySettings, error) {
+	values, err := s.rds.HMGet(ctx, infra.KeyNotify(appId, userId), notifyQuietField, notifyVersionField).Result()
+	if err != nil {
+		return nil, errors.NotifyErr.SetDetail(err.Error())
+	}
+
+	settings := &api.NotifySettings{}
+	if settings.QuietHours, err = quietOf(values[0]); err != nil {
+		return nil, errors.NotifyErr.SetDetail(err.Error())
+	}
+	if v, ok := values[1].(string); ok {
+		settings.Version, _ = strconv.ParseInt(v, 10, 64)
+	}
+
+	if settings.Convs, err = s.store.ListNotify(ctx, appId, userId); err != nil {
+		return nil, errors.NotifyErr.SetDetail(err.Error())
+	}
+
+	sort.Slice(settings.Convs, func(i, j int) bool {
+		return settings.Convs[i].ConvId < settings.Convs[j].ConvId
+	})
+	return settings, nil
+}
+
+// notifyOf 免打扰时段和会话的通知设置，没有设置时为nil
+func (s *ConvService) notifyOf(ctx context.Context, appId string, userId int64, convId string) (*api.QuietHours, *api.ConvNotify, error) {
+	v, err := s.rds.HGet(ctx, infra.KeyNotify(appId, userId), notifyQuietField).Result()
+	if err != nil && err != redis.Nil {
+		return nil, nil, err
+	}
+
+	quiet, err := quietOf(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conv, err := s.store.Notify(ctx, appId, userId, convId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quiet, conv, nil
+}
+
+// quietOf 解析保存的免打扰时段，没有设置时为nil
+func quietOf(v interface{}) (*api.QuietHours, error) {
+	bs, ok := v.(string)
+	if !ok || bs == "" {
+		return nil, nil
+	}
+
+	q := &api.QuietHours{}
+	if err := proto.Unmarshal([]byte(bs), q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func validateNotify(req *api.NotifySettingRequest) error {
+	if q := req.QuietHours; q != nil && (q.Start != "" || q.End != "") {
+		if _, err := time.Parse(quietHoursLayout, q.Start); err != nil {
+			return errors.NotifyInvalid.SetDetail("invalid quiet hours start " + q.Start)
+		}
+		if _, err := time.Parse(quietHoursLayout, q.End); err != nil {
+			return errors.NotifyInvalid.SetDetail("invalid quiet hours end " + q.End)
+		}
+		if _, err := time.LoadLocation(q.Timezone); err != nil {
+			return errors.NotifyInvalid.SetDetail("invalid timezone " + q.Timezone)
+		}
+	}
+
+	for _, c := range req.Convs {
+		if c.GetConvId() == "" {
+			return errors.NotifyInvalid.SetDetail("convId is required")
+		}
+		if c.MuteUntil < 0 {
+			return errors.NotifyInvalid.SetDetail("muteUntil is negative")
+		}
+	}
+	return nil
+}
+
+// isMuted 静音且没有到截止时间
+func isMuted(c *api.ConvNotify, now time.Time) bool {
+	return c.GetMute() && (c.GetMuteUntil() == 0 || now.UnixMilli() < c.GetMuteUntil())
+}
+
+// isQuiet now是否在免打扰时段内，按设置的时区计算当地时间，start等于end时不生效
+func isQuiet(q *api.QuietHours, now time.Time) bool {
+	if q == nil {
+		return false
+	}
+
+	start, err1 := time.Parse(quietHoursLayout, q.Start)
+	end, err2 := time.Parse(quietHoursLayout, q.End)
+	loc, err3 := time.LoadLocation(q.Timezone)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return false
+	}
+
+	local := now.In(loc)
+	cur := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	switch {
+	case from < to:
+		return cur >= from && cur < to
+	case from > to:
+		return cur >= from || cur < to
+	default:
+		return false
+	}
+}
@@ -0,0 +1,297 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	entity "github.com/magicnana999/im/entities"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memConvStore 内存中的会话通知设置，按 appId/userId/convId 保存
+type memConvStore map[string]*api.ConvNotify
+
+func (s memConvStore) Notify(ctx context.Context, appId string, userId int64, convId string) (*api.ConvNotify, error) {
+	return s[fmt.Sprintf("%s/%d/%s", appId, userId, convId)], nil
+}
+
+func (s memConvStore) ListNotify(ctx context.Context, appId string, userId int64) ([]*api.ConvNotify, error) {
+	prefix := fmt.Sprintf("%s/%d/", appId, userId)
+	var ret []*api.ConvNotify
+	for k, c := range s {
+		if strings.HasPrefix(k, prefix) {
+			ret = append(ret, c)
+		}
+	}
+	return ret, nil
+}
+
+func (s memConvStore) SaveNotify(ctx context.Context, appId string, userId int64, convs []*api.ConvNotify) error {
+	for _, c := range convs {
+		key := fmt.Sprintf("%s/%d/%s", appId, userId, c.ConvId)
+		if c.Mute || c.MentionOnly {
+			s[key] = convNotifyOf(&entity.Conv{ConvId: c.ConvId, IsDisturb: boolInt(c.Mute), MuteUntil: c.MuteUntil, MentionOnly: boolInt(c.MentionOnly)})
+		} else {
+			delete(s, key)
+		}
+	}
+	return nil
+}
+
+// convService 用户1为群管理员
+func (f *fixture) convService() *ConvService {
+	f.rds.SAdd(context.Background(), infra.KeyGroupAdmins(testAppId, testGroupId), 1)
+	return &ConvService{rds: f.rds, gs: &GroupService{rds: f.rds}, store: make(memConvStore)}
+}
+
+func newMentionMessage(userId, sequence int64, at ...int64) *api.Message {
+	m := api.NewMessage(userId, 0, testGroupId, sequence, testAppId, "conv", &api.Text{Text: "hi"})
+	for _, u := range at {
+		m.At = append(m.At, &api.At{UserId: u})
+	}
+	return m
+}
+
+func TestMention(t *testing.T) {
+	ctx := context.Background()
+	cs := newFixture(t).group(10, 1).convService()
+
+	assert.NoError(t, cs.Mention(ctx, newMentionMessage(2, 5, 3)))
+	assert.NoError(t, cs.Mention(ctx, newMentionMessage(2, 8, 3)))
+
+	// 返回最早一条未读@
+	seq, err := cs.GetMention(ctx, testAppId, 3, "conv", testGroupId)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), seq)
+
+	seq, err = cs.GetMention(ctx, testAppId, 4, "conv", testGroupId)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), seq)
+
+	assert.NoError(t, cs.ClearMention(ctx, testAppId, 3, "conv", 4))
+	seq, _ = cs.GetMention(ctx, testAppId, 3, "conv", testGroupId)
+	assert.Equal(t, int64(5), seq)
+
+	// 已读越过较早的@后，仍保留较晚的@
+	assert.NoError(t, cs.ClearMention(ctx, testAppId, 3, "conv", 6))
+	seq, _ = cs.GetMention(ctx, testAppId, 3, "conv", testGroupId)
+	assert.Equal(t, int64(8), seq)
+
+	assert.NoError(t, cs.ClearMention(ctx, testAppId, 3, "conv", 8))
+	seq, _ = cs.GetMention(ctx, testAppId, 3, "conv", testGroupId)
+	assert.Equal(t, int64(0), seq)
+}
+
+func TestMentionNonMember(t *testing.T) {
+	ctx := context.Background()
+	cs := newFixture(t).group(10, 1).convService()
+
+	m := newMentionMessage(2, 5, 3, 99)
+	assert.NoError(t, cs.CheckMention(ctx, m))
+	assert.NoError(t, cs.Mention(ctx, m))
+	assert.Len(t, m.At, 1)
+	assert.True(t, m.IsAt(3))
+	assert.False(t, m.IsAt(99))
+
+	seq, err := cs.GetMention(ctx, testAppId, 99, "conv", testGroupId)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), seq)
+}
+
+func TestMentionCap(t *testing.T) {
+	ctx := context.Background()
+	cs := newFixture(t).group(10, 1).convService()
+
+	// 长期不读时只保留最近的标记，并设置过期
+	for seq := int64(1); seq <= maxMention+5; seq++ {
+		assert.NoError(t, cs.Mention(ctx, newMentionMessage(2, seq, 3)))
+	}
+
+	key := infra.KeyConvMention(testAppId, 3, "conv")
+	assert.Equal(t, int64(maxMention), cs.rds.ZCard(ctx, key).Val())
+	assert.True(t, cs.rds.TTL(ctx, key).Val() > 0)
+
+	seq, err := cs.GetMention(ctx, testAppId, 3, "conv", testGroupId)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), seq)
+}
+
+func TestMentionAll(t *testing.T) {
+	ctx := context.Background()
+	cs := newFixture(t).group(10, 1).convService()
+
+	err := cs.CheckMention(ctx, newMentionMessage(2, 3, api.AtAll))
+	assert.ErrorIs(t, err, errors.MentionForbidden)
+
+	assert.NoError(t, cs.CheckMention(ctx, newMentionMessage(1, 3, api.AtAll)))
+	assert.NoError(t, cs.Mention(ctx, newMentionMessage(1, 3, api.AtAll)))
+	assert.NoError(t, cs.Mention(ctx, newMentionMessage(1, 6, api.AtAll)))
+	seq, err := cs.GetMention(ctx, testAppId, 7, "conv", testGroupId)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), seq)
+
+	// 较新的@所有人不覆盖未读的较早标记，已读越过后返回下一条
+	cs.rds.HSet(ctx, infra.KeyGroupRead(testAppId, testGroupId), "7", 3)
+	seq, _ = cs.GetMention(ctx, testAppId, 7, "conv", testGroupId)
+	assert.Equal(t, int64(6), seq)
+
+	cs.rds.HSet(ctx, infra.KeyGroupRead(testAppId, testGroupId), "7", 6)
+	seq, _ = cs.GetMention(ctx, testAppId, 7, "conv", testGroupId)
+	assert.Equal(t, int64(0), seq)
+}
+
+func TestShouldNotify(t *testing.T) {
+	ctx := context.Background()
+	cs := newFixture(t).group(10, 1).convService()
+	m := newMentionMessage(1, 1, 3)
+
+	assert.NoError(t, cs.SetDisturb(ctx, testAppId, 3, "conv", true))
+	assert.NoError(t, cs.SetDisturb(ctx, testAppId, 4, "conv", true))
+
+	ok, err := cs.ShouldNotify(ctx, vo.DeliverFail{M: m, UserId: 3, Mentioned: m.IsAt(3)})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = cs.ShouldNotify(ctx, vo.DeliverFail{M: m, UserId: 4, Mentioned: m.IsAt(4)})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = cs.ShouldNotify(ctx, vo.DeliverFail{M: m, UserId: 5})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSetNotify(t *testing.T) {
+	ctx := context.Background()
+	cs := newFixture(t).group(10, 1).convService()
+
+	_, _, err := cs.SetNotify(ctx, &api.NotifySettingRequest{AppId: testAppId, UserId: 3,
+		QuietHours: &api.QuietHours{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"}})
+	assert.Equal(t, errors.NotifyInvalid.Code, errext.Format(err).Code)
+
+	settings, changed, err := cs.SetNotify(ctx, &api.NotifySettingRequest{
+		AppId:      testAppId,
+		UserId:     3,
+		QuietHours: &api.QuietHours{Start: "22:00", End: "07:00", Timezone: "Asia/Shanghai"},
+		Convs: []*api.ConvNotify{
+			{ConvId: "b", MentionOnly: true},
+			{ConvId: "a", Mute: true, MuteUntil: 1000},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(1), settings.Version)
+	assert.Equal(t, "Asia/Shanghai", settings.QuietHours.Timezone)
+	assert.Len(t, settings.Convs, 2)
+	assert.Equal(t, "a", settings.Convs[0].ConvId)
+
+	// 都为false时删除会话设置，quietHours为空时不修改
+	settings, _, err = cs.SetNotify(ctx, &api.NotifySettingRequest{AppId: testAppId, UserId: 3,
+		Convs: []*api.ConvNotify{{ConvId: "a"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), settings.Version)
+	assert.NotNil(t, settings.QuietHours)
+	assert.Len(t, settings.Convs, 1)
+
+	// start和end都为空时清除免打扰时段
+	settings, changed, err = cs.SetNotify(ctx, &api.NotifySettingRequest{AppId: testAppId, UserId: 3,
+		QuietHours: &api.QuietHours{}})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Nil(t, settings.QuietHours)
+
+	// 只查询时不修改版本
+	settings, changed, err = cs.SetNotify(ctx, &api.NotifySettingRequest{AppId: testAppId, UserId: 3})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, int64(3), settings.Version)
+}
+
+func TestDecide(t *testing.T) {
+	ctx := context.Background()
+	cs := newFixture(t).group(10, 1).convService()
+
+	// 北京时间23:30
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	_, _, err := cs.SetNotify(ctx, &api.NotifySettingRequest{AppId: testAppId, UserId: 3,
+		Convs: []*api.ConvNotify{
+			{ConvId: "muted", Mute: true},
+			{ConvId: "expired", Mute: true, MuteUntil: now.Add(-time.Minute).UnixMilli()},
+			{ConvId: "mention", MentionOnly: true},
+		}})
+	assert.NoError(t, err)
+
+	group := func(convId string, at ...int64) vo.DeliverFail {
+		m := newMentionMessage(1, 1, at...)
+		m.ConvId = convId
+		return vo.DeliverFail{M: m, UserId: 3, Mentioned: m.IsAt(3)}
+	}
+
+	cases := []struct {
+		f      vo.DeliverFail
+		unread bool
+	}{
+		{group("muted"), false},
+		{group("muted", 3), true},
+		{group("expired"), true},
+		{group("mention"), false},
+		{group("mention", 3), true},
+		{group("other"), true},
+	}
+	for i, c := range cases {
+		unread, notify, err := cs.Decide(ctx, c.f, now)
+		assert.NoError(t, err)
+		assert.Equal(t, c.unread, unread, i)
+		assert.Equal(t, c.unread, notify, i)
+	}
+
+	// 免打扰时段内计入未读数但不推送
+	_, _, err = cs.SetNotify(ctx, &api.NotifySettingRequest{AppId: testAppId, UserId: 3,
+		QuietHours: &api.QuietHours{Start: "22:00", End: "07:00", Timezone: "Asia/Shanghai"}})
+	assert.NoError(t, err)
+	unread, notify, err := cs.Decide(ctx, group("other"), now)
+	assert.NoError(t, err)
+	assert.True(t, unread)
+	assert.False(t, notify)
+
+	unread, notify, err = cs.Decide(ctx, group("other"), now.Add(9*time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, unread)
+	assert.True(t, notify)
+}
+
+func TestIsQuiet(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 19, h, m, 0, 0, time.UTC) }
+
+	night := &api.QuietHours{Start: "22:00", End: "07:00"}
+	assert.True(t, isQuiet(night, at(23, 0)))
+	assert.True(t, isQuiet(night, at(6, 59)))
+	assert.False(t, isQuiet(night, at(7, 0)))
+
+	noon := &api.QuietHours{Start: "12:00", End: "14:00", Timezone: "America/New_York"}
+	assert.True(t, isQuiet(noon, at(16, 30)))
+	assert.False(t, isQuiet(noon, at(12, 30)))
+
+	assert.False(t, isQuiet(&api.QuietHours{Start: "08:00", End: "08:00"}, at(8, 0)))
+	assert.False(t, isQuiet(nil, at(8, 0)))
+}
+
+func TestConvNotifyOf(t *testing.T) {
+	assert.Nil(t, convNotifyOf(&entity.Conv{ConvId: "a"}))
+
+	c := convNotifyOf(&entity.Conv{ConvId: "a", IsDisturb: 1, MuteUntil: 1000})
+	assert.True(t, c.Mute)
+	assert.False(t, c.MentionOnly)
+	assert.Equal(t, int64(1000), c.MuteUntil)
+
+	c = convNotifyOf(&entity.Conv{ConvId: "b", MentionOnly: 1})
+	assert.False(t, c.Mute)
+	assert.True(t, c.MentionOnly)
+}
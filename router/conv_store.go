@@ -0,0 +1,93 @@
+package router
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	entity "github.com/magicnana999/im/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// convStore 会话通知设置的存储，保存在每个用户的会话（im_conv）上
+type convStore interface {
+	// Notify 会话的通知设置，没有设置时为nil
+	Notify(ctx context.Context, appId string, userId int64, convId string) (*api.ConvNotify, error)
+	// ListNotify 用户有通知设置的会话
+	ListNotify(ctx context.Context, appId string, userId int64) ([]*api.ConvNotify, error)
+	// SaveNotify 修改会话的通知设置，会话不存在时创建
+	SaveNotify(ctx context.Context, appId string, userId int64, convs []*api.ConvNotify) error
+}
+
+// gormConvStore 静音保存在 Conv.IsDisturb，截止时间和只在@时通知保存在同一行
+type gormConvStore struct {
+	db *gorm.DB
+}
+
+func (s *gormConvStore) Notify(ctx context.Context, appId string, userId int64, convId string) (*api.ConvNotify, error) {
+	var convs []entity.Conv
+	err := s.db.WithContext(ctx).
+		Where("app_id = ? and user_id = ? and conv_id = ?", appId, userId, convId).
+		Limit(1).Find(&convs).Error
+	if err != nil || len(convs) == 0 {
+		return nil, err
+	}
+	return convNotifyOf(&convs[0]), nil
+}
+
+func (s *gormConvStore) ListNotify(ctx context.Context, appId string, userId int64) ([]*api.ConvNotify, error) {
+	var convs []entity.Conv
+	err := s.db.WithContext(ctx).
+		Where("app_id = ? and user_id = ? and (is_disturb = 1 or mention_only = 1)", appId, userId).
+		Find(&convs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*api.ConvNotify, 0, len(convs))
+	for i := range convs {
+		ret = append(ret, convNotifyOf(&convs[i]))
+	}
+	return ret, nil
+}
+
+func (s *gormConvStore) SaveNotify(ctx context.Context, appId string, userId int64, convs []*api.ConvNotify) error {
+	now := time.Now()
+	rows := make([]entity.Conv, 0, len(convs))
+	for _, c := range convs {
+		rows = append(rows, entity.Conv{
+			ConvId:      c.ConvId,
+			AppId:       appId,
+			UserId:      userId,
+			IsDisturb:   boolInt(c.Mute),
+			MuteUntil:   c.MuteUntil,
+			MentionOnly: boolInt(c.MentionOnly),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"is_disturb", "mute_until", "mention_only", "updated_at"}),
+	}).Create(&rows).Error
+}
+
+// convNotifyOf 会话上的通知设置，没有设置时为nil
+func convNotifyOf(c *entity.Conv) *api.ConvNotify {
+	if c.IsDisturb == 0 && c.MentionOnly == 0 {
+		return nil
+	}
+	return &api.ConvNotify{
+		ConvId:      c.ConvId,
+		Mute:        c.IsDisturb == 1,
+		MuteUntil:   c.MuteUntil,
+		MentionOnly: c.MentionOnly == 1,
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+package router
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"go.uber.org/fx"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefDedupWindow clientMsgId默认保留时间
+const DefDedupWindow = 24 * time.Hour
+
+// claimScript KEYS[1]为会话的sequence，KEYS[2]为clientMsgId的记录，可以没有；
+// 首次发送时分配sequence并记录，重发时返回已记录的值
+var claimScript = redis.NewScript(`
+	if KEYS[2] then
+		local v = redis.call("GET", KEYS[2])
+		if v then
+			return {1, v}
+		end
+	end
+	local seq = redis.call("INCR", KEYS[1])
+	if KEYS[2] then
+		redis.call("SET", KEYS[2], ARGV[1] .. ":" .. seq .. ":" .. ARGV[2], "PX", ARGV[3])
+	end
+	return {0, seq}
+`)
+
+// releaseScript 路由失败时删除，只删除自己记录的值
+var releaseScript = redis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// DedupService 分配会话sequence，以及客户端重发去重
+//
+// 客户端在Message.ClientMsgId中填写自己生成的id，重发时保持不变；
+// 同一用户的clientMsgId在窗口内只路由一次，重复的发送返回首次发送的messageId、sequence和sTime。
+// sequence和记录在同一个脚本中写入，并发到达不同broker的重复发送也只有一个会被路由并分配sequence
+type DedupService struct {
+	cfg *global.DedupConfig
+	rds *redis.Client
+}
+
+func getOrDefaultDedupConfig(g *global.Config) *global.DedupConfig {
+	c := &global.DedupConfig{}
+	if g != nil && g.RRS != nil && g.RRS.Dedup != nil {
+		*c = *g.RRS.Dedup
+	}
+
+	if c.Window <= 0 {
+		c.Window = DefDedupWindow
+	}
+
+	return c
+}
+
+func NewDedupService(g *global.Config, rds *redis.Client, lc fx.Lifecycle) *DedupService {
+	return &DedupService{
+		cfg: getOrDefaultDedupConfig(g),
+		rds: rds,
+	}
+}
+
+// Claim 分配sequence并写入m，返回路由结果；Duplicate为true时消息已经路由过，m不变，不需要再次路由
+func (s *DedupService) Claim(ctx context.Context, m *api.Message) (*api.RouteReply, error) {
+	keys := []string{infra.KeySequence(m.AppId, m.ConvId)}
+	if m.ClientMsgId != "" {
+		keys = append(keys, infra.KeyClientMsg(m.AppId, m.UserId, m.ClientMsgId))
+	}
+
+	ret, err := claimScript.Run(ctx, s.rds, keys, m.MessageId, m.STime, s.cfg.Window.Milliseconds()).Slice()
+	if err != nil {
+		return nil, errors.DedupErr.SetDetail(err.Error())
+	}
+	if len(ret) != 2 {
+		return nil, errors.DedupErr.SetDetail("invalid claim result")
+	}
+
+	if dup, _ := ret[0].(int64); dup == 1 {
+		v, _ := ret[1].(string)
+		reply, ok := decodeClaim(v)
+		if !ok {
+			return nil, errors.DedupErr.SetDetail("invalid record " + v)
+		}
+		reply.Duplicate = true
+		return reply, nil
+	}
+
+	m.Sequence, _ = ret[1].(int64)
+	return &api.RouteReply{MessageId: m.MessageId, Sequence: m.Sequence, STime: m.STime}, nil
+}
+
+// Release 路由失败后删除记录，客户端可以用同一个clientMsgId重发，已分配的sequence不回收
+func (s *DedupService) Release(ctx context.Context, m *api.Message) error {
+	if m.ClientMsgId == "" {
+		return nil
+	}
+
+	key := infra.KeyClientMsg(m.AppId, m.UserId, m.ClientMsgId)
+	value := encodeClaim(&api.RouteReply{MessageId: m.MessageId, Sequence: m.Sequence, STime: m.STime})
+	return releaseScript.Run(ctx, s.rds, []string{key}, value).Err()
+}
+
+// encodeClaim 记录格式 messageId:sequence:sTime，messageId由客户端生成，按最后两个分隔符解析
+func encodeClaim(r *api.RouteReply) string {
+	return r.MessageId + ":" + strconv.FormatInt(r.Sequence, 10) + ":" + strconv.FormatInt(r.STime, 10)
+}
+
+func decodeClaim(v string) (*api.RouteReply, bool) {
+	var nums [2]int64
+	for i := len(nums) - 1; i >= 0; i-- {
+		j := strings.LastIndex(v, ":")
+		if j < 0 {
+			return nil, false
+		}
+
+		n, err := strconv.ParseInt(v[j+1:], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		nums[i] = n
+		v = v[:j]
+	}
+	return &api.RouteReply{MessageId: v, Sequence: nums[0], STime: nums[1]}, true
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func (f *fixture) dedupService() *DedupService {
+	return &DedupService{cfg: getOrDefaultDedupConfig(nil), rds: f.rds}
+}
+
+func newClientMessage(clientMsgId string, sTime int64) *api.Message {
+	m := api.NewMessage(1, 2, 0, 0, testAppId, "1:2", &api.Text{Text: "hi"})
+	m.ClientMsgId = clientMsgId
+	m.STime = sTime
+	return m
+}
+
+func TestDedupClaim(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	ds := f.dedupService()
+
+	first := newClientMessage("c1", 100)
+	reply, err := ds.Claim(ctx, first)
+	assert.NoError(t, err)
+	assert.False(t, reply.Duplicate)
+	assert.Equal(t, &api.RouteReply{MessageId: first.MessageId, Sequence: 1, STime: 100}, reply)
+	assert.Equal(t, int64(1), first.Sequence)
+
+	// 重发时客户端重新生成了messageId，返回首次发送的结果，不分配sequence
+	retry := newClientMessage("c1", 200)
+	reply, err = ds.Claim(ctx, retry)
+	assert.NoError(t, err)
+	assert.Equal(t, &api.RouteReply{MessageId: first.MessageId, Sequence: 1, STime: 100, Duplicate: true}, reply)
+	assert.Equal(t, int64(0), retry.Sequence)
+
+	// 没有clientMsgId时不去重
+	for seq := int64(2); seq <= 3; seq++ {
+		reply, err = ds.Claim(ctx, newClientMessage("", 300))
+		assert.NoError(t, err)
+		assert.False(t, reply.Duplicate)
+		assert.Equal(t, seq, reply.Sequence)
+	}
+
+	// 窗口过期后重新路由
+	f.mr.FastForward(DefDedupWindow + time.Second)
+	reply, err = ds.Claim(ctx, newClientMessage("c1", 400))
+	assert.NoError(t, err)
+	assert.False(t, reply.Duplicate)
+	assert.Equal(t, int64(4), reply.Sequence)
+}
+
+func TestDedupRelease(t *testing.T) {
+	ctx := context.Background()
+	ds := newFixture(t).dedupService()
+
+	first := newClientMessage("c1", 100)
+	_, err := ds.Claim(ctx, first)
+	assert.NoError(t, err)
+
+	// 只删除自己记录的值
+	assert.NoError(t, ds.Release(ctx, newClientMessage("c1", 100)))
+	reply, _ := ds.Claim(ctx, newClientMessage("c1", 200))
+	assert.True(t, reply.Duplicate)
+
+	// 已分配的sequence不回收
+	assert.NoError(t, ds.Release(ctx, first))
+	retry := newClientMessage("c1", 200)
+	reply, err = ds.Claim(ctx, retry)
+	assert.NoError(t, err)
+	assert.False(t, reply.Duplicate)
+	assert.Equal(t, retry.MessageId, reply.MessageId)
+	assert.Equal(t, int64(2), reply.Sequence)
+}
+
+func TestDecodeClaim(t *testing.T) {
+	r := &api.RouteReply{MessageId: "a:b", Sequence: 3, STime: 4}
+	got, ok := decodeClaim(encodeClaim(r))
+	assert.True(t, ok)
+	assert.Equal(t, r, got)
+
+	_, ok = decodeClaim("a:3")
+	assert.False(t, ok)
+}
+
+// TestDedupConcurrent 同一条消息的重发同时到达两个broker，两个router共用redis，只有一个被路由
+func TestDedupConcurrent(t *testing.T) {
+	ctx := context.Background()
+	a := newFixture(t).dedupService()
+	b := &DedupService{cfg: a.cfg, rds: a.rds}
+
+	const sends = 50
+	replies := make([]*api.RouteReply, sends)
+	var wg sync.WaitGroup
+	for i := 0; i < sends; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ds := a
+			if i%2 == 1 {
+				ds = b
+			}
+			reply, err := ds.Claim(ctx, newClientMessage("c1", int64(i+1)))
+			assert.NoError(t, err)
+			replies[i] = reply
+		}(i)
+	}
+	wg.Wait()
+
+	var routed *api.RouteReply
+	for _, r := range replies {
+		if !r.Duplicate {
+			assert.Nil(t, routed)
+			routed = r
+		}
+	}
+
+	if assert.NotNil(t, routed) {
+		assert.Equal(t, int64(1), routed.Sequence)
+		for _, r := range replies {
+			assert.Equal(t, routed.MessageId, r.MessageId)
+			assert.Equal(t, routed.Sequence, r.Sequence)
+			assert.Equal(t, routed.STime, r.STime)
+		}
+	}
+}
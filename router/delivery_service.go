@@ -2,73 +2,327 @@ package router
 
 import (
 	"context"
+	"fmt"
 	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/brokerservice"
 	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
 	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/logger"
 	"github.com/magicnana999/im/router/vo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"sync"
 )
 
+const (
+	// DefLargeGroupThreshold 群成员数达到此值时走大群模式
+	DefLargeGroupThreshold = 2000
+
+	// DefLargeGroupShardSize 大群模式下每个分片的成员数
+	DefLargeGroupShardSize = 1000
+
+	// DefMaxLabelsPerDeliver 单个DeliverRequest最多携带的label数
+	DefMaxLabelsPerDeliver = 5000
+
+	// DefDeliverConcurrency 并发处理的分片/broker数
+	DefDeliverConcurrency = 16
+)
+
+// brokerClientProvider 按broker地址获取rpc客户端
+type brokerClientProvider interface {
+	Client(ctx context.Context, addr string) (brokerservice.Client, error)
+}
+
 type DeliveryService struct {
-	us  *UserService
-	bcr *infra.BrokerClientResolver
+	cfg    *global.LargeGroupConfig
+	us     *UserService
+	gs     *GroupService
+	bcr    brokerClientProvider
+	logger *logger.Logger
+}
+
+func getOrDefaultLargeGroupConfig(g *global.Config) *global.LargeGroupConfig {
+	c := &global.LargeGroupConfig{}
+	if g != nil && g.RRS != nil && g.RRS.LargeGroup != nil {
+		*c = *g.RRS.LargeGroup
+	}
+
+	if c.Threshold <= 0 {
+		c.Threshold = DefLargeGroupThreshold
+	}
+
+	if c.ShardSize <= 0 {
+		c.ShardSize = DefLargeGroupShardSize
+	}
+
+	if c.MaxLabels <= 0 {
+		c.MaxLabels = DefMaxLabelsPerDeliver
+	}
+
+	if c.Concurrency <= 0 {
+		c.Concurrency = DefDeliverConcurrency
+	}
+
+	return c
 }
 
-func NewDeliveryService(us *UserService, bcr *infra.BrokerClientResolver) *DeliveryService {
-	return &DeliveryService{us: us, bcr: bcr}
+func NewDeliveryService(
+	g *global.Config,
+	us *UserService,
+	gs *GroupService,
+	bcr *infra.BrokerClientResolver) *DeliveryService {
+	return newDeliveryService(getOrDefaultLargeGroupConfig(g), us, gs, bcr)
 }
 
-func (s *DeliveryService) deliverToUser(ctx context.Context, m *api.Message) ([]vo.DeliverFail, error) {
+func newDeliveryService(
+	c *global.LargeGroupConfig,
+	us *UserService,
+	gs *GroupService,
+	bcr brokerClientProvider) *DeliveryService {
+	return &DeliveryService{
+		cfg:    c,
+		us:     us,
+		gs:     gs,
+		bcr:    bcr,
+		logger: logger.Named("delivery"),
+	}
+}
 
-	ret := make([]vo.DeliverFail, 0)
+func (s *DeliveryService) deliverToUser(ctx context.Context, m *api.Message) ([]vo.DeliverFail, error) {
 
 	ucs, err := s.us.GetUserClients(ctx, m.AppId, m.To)
 	if err != nil {
 		return []vo.DeliverFail{{M: m, UserId: m.To}}, errors.RouteErr.SetDetail(err.Error())
 	}
 
-	eachBrokerMsg := make(map[string]*api.DeliverRequest)
-
+	eachBrokerLabels := make(map[string][]string)
 	for _, v := range ucs {
-		request := eachBrokerMsg[v.BrokerAddr]
-		if request == nil {
-			request = &api.DeliverRequest{
-				MessageId:  m.MessageId,
-				Message:    m,
-				UserLabels: make([]string, 0),
-			}
-			eachBrokerMsg[v.BrokerAddr] = request
-		}
-
-		request.UserLabels = append(request.UserLabels, v.Label)
+		eachBrokerLabels[v.BrokerAddr] = append(eachBrokerLabels[v.BrokerAddr], v.Label)
 	}
 
-	if len(eachBrokerMsg) == 0 {
+	if len(eachBrokerLabels) == 0 {
 		return []vo.DeliverFail{{M: m, UserId: m.To}}, errors.RouteErr.SetDetail("no user clients online")
+	}
+
+	failed := s.deliverToBrokers(ctx, m, eachBrokerLabels)
+	if len(failed) != 0 {
+		return []vo.DeliverFail{{M: m, UserId: m.To, Label: failed}}, errors.RouteErr.SetDetail("some connection delivery fail")
+	}
+
+	return nil, nil
+}
+
+// deliverToGroup 群消息投递，成员数达到阈值时按分片投递
+func (s *DeliveryService) deliverToGroup(ctx context.Context, m *api.Message) ([]vo.DeliverFail, error) {
+
+	count, err := s.gs.CountGroupMembers(ctx, m.AppId, m.GroupId)
+	if err != nil {
+		return nil, errors.RouteErr.SetDetail(err.Error())
+	}
 
+	if count == 0 {
+		return nil, errors.RouteErr.SetDetail("no group members found")
 	}
 
-	for k, v := range eachBrokerMsg {
-		cli, err := s.bcr.Client(ctx, k)
+	if count < s.cfg.Threshold {
+		members, err := s.gs.GetGroupMembers(ctx, m.AppId, m.GroupId)
 		if err != nil {
-			ret = append(ret, vo.DeliverFail{M: m, UserId: m.To, Label: v.UserLabels})
-			continue
+			return nil, errors.RouteErr.SetDetail(err.Error())
 		}
+		return s.deliverToMembers(ctx, m, members)
+	}
 
-		rep, err := cli.Deliver(ctx, v)
-		if err != nil {
-			ret = append(ret, vo.DeliverFail{M: m, UserId: m.To, Label: v.UserLabels})
-			continue
+	return s.deliverToLargeGroup(ctx, m, count)
+}
+
+// deliverToLargeGroup 把成员列表切成分片并发投递。
+// 分片的成员查询失败时无法确定投递对象，返回错误，由调用方记录
+func (s *DeliveryService) deliverToLargeGroup(ctx context.Context, m *api.Message, count int64) ([]vo.DeliverFail, error) {
+
+	shards := splitGroupShards(m, count, s.cfg.ShardSize)
+
+	var (
+		lock sync.Mutex
+		ret  []vo.DeliverFail
+		lost int
+	)
+
+	eg := &errgroup.Group{}
+	eg.SetLimit(s.cfg.Concurrency)
+	for _, shard := range shards {
+		eg.Go(func() error {
+			fail, err := s.deliverShard(ctx, m, shard)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil && len(fail) == 0 {
+				lost++
+				s.logger.Error("shard members lookup fail",
+					zap.String("messageId", m.MessageId),
+					zap.Int64("groupId", shard.GroupId),
+					zap.Int64("start", shard.Start),
+					zap.Int64("stop", shard.Stop),
+					zap.Error(err))
+			}
+			ret = append(ret, fail...)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	if lost > 0 {
+		return ret, errors.RouteErr.SetDetail(fmt.Sprintf("%d of %d shards lookup fail", lost, len(shards)))
+	}
+	if len(ret) != 0 {
+		return ret, errors.RouteErr.SetDetail("some members delivery fail")
+	}
+	return nil, nil
+}
+
+// deliverShard 投递一个分片内的群成员
+func (s *DeliveryService) deliverShard(ctx context.Context, m *api.Message, shard vo.GroupShard) ([]vo.DeliverFail, error) {
+	members, err := s.gs.RangeGroupMembers(ctx, shard.AppId, shard.GroupId, shard.Start, shard.Stop)
+	if err != nil {
+		return nil, errors.RouteErr.SetDetail(err.Error())
+	}
+	return s.deliverToMembers(ctx, m, members)
+}
+
+// deliverToMembers 批量查询成员的在线连接，按broker聚合后投递，发送者本人不投递
+func (s *DeliveryService) deliverToMembers(ctx context.Context, m *api.Message, members []int64) ([]vo.DeliverFail, error) {
+
+	targets := make([]int64, 0, len(members))
+	for _, userId := range members {
+		if userId != m.UserId {
+			targets = append(targets, userId)
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	clients, err := s.us.GetUsersClients(ctx, m.AppId, targets)
+	if err != nil {
+		ret := make([]vo.DeliverFail, 0, len(targets))
+		for _, userId := range targets {
+			ret = append(ret, vo.DeliverFail{M: m, UserId: userId, Mentioned: m.IsAt(userId)})
 		}
+		return ret, errors.RouteErr.SetDetail(err.Error())
+	}
 
-		if rep != nil && rep.Code != 0 {
-			ret = append(ret, vo.DeliverFail{M: m, UserId: m.To, Label: v.UserLabels})
+	ret := make([]vo.DeliverFail, 0)
+	owners := make(map[string]int64)
+	eachBrokerLabels := make(map[string][]string)
+	for _, userId := range targets {
+		ucs, ok := clients[userId]
+		if !ok {
+			ret = append(ret, vo.DeliverFail{M: m, UserId: userId, Mentioned: m.IsAt(userId)})
 			continue
 		}
+		for _, v := range ucs {
+			owners[v.Label] = userId
+			eachBrokerLabels[v.BrokerAddr] = append(eachBrokerLabels[v.BrokerAddr], v.Label)
+		}
+	}
+
+	failed := s.deliverToBrokers(ctx, m, eachBrokerLabels)
+	if len(failed) > 0 {
+		eachUserLabels := make(map[int64][]string)
+		for _, label := range failed {
+			eachUserLabels[owners[label]] = append(eachUserLabels[owners[label]], label)
+		}
+		for userId, labels := range eachUserLabels {
+			ret = append(ret, vo.DeliverFail{M: m, UserId: userId, Label: labels, Mentioned: m.IsAt(userId)})
+		}
 	}
 
 	if len(ret) != 0 {
-		return ret, errors.RouteErr.SetDetail("some connection delivery fail")
+		return ret, errors.RouteErr.SetDetail("some members delivery fail")
 	}
+	return nil, nil
+}
 
+// deliverToBrokers 每个broker的label按MaxLabels切分成DeliverRequest并发投递，返回投递失败的label
+func (s *DeliveryService) deliverToBrokers(ctx context.Context, m *api.Message, eachBrokerLabels map[string][]string) []string {
+
+	var (
+		lock   sync.Mutex
+		failed []string
+	)
+
+	eg := &errgroup.Group{}
+	eg.SetLimit(s.cfg.Concurrency)
+	for addr, labels := range eachBrokerLabels {
+		for i := 0; i < len(labels); i += s.cfg.MaxLabels {
+			end := min(i+s.cfg.MaxLabels, len(labels))
+			req := &api.DeliverRequest{
+				MessageId:  m.MessageId,
+				Message:    m,
+				UserLabels: labels[i:end],
+			}
+
+			eg.Go(func() error {
+				fail, err := s.deliver(ctx, addr, req)
+				if err != nil {
+					s.logger.Debug("deliver failed",
+						zap.String("broker", addr),
+						zap.String("messageId", m.MessageId),
+						zap.Int("labels", len(fail)),
+						zap.Error(err))
+				}
+				if len(fail) > 0 {
+					lock.Lock()
+					failed = append(failed, fail...)
+					lock.Unlock()
+				}
+				return nil
+			})
+		}
+	}
+	_ = eg.Wait()
+
+	return failed
+}
+
+// deliver 投递一个请求，返回失败的label；请求失败时所有label都失败
+func (s *DeliveryService) deliver(ctx context.Context, addr string, req *api.DeliverRequest) ([]string, error) {
+	cli, err := s.bcr.Client(ctx, addr)
+	if err != nil {
+		return req.UserLabels, err
+	}
+
+	rep, err := cli.Deliver(ctx, req)
+	if err != nil {
+		return req.UserLabels, err
+	}
+
+	if rep != nil && rep.Code != 0 {
+		return req.UserLabels, errors.RouteErr.SetDetail(rep.Message)
+	}
+
+	var failed []string
+	for _, r := range rep.GetResults() {
+		if r.Code != 0 {
+			failed = append(failed, r.Label)
+		}
+	}
+	if len(failed) > 0 {
+		return failed, errors.RouteErr.SetDetail("some labels delivery fail")
+	}
 	return nil, nil
 }
+
+// splitGroupShards 按ZRANGE的下标区间切分群成员
+func splitGroupShards(m *api.Message, count, size int64) []vo.GroupShard {
+	shards := make([]vo.GroupShard, 0, (count+size-1)/size)
+	for start := int64(0); start < count; start += size {
+		shards = append(shards, vo.GroupShard{
+			AppId:   m.AppId,
+			GroupId: m.GroupId,
+			Start:   start,
+			Stop:    min(start+size, count) - 1,
+		})
+	}
+	return shards
+}
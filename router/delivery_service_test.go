@@ -0,0 +1,115 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newGroupMessage() *api.Message {
+	return api.NewMessage(1, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "hello"})
+}
+
+func TestSplitGroupShards(t *testing.T) {
+	shards := splitGroupShards(newGroupMessage(), 2500, 1000)
+	assert.Len(t, shards, 3)
+	assert.Equal(t, int64(0), shards[0].Start)
+	assert.Equal(t, int64(999), shards[0].Stop)
+	assert.Equal(t, int64(2000), shards[2].Start)
+	assert.Equal(t, int64(2499), shards[2].Stop)
+}
+
+func TestDeliverToLargeGroup(t *testing.T) {
+	f := newFixture(t).group(5000, 4)
+	ds := f.deliveryService(&global.LargeGroupConfig{Threshold: 1000, ShardSize: 1000, MaxLabels: 300})
+
+	fail, err := ds.deliverToGroup(context.Background(), newGroupMessage())
+	assert.Error(t, err)
+
+	// 奇数userId离线，发送者1不投递
+	assert.Len(t, fail, 2499)
+	for _, df := range fail {
+		assert.Equal(t, int64(1), df.UserId%2)
+		assert.Empty(t, df.Label)
+	}
+
+	delivered := 0
+	var requests int64
+	for _, b := range f.fbs {
+		b.labels.Range(func(key, value any) bool {
+			delivered++
+			return true
+		})
+		requests += b.requests.Load()
+	}
+	assert.Equal(t, 2500, delivered)
+
+	// 5个分片，每个分片每个broker 125个label，不需要再切分
+	assert.Equal(t, int64(5*4), requests)
+}
+
+func TestDeliverToGroupBrokerDown(t *testing.T) {
+	f := newFixture(t).group(100, 2)
+	delete(f.fbs, "127.0.0.1:7000")
+	ds := f.deliveryService(nil)
+
+	fail, err := ds.deliverToGroup(context.Background(), newGroupMessage())
+	assert.Error(t, err)
+
+	labels := 0
+	for _, df := range fail {
+		labels += len(df.Label)
+	}
+	assert.Equal(t, 25, labels)
+}
+
+func TestDeliverToUserPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t).group(0, 2)
+	ds := f.deliveryService(nil)
+
+	for i, label := range []string{"iOS", "web"} {
+		uc := vo.UserClient{AppId: testAppId, UserId: 2, Label: label, BrokerAddr: fmt.Sprintf("127.0.0.1:%d", 7000+i)}
+		js, _ := json.Marshal(uc)
+		assert.NoError(t, f.rds.HSet(ctx, infra.KeyUserClients(testAppId, 2), label, string(js)).Err())
+	}
+
+	m := api.NewMessage(1, 2, 0, 1, testAppId, "conv", &api.Text{Text: "hello"})
+	fail, err := ds.deliverToUser(ctx, m)
+	assert.NoError(t, err)
+	assert.Empty(t, fail)
+
+	// broker返回web连接已断开，只有该连接转入离线
+	f.fbs["127.0.0.1:7001"].rejects.Store("web", struct{}{})
+	fail, err = ds.deliverToUser(ctx, m)
+	assert.Error(t, err)
+	assert.Equal(t, []vo.DeliverFail{{M: m, UserId: 2, Label: []string{"web"}}}, fail)
+}
+
+func benchmarkDeliverToGroup(b *testing.B, members int, c *global.LargeGroupConfig) {
+	ds := newFixture(b).group(members, 16).deliveryService(c)
+	m := newGroupMessage()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ds.deliverToGroup(context.Background(), m)
+	}
+}
+
+func BenchmarkDeliverToGroup_20000(b *testing.B) {
+	benchmarkDeliverToGroup(b, 20_000, &global.LargeGroupConfig{Threshold: 1_000_000})
+}
+
+func BenchmarkDeliverToLargeGroup_20000(b *testing.B) {
+	benchmarkDeliverToGroup(b, 20_000, &global.LargeGroupConfig{Threshold: 2000})
+}
+
+func BenchmarkDeliverToLargeGroup_50000(b *testing.B) {
+	benchmarkDeliverToGroup(b, 50_000, &global.LargeGroupConfig{Threshold: 2000})
+}
@@ -0,0 +1,129 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/api/kitex_gen/api/brokerservice"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	testAppId   = "19860220"
+	testGroupId = int64(1001)
+)
+
+func TestMain(m *testing.M) {
+	logger.Init(&logger.Config{Dir: filepath.Join(os.TempDir(), "im-router-test")})
+	ret := m.Run()
+	logger.Close()
+	os.Exit(ret)
+}
+
+// fakeBroker 记录收到的DeliverRequest，rejects中的label返回投递失败
+type fakeBroker struct {
+	requests atomic.Int64
+	labels   sync.Map
+	rejects  sync.Map
+
+	mu       sync.Mutex
+	messages []*api.Message
+}
+
+func (b *fakeBroker) Deliver(ctx context.Context, req *api.DeliverRequest, callOptions ...callopt.Option) (*api.DeliverReply, error) {
+	b.requests.Add(1)
+	rep := &api.DeliverReply{MessageId: req.MessageId}
+	for _, label := range req.UserLabels {
+		b.labels.Store(label, struct{}{})
+		if _, ok := b.rejects.Load(label); ok {
+			rep.Results = append(rep.Results, &api.DeliverResult{Label: label, Code: 1110, Message: "user conn not found"})
+		}
+	}
+
+	b.mu.Lock()
+	b.messages = append(b.messages, req.Message)
+	b.mu.Unlock()
+	return rep, nil
+}
+
+type fakeBrokers map[string]*fakeBroker
+
+// delivered 所有broker收到的消息
+func (f fakeBrokers) delivered() []*api.Message {
+	var ret []*api.Message
+	for _, b := range f {
+		b.mu.Lock()
+		ret = append(ret, b.messages...)
+		b.mu.Unlock()
+	}
+	return ret
+}
+
+func (f fakeBrokers) Client(ctx context.Context, addr string) (brokerservice.Client, error) {
+	if b, ok := f[addr]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("broker %s not found", addr)
+}
+
+// fixture 各服务测试共用的环境，miniredis在测试结束时关闭
+type fixture struct {
+	tb  testing.TB
+	mr  *miniredis.Miniredis
+	rds *redis.Client
+	fbs fakeBrokers
+}
+
+func newFixture(tb testing.TB) *fixture {
+	mr := miniredis.RunT(tb)
+	rds := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	tb.Cleanup(func() { rds.Close() })
+	return &fixture{tb: tb, mr: mr, rds: rds, fbs: make(fakeBrokers)}
+}
+
+// group 准备members个群成员，偶数userId在线，连接平均分布在brokers个broker上
+func (f *fixture) group(members, brokers int) *fixture {
+	ctx := context.Background()
+	for i := 0; i < brokers; i++ {
+		f.fbs[fmt.Sprintf("127.0.0.1:%d", 7000+i)] = &fakeBroker{}
+	}
+
+	pipe := f.rds.Pipeline()
+	for i := 1; i <= members; i++ {
+		userId := int64(i)
+		pipe.ZAdd(ctx, infra.KeyGroupMembers(testAppId, testGroupId), &redis.Z{Score: float64(i), Member: userId})
+		if userId%2 != 0 {
+			continue
+		}
+		uc := vo.UserClient{
+			AppId:      testAppId,
+			UserId:     userId,
+			OS:         "iOS",
+			BrokerAddr: fmt.Sprintf("127.0.0.1:%d", 7000+(i/2)%brokers),
+		}
+		js, _ := json.Marshal(uc)
+		pipe.HSet(ctx, infra.KeyUserClients(testAppId, userId), fmt.Sprintf("%s#%d#iOS", testAppId, userId), string(js))
+	}
+	_, err := pipe.Exec(ctx)
+	assert.NoError(f.tb, err)
+
+	return f
+}
+
+func (f *fixture) deliveryService(c *global.LargeGroupConfig) *DeliveryService {
+	g := &global.Config{RRS: &global.RRSConfig{LargeGroup: c}}
+	return newDeliveryService(getOrDefaultLargeGroupConfig(g), &UserService{rds: f.rds}, &GroupService{rds: f.rds}, f.fbs)
+}
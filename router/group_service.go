@@ -0,0 +1,127 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v8"
+	entity "github.com/magicnana999/im/entities"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/router/vo"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"strconv"
+)
+
+// GroupService 群成员查询，成员保存在 zset 中，score 为入群时间
+type GroupService struct {
+	rds *redis.Client
+	db  *gorm.DB
+}
+
+func NewGroupService(rds *redis.Client, db *gorm.DB, lc fx.Lifecycle) *GroupService {
+	return &GroupService{
+		rds: rds,
+		db:  db,
+	}
+}
+
+// CountGroupMembers 群成员数量
+func (s *GroupService) CountGroupMembers(ctx context.Context, appId string, groupId int64) (int64, error) {
+	key := infra.KeyGroupMembers(appId, groupId)
+	return s.rds.ZCard(ctx, key).Result()
+}
+
+// GetGroupMembers 获取全部群成员
+func (s *GroupService) GetGroupMembers(ctx context.Context, appId string, groupId int64) ([]int64, error) {
+	return s.RangeGroupMembers(ctx, appId, groupId, 0, -1)
+}
+
+// RangeGroupMembers 按入群顺序分页获取群成员，start/stop 语义与 ZRANGE 一致
+func (s *GroupService) RangeGroupMembers(ctx context.Context, appId string, groupId int64, start, stop int64) ([]int64, error) {
+	key := infra.KeyGroupMembers(appId, groupId)
+	ms, err := s.rds.ZRange(ctx, key, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(ms))
+	for _, m := range ms {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// IsGroupMember 是否群成员
+func (s *GroupService) IsGroupMember(ctx context.Context, appId string, groupId, userId int64) (bool, error) {
+	key := infra.KeyGroupMembers(appId, groupId)
+	err := s.rds.ZScore(ctx, key, strconv.FormatInt(userId, 10)).Err()
+	if err == redis.Nil {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// FilterGroupMembers 返回userIds中的群成员，保持原顺序
+func (s *GroupService) FilterGroupMembers(ctx context.Context, appId string, groupId int64, userIds []int64) ([]int64, error) {
+	key := infra.KeyGroupMembers(appId, groupId)
+	pipe := s.rds.Pipeline()
+	cmds := make([]*redis.FloatCmd, 0, len(userIds))
+	for _, userId := range userIds {
+		cmds = append(cmds, pipe.ZScore(ctx, key, strconv.FormatInt(userId, 10)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	ret := make([]int64, 0, len(userIds))
+	for i, cmd := range cmds {
+		if cmd.Err() == nil {
+			ret = append(ret, userIds[i])
+		}
+	}
+	return ret, nil
+}
+
+// IsGroupAdmin 是否群管理员（含群主），管理员保存在 set 中
+func (s *GroupService) IsGroupAdmin(ctx context.Context, appId string, groupId, userId int64) (bool, error) {
+	key := infra.KeyGroupAdmins(appId, groupId)
+	return s.rds.SIsMember(ctx, key, userId).Result()
+}
+
+// GetGroupProfile 获取群资料，先读缓存，没有时从 im_group 加载并缓存，群不存在时返回 redis.Nil
+func (s *GroupService) GetGroupProfile(ctx context.Context, appId string, groupId int64) (*vo.GroupProfile, error) {
+	key := infra.KeyGroup(appId, groupId)
+	val, err := s.rds.Get(ctx, key).Bytes()
+	if err == nil {
+		var profile vo.GroupProfile
+		if err := json.Unmarshal(val, &profile); err != nil {
+			return nil, err
+		}
+		return &profile, nil
+	}
+	if err != redis.Nil || s.db == nil {
+		return nil, err
+	}
+
+	var groups []entity.Group
+	if err := s.db.WithContext(ctx).Where("app_id = ? and group_id = ?", appId, groupId).Limit(1).Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, redis.Nil
+	}
+
+	profile := &vo.GroupProfile{AppId: appId, GroupId: groupId, GroupName: groups[0].GroupName, Avatar: groups[0].GroupAvatar}
+	js, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rds.Set(ctx, key, js, DefProfileExpire).Err(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -0,0 +1,226 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+const (
+	// MaxOneTimePreKeys 每个设备保留的one-time pre-key上限，超出时丢弃最早上传的
+	MaxOneTimePreKeys = 100
+
+	// DefKeyFetchLimit 每个用户在窗口内拉取公钥的默认次数上限
+	DefKeyFetchLimit = 60
+
+	// DefKeyFetchWindow 拉取限流的默认窗口
+	DefKeyFetchWindow = time.Minute
+
+	// maxKeyRegisterRetries 注册时设备公钥被并发修改的重试次数
+	maxKeyRegisterRetries = 3
+)
+
+// fetchLimitScript 固定窗口计数，窗口内第一次拉取时设置过期时间
+var fetchLimitScript = redis.NewScript(`
+	local n = redis.call("INCR", KEYS[1])
+	if n == 1 then
+		redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	end
+	return n
+`)
+
+// KeyService 端到端加密的公钥目录，服务端只保存公钥，不参与加解密
+//
+// 设备的identity key和signed pre-key保存在 keys:devices hash 中（deviceId -> DeviceKeys）；
+// one-time pre-key按设备保存在list中，被拉取一次后即删除；identity key变化时旧的one-time pre-key一并删除。
+// 拉取按用户限流，避免耗尽他人的one-time pre-key
+type KeyService struct {
+	cfg *global.KeysConfig
+	rds *redis.Client
+}
+
+func getOrDefaultKeysConfig(g *global.Config) *global.KeysConfig {
+	c := &global.KeysConfig{}
+	if g != nil && g.RRS != nil && g.RRS.Keys != nil {
+		*c = *g.RRS.Keys
+	}
+
+	if c.FetchLimit <= 0 {
+		c.FetchLimit = DefKeyFetchLimit
+	}
+
+	if c.FetchWindow <= 0 {
+		c.FetchWindow = DefKeyFetchWindow
+	}
+
+	return c
+}
+
+func NewKeyService(g *global.Config, rds *redis.Client, lc fx.Lifecycle) *KeyService {
+	return &KeyService{
+		cfg: getOrDefaultKeysConfig(g),
+		rds: rds,
+	}
+}
+
+// Register 注册或更新设备公钥，返回服务端剩余的one-time pre-key数量
+func (s *KeyService) Register(ctx context.Context, req *api.KeyRegisterRequest) (*api.KeyRegisterReply, error) {
+	keys := req.GetKeys()
+	if err := validateDeviceKeys(keys); err != nil {
+		return nil, err
+	}
+
+	device := &api.DeviceKeys{
+		DeviceId:     keys.DeviceId,
+		IdentityKey:  keys.IdentityKey,
+		SignedPreKey: keys.SignedPreKey,
+	}
+	bs, err := proto.Marshal(device)
+	if err != nil {
+		return nil, errors.KeyErr.SetDetail(err.Error())
+	}
+
+	preKeys := make([]any, 0, len(keys.OneTimePreKeys))
+	for _, k := range keys.OneTimePreKeys {
+		pk, err := proto.Marshal(k)
+		if err != nil {
+			return nil, errors.KeyErr.SetDetail(err.Error())
+		}
+		preKeys = append(preKeys, pk)
+	}
+
+	devicesKey := infra.KeyDeviceKeys(req.AppId, req.UserId)
+	listKey := infra.KeyOneTimePreKeys(req.AppId, req.UserId, keys.DeviceId)
+
+	var count *redis.IntCmd
+	register := func(tx *redis.Tx) error {
+		rotated, err := identityChanged(ctx, tx, devicesKey, keys)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, devicesKey, keys.DeviceId, bs)
+			if rotated {
+				pipe.Del(ctx, listKey)
+			}
+			if len(preKeys) > 0 {
+				pipe.RPush(ctx, listKey, preKeys...)
+				pipe.LTrim(ctx, listKey, -MaxOneTimePreKeys, -1)
+			}
+			count = pipe.LLen(ctx, listKey)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < maxKeyRegisterRetries; i++ {
+		if err = s.rds.Watch(ctx, register, devicesKey); err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		return nil, errors.KeyErr.SetDetail(err.Error())
+	}
+
+	return &api.KeyRegisterReply{OneTimePreKeyCount: int32(count.Val())}, nil
+}
+
+// identityChanged 设备已注册且identity key不同，此前上传的one-time pre-key不再可用
+func identityChanged(ctx context.Context, tx *redis.Tx, devicesKey string, keys *api.DeviceKeys) (bool, error) {
+	v, err := tx.HGet(ctx, devicesKey, keys.DeviceId).Bytes()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	prev := &api.DeviceKeys{}
+	if err := proto.Unmarshal(v, prev); err != nil {
+		return false, err
+	}
+	return !bytes.Equal(prev.IdentityKey, keys.IdentityKey), nil
+}
+
+// Fetch 拉取目标用户的设备公钥，每个设备附带一个one-time pre-key（已耗尽时为空）
+func (s *KeyService) Fetch(ctx context.Context, req *api.KeyFetchRequest) (*api.KeyFetchReply, error) {
+	n, err := fetchLimitScript.Run(ctx, s.rds, []string{infra.KeyFetchLimit(req.AppId, req.UserId)}, s.cfg.FetchWindow.Milliseconds()).Int64()
+	if err != nil {
+		return nil, errors.KeyErr.SetDetail(err.Error())
+	}
+	if n > s.cfg.FetchLimit {
+		return nil, errors.KeyLimited
+	}
+
+	key := infra.KeyDeviceKeys(req.AppId, req.TargetUserId)
+
+	var values map[string]string
+	if req.DeviceId != "" {
+		v, err := s.rds.HGet(ctx, key, req.DeviceId).Result()
+		if err == redis.Nil {
+			return nil, errors.KeyNotFound.SetDetail(req.DeviceId)
+		}
+		if err != nil {
+			return nil, errors.KeyErr.SetDetail(err.Error())
+		}
+		values = map[string]string{req.DeviceId: v}
+	} else {
+		var err error
+		if values, err = s.rds.HGetAll(ctx, key).Result(); err != nil {
+			return nil, errors.KeyErr.SetDetail(err.Error())
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, errors.KeyNotFound
+	}
+
+	reply := &api.KeyFetchReply{UserId: req.TargetUserId}
+	for deviceId, v := range values {
+		device := &api.DeviceKeys{}
+		if err := proto.Unmarshal([]byte(v), device); err != nil {
+			return nil, errors.KeyErr.SetDetail(err.Error())
+		}
+
+		pk, err := s.rds.LPop(ctx, infra.KeyOneTimePreKeys(req.AppId, req.TargetUserId, deviceId)).Bytes()
+		if err != nil && err != redis.Nil {
+			return nil, errors.KeyErr.SetDetail(err.Error())
+		}
+		if len(pk) > 0 {
+			preKey := &api.PreKey{}
+			if err := proto.Unmarshal(pk, preKey); err != nil {
+				return nil, errors.KeyErr.SetDetail(err.Error())
+			}
+			device.OneTimePreKeys = []*api.PreKey{preKey}
+		}
+
+		reply.Devices = append(reply.Devices, device)
+	}
+	return reply, nil
+}
+
+func validateDeviceKeys(keys *api.DeviceKeys) error {
+	if keys.GetDeviceId() == "" {
+		return errors.KeyInvalid.SetDetail("deviceId is required")
+	}
+	if len(keys.IdentityKey) == 0 {
+		return errors.KeyInvalid.SetDetail("identityKey is required")
+	}
+	if spk := keys.SignedPreKey; spk == nil || spk.KeyId == "" || len(spk.PublicKey) == 0 || len(spk.Signature) == 0 {
+		return errors.KeyInvalid.SetDetail("signedPreKey is required")
+	}
+	for _, k := range keys.OneTimePreKeys {
+		if k.GetKeyId() == "" || len(k.GetPublicKey()) == 0 {
+			return errors.KeyInvalid.SetDetail("invalid oneTimePreKey")
+		}
+	}
+	return nil
+}
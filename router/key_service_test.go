@@ -0,0 +1,148 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func (f *fixture) keyService() *KeyService {
+	return &KeyService{cfg: getOrDefaultKeysConfig(nil), rds: f.rds}
+}
+
+func newDeviceKeys(deviceId string, preKeys int) *api.DeviceKeys {
+	keys := &api.DeviceKeys{
+		DeviceId:     deviceId,
+		IdentityKey:  []byte("identity-" + deviceId),
+		SignedPreKey: &api.PreKey{KeyId: "spk", PublicKey: []byte("spk-" + deviceId), Signature: []byte("sig")},
+	}
+	for i := 0; i < preKeys; i++ {
+		keys.OneTimePreKeys = append(keys.OneTimePreKeys, &api.PreKey{KeyId: fmt.Sprintf("otk%d", i), PublicKey: []byte{byte(i)}})
+	}
+	return keys
+}
+
+func TestKeyRegisterFetch(t *testing.T) {
+	ctx := context.Background()
+	ks := newFixture(t).keyService()
+
+	reply, err := ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: newDeviceKeys("phone", 2)})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), reply.OneTimePreKeyCount)
+
+	_, err = ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: newDeviceKeys("pc", 0)})
+	assert.NoError(t, err)
+
+	fetch := &api.KeyFetchRequest{AppId: testAppId, UserId: 3, TargetUserId: 2, DeviceId: "phone"}
+
+	// one-time pre-key按上传顺序取出，取出后删除
+	for _, keyId := range []string{"otk0", "otk1"} {
+		r, err := ks.Fetch(ctx, fetch)
+		assert.NoError(t, err)
+		assert.Len(t, r.Devices, 1)
+		assert.Equal(t, []byte("identity-phone"), r.Devices[0].IdentityKey)
+		assert.Equal(t, "spk", r.Devices[0].SignedPreKey.KeyId)
+		if assert.Len(t, r.Devices[0].OneTimePreKeys, 1) {
+			assert.Equal(t, keyId, r.Devices[0].OneTimePreKeys[0].KeyId)
+		}
+	}
+
+	// 耗尽后只返回signed pre-key
+	r, err := ks.Fetch(ctx, fetch)
+	assert.NoError(t, err)
+	assert.Empty(t, r.Devices[0].OneTimePreKeys)
+
+	fetch.DeviceId = ""
+	r, err = ks.Fetch(ctx, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), r.UserId)
+	assert.Len(t, r.Devices, 2)
+}
+
+func TestKeyRegisterCap(t *testing.T) {
+	ctx := context.Background()
+	ks := newFixture(t).keyService()
+
+	_, err := ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: newDeviceKeys("phone", MaxOneTimePreKeys)})
+	assert.NoError(t, err)
+
+	reply, err := ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: newDeviceKeys("phone", 10)})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(MaxOneTimePreKeys), reply.OneTimePreKeyCount)
+}
+
+func TestKeyRotate(t *testing.T) {
+	ctx := context.Background()
+	ks := newFixture(t).keyService()
+
+	_, err := ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: newDeviceKeys("phone", 5)})
+	assert.NoError(t, err)
+
+	// identity key不变时保留已上传的one-time pre-key
+	reply, err := ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: newDeviceKeys("phone", 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(6), reply.OneTimePreKeyCount)
+
+	// identity key变化后旧的one-time pre-key作废，只保留本次上传的
+	keys := newDeviceKeys("phone", 2)
+	keys.IdentityKey = []byte("identity-new")
+	reply, err = ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: keys})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), reply.OneTimePreKeyCount)
+
+	r, err := ks.Fetch(ctx, &api.KeyFetchRequest{AppId: testAppId, UserId: 3, TargetUserId: 2, DeviceId: "phone"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("identity-new"), r.Devices[0].IdentityKey)
+	assert.Equal(t, "otk0", r.Devices[0].OneTimePreKeys[0].KeyId)
+}
+
+func TestKeyFetchLimit(t *testing.T) {
+	ctx := context.Background()
+	ks := newFixture(t).keyService()
+	ks.cfg.FetchLimit = 2
+
+	_, err := ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: newDeviceKeys("phone", 5)})
+	assert.NoError(t, err)
+
+	fetch := &api.KeyFetchRequest{AppId: testAppId, UserId: 3, TargetUserId: 2}
+	for i := 0; i < 2; i++ {
+		_, err = ks.Fetch(ctx, fetch)
+		assert.NoError(t, err)
+	}
+	_, err = ks.Fetch(ctx, fetch)
+	assert.ErrorIs(t, err, errors.KeyLimited)
+
+	// 限流按拉取者计算
+	_, err = ks.Fetch(ctx, &api.KeyFetchRequest{AppId: testAppId, UserId: 4, TargetUserId: 2})
+	assert.NoError(t, err)
+	assert.True(t, ks.rds.PTTL(ctx, infra.KeyFetchLimit(testAppId, 3)).Val() > 0)
+}
+
+func TestKeyInvalid(t *testing.T) {
+	ctx := context.Background()
+	ks := newFixture(t).keyService()
+
+	invalid := []*api.DeviceKeys{
+		nil,
+		{IdentityKey: []byte{1}},
+		{DeviceId: "phone", SignedPreKey: &api.PreKey{KeyId: "spk", PublicKey: []byte{1}, Signature: []byte{1}}},
+		{DeviceId: "phone", IdentityKey: []byte{1}, SignedPreKey: &api.PreKey{KeyId: "spk", PublicKey: []byte{1}}},
+		{DeviceId: "phone", IdentityKey: []byte{1}, SignedPreKey: &api.PreKey{KeyId: "spk", PublicKey: []byte{1}, Signature: []byte{1}},
+			OneTimePreKeys: []*api.PreKey{{KeyId: "otk"}}},
+	}
+	for i, keys := range invalid {
+		_, err := ks.Register(ctx, &api.KeyRegisterRequest{AppId: testAppId, UserId: 2, Keys: keys})
+		assert.Equal(t, errors.KeyInvalid.Code, errext.Format(err).Code, i)
+	}
+
+	_, err := ks.Fetch(ctx, &api.KeyFetchRequest{AppId: testAppId, UserId: 3, TargetUserId: 2})
+	assert.Equal(t, errors.KeyNotFound.Code, errext.Format(err).Code)
+
+	_, err = ks.Fetch(ctx, &api.KeyFetchRequest{AppId: testAppId, UserId: 3, TargetUserId: 2, DeviceId: "phone"})
+	assert.Equal(t, errors.KeyNotFound.Code, errext.Format(err).Code)
+}
@@ -0,0 +1,150 @@
+package router
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+const (
+	// DefHistoryExpire 消息历史保存时间
+	DefHistoryExpire = 30 * 24 * time.Hour
+
+	// DefReferSnippetLength 引用文本的最大字数
+	DefReferSnippetLength = 100
+)
+
+// advanceStatusScript 消息存在且投递状态向后推进时写入
+var advanceStatusScript = redis.NewScript(`
+	if redis.call("EXISTS", KEYS[1]) == 0 then
+		return 0
+	end
+	local rank = {offline = 1, delivered = 2}
+	local cur = redis.call("HGET", KEYS[1], "status")
+	if cur and (rank[cur] or 0) >= (rank[ARGV[1]] or 0) then
+		return 0
+	end
+	redis.call("HSET", KEYS[1], "status", ARGV[1])
+	return 1
+`)
+
+// MessageStore 消息历史，每条消息一个hash：body为protobuf编码的消息，recalled为撤回标记，status为投递状态
+type MessageStore struct {
+	cfg *global.HistoryConfig
+	rds *redis.Client
+}
+
+func getOrDefaultHistoryConfig(g *global.Config) *global.HistoryConfig {
+	c := &global.HistoryConfig{}
+	if g != nil && g.RRS != nil && g.RRS.History != nil {
+		*c = *g.RRS.History
+	}
+
+	if c.Expire <= 0 {
+		c.Expire = DefHistoryExpire
+	}
+
+	if c.SnippetLength <= 0 {
+		c.SnippetLength = DefReferSnippetLength
+	}
+
+	return c
+}
+
+func NewMessageStore(g *global.Config, rds *redis.Client, lc fx.Lifecycle) *MessageStore {
+	return &MessageStore{
+		cfg: getOrDefaultHistoryConfig(g),
+		rds: rds,
+	}
+}
+
+// Save 保存消息
+func (s *MessageStore) Save(ctx context.Context, m *api.Message) error {
+	body, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	key := infra.KeyMessage(m.AppId, m.MessageId)
+	pipe := s.rds.TxPipeline()
+	pipe.HSet(ctx, key, "body", body)
+	pipe.Expire(ctx, key, s.cfg.Expire)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Get 读取消息及撤回标记，不存在时返回 redis.Nil
+func (s *MessageStore) Get(ctx context.Context, appId, messageId string) (*api.Message, bool, error) {
+	vals, err := s.rds.HMGet(ctx, infra.KeyMessage(appId, messageId), "body", "recalled").Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	body, ok := vals[0].(string)
+	if !ok {
+		return nil, false, redis.Nil
+	}
+
+	var m api.Message
+	if err := proto.Unmarshal([]byte(body), &m); err != nil {
+		return nil, false, err
+	}
+
+	return &m, vals[1] != nil, nil
+}
+
+// List 批量读取消息，已撤回的值为nil，不存在的不返回
+func (s *MessageStore) List(ctx context.Context, appId string, messageIds []string) (map[string]*api.Message, error) {
+	pipe := s.rds.Pipeline()
+	cmds := make([]*redis.SliceCmd, 0, len(messageIds))
+	for _, id := range messageIds {
+		cmds = append(cmds, pipe.HMGet(ctx, infra.KeyMessage(appId, id), "body", "recalled"))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]*api.Message, len(messageIds))
+	for i, cmd := range cmds {
+		vals := cmd.Val()
+		body, ok := vals[0].(string)
+		if !ok {
+			continue
+		}
+		if vals[1] != nil {
+			ret[messageIds[i]] = nil
+			continue
+		}
+
+		var m api.Message
+		if err := proto.Unmarshal([]byte(body), &m); err != nil {
+			return nil, err
+		}
+		ret[messageIds[i]] = &m
+	}
+	return ret, nil
+}
+
+// Recall 标记消息已撤回，消息不存在时返回 redis.Nil
+func (s *MessageStore) Recall(ctx context.Context, appId, messageId string) error {
+	key := infra.KeyMessage(appId, messageId)
+	n, err := s.rds.Exists(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return redis.Nil
+	}
+	return s.rds.HSet(ctx, key, "recalled", 1).Err()
+}
+
+// AdvanceStatus 推进投递状态，返回是否有变化；消息不存在或状态没有推进时返回false
+func (s *MessageStore) AdvanceStatus(ctx context.Context, appId, messageId, status string) (bool, error) {
+	n, err := advanceStatusScript.Run(ctx, s.rds, []string{infra.KeyMessage(appId, messageId)}, status).Int()
+	return n == 1, err
+}
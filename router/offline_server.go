@@ -0,0 +1,95 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// OfflineServer 消费 msg-offline，把转离线的消息保存到 im_message_offline
+type OfflineServer struct {
+	reader *kafka.Reader
+	os     *OfflineService
+	cancel context.CancelFunc
+	logger *logger.Logger
+}
+
+func NewOfflineServer(g *global.Config, os *OfflineService, lc fx.Lifecycle) *OfflineServer {
+	s := &OfflineServer{
+		reader: infra.NewKafkaConsumer(g, infra.Offline),
+		os:     os,
+		logger: logger.Named("offline"),
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return s.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+	return s
+}
+
+func (s *OfflineServer) Start(ctx context.Context) error {
+	c, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func() {
+		s.logger.Info("offline consumer started")
+		failures := 0
+		for {
+			km, err := s.reader.ReadMessage(c)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				s.logger.Error("failed to read offline message", zap.Int("failures", failures), zap.Error(err))
+				if !consumeBackoff(c, failures) {
+					return
+				}
+				failures++
+				continue
+			}
+			failures = 0
+			s.consume(c, km)
+		}
+	}()
+	return nil
+}
+
+func (s *OfflineServer) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if err := s.reader.Close(); err != nil {
+		s.logger.Error("failed to close offline consumer", zap.Error(err))
+		return err
+	}
+	s.logger.Info("offline consumer stopped")
+	return nil
+}
+
+// consume 保存失败时消息内容仍在历史中，只记录日志
+func (s *OfflineServer) consume(ctx context.Context, km kafka.Message) {
+	var m api.Message
+	if err := proto.Unmarshal(km.Value, &m); err != nil {
+		s.logger.Error("invalid offline message", zap.Error(err))
+		return
+	}
+
+	if err := s.os.Save(ctx, &m); err != nil {
+		s.logger.Warn("failed to save offline message",
+			zap.String("appId", m.AppId),
+			zap.String("messageId", m.MessageId),
+			zap.Error(err))
+	}
+}
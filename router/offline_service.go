@@ -0,0 +1,302 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefOfflineExpire 离线收件箱的默认保留时间
+	DefOfflineExpire = 7 * 24 * time.Hour
+
+	// DefOfflineMaxMessages 每个用户离线收件箱默认保留的消息数
+	DefOfflineMaxMessages = 1000
+
+	// DefOfflinePullLimit 登录后每次拉取的最大消息数
+	DefOfflinePullLimit = 100
+)
+
+// OfflineService 在线投递失败的兜底
+//
+// 投递失败的连接和没有在线连接的用户，消息id写入用户的离线收件箱 im:{appId}:offline:{userId}，
+// 按sTime排序，消息内容在消息历史中，同时经 msg-offline 保存到 im_message_offline，历史过期后从中读取；
+// 另外写入推送任务，由推送服务通知设备。用户登录后分页拉取，ack后从收件箱删除
+type OfflineService struct {
+	cfg    *global.OfflineConfig
+	rds    *redis.Client
+	ms     *MessageStore
+	store  offlineStore
+	kw     kafkaWriter
+	logger *logger.Logger
+}
+
+func getOrDefaultOfflineConfig(g *global.Config) *global.OfflineConfig {
+	c := &global.OfflineConfig{}
+	if g != nil && g.RRS != nil && g.RRS.Offline != nil {
+		*c = *g.RRS.Offline
+	}
+
+	if c.Expire <= 0 {
+		c.Expire = DefOfflineExpire
+	}
+
+	if c.MaxMessages <= 0 {
+		c.MaxMessages = DefOfflineMaxMessages
+	}
+
+	return c
+}
+
+func NewOfflineService(g *global.Config, rds *redis.Client, db *gorm.DB, ms *MessageStore, kw *kafka.Writer, lc fx.Lifecycle) *OfflineService {
+	return newOfflineService(getOrDefaultOfflineConfig(g), rds, ms, &gormOfflineStore{db: db}, kw)
+}
+
+func newOfflineService(c *global.OfflineConfig, rds *redis.Client, ms *MessageStore, store offlineStore, kw kafkaWriter) *OfflineService {
+	return &OfflineService{
+		cfg:    c,
+		rds:    rds,
+		ms:     ms,
+		store:  store,
+		kw:     kw,
+		logger: logger.Named("offline"),
+	}
+}
+
+// Fallback 保存到离线收件箱并写入推送任务，返回保存到收件箱的数量
+func (s *OfflineService) Fallback(ctx context.Context, fails []vo.DeliverFail) (int, error) {
+	if len(fails) == 0 {
+		return 0, nil
+	}
+
+	pipe := s.rds.Pipeline()
+	for _, f := range fails {
+		key := infra.KeyOffline(f.M.AppId, f.UserId)
+		pipe.ZAdd(ctx, key, &redis.Z{Score: float64(f.M.STime), Member: f.M.MessageId})
+		pipe.ZRemRangeByRank(ctx, key, 0, -s.cfg.MaxMessages-1)
+		pipe.PExpire(ctx, key, s.cfg.Expire)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.OfflineErr.SetDetail(err.Error())
+	}
+
+	if err := s.persist(ctx, fails); err != nil {
+		return len(fails), errors.OfflineErr.SetDetail(err.Error())
+	}
+
+	if err := s.push(ctx, fails); err != nil {
+		return len(fails), errors.OfflineErr.SetDetail(err.Error())
+	}
+	return len(fails), nil
+}
+
+// persist 每条消息写入一次 msg-offline，由OfflineServer保存到 im_message_offline
+func (s *OfflineService) persist(ctx context.Context, fails []vo.DeliverFail) error {
+	if s.kw == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	kms := make([]kafka.Message, 0, 1)
+	for _, f := range fails {
+		if seen[f.M.MessageId] {
+			continue
+		}
+		seen[f.M.MessageId] = true
+
+		bs, err := proto.Marshal(f.M)
+		if err != nil {
+			return err
+		}
+		kms = append(kms, kafka.Message{Topic: infra.Offline.Topic, Key: []byte(f.M.MessageId), Value: bs})
+	}
+	return s.kw.WriteMessages(ctx, kms...)
+}
+
+// Save 保存msg-offline中的消息
+func (s *OfflineService) Save(ctx context.Context, ms ...*api.Message) error {
+	return s.store.Save(ctx, ms)
+}
+
+// Pull 按sTime从旧到新分页拉取离线收件箱，包括broker转离线的消息。
+// 同一sTime的消息不跨页，since只需要记录上一页最后的sTime；
+// 已撤回和内容已过期的消息不返回，并从收件箱删除
+func (s *OfflineService) Pull(ctx context.Context, req *api.OfflineRequest) (*api.OfflineReply, error) {
+	limit := int(req.Limit)
+	if limit <= 0 || limit > DefOfflinePullLimit {
+		limit = DefOfflinePullLimit
+	}
+
+	key := infra.KeyOffline(req.AppId, req.UserId)
+	zs, err := s.rds.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min:   "(" + strconv.FormatInt(req.Since, 10),
+		Max:   "+inf",
+		Count: int64(limit + 1),
+	}).Result()
+	if err != nil {
+		return nil, errors.OfflineErr.SetDetail(err.Error())
+	}
+
+	reply := &api.OfflineReply{Next: req.Since}
+	if len(zs) > limit {
+		reply.More = true
+		if zs, err = s.page(ctx, key, zs, limit); err != nil {
+			return nil, errors.OfflineErr.SetDetail(err.Error())
+		}
+	}
+	if len(zs) == 0 {
+		return reply, nil
+	}
+	reply.Next = int64(zs[len(zs)-1].Score)
+
+	ids := make([]string, 0, len(zs))
+	for _, z := range zs {
+		ids = append(ids, z.Member.(string))
+	}
+
+	found, err := s.messages(ctx, req.AppId, ids)
+	if err != nil {
+		return nil, errors.OfflineErr.SetDetail(err.Error())
+	}
+
+	var stale []string
+	for _, id := range ids {
+		if m := found[id]; m != nil {
+			reply.Messages = append(reply.Messages, m)
+		} else {
+			stale = append(stale, id)
+		}
+	}
+
+	if len(stale) > 0 {
+		if err := s.Remove(ctx, req.AppId, req.UserId, stale); err != nil {
+			s.logger.Warn("failed to remove stale offline messages", zap.Int("count", len(stale)), zap.Error(err))
+		}
+	}
+	return reply, nil
+}
+
+// page 取前limit条，去掉与下一条sTime相同的部分；同一sTime超过limit条时一次全部返回
+func (s *OfflineService) page(ctx context.Context, key string, zs []redis.Z, limit int) ([]redis.Z, error) {
+	last := zs[limit].Score
+	n := limit
+	for n > 0 && zs[n-1].Score == last {
+		n--
+	}
+	if n > 0 {
+		return zs[:n], nil
+	}
+
+	score := strconv.FormatFloat(last, 'f', -1, 64)
+	return s.rds.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: score, Max: score}).Result()
+}
+
+// messages 先从消息历史读取，历史中不存在的从 im_message_offline 读取，已撤回的为nil
+func (s *OfflineService) messages(ctx context.Context, appId string, ids []string) (map[string]*api.Message, error) {
+	found, err := s.ms.List(ctx, appId, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	ms, err := s.store.List(ctx, appId, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range ms {
+		found[m.MessageId] = m
+	}
+	return found, nil
+}
+
+// Remove 接收者已收到，从离线收件箱和broker的投递记录中删除
+func (s *OfflineService) Remove(ctx context.Context, appId string, userId int64, messageIds []string) error {
+	if len(messageIds) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, 0, len(messageIds))
+	for _, id := range messageIds {
+		members = append(members, id)
+	}
+
+	pipe := s.rds.Pipeline()
+	pipe.ZRem(ctx, infra.KeyOffline(appId, userId), members...)
+	pipe.HDel(ctx, infra.KeyOfflineAttempt(appId, userId), messageIds...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.OfflineErr.SetDetail(err.Error())
+	}
+	return nil
+}
+
+// push 服务端生成的消息不推送
+func (s *OfflineService) push(ctx context.Context, fails []vo.DeliverFail) error {
+	if s.kw == nil {
+		return nil
+	}
+
+	encoded := make(map[string][]byte)
+	kms := make([]kafka.Message, 0, len(fails))
+	for _, f := range fails {
+		if f.M.IsSystem() {
+			continue
+		}
+
+		bs, ok := encoded[f.M.MessageId]
+		if !ok {
+			var err error
+			if bs, err = proto.Marshal(f.M); err != nil {
+				return err
+			}
+			encoded[f.M.MessageId] = bs
+		}
+
+		value, err := json.Marshal(&vo.PushTask{
+			AppId:     f.M.AppId,
+			UserId:    f.UserId,
+			Labels:    f.Label,
+			Mentioned: f.Mentioned,
+			Message:   bs,
+		})
+		if err != nil {
+			return err
+		}
+		kms = append(kms, kafka.Message{Topic: infra.Push.Topic, Key: []byte(strconv.FormatInt(f.UserId, 10)), Value: value})
+	}
+
+	if len(kms) == 0 {
+		return nil
+	}
+	return s.kw.WriteMessages(ctx, kms...)
+}
+
+// fallback 兜底失败时只能记录日志，消息仍在历史中；返回保存到收件箱的数量
+func (s *OfflineService) fallback(ctx context.Context, fails []vo.DeliverFail) int {
+	n, err := s.Fallback(ctx, fails)
+	if err != nil {
+		s.logger.Error("offline fallback failed", zap.Int("fails", len(fails)), zap.Int("saved", n), zap.Error(err))
+	}
+	return n
+}
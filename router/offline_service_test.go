@@ -0,0 +1,193 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakePush 记录写入的推送任务和转离线的消息
+type fakePush struct {
+	err      error
+	tasks    []vo.PushTask
+	messages []string
+}
+
+func (p *fakePush) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	for _, km := range msgs {
+		if km.Topic == infra.Offline.Topic {
+			p.messages = append(p.messages, string(km.Key))
+			continue
+		}
+
+		var task vo.PushTask
+		if err := json.Unmarshal(km.Value, &task); err != nil {
+			return err
+		}
+		p.tasks = append(p.tasks, task)
+	}
+	return nil
+}
+
+// memOfflineStore 按消息id保存
+type memOfflineStore map[string]*api.Message
+
+func (s memOfflineStore) Save(ctx context.Context, ms []*api.Message) error {
+	for _, m := range ms {
+		s[m.MessageId] = m
+	}
+	return nil
+}
+
+func (s memOfflineStore) List(ctx context.Context, appId string, messageIds []string) ([]*api.Message, error) {
+	var ret []*api.Message
+	for _, id := range messageIds {
+		if m, ok := s[id]; ok {
+			ret = append(ret, m)
+		}
+	}
+	return ret, nil
+}
+
+func (f *fixture) offlineService(c *global.OfflineConfig, push *fakePush) *OfflineService {
+	g := &global.Config{RRS: &global.RRSConfig{Offline: c}}
+	ms := &MessageStore{cfg: getOrDefaultHistoryConfig(nil), rds: f.rds}
+	return newOfflineService(getOrDefaultOfflineConfig(g), f.rds, ms, make(memOfflineStore), push)
+}
+
+func TestOfflineFallback(t *testing.T) {
+	ctx := context.Background()
+	f, push := newFixture(t), &fakePush{}
+	s := f.offlineService(&global.OfflineConfig{Expire: time.Hour, MaxMessages: 2}, push)
+
+	var fails []vo.DeliverFail
+	for i := 1; i <= 3; i++ {
+		m := newGroupMessage()
+		m.STime = int64(i)
+		fails = append(fails,
+			vo.DeliverFail{M: m, UserId: 2, Label: []string{"web"}},
+			vo.DeliverFail{M: m, UserId: 3, Mentioned: true})
+	}
+	n, err := s.Fallback(ctx, fails)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+
+	// 每条消息只转存一次
+	assert.Equal(t, []string{fails[0].M.MessageId, fails[2].M.MessageId, fails[4].M.MessageId}, push.messages)
+
+	// 只保留最新的两条
+	ids, err := f.rds.ZRange(ctx, infra.KeyOffline(testAppId, 2), 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{fails[2].M.MessageId, fails[4].M.MessageId}, ids)
+	assert.Equal(t, time.Hour, f.mr.TTL(infra.KeyOffline(testAppId, 3)))
+
+	assert.Len(t, push.tasks, 6)
+	assert.Equal(t, []string{"web"}, push.tasks[0].Labels)
+	assert.False(t, push.tasks[0].Mentioned)
+	assert.Empty(t, push.tasks[1].Labels)
+	assert.True(t, push.tasks[1].Mentioned)
+
+	var m api.Message
+	assert.NoError(t, proto.Unmarshal(push.tasks[1].Message, &m))
+	assert.Equal(t, fails[1].M.MessageId, m.MessageId)
+}
+
+func TestOfflineFallbackError(t *testing.T) {
+	ctx := context.Background()
+	f, push := newFixture(t), &fakePush{}
+	s := f.offlineService(nil, push)
+	fails := []vo.DeliverFail{{M: newGroupMessage(), UserId: 2}}
+
+	// 推送任务写入失败
+	push.err = io.ErrClosedPipe
+	n, err := s.Fallback(ctx, fails)
+	assert.Equal(t, errors.OfflineErr.Code, errext.Format(err).Code)
+	assert.Equal(t, 1, n)
+
+	// 系统消息不推送
+	push.err = nil
+	fails[0].M.MessageType = api.MessageTypeReceipt
+	n, err = s.Fallback(ctx, fails)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Empty(t, push.tasks)
+
+	// 收件箱写入失败时没有保存
+	f.mr.Close()
+	n, err = s.Fallback(ctx, fails)
+	assert.Equal(t, errors.OfflineErr.Code, errext.Format(err).Code)
+	assert.Equal(t, 0, n)
+}
+
+func TestOfflinePull(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	s := f.offlineService(nil, &fakePush{})
+
+	// sTime为1、2、2、2、3，第二条broker转离线时有投递记录
+	var ms []*api.Message
+	for i, sTime := range []int64{1, 2, 2, 2, 3} {
+		m := newGroupMessage()
+		m.STime, m.Sequence = sTime, int64(i+1)
+		ms = append(ms, m)
+		assert.NoError(t, s.ms.Save(ctx, m))
+		_, err := s.Fallback(ctx, []vo.DeliverFail{{M: m, UserId: 2}})
+		assert.NoError(t, err)
+	}
+	f.rds.HSet(ctx, infra.KeyOfflineAttempt(testAppId, 2), ms[1].MessageId, "{}")
+
+	ids := func(reply *api.OfflineReply) []string {
+		var ret []string
+		for _, m := range reply.Messages {
+			ret = append(ret, m.MessageId)
+		}
+		return ret
+	}
+
+	// 同一sTime的消息不跨页
+	reply, err := s.Pull(ctx, &api.OfflineRequest{AppId: testAppId, UserId: 2, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ms[0].MessageId}, ids(reply))
+	assert.True(t, reply.More)
+	assert.Equal(t, int64(1), reply.Next)
+
+	// 同一sTime超过limit时一次全部返回
+	reply, err = s.Pull(ctx, &api.OfflineRequest{AppId: testAppId, UserId: 2, Since: reply.Next, Limit: 2})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{ms[1].MessageId, ms[2].MessageId, ms[3].MessageId}, ids(reply))
+	assert.Equal(t, int64(2), reply.Next)
+
+	// 历史过期的从im_message_offline读取，已撤回的不返回并从收件箱删除
+	f.mr.Del(infra.KeyMessage(testAppId, ms[4].MessageId))
+	assert.NoError(t, s.Save(ctx, ms[4]))
+	assert.NoError(t, s.ms.Recall(ctx, testAppId, ms[0].MessageId))
+
+	reply, err = s.Pull(ctx, &api.OfflineRequest{AppId: testAppId, UserId: 2, Since: reply.Next})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ms[4].MessageId}, ids(reply))
+	assert.False(t, reply.More)
+
+	reply, err = s.Pull(ctx, &api.OfflineRequest{AppId: testAppId, UserId: 2})
+	assert.NoError(t, err)
+	assert.Len(t, reply.Messages, 4)
+	assert.NotContains(t, ids(reply), ms[0].MessageId)
+
+	// ack后从收件箱和投递记录中删除
+	assert.NoError(t, s.Remove(ctx, testAppId, 2, []string{ms[1].MessageId, ms[2].MessageId}))
+	assert.Equal(t, int64(2), f.rds.ZCard(ctx, infra.KeyOffline(testAppId, 2)).Val())
+	assert.False(t, f.mr.Exists(infra.KeyOfflineAttempt(testAppId, 2)))
+}
@@ -0,0 +1,102 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	entity "github.com/magicnana999/im/entities"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// offlineStore 转离线的消息内容，消息历史过期后从这里读取
+type offlineStore interface {
+	// Save 保存消息，已存在的忽略
+	Save(ctx context.Context, ms []*api.Message) error
+	// List 按消息id读取，不存在的不返回
+	List(ctx context.Context, appId string, messageIds []string) ([]*api.Message, error)
+}
+
+// gormOfflineStore 保存在 im_message_offline，每条消息一行，Content为消息的json
+type gormOfflineStore struct {
+	db *gorm.DB
+}
+
+func (s *gormOfflineStore) Save(ctx context.Context, ms []*api.Message) error {
+	if len(ms) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	rows := make([]entity.MessageOffline, 0, len(ms))
+	for _, m := range ms {
+		row, err := messageOfflineOf(m)
+		if err != nil {
+			return err
+		}
+		row.CreatedAt, row.UpdatedAt = now, now
+		rows = append(rows, *row)
+	}
+
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (s *gormOfflineStore) List(ctx context.Context, appId string, messageIds []string) ([]*api.Message, error) {
+	if len(messageIds) == 0 {
+		return nil, nil
+	}
+
+	var rows []entity.MessageOffline
+	err := s.db.WithContext(ctx).
+		Where("app_id = ? and message_id in ?", appId, messageIds).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*api.Message, 0, len(rows))
+	for i := range rows {
+		m := &api.Message{}
+		if err := protojson.Unmarshal([]byte(rows[i].Content), m); err != nil {
+			return nil, err
+		}
+		ret = append(ret, m)
+	}
+	return ret, nil
+}
+
+// messageOfflineOf 消息转为 im_message_offline 的一行，@和引用另外保存为json便于查询
+func messageOfflineOf(m *api.Message) (*entity.MessageOffline, error) {
+	content, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := json.Marshal(m.At)
+	if err != nil {
+		return nil, err
+	}
+
+	refer, err := json.Marshal(m.Refer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.MessageOffline{Message: entity.Message{
+		MessageId: m.MessageId,
+		AppId:     m.AppId,
+		UserId:    m.UserId,
+		To:        m.To,
+		GroupId:   m.GroupId,
+		ConvId:    m.ConvId,
+		Sequence:  m.Sequence,
+		CTime:     m.CTime,
+		STime:     m.STime,
+		CType:     m.MessageType,
+		At:        string(at),
+		Refer:     string(refer),
+		Content:   string(content),
+	}}, nil
+}
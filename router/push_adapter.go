@@ -0,0 +1,159 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/router/vo"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PushAdapter 推送通道，每个vendor一个实现
+//
+// token已失效时返回 errors.PushTokenExpired，PushService会删除该token
+type PushAdapter interface {
+	Vendor() string
+	Send(ctx context.Context, n *vo.Notification) error
+}
+
+// HttpPushAdapter 把通知以json POST到指定地址，用于本地调试、测试或对接自建的推送网关。
+// 2xx为成功，404/410表示token已失效
+type HttpPushAdapter struct {
+	vendor string
+	url    string
+	client *http.Client
+}
+
+func NewHttpPushAdapter(vendor, url string, timeout time.Duration) *HttpPushAdapter {
+	return &HttpPushAdapter{
+		vendor: vendor,
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (a *HttpPushAdapter) Vendor() string {
+	return a.vendor
+}
+
+func (a *HttpPushAdapter) Send(ctx context.Context, n *vo.Notification) error {
+	bs, err := json.Marshal(n)
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(bs))
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return errors.PushTokenExpired
+	default:
+		return errors.PushErr.SetDetail("http status: " + resp.Status)
+	}
+}
+
+// signJWT header和claims按json编码，sign对"header.claims"签名
+func signJWT(header, claims any, sign func([]byte) ([]byte, error)) (string, error) {
+	h, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(h) + "." + enc.EncodeToString(c)
+	sig, err := sign([]byte(unsigned))
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// tokenRefreshAhead 访问令牌在过期前提前刷新的时间
+const tokenRefreshAhead = time.Minute
+
+// accessToken 推送通道的访问令牌，过期前复用，fetch返回令牌和有效期
+type accessToken struct {
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	fetch   func(ctx context.Context) (string, time.Duration, error)
+}
+
+func (t *accessToken) get(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Add(tokenRefreshAhead).Before(t.expires) {
+		return t.token, nil
+	}
+
+	token, ttl, err := t.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expires = token, time.Now().Add(ttl)
+	return token, nil
+}
+
+// reset 通道返回未认证时丢弃，下次重新获取
+func (t *accessToken) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = ""
+}
+
+// oauthTokenReply oauth2令牌接口的返回
+type oauthTokenReply struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"` //秒
+}
+
+// fetchOAuthToken 以表单请求oauth2令牌
+func fetchOAuthToken(ctx context.Context, client *http.Client, tokenURL string, form url.Values) (string, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, errors.PushErr.SetDetail("token status: " + resp.Status)
+	}
+
+	var reply oauthTokenReply
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return "", 0, err
+	}
+	if reply.AccessToken == "" {
+		return "", 0, errors.PushErr.SetDetail("empty access token")
+	}
+	return reply.AccessToken, time.Duration(reply.ExpiresIn) * time.Second, nil
+}
@@ -0,0 +1,192 @@
+package router
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePKCS8 私钥以PEM编码的PKCS#8写入临时文件
+func writePKCS8(t *testing.T, key any) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// jwtPart 解码jwt的header或claims
+func jwtPart(t *testing.T, jwt string, i int) map[string]any {
+	parts := strings.Split(jwt, ".")
+	assert.Len(t, parts, 3)
+	bs, err := base64.RawURLEncoding.DecodeString(parts[i])
+	assert.NoError(t, err)
+
+	var ret map[string]any
+	assert.NoError(t, json.Unmarshal(bs, &ret))
+	return ret
+}
+
+func TestAPNsAdapter(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "apns.p8")
+	assert.NoError(t, os.WriteFile(keyFile, writePKCS8(t, key), 0600))
+
+	var got *http.Request
+	var payload apnsPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		json.NewDecoder(r.Body).Decode(&payload)
+		if strings.HasSuffix(r.URL.Path, "/expired") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+		}
+	}))
+	defer srv.Close()
+
+	a, err := NewAPNsAdapter(&global.APNsConfig{KeyFile: keyFile, KeyId: "kid", TeamId: "team", Topic: "com.im"}, time.Second)
+	assert.NoError(t, err)
+	a.url = srv.URL
+
+	n := &vo.Notification{Token: "t1", Title: "title", Body: "body", ConvId: "conv", MessageId: "m1", Badge: 3, CollapseId: "conv"}
+	assert.NoError(t, a.Send(context.Background(), n))
+	assert.Equal(t, "/3/device/t1", got.URL.Path)
+	assert.Equal(t, "com.im", got.Header.Get("apns-topic"))
+	assert.Equal(t, "conv", got.Header.Get("apns-collapse-id"))
+	assert.Equal(t, int64(3), payload.Aps.Badge)
+	assert.Equal(t, "body", payload.Aps.Alert.Body)
+	assert.Equal(t, "m1", payload.MessageId)
+
+	jwt := strings.TrimPrefix(got.Header.Get("Authorization"), "bearer ")
+	assert.Equal(t, map[string]any{"alg": "ES256", "kid": "kid"}, jwtPart(t, jwt, 0))
+	assert.Equal(t, "team", jwtPart(t, jwt, 1)["iss"])
+
+	// 令牌在刷新间隔内复用
+	assert.NoError(t, a.Send(context.Background(), n))
+	assert.Equal(t, jwt, strings.TrimPrefix(got.Header.Get("Authorization"), "bearer "))
+
+	n.Token = "expired"
+	err = a.Send(context.Background(), n)
+	assert.Equal(t, errors.PushTokenExpired.Code, errext.Format(err).Code)
+}
+
+func TestFCMAdapter(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	tokens := 0
+	var got fcmRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokens++
+			r.ParseForm()
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.Form.Get("grant_type"))
+			assert.Equal(t, "sa@im.iam", jwtPart(t, r.Form.Get("assertion"), 1)["iss"])
+			w.Write([]byte(`{"access_token":"at","expires_in":3600}`))
+		case "/v1/projects/im/messages:send":
+			assert.Equal(t, "Bearer at", r.Header.Get("Authorization"))
+			json.NewDecoder(r.Body).Decode(&got)
+			if got.Message.Token == "expired" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"status":"INVALID_ARGUMENT","details":[{"errorCode":"UNREGISTERED"}]}}`))
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cred, _ := json.Marshal(&fcmCredentials{ProjectId: "im", PrivateKey: string(writePKCS8(t, key)), ClientEmail: "sa@im.iam", TokenURI: srv.URL + "/token"})
+	credFile := filepath.Join(t.TempDir(), "fcm.json")
+	assert.NoError(t, os.WriteFile(credFile, cred, 0600))
+
+	as, err := NewFCMAdapters(&global.FCMConfig{CredentialsFile: credFile, Vendors: []string{"Samsung", "Android"}}, time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, as, 2)
+	assert.Equal(t, "Android", as[1].Vendor())
+
+	n := &vo.Notification{Token: "t1", Title: "title", Body: "body", UserId: 3, ConvId: "conv", CollapseId: "conv", Badge: 2}
+	for _, a := range as {
+		a.sendURL = srv.URL + "/v1/projects/im/messages:send"
+		assert.NoError(t, a.Send(context.Background(), n))
+	}
+	assert.Equal(t, "t1", got.Message.Token)
+	assert.Equal(t, "3", got.Message.Data["userId"])
+	assert.Equal(t, int64(2), got.Message.Android.Notification.NotificationCount)
+
+	// vendor共用访问令牌
+	assert.Equal(t, 1, tokens)
+
+	n.Token = "expired"
+	err = as[0].Send(context.Background(), n)
+	assert.Equal(t, errors.PushTokenExpired.Code, errext.Format(err).Code)
+}
+
+func TestHuaweiAdapter(t *testing.T) {
+	tokens := 0
+	var got huaweiRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokens++
+			r.ParseForm()
+			assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+			w.Write([]byte(`{"access_token":"at","expires_in":3600}`))
+			return
+		}
+
+		json.NewDecoder(r.Body).Decode(&got)
+		switch got.Message.Token[0] {
+		case "expired":
+			w.Write([]byte(`{"code":"80300007","msg":"All the tokens are invalid"}`))
+		case "auth":
+			w.Write([]byte(`{"code":"80200003","msg":"OAuth token expired"}`))
+		default:
+			w.Write([]byte(`{"code":"80000000","msg":"Success"}`))
+		}
+	}))
+	defer srv.Close()
+
+	as := NewHuaweiAdapters(&global.HuaweiPushConfig{AppId: "app", ClientSecret: "secret"}, time.Second)
+	assert.Len(t, as, 1)
+	a := as[0]
+	assert.Equal(t, "Huawei", a.Vendor())
+	assert.True(t, strings.HasSuffix(a.sendURL, "/v1/app/messages:send"))
+
+	a.sendURL = srv.URL + "/send"
+	a.token = &accessToken{fetch: func(ctx context.Context) (string, time.Duration, error) {
+		return fetchOAuthToken(ctx, a.client, srv.URL+"/token", url.Values{"grant_type": {"client_credentials"}})
+	}}
+
+	n := &vo.Notification{Token: "t1", Title: "title", Body: "body", CollapseId: "conv"}
+	assert.NoError(t, a.Send(context.Background(), n))
+	assert.Equal(t, "conv", got.Message.Android.Notification.Tag)
+
+	n.Token = "expired"
+	err := a.Send(context.Background(), n)
+	assert.Equal(t, errors.PushTokenExpired.Code, errext.Format(err).Code)
+
+	// 令牌过期后重新获取
+	n.Token = "auth"
+	err = a.Send(context.Background(), n)
+	assert.Equal(t, errors.PushErr.Code, errext.Format(err).Code)
+	n.Token = "t1"
+	assert.NoError(t, a.Send(context.Background(), n))
+	assert.Equal(t, 2, tokens)
+}
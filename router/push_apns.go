@@ -0,0 +1,217 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/router/vo"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// APNsVendor 苹果设备注册的vendor
+	APNsVendor = "iOS"
+
+	apnsProductionURL = "https://api.push.apple.com"
+	apnsSandboxURL    = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenRefresh 认证令牌的刷新间隔，苹果要求在20到60分钟之间
+	apnsTokenRefresh = 50 * time.Minute
+
+	// apnsMaxCollapseId apns-collapse-id的最大字节数
+	apnsMaxCollapseId = 64
+)
+
+// APNsAdapter 苹果推送，HTTP/2接口，使用.p8私钥签发的令牌认证。
+// 410或BadDeviceToken/Unregistered表示token已失效
+type APNsAdapter struct {
+	url      string
+	keyId    string
+	teamId   string
+	topic    string
+	key      *ecdsa.PrivateKey
+	client   *http.Client
+	mu       sync.Mutex
+	jwt      string
+	issuedAt time.Time
+}
+
+func NewAPNsAdapter(c *global.APNsConfig, timeout time.Duration) (*APNsAdapter, error) {
+	bs, err := os.ReadFile(c.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parseAPNsKey(bs)
+	if err != nil {
+		return nil, err
+	}
+
+	url := apnsProductionURL
+	if c.Sandbox {
+		url = apnsSandboxURL
+	}
+
+	return &APNsAdapter{
+		url:    url,
+		keyId:  c.KeyId,
+		teamId: c.TeamId,
+		topic:  c.Topic,
+		key:    key,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// parseAPNsKey .p8为PEM编码的PKCS#8 EC私钥
+func parseAPNsKey(bs []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(bs)
+	if block == nil {
+		return nil, errors.PushErr.SetDetail("invalid apns key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.PushErr.SetDetail("apns key is not ecdsa")
+	}
+	return ec, nil
+}
+
+func (a *APNsAdapter) Vendor() string {
+	return APNsVendor
+}
+
+// apnsPayload aps以外的字段由客户端点击通知后打开会话
+type apnsPayload struct {
+	Aps       apnsAps `json:"aps"`
+	AppId     string  `json:"appId"`
+	UserId    int64   `json:"userId"`
+	ConvId    string  `json:"convId"`
+	MessageId string  `json:"messageId"`
+}
+
+type apnsAps struct {
+	Alert    apnsAlert `json:"alert"`
+	Badge    int64     `json:"badge"`
+	Sound    string    `json:"sound"`
+	ThreadId string    `json:"thread-id,omitempty"`
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func (a *APNsAdapter) Send(ctx context.Context, n *vo.Notification) error {
+	bs, err := json.Marshal(&apnsPayload{
+		Aps: apnsAps{
+			Alert:    apnsAlert{Title: n.Title, Body: n.Body},
+			Badge:    n.Badge,
+			Sound:    "default",
+			ThreadId: n.ConvId,
+		},
+		AppId:     n.AppId,
+		UserId:    n.UserId,
+		ConvId:    n.ConvId,
+		MessageId: n.MessageId,
+	})
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+
+	token, err := a.token()
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url+"/3/device/"+n.Token, bytes.NewReader(bs))
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", a.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	if n.CollapseId != "" && len(n.CollapseId) <= apnsMaxCollapseId {
+		req.Header.Set("apns-collapse-id", n.CollapseId)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var reply struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(resp.Body).Decode(&reply)
+
+	switch {
+	case resp.StatusCode == http.StatusGone, reply.Reason == "BadDeviceToken", reply.Reason == "Unregistered":
+		return errors.PushTokenExpired
+	case reply.Reason == "ExpiredProviderToken":
+		a.resetToken()
+	}
+	return errors.PushErr.SetDetail("apns: " + resp.Status + " " + reply.Reason)
+}
+
+// token 认证令牌，超过刷新间隔时重新签发
+func (a *APNsAdapter) token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.jwt != "" && now.Sub(a.issuedAt) < apnsTokenRefresh {
+		return a.jwt, nil
+	}
+
+	header := map[string]string{"alg": "ES256", "kid": a.keyId}
+	claims := map[string]any{"iss": a.teamId, "iat": now.Unix()}
+	jwt, err := signJWT(header, claims, a.sign)
+	if err != nil {
+		return "", err
+	}
+
+	a.jwt, a.issuedAt = jwt, now
+	return jwt, nil
+}
+
+func (a *APNsAdapter) resetToken() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.jwt = ""
+}
+
+// sign ES256签名为r和s各32字节拼接
+func (a *APNsAdapter) sign(bs []byte) ([]byte, error) {
+	digest := sha256.Sum256(bs)
+	r, s, err := ecdsa.Sign(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
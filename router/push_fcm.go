@@ -0,0 +1,229 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/router/vo"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	fcmScope   = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmSendURL = "https://fcm.googleapis.com/v1/projects/"
+
+	// fcmAssertionExpire 服务账号断言的有效期，google要求不超过1小时
+	fcmAssertionExpire = time.Hour
+)
+
+// DefFCMVendors 默认通过FCM推送的vendor
+var DefFCMVendors = []string{"Samsung"}
+
+// fcmCredentials 服务账号的json密钥
+type fcmCredentials struct {
+	ProjectId   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMAdapter Firebase推送，HTTP v1接口，用服务账号换取访问令牌。
+// 一个FCM通道可以服务多个vendor，按vendor各注册一个adapter。
+// 404或UNREGISTERED表示token已失效
+type FCMAdapter struct {
+	vendor  string
+	sendURL string
+	client  *http.Client
+	token   *accessToken
+}
+
+// NewFCMAdapters 按配置的vendor创建，共用同一个访问令牌
+func NewFCMAdapters(c *global.FCMConfig, timeout time.Duration) ([]*FCMAdapter, error) {
+	bs, err := os.ReadFile(c.CredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var cred fcmCredentials
+	if err := json.Unmarshal(bs, &cred); err != nil {
+		return nil, err
+	}
+
+	key, err := parseRSAKey([]byte(cred.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: timeout}
+	token := &accessToken{fetch: func(ctx context.Context) (string, time.Duration, error) {
+		return fetchFCMToken(ctx, client, &cred, key)
+	}}
+
+	vendors := c.Vendors
+	if len(vendors) == 0 {
+		vendors = DefFCMVendors
+	}
+
+	ret := make([]*FCMAdapter, 0, len(vendors))
+	for _, v := range vendors {
+		ret = append(ret, &FCMAdapter{
+			vendor:  v,
+			sendURL: fcmSendURL + cred.ProjectId + "/messages:send",
+			client:  client,
+			token:   token,
+		})
+	}
+	return ret, nil
+}
+
+// parseRSAKey 服务账号的私钥为PEM编码的PKCS#8 RSA私钥
+func parseRSAKey(bs []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(bs)
+	if block == nil {
+		return nil, errors.PushErr.SetDetail("invalid fcm private key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rk, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.PushErr.SetDetail("fcm private key is not rsa")
+	}
+	return rk, nil
+}
+
+// fetchFCMToken 用RS256签名的断言换取访问令牌
+func fetchFCMToken(ctx context.Context, client *http.Client, cred *fcmCredentials, key *rsa.PrivateKey) (string, time.Duration, error) {
+	now := time.Now()
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]any{
+		"iss":   cred.ClientEmail,
+		"scope": fcmScope,
+		"aud":   cred.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(fcmAssertionExpire).Unix(),
+	}
+
+	assertion, err := signJWT(header, claims, func(bs []byte) ([]byte, error) {
+		digest := sha256.Sum256(bs)
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	return fetchOAuthToken(ctx, client, cred.TokenURI, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+}
+
+func (a *FCMAdapter) Vendor() string {
+	return a.vendor
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data"`
+	Android      fcmAndroid        `json:"android"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmAndroid struct {
+	CollapseKey  string                 `json:"collapse_key,omitempty"`
+	Notification fcmAndroidNotification `json:"notification"`
+}
+
+type fcmAndroidNotification struct {
+	Tag               string `json:"tag,omitempty"`
+	NotificationCount int64  `json:"notification_count"`
+}
+
+func (a *FCMAdapter) Send(ctx context.Context, n *vo.Notification) error {
+	bs, err := json.Marshal(&fcmRequest{Message: fcmMessage{
+		Token:        n.Token,
+		Notification: fcmNotification{Title: n.Title, Body: n.Body},
+		Data: map[string]string{
+			"appId":     n.AppId,
+			"userId":    strconv.FormatInt(n.UserId, 10),
+			"convId":    n.ConvId,
+			"messageId": n.MessageId,
+		},
+		Android: fcmAndroid{
+			CollapseKey:  n.CollapseId,
+			Notification: fcmAndroidNotification{Tag: n.CollapseId, NotificationCount: n.Badge},
+		},
+	}})
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+
+	token, err := a.token.get(ctx)
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.sendURL, bytes.NewReader(bs))
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var reply struct {
+		Error struct {
+			Status  string `json:"status"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	json.NewDecoder(resp.Body).Decode(&reply)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.PushTokenExpired
+	}
+	for _, d := range reply.Error.Details {
+		if d.ErrorCode == "UNREGISTERED" {
+			return errors.PushTokenExpired
+		}
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		a.token.reset()
+	}
+	return errors.PushErr.SetDetail("fcm: " + resp.Status + " " + reply.Error.Status)
+}
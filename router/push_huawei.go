@@ -0,0 +1,148 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/router/vo"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	huaweiTokenURL = "https://oauth-login.cloud.huawei.com/oauth2/v3/token"
+	huaweiSendURL  = "https://push-api.cloud.huawei.com/v1/"
+
+	huaweiSuccess        = "80000000"
+	huaweiTokenInvalid   = "80300007" //所有token都无效
+	huaweiAuthFailed     = "80200001"
+	huaweiAuthExpired    = "80200003"
+	huaweiClickOpenApp   = 3 //点击通知打开app
+	huaweiCollapseNotify = -1
+)
+
+// DefHuaweiVendors 默认通过华为推送的vendor
+var DefHuaweiVendors = []string{"Huawei"}
+
+// HuaweiAdapter 华为推送服务，用appId和密钥换取访问令牌。
+// 返回80300007表示token已失效
+type HuaweiAdapter struct {
+	vendor  string
+	sendURL string
+	client  *http.Client
+	token   *accessToken
+}
+
+// NewHuaweiAdapters 按配置的vendor创建，共用同一个访问令牌
+func NewHuaweiAdapters(c *global.HuaweiPushConfig, timeout time.Duration) []*HuaweiAdapter {
+	client := &http.Client{Timeout: timeout}
+	token := &accessToken{fetch: func(ctx context.Context) (string, time.Duration, error) {
+		return fetchOAuthToken(ctx, client, huaweiTokenURL, url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {c.AppId},
+			"client_secret": {c.ClientSecret},
+		})
+	}}
+
+	vendors := c.Vendors
+	if len(vendors) == 0 {
+		vendors = DefHuaweiVendors
+	}
+
+	ret := make([]*HuaweiAdapter, 0, len(vendors))
+	for _, v := range vendors {
+		ret = append(ret, &HuaweiAdapter{
+			vendor:  v,
+			sendURL: huaweiSendURL + c.AppId + "/messages:send",
+			client:  client,
+			token:   token,
+		})
+	}
+	return ret
+}
+
+func (a *HuaweiAdapter) Vendor() string {
+	return a.vendor
+}
+
+type huaweiRequest struct {
+	Message huaweiMessage `json:"message"`
+}
+
+type huaweiMessage struct {
+	Token   []string      `json:"token"`
+	Android huaweiAndroid `json:"android"`
+}
+
+type huaweiAndroid struct {
+	CollapseKey  int                `json:"collapse_key"`
+	Notification huaweiNotification `json:"notification"`
+}
+
+type huaweiNotification struct {
+	Title       string            `json:"title"`
+	Body        string            `json:"body"`
+	Tag         string            `json:"tag,omitempty"`
+	ClickAction huaweiClickAction `json:"click_action"`
+}
+
+type huaweiClickAction struct {
+	Type int `json:"type"`
+}
+
+func (a *HuaweiAdapter) Send(ctx context.Context, n *vo.Notification) error {
+	bs, err := json.Marshal(&huaweiRequest{Message: huaweiMessage{
+		Token: []string{n.Token},
+		Android: huaweiAndroid{
+			CollapseKey: huaweiCollapseNotify,
+			Notification: huaweiNotification{
+				Title:       n.Title,
+				Body:        n.Body,
+				Tag:         n.CollapseId,
+				ClickAction: huaweiClickAction{Type: huaweiClickOpenApp},
+			},
+		},
+	}})
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+
+	token, err := a.token.get(ctx)
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.sendURL, bytes.NewReader(bs))
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return errors.PushErr.SetDetail("huawei: " + resp.Status)
+	}
+
+	switch reply.Code {
+	case huaweiSuccess:
+		return nil
+	case huaweiTokenInvalid:
+		return errors.PushTokenExpired
+	case huaweiAuthFailed, huaweiAuthExpired:
+		a.token.reset()
+	}
+	return errors.PushErr.SetDetail("huawei: " + reply.Code + " " + reply.Msg)
+}
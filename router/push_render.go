@@ -0,0 +1,127 @@
+package router
+
+import (
+	"fmt"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"strings"
+)
+
+const (
+	// DefPushLocale token和配置都没有指定语言时的通知语言
+	DefPushLocale = "zh-CN"
+
+	pushTextTitle      = "title"
+	pushTextGroupTitle = "groupTitle"
+	pushTextMention    = "mention"
+	pushTextCollapsed  = "collapsed"
+	pushTextDefault    = "default"
+)
+
+// pushTexts 通知文案，按语言和消息类型；文本以外的类型只显示占位文字
+var pushTexts = map[string]map[string]string{
+	"zh": {
+		pushTextTitle:           "新消息",
+		pushTextGroupTitle:      "群聊新消息",
+		pushTextMention:         "[有人@我]",
+		pushTextCollapsed:       "[%d条]",
+		pushTextDefault:         "[消息]",
+		api.MessageTypeImage:    "[图片]",
+		api.MessageTypeAudio:    "[语音]",
+		api.MessageTypeVideo:    "[视频]",
+		api.MessageTypeFile:     "[文件]",
+		api.MessageTypeLocation: "[位置]",
+		api.MessageTypeCard:     "[名片]",
+		api.MessageTypeCustom:   "[自定义消息]",
+		api.MessageTypeMerged:   "[聊天记录]",
+		api.MessageTypeEnvelope: "[加密消息]",
+	},
+	"en": {
+		pushTextTitle:           "New message",
+		pushTextGroupTitle:      "New group message",
+		pushTextMention:         "[Mentioned]",
+		pushTextCollapsed:       "[%d messages] ",
+		pushTextDefault:         "[Message]",
+		api.MessageTypeImage:    "[Image]",
+		api.MessageTypeAudio:    "[Voice]",
+		api.MessageTypeVideo:    "[Video]",
+		api.MessageTypeFile:     "[File]",
+		api.MessageTypeLocation: "[Location]",
+		api.MessageTypeCard:     "[Contact]",
+		api.MessageTypeCustom:   "[Custom message]",
+		api.MessageTypeMerged:   "[Chat history]",
+		api.MessageTypeEnvelope: "[Encrypted message]",
+	},
+}
+
+// pushLanguage 按语言匹配文案，zh-TW、zh_CN都使用zh，不支持的语言使用def
+func pushLanguage(locale, def string) string {
+	for _, l := range []string{locale, def, DefPushLocale} {
+		lang := strings.ToLower(l)
+		if i := strings.IndexAny(lang, "-_"); i >= 0 {
+			lang = lang[:i]
+		}
+		if _, ok := pushTexts[lang]; ok {
+			return lang
+		}
+	}
+	return "zh"
+}
+
+// renderNotification 生成通知的标题和内容，文本截断为preview个字；
+// 标题为群名或发送者，名称为空时使用默认标题。群消息和合并的通知内容带上发送者，
+// count为合并的消息数，大于1时内容为最后一条消息加上条数
+func renderNotification(m *api.Message, lang, sender, group string, mentioned bool, count int64, preview int) (string, string) {
+	texts := pushTexts[lang]
+
+	title := texts[pushTextTitle]
+	switch {
+	case group != "":
+		title = group
+	case m.IsToGroup():
+		title = texts[pushTextGroupTitle]
+	case sender != "":
+		title = sender
+	}
+
+	body := renderBody(m, texts, preview)
+	if sender != "" && (m.IsToGroup() || count > 1) {
+		body = sender + ": " + body
+	}
+	if count > 1 {
+		body = fmt.Sprintf(texts[pushTextCollapsed], count) + body
+	}
+	if mentioned {
+		body = texts[pushTextMention] + body
+	}
+	return title, body
+}
+
+func renderBody(m *api.Message, texts map[string]string, preview int) string {
+	placeholder, ok := texts[m.MessageType]
+	if !ok {
+		placeholder = texts[pushTextDefault]
+	}
+
+	var detail string
+	switch c := m.Content.(type) {
+	case *api.Message_Text:
+		runes := []rune(c.Text.GetText())
+		if len(runes) > preview {
+			return string(runes[:preview]) + "…"
+		}
+		return string(runes)
+	case *api.Message_File:
+		detail = c.File.GetName()
+	case *api.Message_Location:
+		detail = c.Location.GetTitle()
+	case *api.Message_Card:
+		detail = c.Card.GetName()
+	case *api.Message_Merged:
+		detail = c.Merged.GetTitle()
+	}
+
+	if detail == "" {
+		return placeholder
+	}
+	return placeholder + " " + detail
+}
@@ -0,0 +1,153 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	// consumeBackoffMin 读取kafka失败后第一次等待的时间，连续失败时加倍
+	consumeBackoffMin = 100 * time.Millisecond
+
+	// consumeBackoffMax 读取kafka失败后最长的等待时间
+	consumeBackoffMax = 5 * time.Second
+
+	// pushConsumeAttempts 推送任务合并失败时最多处理的次数，超过后写入死信
+	pushConsumeAttempts = 3
+)
+
+// consumeBackoff 第failures次连续读取失败后等待，ctx结束时返回false
+func consumeBackoff(ctx context.Context, failures int) bool {
+	d := consumeBackoffMax
+	if failures < 16 {
+		d = min(consumeBackoffMin<<failures, consumeBackoffMax)
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// PushServer 消费推送任务并定时发送合并后的推送，没有配置推送通道时不运行
+type PushServer struct {
+	reader *kafka.Reader
+	ps     *PushService
+	cancel context.CancelFunc
+	logger *logger.Logger
+}
+
+func NewPushServer(g *global.Config, ps *PushService, lc fx.Lifecycle) *PushServer {
+	s := &PushServer{
+		ps:     ps,
+		logger: logger.Named("push"),
+	}
+
+	if !ps.Enabled() {
+		s.logger.Info("no push vendor configured")
+		return s
+	}
+
+	s.reader = infra.NewKafkaConsumer(g, infra.Push)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return s.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+	return s
+}
+
+func (s *PushServer) Start(ctx context.Context) error {
+	c, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func() {
+		s.logger.Info("push consumer started")
+		failures := 0
+		for {
+			km, err := s.reader.ReadMessage(c)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				s.logger.Error("failed to read push task", zap.Int("failures", failures), zap.Error(err))
+				if !consumeBackoff(c, failures) {
+					return
+				}
+				failures++
+				continue
+			}
+			failures = 0
+			s.consume(c, km)
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(pushFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.Done():
+				return
+			case now := <-ticker.C:
+				s.ps.Flush(c, now)
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *PushServer) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if err := s.reader.Close(); err != nil {
+		s.logger.Error("failed to close push consumer", zap.Error(err))
+		return err
+	}
+	s.logger.Info("push consumer stopped")
+	return nil
+}
+
+// consume 合并失败时重试，仍失败时写入死信
+func (s *PushServer) consume(ctx context.Context, km kafka.Message) {
+	var task vo.PushTask
+	if err := json.Unmarshal(km.Value, &task); err != nil {
+		s.logger.Error("invalid push task", zap.Error(err))
+		return
+	}
+
+	var err error
+	for i := 0; i < pushConsumeAttempts; i++ {
+		if _, err = s.ps.Push(ctx, &task); err == nil {
+			return
+		}
+		if !consumeBackoff(ctx, i) {
+			break
+		}
+	}
+
+	s.logger.Warn("push fail",
+		zap.String("appId", task.AppId),
+		zap.Int64("userId", task.UserId),
+		zap.Error(err))
+	if err := s.ps.DeadLetter(ctx, &task); err != nil {
+		s.logger.Error("failed to write push dead letter", zap.Int64("userId", task.UserId), zap.Error(err))
+	}
+}
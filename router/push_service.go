@@ -0,0 +1,614 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/define"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefPushTimeout 调用推送通道的默认超时
+	DefPushTimeout = 5 * time.Second
+
+	// DefPushPreview 文本消息在通知中显示的默认字数
+	DefPushPreview = 50
+
+	// DefPushCollapse 同一会话推送的默认合并窗口
+	DefPushCollapse = 5 * time.Second
+
+	// DefPushMaxAttempts 发送失败的推送默认最多发送的次数
+	DefPushMaxAttempts = 3
+
+	// DefPushRetryInterval 发送失败后第一次重试的默认间隔
+	DefPushRetryInterval = 10 * time.Second
+
+	// pushFlushInterval 检查合并窗口到期的间隔
+	pushFlushInterval = time.Second
+
+	// pushFlushBatch 每次发送的待发推送上限
+	pushFlushBatch = 100
+
+	// pushBadgeExpire 未读数最后一次增加后的保留时间
+	pushBadgeExpire = 30 * 24 * time.Hour
+)
+
+// pushCollapseScript KEYS[1]为未读数，KEYS[2]为待发推送，KEYS[3]为到期时间；
+// ARGV为convId、推送任务、是否被@、到期时间、到期成员、未读数过期毫秒、是否推送。
+// 会话没有待发推送时开始计时，否则累加条数；被@的任务不会被后到的普通消息替换。
+// 不推送时只增加未读数。返回待发的消息数
+var pushCollapseScript = redis.NewScript(`
+	redis.call("HINCRBY", KEYS[1], ARGV[1], 1)
+	redis.call("PEXPIRE", KEYS[1], ARGV[6])
+	if ARGV[7] ~= "1" then
+		return 0
+	end
+	local p = {count = 1, mentioned = ARGV[3] == "1", task = ARGV[2]}
+	local v = redis.call("HGET", KEYS[2], ARGV[1])
+	if v then
+		local o = cjson.decode(v)
+		p.count = o.count + 1
+		if o.mentioned and not p.mentioned then
+			p.mentioned = true
+			p.task = o.task
+		end
+	else
+		redis.call("ZADD", KEYS[3], ARGV[4], ARGV[5])
+	end
+	redis.call("HSET", KEYS[2], ARGV[1], cjson.encode(p))
+	redis.call("PEXPIRE", KEYS[2], ARGV[6])
+	return p.count
+`)
+
+// pushClaimScript KEYS[1]为到期时间，KEYS[2]为待发推送，ARGV为到期成员、convId；
+// 取出并删除待发推送，已被其他router取出或已取消时返回nil
+var pushClaimScript = redis.NewScript(`
+	if redis.call("ZREM", KEYS[1], ARGV[1]) == 0 then
+		return false
+	end
+	local v = redis.call("HGET", KEYS[2], ARGV[2])
+	redis.call("HDEL", KEYS[2], ARGV[2])
+	return v
+`)
+
+// pushRetryScript KEYS[1]为待发推送，KEYS[2]为到期时间，ARGV为convId、待发推送、到期时间、到期成员；
+// 会话已有新的待发推送时由新的推送通知，不再重试，返回0
+var pushRetryScript = redis.NewScript(`
+	if redis.call("HSETNX", KEYS[1], ARGV[1], ARGV[2]) == 0 then
+		return 0
+	end
+	redis.call("ZADD", KEYS[2], ARGV[3], ARGV[4])
+	return 1
+`)
+
+// pendingPush 会话中待发的推送，由pushCollapseScript维护
+type pendingPush struct {
+	Count     int64  `json:"count"`
+	Mentioned bool   `json:"mentioned"`
+	Task      string `json:"task"`               //最后一条或被@的推送任务
+	Attempts  int    `json:"attempts,omitempty"` //已发送失败的次数
+}
+
+// PushService 设备推送token的注册和离线推送
+//
+// token按连接的label保存在 im:{appId}:push:tokens:{userId} hash 中（label -> PushToken）。
+// 推送任务中有label时只推送这些连接，否则推送用户的所有设备。
+//
+// 同一会话的推送在合并窗口内合并为一条，待发推送保存在 im:{appId}:push:pending:{userId}，
+// 到期时间在 im:push:due；每个会话的未读数保存在 im:{appId}:push:badge:{userId}，合计为角标数。
+// 发送失败的推送按退避时间重新放回待发推送，只发送失败的设备；超过次数后写入 msg-push-dlq
+type PushService struct {
+	cfg      *global.PushConfig
+	rds      *redis.Client
+	us       *UserService
+	gs       *GroupService
+	cs       *ConvService
+	kw       kafkaWriter
+	adapters map[string]PushAdapter
+	logger   *logger.Logger
+}
+
+func getOrDefaultPushConfig(g *global.Config) *global.PushConfig {
+	c := &global.PushConfig{}
+	if g != nil && g.RRS != nil && g.RRS.Push != nil {
+		*c = *g.RRS.Push
+	}
+
+	if c.Locale == "" {
+		c.Locale = DefPushLocale
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = DefPushTimeout
+	}
+
+	if c.Preview <= 0 {
+		c.Preview = DefPushPreview
+	}
+
+	if c.Collapse <= 0 {
+		c.Collapse = DefPushCollapse
+	}
+
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefPushMaxAttempts
+	}
+
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = DefPushRetryInterval
+	}
+
+	return c
+}
+
+// NewPushService 厂商通道优先，Vendors中的http通道替换同名的厂商通道
+func NewPushService(g *global.Config, rds *redis.Client, us *UserService, gs *GroupService, cs *ConvService, kw *kafka.Writer, lc fx.Lifecycle) (*PushService, error) {
+	c := getOrDefaultPushConfig(g)
+	s := newPushService(c, rds, us, gs, cs, kw)
+
+	if c.APNs != nil {
+		a, err := NewAPNsAdapter(c.APNs, c.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		s.AddAdapter(a)
+	}
+
+	if c.FCM != nil {
+		as, err := NewFCMAdapters(c.FCM, c.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range as {
+			s.AddAdapter(a)
+		}
+	}
+
+	if c.Huawei != nil {
+		for _, a := range NewHuaweiAdapters(c.Huawei, c.Timeout) {
+			s.AddAdapter(a)
+		}
+	}
+
+	for vendor, url := range c.Vendors {
+		s.AddAdapter(NewHttpPushAdapter(vendor, url, c.Timeout))
+	}
+	return s, nil
+}
+
+func newPushService(c *global.PushConfig, rds *redis.Client, us *UserService, gs *GroupService, cs *ConvService, kw kafkaWriter) *PushService {
+	return &PushService{
+		cfg:      c,
+		rds:      rds,
+		us:       us,
+		gs:       gs,
+		cs:       cs,
+		kw:       kw,
+		adapters: make(map[string]PushAdapter),
+		logger:   logger.Named("push"),
+	}
+}
+
+// AddAdapter 设置vendor的推送通道，替换已有的
+func (s *PushService) AddAdapter(a PushAdapter) {
+	s.adapters[a.Vendor()] = a
+}
+
+// Enabled 至少配置了一个推送通道
+func (s *PushService) Enabled() bool {
+	return len(s.adapters) > 0
+}
+
+// Register 保存连接的推送token，token为空时删除
+func (s *PushService) Register(ctx context.Context, req *api.PushRegisterRequest) (*api.PushRegisterReply, error) {
+	if req.AppId == "" || req.UserId == 0 || req.Label == "" {
+		return nil, errors.PushInvalid.SetDetail("appId, userId and label are required")
+	}
+
+	key := infra.KeyPushTokens(req.AppId, req.UserId)
+	if req.Token == "" {
+		if err := s.rds.HDel(ctx, key, req.Label).Err(); err != nil {
+			return nil, errors.PushErr.SetDetail(err.Error())
+		}
+		return &api.PushRegisterReply{}, nil
+	}
+
+	if define.OSType(req.Vendor).GetDeviceType() != define.Mobile {
+		return nil, errors.PushInvalid.SetDetail("unsupported vendor " + req.Vendor)
+	}
+
+	bs, err := json.Marshal(&vo.PushToken{
+		Label:      req.Label,
+		Vendor:     req.Vendor,
+		Token:      req.Token,
+		Locale:     req.Locale,
+		UpdateTime: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, errors.PushErr.SetDetail(err.Error())
+	}
+
+	if err := s.rds.HSet(ctx, key, req.Label, bs).Err(); err != nil {
+		return nil, errors.PushErr.SetDetail(err.Error())
+	}
+	return &api.PushRegisterReply{}, nil
+}
+
+// Push 推送任务合并到会话的待发推送，返回会话中待发的消息数；
+// 第一条消息开始计时，合并窗口到期后由Flush发出。按接收者的通知设置决定是否计入未读数和推送
+func (s *PushService) Push(ctx context.Context, task *vo.PushTask) (int64, error) {
+	var m api.Message
+	if err := proto.Unmarshal(task.Message, &m); err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+
+	// 发送者自己的其他设备不推送
+	if m.UserId == task.UserId {
+		return 0, nil
+	}
+
+	unread, notify, err := s.cs.Decide(ctx, vo.DeliverFail{M: &m, UserId: task.UserId, Mentioned: task.Mentioned}, time.Now())
+	if err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+	if !unread {
+		return 0, nil
+	}
+
+	bs, err := json.Marshal(task)
+	if err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+
+	mentioned, push := "0", "0"
+	if task.Mentioned {
+		mentioned = "1"
+	}
+	if notify {
+		push = "1"
+	}
+
+	keys := []string{
+		infra.KeyPushBadge(task.AppId, task.UserId),
+		infra.KeyPushPending(task.AppId, task.UserId),
+		infra.KeyPushDue(),
+	}
+	due := time.Now().Add(s.cfg.Collapse).UnixMilli()
+	count, err := pushCollapseScript.Run(ctx, s.rds, keys,
+		m.ConvId, bs, mentioned, due, pushDueMember(task.AppId, task.UserId, m.ConvId), pushBadgeExpire.Milliseconds(), push).Int64()
+	if err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+	return count, nil
+}
+
+// Flush 发送合并窗口在now之前到期的推送，返回发出的通知数。
+// 用户已重新连接时不再推送
+func (s *PushService) Flush(ctx context.Context, now time.Time) (int, error) {
+	members, err := s.rds.ZRangeByScore(ctx, infra.KeyPushDue(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.UnixMilli(), 10),
+		Count: pushFlushBatch,
+	}).Result()
+	if err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+
+	sent := 0
+	var ret error
+	for _, member := range members {
+		n, err := s.flush(ctx, member)
+		sent += n
+		if err != nil {
+			s.logger.Warn("push fail", zap.String("pending", member), zap.Int("sent", n), zap.Error(err))
+			ret = err
+		}
+	}
+	return sent, ret
+}
+
+// flush 取出一个会话的待发推送，多个router同时处理时只有一方取到
+func (s *PushService) flush(ctx context.Context, member string) (int, error) {
+	appId, userId, convId, ok := parsePushDueMember(member)
+	if !ok {
+		s.logger.Error("invalid pending push", zap.String("pending", member))
+		return 0, s.rds.ZRem(ctx, infra.KeyPushDue(), member).Err()
+	}
+
+	v, err := pushClaimScript.Run(ctx, s.rds, []string{infra.KeyPushDue(), infra.KeyPushPending(appId, userId)}, member, convId).Text()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+
+	var p pendingPush
+	if err := json.Unmarshal([]byte(v), &p); err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+
+	var task vo.PushTask
+	if err := json.Unmarshal([]byte(p.Task), &task); err != nil {
+		return 0, errors.PushErr.SetDetail(err.Error())
+	}
+
+	sent, retry, err := s.deliver(ctx, appId, userId, p, &task)
+	if retry != nil {
+		s.retry(ctx, member, convId, p, retry)
+	}
+	return sent, err
+}
+
+// deliver 发送到任务中的设备，返回需要重试的任务：发送前出错时为原任务，
+// 部分设备发送失败时只包含这些设备，token失效的不重试
+func (s *PushService) deliver(ctx context.Context, appId string, userId int64, p pendingPush, task *vo.PushTask) (int, *vo.PushTask, error) {
+	var m api.Message
+	if err := proto.Unmarshal(task.Message, &m); err != nil {
+		return 0, nil, errors.PushErr.SetDetail(err.Error())
+	}
+
+	online, err := s.online(ctx, task)
+	if err != nil {
+		return 0, task, errors.PushErr.SetDetail(err.Error())
+	}
+	if online {
+		return 0, nil, nil
+	}
+
+	tokens, err := s.tokens(ctx, task)
+	if err != nil {
+		return 0, task, errors.PushErr.SetDetail(err.Error())
+	}
+
+	badge, err := s.Badge(ctx, appId, userId)
+	if err != nil {
+		return 0, task, errors.PushErr.SetDetail(err.Error())
+	}
+
+	sender, group := s.names(ctx, &m)
+
+	sent := 0
+	var failed []string
+	var ret error
+	for _, t := range tokens {
+		adapter, ok := s.adapters[t.Vendor]
+		if !ok {
+			continue
+		}
+		if err := s.send(ctx, adapter, &m, task, t, p, sender, group, badge); err != nil {
+			if errext.Format(err).Code != errors.PushTokenExpired.Code {
+				failed = append(failed, t.Label)
+			}
+			ret = err
+			continue
+		}
+		sent++
+	}
+
+	if len(failed) == 0 {
+		return sent, nil, ret
+	}
+	retry := *task
+	retry.Labels = failed
+	return sent, &retry, ret
+}
+
+// retry 按退避时间放回待发推送，超过次数或放回失败时写入死信
+func (s *PushService) retry(ctx context.Context, member, convId string, p pendingPush, task *vo.PushTask) {
+	p.Attempts++
+	if p.Attempts >= s.cfg.MaxAttempts {
+		s.deadLetter(ctx, task, "max attempts")
+		return
+	}
+
+	bs, err := json.Marshal(task)
+	if err != nil {
+		s.deadLetter(ctx, task, err.Error())
+		return
+	}
+	p.Task = string(bs)
+
+	v, err := json.Marshal(&p)
+	if err != nil {
+		s.deadLetter(ctx, task, err.Error())
+		return
+	}
+
+	keys := []string{infra.KeyPushPending(task.AppId, task.UserId), infra.KeyPushDue()}
+	due := time.Now().Add(s.cfg.RetryInterval << (p.Attempts - 1)).UnixMilli()
+	if err := pushRetryScript.Run(ctx, s.rds, keys, convId, v, due, member).Err(); err != nil {
+		s.deadLetter(ctx, task, err.Error())
+	}
+}
+
+// DeadLetter 写入 msg-push-dlq，格式与推送任务相同，可以重新写入 msg-push
+func (s *PushService) DeadLetter(ctx context.Context, task *vo.PushTask) error {
+	if s.kw == nil {
+		return nil
+	}
+
+	bs, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	return s.kw.WriteMessages(ctx, kafka.Message{
+		Topic: infra.PushDLQ.Topic,
+		Key:   []byte(strconv.FormatInt(task.UserId, 10)),
+		Value: bs,
+	})
+}
+
+// deadLetter 写入死信失败时只能记录日志
+func (s *PushService) deadLetter(ctx context.Context, task *vo.PushTask, reason string) {
+	err := s.DeadLetter(ctx, task)
+	s.logger.Warn("push dead letter",
+		zap.String("appId", task.AppId),
+		zap.Int64("userId", task.UserId),
+		zap.Strings("labels", task.Labels),
+		zap.String("reason", reason),
+		zap.Error(err))
+}
+
+// online 任务中的连接（没有时为任意连接）已重新登录
+func (s *PushService) online(ctx context.Context, task *vo.PushTask) (bool, error) {
+	ucs, err := s.us.GetUsersClients(ctx, task.AppId, []int64{task.UserId})
+	if err != nil {
+		return false, err
+	}
+
+	for _, uc := range ucs[task.UserId] {
+		if len(task.Labels) == 0 || slices.Contains(task.Labels, uc.Label) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Cancel 用户在线收到了这些会话的消息，取消待发推送；未读数只在已读时清除
+func (s *PushService) Cancel(ctx context.Context, appId string, userId int64, convIds []string) error {
+	if len(convIds) == 0 {
+		return nil
+	}
+
+	members := make([]any, 0, len(convIds))
+	for _, convId := range convIds {
+		members = append(members, pushDueMember(appId, userId, convId))
+	}
+
+	pipe := s.rds.TxPipeline()
+	pipe.ZRem(ctx, infra.KeyPushDue(), members...)
+	pipe.HDel(ctx, infra.KeyPushPending(appId, userId), convIds...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	return nil
+}
+
+// ClearBadge 会话已读，清除该会话的未读数
+func (s *PushService) ClearBadge(ctx context.Context, appId string, userId int64, convId string) error {
+	if err := s.rds.HDel(ctx, infra.KeyPushBadge(appId, userId), convId).Err(); err != nil {
+		return errors.PushErr.SetDetail(err.Error())
+	}
+	return nil
+}
+
+// Badge 所有会话的未读数之和
+func (s *PushService) Badge(ctx context.Context, appId string, userId int64) (int64, error) {
+	values, err := s.rds.HVals(ctx, infra.KeyPushBadge(appId, userId)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	var badge int64
+	for _, v := range values {
+		n, _ := strconv.ParseInt(v, 10, 64)
+		badge += n
+	}
+	return badge, nil
+}
+
+// tokens 任务中的label有值时只返回这些连接的token
+func (s *PushService) tokens(ctx context.Context, task *vo.PushTask) ([]vo.PushToken, error) {
+	values, err := s.rds.HGetAll(ctx, infra.KeyPushTokens(task.AppId, task.UserId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]vo.PushToken, 0, len(values))
+	for label, v := range values {
+		if len(task.Labels) > 0 && !slices.Contains(task.Labels, label) {
+			continue
+		}
+
+		var t vo.PushToken
+		if err := json.Unmarshal([]byte(v), &t); err != nil {
+			s.logger.Error("invalid push token", zap.String("label", label), zap.Error(err))
+			continue
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// names 发送者和群的名称，查询失败时为空，通知使用默认标题
+func (s *PushService) names(ctx context.Context, m *api.Message) (string, string) {
+	var sender, group string
+	if p, err := s.us.GetUserProfile(ctx, m.AppId, m.UserId); err == nil {
+		sender = p.UserName
+	} else if err != redis.Nil {
+		s.logger.Warn("failed to get sender profile", zap.Int64("userId", m.UserId), zap.Error(err))
+	}
+
+	if !m.IsToGroup() {
+		return sender, group
+	}
+	if p, err := s.gs.GetGroupProfile(ctx, m.AppId, m.GroupId); err == nil {
+		group = p.GroupName
+	} else if err != redis.Nil {
+		s.logger.Warn("failed to get group profile", zap.Int64("groupId", m.GroupId), zap.Error(err))
+	}
+	return sender, group
+}
+
+// send token失效时删除
+func (s *PushService) send(ctx context.Context, adapter PushAdapter, m *api.Message, task *vo.PushTask, t vo.PushToken, p pendingPush, sender, group string, badge int64) error {
+	title, body := renderNotification(m, pushLanguage(t.Locale, s.cfg.Locale), sender, group, p.Mentioned, p.Count, s.cfg.Preview)
+	err := adapter.Send(ctx, &vo.Notification{
+		Vendor:     t.Vendor,
+		Token:      t.Token,
+		Title:      title,
+		Body:       body,
+		AppId:      task.AppId,
+		UserId:     task.UserId,
+		ConvId:     m.ConvId,
+		MessageId:  m.MessageId,
+		Count:      p.Count,
+		Badge:      badge,
+		CollapseId: m.ConvId,
+	})
+	if err == nil {
+		return nil
+	}
+
+	if errext.Format(err).Code == errors.PushTokenExpired.Code {
+		if err := s.rds.HDel(ctx, infra.KeyPushTokens(task.AppId, task.UserId), t.Label).Err(); err != nil {
+			s.logger.Error("failed to delete expired token", zap.String("label", t.Label), zap.Error(err))
+		}
+	}
+	return err
+}
+
+func pushDueMember(appId string, userId int64, convId string) string {
+	return fmt.Sprintf("%s:%d:%s", appId, userId, convId)
+}
+
+// parsePushDueMember convId中可能有冒号，只按前两个拆分
+func parsePushDueMember(member string) (string, int64, string, bool) {
+	parts := strings.SplitN(member, ":", 3)
+	if len(parts) != 3 {
+		return "", 0, "", false
+	}
+
+	userId, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, "", false
+	}
+	return parts[0], userId, parts[2], true
+}
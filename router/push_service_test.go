@@ -0,0 +1,391 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeVendor 本地的推送通道，记录收到的通知，expired中的token返回410，failing中的返回500
+type fakeVendor struct {
+	mu      sync.Mutex
+	got     []vo.Notification
+	expired map[string]bool
+	failing map[string]bool
+}
+
+func (v *fakeVendor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var n vo.Notification
+	if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.expired[n.Token] {
+		w.WriteHeader(http.StatusGone)
+		return
+	}
+	if v.failing[n.Token] {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	v.got = append(v.got, n)
+}
+
+func (v *fakeVendor) notifications() []vo.Notification {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	ret := v.got
+	v.got = nil
+	return ret
+}
+
+// pushService 死信写入fakePush
+func (f *fixture) pushService() (*PushService, *fakeVendor) {
+	cs := f.convService()
+	v := &fakeVendor{expired: map[string]bool{}, failing: map[string]bool{}}
+	srv := httptest.NewServer(v)
+	f.tb.Cleanup(srv.Close)
+
+	c := &global.PushConfig{Locale: "en-US", Preview: 5, RetryInterval: time.Minute}
+	s := newPushService(getOrDefaultPushConfig(&global.Config{RRS: &global.RRSConfig{Push: c}}), cs.rds, &UserService{rds: cs.rds}, cs.gs, cs, &fakePush{})
+	s.AddAdapter(NewHttpPushAdapter("iOS", srv.URL, time.Second))
+	s.AddAdapter(NewHttpPushAdapter("Huawei", srv.URL, time.Second))
+	return s, v
+}
+
+func registerPush(t *testing.T, s *PushService, label, vendor, token, locale string) {
+	_, err := s.Register(context.Background(), &api.PushRegisterRequest{
+		AppId: testAppId, UserId: 3, Label: label, Vendor: vendor, Token: token, Locale: locale,
+	})
+	assert.NoError(t, err)
+}
+
+func newPushTask(t *testing.T, m *api.Message, mentioned bool, labels ...string) *vo.PushTask {
+	bs, err := proto.Marshal(m)
+	assert.NoError(t, err)
+	return &vo.PushTask{AppId: m.AppId, UserId: 3, Labels: labels, Mentioned: mentioned, Message: bs}
+}
+
+func TestPushRegister(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newFixture(t).group(10, 1).pushService()
+
+	_, err := s.Register(ctx, &api.PushRegisterRequest{AppId: testAppId, UserId: 3, Label: "ios", Vendor: "Windows", Token: "t"})
+	assert.Equal(t, errors.PushInvalid.Code, errext.Format(err).Code)
+
+	registerPush(t, s, "ios", "iOS", "t1", "")
+	registerPush(t, s, "ios", "iOS", "t2", "")
+	tokens, err := s.tokens(ctx, &vo.PushTask{AppId: testAppId, UserId: 3})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, "t2", tokens[0].Token)
+
+	// token为空时注销
+	registerPush(t, s, "ios", "", "", "")
+	tokens, err = s.tokens(ctx, &vo.PushTask{AppId: testAppId, UserId: 3})
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+// flushPush 合并窗口到期后发送
+func flushPush(t *testing.T, s *PushService) int {
+	sent, err := s.Flush(context.Background(), time.Now().Add(s.cfg.Collapse))
+	assert.NoError(t, err)
+	return sent
+}
+
+func TestPush(t *testing.T) {
+	ctx := context.Background()
+	s, v := newFixture(t).group(10, 1).pushService()
+
+	registerPush(t, s, "ios", "iOS", "t-ios", "zh-CN")
+	registerPush(t, s, "huawei", "Huawei", "t-huawei", "")
+	registerPush(t, s, "vivo", "Vivo", "t-vivo", "")
+
+	// 没有在线连接时推送所有设备，没有配置通道的vivo跳过
+	m := api.NewMessage(2, 3, 0, 1, testAppId, "conv", &api.Text{Text: "hello world"})
+	_, err := s.Push(ctx, newPushTask(t, m, false))
+	assert.NoError(t, err)
+
+	// 合并窗口未到期时不发送
+	sent, err := s.Flush(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Equal(t, 2, flushPush(t, s))
+
+	got := map[string]vo.Notification{}
+	for _, n := range v.notifications() {
+		got[n.Token] = n
+	}
+	assert.Equal(t, "新消息", got["t-ios"].Title)
+	assert.Equal(t, "hello…", got["t-ios"].Body)
+	assert.Equal(t, "New message", got["t-huawei"].Title)
+	assert.Equal(t, m.MessageId, got["t-huawei"].MessageId)
+	assert.Equal(t, int64(1), got["t-huawei"].Badge)
+
+	// 只推送投递失败的连接
+	_, err = s.Push(ctx, newPushTask(t, m, false, "huawei"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, flushPush(t, s))
+	assert.Equal(t, "t-huawei", v.notifications()[0].Token)
+
+	// 发送者自己的设备不推送
+	own := api.NewMessage(3, 2, 0, 1, testAppId, "conv", &api.Text{Text: "hi"})
+	count, err := s.Push(ctx, newPushTask(t, own, false))
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Zero(t, flushPush(t, s))
+}
+
+func TestPushCollapse(t *testing.T) {
+	ctx := context.Background()
+	s, v := newFixture(t).group(10, 1).pushService()
+	registerPush(t, s, "huawei", "Huawei", "t-huawei", "")
+
+	// 群里200条离线消息合并为一条推送，其中的@不被后面的消息覆盖
+	var mention *api.Message
+	for seq := int64(1); seq <= 200; seq++ {
+		m := newMentionMessage(2, seq)
+		if seq == 50 {
+			m = newMentionMessage(2, seq, 3)
+			mention = m
+		}
+		count, err := s.Push(ctx, newPushTask(t, m, seq == 50))
+		assert.NoError(t, err)
+		assert.Equal(t, seq, count)
+	}
+
+	// 另一个会话的未读数计入角标
+	single := api.NewMessage(2, 3, 0, 1, testAppId, "single", &api.Text{Text: "hi"})
+	_, err := s.Push(ctx, newPushTask(t, single, false))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, flushPush(t, s))
+	got := map[string]vo.Notification{}
+	for _, n := range v.notifications() {
+		got[n.ConvId] = n
+	}
+	assert.Equal(t, "[Mentioned][200 messages] hi", got["conv"].Body)
+	assert.Equal(t, mention.MessageId, got["conv"].MessageId)
+	assert.Equal(t, int64(200), got["conv"].Count)
+	assert.Equal(t, "conv", got["conv"].CollapseId)
+	assert.Equal(t, int64(201), got["conv"].Badge)
+	assert.Equal(t, "hi", got["single"].Body)
+
+	// 已读后角标减少，新的窗口重新计数
+	assert.NoError(t, s.ClearBadge(ctx, testAppId, 3, "conv"))
+	_, err = s.Push(ctx, newPushTask(t, single, false))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, flushPush(t, s))
+	n := v.notifications()[0]
+	assert.Equal(t, int64(1), n.Count)
+	assert.Equal(t, int64(2), n.Badge)
+}
+
+func TestPushCancel(t *testing.T) {
+	ctx := context.Background()
+	s, v := newFixture(t).group(10, 1).pushService()
+	registerPush(t, s, "huawei", "Huawei", "t-huawei", "")
+
+	push := func(convId string) {
+		m := api.NewMessage(2, 3, 0, 1, testAppId, convId, &api.Text{Text: "hi"})
+		_, err := s.Push(ctx, newPushTask(t, m, false))
+		assert.NoError(t, err)
+	}
+
+	// 在线收到会话的消息后取消该会话的推送，未读数只在已读时清除
+	push("a")
+	push("b")
+	assert.NoError(t, s.Cancel(ctx, testAppId, 3, []string{"a"}))
+	assert.Equal(t, 1, flushPush(t, s))
+	n := v.notifications()[0]
+	assert.Equal(t, "b", n.ConvId)
+	assert.Equal(t, int64(2), n.Badge)
+
+	// 到期时用户已重新连接，不再推送
+	push("c")
+	js, _ := json.Marshal(vo.UserClient{AppId: testAppId, UserId: 3})
+	assert.NoError(t, s.rds.HSet(ctx, infra.KeyUserClients(testAppId, 3), "huawei", string(js)).Err())
+	assert.Zero(t, flushPush(t, s))
+	assert.Zero(t, s.rds.ZCard(ctx, infra.KeyPushDue()).Val())
+}
+
+func TestPushDisturbAndMention(t *testing.T) {
+	ctx := context.Background()
+	s, v := newFixture(t).group(10, 1).pushService()
+	registerPush(t, s, "huawei", "Huawei", "t-huawei", "")
+
+	m := newMentionMessage(2, 1, 3)
+	m.SetContent(&api.Image{Url: "http://img"})
+	assert.NoError(t, s.cs.SetDisturb(ctx, testAppId, 3, m.ConvId, true))
+
+	// 免打扰的会话不推送，被@时仍然推送
+	count, err := s.Push(ctx, newPushTask(t, m, false))
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	count, err = s.Push(ctx, newPushTask(t, m, true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, 1, flushPush(t, s))
+
+	n := v.notifications()[0]
+	assert.Equal(t, "New group message", n.Title)
+	assert.Equal(t, "[Mentioned][Image]", n.Body)
+
+	// 有资料时标题为群名，内容带上发送者
+	user, _ := json.Marshal(&vo.UserProfile{AppId: testAppId, UserId: m.UserId, UserName: "alice"})
+	assert.NoError(t, s.rds.Set(ctx, infra.KeyUser(testAppId, m.UserId), user, 0).Err())
+	group, _ := json.Marshal(&vo.GroupProfile{AppId: testAppId, GroupId: m.GroupId, GroupName: "team"})
+	assert.NoError(t, s.rds.Set(ctx, infra.KeyGroup(testAppId, m.GroupId), group, 0).Err())
+
+	_, err = s.Push(ctx, newPushTask(t, m, true))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, flushPush(t, s))
+
+	n = v.notifications()[0]
+	assert.Equal(t, "team", n.Title)
+	assert.Equal(t, "[Mentioned]alice: [Image]", n.Body)
+}
+
+func TestPushQuietHours(t *testing.T) {
+	ctx := context.Background()
+	s, v := newFixture(t).group(10, 1).pushService()
+	registerPush(t, s, "huawei", "Huawei", "t-huawei", "")
+
+	// 免打扰时段内只累计未读数
+	now := time.Now().UTC()
+	_, _, err := s.cs.SetNotify(ctx, &api.NotifySettingRequest{AppId: testAppId, UserId: 3,
+		QuietHours: &api.QuietHours{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}})
+	assert.NoError(t, err)
+
+	m := api.NewMessage(2, 3, 0, 1, testAppId, "conv", &api.Text{Text: "hi"})
+	count, err := s.Push(ctx, newPushTask(t, m, false))
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Zero(t, flushPush(t, s))
+	assert.Empty(t, v.notifications())
+
+	badge, err := s.Badge(ctx, testAppId, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), badge)
+}
+
+func TestPushTokenExpired(t *testing.T) {
+	ctx := context.Background()
+	s, v := newFixture(t).group(10, 1).pushService()
+	registerPush(t, s, "ios", "iOS", "t-ios", "")
+	v.expired["t-ios"] = true
+
+	m := api.NewMessage(2, 3, 0, 1, testAppId, "conv", &api.Text{Text: "hi"})
+	_, err := s.Push(ctx, newPushTask(t, m, false))
+	assert.NoError(t, err)
+
+	sent, err := s.Flush(ctx, time.Now().Add(s.cfg.Collapse))
+	assert.Equal(t, errors.PushTokenExpired.Code, errext.Format(err).Code)
+	assert.Zero(t, sent)
+
+	n, err := s.rds.HLen(ctx, infra.KeyPushTokens(testAppId, 3)).Result()
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestPushRetry(t *testing.T) {
+	ctx := context.Background()
+	s, v := newFixture(t).group(10, 1).pushService()
+	registerPush(t, s, "ios", "iOS", "t-ios", "")
+	registerPush(t, s, "huawei", "Huawei", "t-huawei", "")
+	v.failing["t-huawei"] = true
+
+	m := api.NewMessage(2, 3, 0, 1, testAppId, "conv", &api.Text{Text: "hi"})
+	_, err := s.Push(ctx, newPushTask(t, m, false))
+	assert.NoError(t, err)
+
+	// 发送失败的设备按退避时间放回待发推送
+	now := time.Now().Add(s.cfg.Collapse)
+	sent, err := s.Flush(ctx, now)
+	assert.Equal(t, errors.PushErr.Code, errext.Format(err).Code)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, v.notifications(), 1)
+
+	sent, err = s.Flush(ctx, now)
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+
+	// 重试只发送失败的设备，第二次失败后间隔加倍
+	v.failing["t-huawei"] = false
+	now = now.Add(s.cfg.RetryInterval)
+	sent, err = s.Flush(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, "t-huawei", v.notifications()[0].Token)
+
+	// 超过次数后写入死信
+	v.failing["t-huawei"] = true
+	_, err = s.Push(ctx, newPushTask(t, m, false))
+	assert.NoError(t, err)
+	for i := 0; i < s.cfg.MaxAttempts; i++ {
+		now = now.Add(s.cfg.Collapse + s.cfg.RetryInterval<<i)
+		_, err = s.Flush(ctx, now)
+		assert.Error(t, err)
+	}
+	assert.Zero(t, s.rds.ZCard(ctx, infra.KeyPushDue()).Val())
+
+	dlq := s.kw.(*fakePush).tasks
+	assert.Len(t, dlq, 1)
+	assert.Equal(t, []string{"huawei"}, dlq[0].Labels)
+	assert.Equal(t, int64(3), dlq[0].UserId)
+}
+
+func TestRenderNotification(t *testing.T) {
+	file := api.NewMessage(2, 3, 0, 1, testAppId, "conv", &api.File{Name: "a.pdf"})
+	_, body := renderNotification(file, pushLanguage("zh_TW", ""), "", "", false, 1, 10)
+	assert.Equal(t, "[文件] a.pdf", body)
+
+	envelope := api.NewMessage(2, 3, 0, 1, testAppId, "conv", &api.Envelope{Ciphertext: []byte("x")})
+	_, body = renderNotification(envelope, pushLanguage("fr-FR", "en"), "", "", false, 1, 10)
+	assert.Equal(t, "[Encrypted message]", body)
+
+	assert.Equal(t, "zh", pushLanguage("", ""))
+
+	// 标题为发送者或群名，群消息和合并的通知内容带上发送者
+	text := api.NewMessage(2, 3, 0, 1, testAppId, "conv", &api.Text{Text: "hi"})
+	title, body := renderNotification(text, "en", "alice", "", false, 1, 10)
+	assert.Equal(t, "alice", title)
+	assert.Equal(t, "hi", body)
+
+	title, body = renderNotification(text, "en", "alice", "", false, 3, 10)
+	assert.Equal(t, "alice", title)
+	assert.Equal(t, "[3 messages] alice: hi", body)
+
+	group := api.NewMessage(2, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "hi"})
+	title, body = renderNotification(group, "zh", "alice", "team", true, 2, 10)
+	assert.Equal(t, "team", title)
+	assert.Equal(t, "[有人@我][2条]alice: hi", body)
+}
+
+func TestParsePushDueMember(t *testing.T) {
+	appId, userId, convId, ok := parsePushDueMember(pushDueMember(testAppId, 3, "1:2"))
+	assert.True(t, ok)
+	assert.Equal(t, testAppId, appId)
+	assert.Equal(t, int64(3), userId)
+	assert.Equal(t, "1:2", convId)
+
+	_, _, _, ok = parsePushDueMember("19860220:x:conv")
+	assert.False(t, ok)
+}
@@ -0,0 +1,333 @@
+package router
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/logger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefReceiptFlushInterval 已读数推送的节流间隔，间隔内的多次已读合并成一次推送
+	DefReceiptFlushInterval = time.Second
+
+	// DefReceiptMaxTracked 每个群最多跟踪最近多少条消息的已读
+	DefReceiptMaxTracked = 1000
+
+	// DefReceiptExpire 回执保存时间
+	DefReceiptExpire = 7 * 24 * time.Hour
+)
+
+// readScript 更新成员的已读sequence，返回 (旧sequence, 新sequence] 区间内被跟踪的消息
+var readScript = redis.NewScript(`
+	local old = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+	local seq = tonumber(ARGV[2])
+	if seq <= old then
+		return {}
+	end
+	redis.call("HSET", KEYS[1], ARGV[1], seq)
+	return redis.call("ZRANGEBYSCORE", KEYS[2], "(" .. old, seq)
+`)
+
+type receiptKey struct {
+	appId     string
+	messageId string
+}
+
+// ReceiptService 群消息已读回执，成员的已读sequence和每条消息的已读数保存在redis，
+// 已读数变化后按FlushInterval节流推送给发送者
+type ReceiptService struct {
+	cfg    *global.ReceiptConfig
+	rds    *redis.Client
+	gs     *GroupService
+	ds     *DeliveryService
+	dirty  map[receiptKey]struct{}
+	lock   sync.Mutex
+	cancel context.CancelFunc
+	logger *logger.Logger
+}
+
+func getOrDefaultReceiptConfig(g *global.Config) *global.ReceiptConfig {
+	c := &global.ReceiptConfig{}
+	if g != nil && g.RRS != nil && g.RRS.Receipt != nil {
+		*c = *g.RRS.Receipt
+	}
+
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = DefReceiptFlushInterval
+	}
+
+	if c.MaxTracked <= 0 {
+		c.MaxTracked = DefReceiptMaxTracked
+	}
+
+	if c.Expire <= 0 {
+		c.Expire = DefReceiptExpire
+	}
+
+	return c
+}
+
+func NewReceiptService(
+	g *global.Config,
+	rds *redis.Client,
+	gs *GroupService,
+	ds *DeliveryService,
+	lc fx.Lifecycle) *ReceiptService {
+
+	s := newReceiptService(getOrDefaultReceiptConfig(g), rds, gs, ds)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return s.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+	return s
+}
+
+func newReceiptService(c *global.ReceiptConfig, rds *redis.Client, gs *GroupService, ds *DeliveryService) *ReceiptService {
+	return &ReceiptService{
+		cfg:    c,
+		rds:    rds,
+		gs:     gs,
+		ds:     ds,
+		dirty:  make(map[receiptKey]struct{}),
+		logger: logger.Named("receipt"),
+	}
+}
+
+func (s *ReceiptService) Start(ctx context.Context) error {
+	c, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(s.cfg.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.Done():
+				return
+			case <-ticker.C:
+				s.flush(c)
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *ReceiptService) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.flush(ctx)
+	return nil
+}
+
+// Track 记录一条群消息，之后成员的已读会计入这条消息
+func (s *ReceiptService) Track(ctx context.Context, m *api.Message) error {
+	receiptsKey := infra.KeyGroupReceipts(m.AppId, m.GroupId)
+	receiptKey := infra.KeyReceipt(m.AppId, m.MessageId)
+
+	pipe := s.rds.TxPipeline()
+	pipe.HSet(ctx, receiptKey,
+		"userId", m.UserId,
+		"groupId", m.GroupId,
+		"sequence", m.Sequence,
+		"count", 0)
+	pipe.Expire(ctx, receiptKey, s.cfg.Expire)
+	pipe.ZAdd(ctx, receiptsKey, &redis.Z{Score: float64(m.Sequence), Member: m.MessageId})
+	pipe.ZRemRangeByRank(ctx, receiptsKey, 0, -s.cfg.MaxTracked-1)
+	pipe.Expire(ctx, receiptsKey, s.cfg.Expire)
+
+	// 发送者自己视为已读
+	pipe.HSet(ctx, infra.KeyGroupRead(m.AppId, m.GroupId), strconv.FormatInt(m.UserId, 10), m.Sequence)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Read 成员已读到sequence，返回本次已读计入的消息数，非群成员不能上报。
+// sequence超过会话当前的sequence时按当前值计，req.Sequence随之修改
+func (s *ReceiptService) Read(ctx context.Context, req *api.ReadRequest) (int, error) {
+	member, err := s.gs.IsGroupMember(ctx, req.AppId, req.GroupId, req.UserId)
+	if err != nil {
+		return 0, errors.ReadErr.SetDetail(err.Error())
+	}
+	if !member {
+		return 0, errors.ReadForbidden
+	}
+
+	max, err := s.rds.Get(ctx, infra.KeySequence(req.AppId, req.ConvId)).Int64()
+	if err != nil && err != redis.Nil {
+		return 0, errors.ReadErr.SetDetail(err.Error())
+	}
+	if req.Sequence > max {
+		req.Sequence = max
+	}
+
+	keys := []string{infra.KeyGroupRead(req.AppId, req.GroupId), infra.KeyGroupReceipts(req.AppId, req.GroupId)}
+	ids, err := readScript.Run(ctx, s.rds, keys, req.UserId, req.Sequence).StringSlice()
+	if err != nil {
+		return 0, errors.ReadErr.SetDetail(err.Error())
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	pipe := s.rds.Pipeline()
+	senders := make([]*redis.StringCmd, len(ids))
+	for i, id := range ids {
+		senders[i] = pipe.HGet(ctx, infra.KeyReceipt(req.AppId, id), "userId")
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, errors.ReadErr.SetDetail(err.Error())
+	}
+
+	counted := make([]string, 0, len(ids))
+	pipe = s.rds.Pipeline()
+	for i, id := range ids {
+		sender, err := senders[i].Int64()
+		if err != nil || sender == req.UserId {
+			continue
+		}
+		pipe.HIncrBy(ctx, infra.KeyReceipt(req.AppId, id), "count", 1)
+		counted = append(counted, id)
+	}
+
+	if len(counted) == 0 {
+		return 0, nil
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.ReadErr.SetDetail(err.Error())
+	}
+
+	s.lock.Lock()
+	for _, id := range counted {
+		s.dirty[receiptKey{appId: req.AppId, messageId: id}] = struct{}{}
+	}
+	s.lock.Unlock()
+
+	return len(counted), nil
+}
+
+// QueryReceipt 查询一条群消息的已读/未读成员，发送者本人不在列表中。
+// 只有仍在群中的发送者可以查询，回执已过期或不属于该群时返回 ReceiptNotFound
+func (s *ReceiptService) QueryReceipt(ctx context.Context, req *api.ReceiptRequest) (*api.ReceiptReply, error) {
+
+	member, err := s.gs.IsGroupMember(ctx, req.AppId, req.GroupId, req.UserId)
+	if err != nil {
+		return nil, errors.ReceiptErr.SetDetail(err.Error())
+	}
+	if !member {
+		return nil, errors.ReceiptForbidden
+	}
+
+	vals, err := s.rds.HMGet(ctx, infra.KeyReceipt(req.AppId, req.MessageId), "userId", "groupId", "sequence").Result()
+	if err != nil {
+		return nil, errors.ReceiptErr.SetDetail(err.Error())
+	}
+
+	sender, ok := vals[0].(string)
+	if !ok || toString(vals[1]) != strconv.FormatInt(req.GroupId, 10) {
+		return nil, errors.ReceiptNotFound
+	}
+	if sender != strconv.FormatInt(req.UserId, 10) {
+		return nil, errors.ReceiptForbidden
+	}
+	sequence, _ := strconv.ParseInt(toString(vals[2]), 10, 64)
+
+	members, err := s.gs.GetGroupMembers(ctx, req.AppId, req.GroupId)
+	if err != nil {
+		return nil, errors.ReceiptErr.SetDetail(err.Error())
+	}
+
+	reads, err := s.rds.HGetAll(ctx, infra.KeyGroupRead(req.AppId, req.GroupId)).Result()
+	if err != nil {
+		return nil, errors.ReceiptErr.SetDetail(err.Error())
+	}
+
+	rep := &api.ReceiptReply{
+		MessageId:     req.MessageId,
+		ReadUserIds:   make([]int64, 0),
+		UnreadUserIds: make([]int64, 0),
+	}
+	for _, userId := range members {
+		if userId == req.UserId {
+			continue
+		}
+		readSeq, _ := strconv.ParseInt(reads[strconv.FormatInt(userId, 10)], 10, 64)
+		if readSeq >= sequence {
+			rep.ReadUserIds = append(rep.ReadUserIds, userId)
+		} else {
+			rep.UnreadUserIds = append(rep.UnreadUserIds, userId)
+		}
+	}
+	return rep, nil
+}
+
+// flush 把节流期间已读数有变化的消息推送给发送者
+func (s *ReceiptService) flush(ctx context.Context) {
+	s.lock.Lock()
+	if len(s.dirty) == 0 {
+		s.lock.Unlock()
+		return
+	}
+	dirty := s.dirty
+	s.dirty = make(map[receiptKey]struct{})
+	s.lock.Unlock()
+
+	for k := range dirty {
+		if err := s.push(ctx, k); err != nil {
+			s.logger.Debug("receipt push failed", zap.String("messageId", k.messageId), zap.Error(err))
+		}
+	}
+}
+
+func (s *ReceiptService) push(ctx context.Context, k receiptKey) error {
+	vals, err := s.rds.HMGet(ctx, infra.KeyReceipt(k.appId, k.messageId), "userId", "groupId", "count").Result()
+	if err != nil {
+		return err
+	}
+
+	sender, _ := strconv.ParseInt(toString(vals[0]), 10, 64)
+	groupId, _ := strconv.ParseInt(toString(vals[1]), 10, 64)
+	count, _ := strconv.ParseInt(toString(vals[2]), 10, 64)
+	if sender == 0 || groupId == 0 {
+		return nil
+	}
+
+	members, err := s.gs.CountGroupMembers(ctx, k.appId, groupId)
+	if err != nil {
+		return err
+	}
+
+	receipt := &api.Receipt{
+		MessageId:   k.messageId,
+		GroupId:     groupId,
+		ReadCount:   int32(count),
+		MemberCount: int32(max(members-1, 0)),
+	}
+
+	m := api.NewMessage(sender, sender, 0, 0, k.appId, "", receipt)
+	_, err = s.ds.deliverToUser(ctx, m)
+	return err
+}
+
+func toString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package router
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func (f *fixture) receiptService() *ReceiptService {
+	return newReceiptService(getOrDefaultReceiptConfig(nil), f.rds, &GroupService{rds: f.rds}, f.deliveryService(nil))
+}
+
+// track 记录回执，会话sequence随之更新
+func track(t *testing.T, rs *ReceiptService, m *api.Message) {
+	assert.NoError(t, rs.rds.Set(context.Background(), infra.KeySequence(m.AppId, m.ConvId), m.Sequence, 0).Err())
+	assert.NoError(t, rs.Track(context.Background(), m))
+}
+
+func TestReceiptReadAndQuery(t *testing.T) {
+	ctx := context.Background()
+	rs := newFixture(t).group(10, 2).receiptService()
+
+	m1 := api.NewMessage(1, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "m1"})
+	m2 := api.NewMessage(1, 0, testGroupId, 2, testAppId, "conv", &api.Text{Text: "m2"})
+	track(t, rs, m1)
+	track(t, rs, m2)
+
+	// 2读到m1，3读到m2，重复上报不重复计数
+	n, err := rs.Read(ctx, &api.ReadRequest{AppId: testAppId, UserId: 2, ConvId: "conv", GroupId: testGroupId, Sequence: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = rs.Read(ctx, &api.ReadRequest{AppId: testAppId, UserId: 3, ConvId: "conv", GroupId: testGroupId, Sequence: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = rs.Read(ctx, &api.ReadRequest{AppId: testAppId, UserId: 3, ConvId: "conv", GroupId: testGroupId, Sequence: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	rep, err := rs.QueryReceipt(ctx, &api.ReceiptRequest{AppId: testAppId, UserId: 1, GroupId: testGroupId, MessageId: m1.MessageId})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{2, 3}, rep.ReadUserIds)
+	assert.Len(t, rep.UnreadUserIds, 7)
+
+	rep, err = rs.QueryReceipt(ctx, &api.ReceiptRequest{AppId: testAppId, UserId: 1, GroupId: testGroupId, MessageId: m2.MessageId})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{3}, rep.ReadUserIds)
+
+	_, err = rs.QueryReceipt(ctx, &api.ReceiptRequest{AppId: testAppId, UserId: 2, GroupId: testGroupId, MessageId: m1.MessageId})
+	assert.ErrorIs(t, err, errors.ReceiptForbidden)
+
+	// 回执不存在或不属于请求的群时不返回成员列表
+	_, err = rs.QueryReceipt(ctx, &api.ReceiptRequest{AppId: testAppId, UserId: 1, GroupId: testGroupId, MessageId: "missing"})
+	assert.ErrorIs(t, err, errors.ReceiptNotFound)
+	_, err = rs.QueryReceipt(ctx, &api.ReceiptRequest{AppId: testAppId, UserId: 1, GroupId: testGroupId + 1, MessageId: m1.MessageId})
+	assert.ErrorIs(t, err, errors.ReceiptForbidden)
+}
+
+func TestReceiptReadFuture(t *testing.T) {
+	ctx := context.Background()
+	rs := newFixture(t).group(10, 2).receiptService()
+
+	m1 := api.NewMessage(1, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "m1"})
+	track(t, rs, m1)
+
+	// 超过会话sequence的已读按当前sequence计，之后的消息仍然计入
+	req := &api.ReadRequest{AppId: testAppId, UserId: 2, ConvId: "conv", GroupId: testGroupId, Sequence: 100}
+	n, err := rs.Read(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, int64(1), req.Sequence)
+
+	m2 := api.NewMessage(1, 0, testGroupId, 2, testAppId, "conv", &api.Text{Text: "m2"})
+	track(t, rs, m2)
+	n, err = rs.Read(ctx, &api.ReadRequest{AppId: testAppId, UserId: 2, ConvId: "conv", GroupId: testGroupId, Sequence: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestReceiptNonMember(t *testing.T) {
+	ctx := context.Background()
+	rs := newFixture(t).group(10, 2).receiptService()
+
+	m := api.NewMessage(1, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "m1"})
+	track(t, rs, m)
+
+	// 11不在群中
+	_, err := rs.Read(ctx, &api.ReadRequest{AppId: testAppId, UserId: 11, ConvId: "conv", GroupId: testGroupId, Sequence: 1})
+	assert.ErrorIs(t, err, errors.ReadForbidden)
+	_, err = rs.QueryReceipt(ctx, &api.ReceiptRequest{AppId: testAppId, UserId: 11, GroupId: testGroupId, MessageId: m.MessageId})
+	assert.ErrorIs(t, err, errors.ReceiptForbidden)
+}
+
+func TestReceiptFlushThrottle(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t).group(100, 2)
+	rs := f.receiptService()
+
+	// 发送者2在线
+	m := api.NewMessage(2, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "hello"})
+	track(t, rs, m)
+
+	for i := int64(3); i <= 100; i++ {
+		_, err := rs.Read(ctx, &api.ReadRequest{AppId: testAppId, UserId: i, ConvId: "conv", GroupId: testGroupId, Sequence: 1})
+		assert.NoError(t, err)
+	}
+
+	// 98次已读合并成一次推送
+	rs.flush(ctx)
+	rs.flush(ctx)
+
+	var requests int64
+	for _, b := range f.fbs {
+		requests += b.requests.Load()
+	}
+	assert.Equal(t, int64(1), requests)
+}
+
+func TestGetOrDefaultReceiptConfig(t *testing.T) {
+	c := getOrDefaultReceiptConfig(&global.Config{RRS: &global.RRSConfig{Receipt: &global.ReceiptConfig{MaxTracked: 10}}})
+	assert.Equal(t, int64(10), c.MaxTracked)
+	assert.Equal(t, DefReceiptFlushInterval, c.FlushInterval)
+}
@@ -0,0 +1,105 @@
+package router
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
+)
+
+// ReferService 校验引用消息，客户端只需要提供 Refer.MessageId，
+// 发送者、头像和内容摘要以历史消息为准，客户端填写的内容会被覆盖
+type ReferService struct {
+	cfg *global.HistoryConfig
+	ms  *MessageStore
+	us  *UserService
+}
+
+func NewReferService(g *global.Config, ms *MessageStore, us *UserService, lc fx.Lifecycle) *ReferService {
+	return &ReferService{
+		cfg: getOrDefaultHistoryConfig(g),
+		ms:  ms,
+		us:  us,
+	}
+}
+
+// Resolve 用历史消息重建 m.Refer
+func (s *ReferService) Resolve(ctx context.Context, m *api.Message) error {
+	for i, r := range m.Refer {
+		refer, err := s.resolve(ctx, m, r.GetMessageId())
+		if err != nil {
+			return err
+		}
+		m.Refer[i] = refer
+	}
+	return nil
+}
+
+func (s *ReferService) resolve(ctx context.Context, m *api.Message, messageId string) (*api.Refer, error) {
+	if messageId == "" {
+		return nil, errors.ReferNotFound.SetDetail("messageId is required")
+	}
+
+	ref, recalled, err := s.ms.Get(ctx, m.AppId, messageId)
+	if err == redis.Nil {
+		return nil, errors.ReferNotFound.SetDetail(messageId)
+	}
+	if err != nil {
+		return nil, errors.ReferErr.SetDetail(err.Error())
+	}
+
+	if !sameConv(m, ref) {
+		return nil, errors.ReferForbidden.SetDetail(messageId)
+	}
+
+	refer := &api.Refer{
+		MessageId: messageId,
+		UserId:    ref.UserId,
+	}
+
+	// 撤回的消息只保留墓碑
+	if recalled {
+		refer.Recalled = true
+		return refer, nil
+	}
+
+	profile, err := s.us.GetUserProfile(ctx, m.AppId, ref.UserId)
+	if err != nil && err != redis.Nil {
+		return nil, errors.ReferErr.SetDetail(err.Error())
+	}
+	if profile != nil {
+		refer.Name = profile.UserName
+		refer.Avatar = profile.Avatar
+	}
+
+	refer.SetContent(s.snippet(ref.GetContentMessage()))
+	return refer, nil
+}
+
+// sameConv ref是否和m属于同一会话，按发送者、接收者和群判断，不使用客户端填写的convId
+func sameConv(m, ref *api.Message) bool {
+	if m.IsToGroup() {
+		return ref.GroupId == m.GroupId
+	}
+	if ref.IsToGroup() {
+		return false
+	}
+	return (ref.UserId == m.UserId && ref.To == m.To) || (ref.UserId == m.To && ref.To == m.UserId)
+}
+
+// snippet 文本截断为SnippetLength个字，其他类型原样引用
+func (s *ReferService) snippet(c proto.Message) proto.Message {
+	text, ok := c.(*api.Text)
+	if !ok {
+		return c
+	}
+
+	runes := []rune(text.Text)
+	if len(runes) <= s.cfg.SnippetLength {
+		return text
+	}
+	return &api.Text{Text: string(runes[:s.cfg.SnippetLength])}
+}
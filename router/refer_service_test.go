@@ -0,0 +1,134 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/router/vo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// referService 用户2的资料在缓存中
+func (f *fixture) referService() *ReferService {
+	c := getOrDefaultHistoryConfig(&global.Config{RRS: &global.RRSConfig{History: &global.HistoryConfig{SnippetLength: 5}}})
+
+	js, _ := json.Marshal(vo.UserProfile{AppId: testAppId, UserId: 2, UserName: "bob", Avatar: "bob.png"})
+	f.rds.Set(context.Background(), infra.KeyUser(testAppId, 2), js, 0)
+
+	return &ReferService{
+		cfg: c,
+		ms:  &MessageStore{cfg: c, rds: f.rds},
+		us:  &UserService{rds: f.rds},
+	}
+}
+
+func newReferMessage(convId string, messageId string) *api.Message {
+	m := api.NewMessage(3, 0, testGroupId, 2, testAppId, convId, &api.Text{Text: "reply"})
+	m.Refer = []*api.Refer{{MessageId: messageId, UserId: 9, Name: "forged", Content: &api.Refer_Text{Text: &api.Text{Text: "forged"}}}}
+	return m
+}
+
+func TestReferResolve(t *testing.T) {
+	ctx := context.Background()
+	fs := newFixture(t).group(10, 1).referService()
+
+	orig := api.NewMessage(2, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: strings.Repeat("好", 10)})
+	assert.NoError(t, fs.ms.Save(ctx, orig))
+
+	m := newReferMessage("conv", orig.MessageId)
+	assert.NoError(t, fs.Resolve(ctx, m))
+
+	r := m.Refer[0]
+	assert.Equal(t, int64(2), r.UserId)
+	assert.Equal(t, "bob", r.Name)
+	assert.Equal(t, "bob.png", r.Avatar)
+	assert.Equal(t, api.MessageTypeText, r.CType)
+	assert.Equal(t, strings.Repeat("好", 5), r.GetText().Text)
+	assert.False(t, r.Recalled)
+}
+
+func TestReferReject(t *testing.T) {
+	ctx := context.Background()
+	fs := newFixture(t).group(10, 1).referService()
+
+	orig := api.NewMessage(2, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "hello"})
+	assert.NoError(t, fs.ms.Save(ctx, orig))
+
+	// 会话以群为准，客户端填写相同的convId也不能引用其他群的消息
+	m := newReferMessage("conv", orig.MessageId)
+	m.GroupId = testGroupId + 1
+	err := fs.Resolve(ctx, m)
+	assert.Equal(t, errors.ReferForbidden.Code, errext.Format(err).Code)
+
+	m = api.NewMessage(3, 2, 0, 2, testAppId, "conv", &api.Text{Text: "reply"})
+	m.Refer = []*api.Refer{{MessageId: orig.MessageId}}
+	err = fs.Resolve(ctx, m)
+	assert.Equal(t, errors.ReferForbidden.Code, errext.Format(err).Code)
+
+	err = fs.Resolve(ctx, newReferMessage("conv", "not-exist"))
+	assert.Equal(t, errors.ReferNotFound.Code, errext.Format(err).Code)
+
+	err = fs.Resolve(ctx, newReferMessage("conv", ""))
+	assert.Equal(t, errors.ReferNotFound.Code, errext.Format(err).Code)
+}
+
+func TestReferSingle(t *testing.T) {
+	ctx := context.Background()
+	fs := newFixture(t).group(10, 1).referService()
+
+	orig := api.NewMessage(2, 3, 0, 1, testAppId, "a", &api.Text{Text: "hello"})
+	assert.NoError(t, fs.ms.Save(ctx, orig))
+
+	// 单聊按发送者和接收者判断，与convId无关
+	m := api.NewMessage(3, 2, 0, 2, testAppId, "b", &api.Text{Text: "reply"})
+	m.Refer = []*api.Refer{{MessageId: orig.MessageId}}
+	assert.NoError(t, fs.Resolve(ctx, m))
+	assert.Equal(t, "bob", m.Refer[0].Name)
+
+	m = api.NewMessage(4, 2, 0, 2, testAppId, "a", &api.Text{Text: "reply"})
+	m.Refer = []*api.Refer{{MessageId: orig.MessageId}}
+	err := fs.Resolve(ctx, m)
+	assert.Equal(t, errors.ReferForbidden.Code, errext.Format(err).Code)
+}
+
+func TestReferRecalled(t *testing.T) {
+	ctx := context.Background()
+	fs := newFixture(t).group(10, 1).referService()
+
+	orig := api.NewMessage(2, 0, testGroupId, 1, testAppId, "conv", &api.Text{Text: "hello"})
+	assert.NoError(t, fs.ms.Save(ctx, orig))
+	assert.NoError(t, fs.ms.Recall(ctx, testAppId, orig.MessageId))
+
+	m := newReferMessage("conv", orig.MessageId)
+	assert.NoError(t, fs.Resolve(ctx, m))
+
+	r := m.Refer[0]
+	assert.True(t, r.Recalled)
+	assert.Equal(t, int64(2), r.UserId)
+	assert.Empty(t, r.Name)
+	assert.Nil(t, r.Content)
+}
+
+func TestReferEnvelope(t *testing.T) {
+	ctx := context.Background()
+	fs := newFixture(t).group(10, 1).referService()
+
+	orig := api.NewMessage(2, 0, testGroupId, 1, testAppId, "conv", &api.Envelope{Ciphertext: []byte{1, 2, 3}, KeyId: "k1", DeviceId: "d1"})
+	assert.NoError(t, fs.ms.Save(ctx, orig))
+
+	// 存储不解析密文，原样取回
+	got, _, err := fs.ms.Get(ctx, testAppId, orig.MessageId)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, got.GetEnvelope().GetCiphertext())
+
+	m := newReferMessage("conv", orig.MessageId)
+	assert.NoError(t, fs.Resolve(ctx, m))
+	assert.Equal(t, api.MessageTypeEnvelope, m.Refer[0].CType)
+	assert.Nil(t, m.Refer[0].Content)
+}
@@ -2,17 +2,22 @@ package router
 
 import (
 	"context"
-	"fmt"
 	"github.com/cloudwego/kitex/pkg/registry"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 	"github.com/cloudwego/kitex/server"
+	"github.com/go-redis/redis/v8"
 	"github.com/magicnana999/im/api/kitex_gen/api"
 	"github.com/magicnana999/im/api/kitex_gen/api/routerservice"
 	"github.com/magicnana999/im/errors"
 	"github.com/magicnana999/im/global"
 	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/magicnana999/im/router/vo"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 	"net"
+	"time"
 )
 
 type RpcRouterServer struct {
@@ -20,6 +25,17 @@ type RpcRouterServer struct {
 	registry registry.Registry
 	server   server.Server
 	ds       *DeliveryService
+	rs       *ReceiptService
+	cs       *ConvService
+	ms       *MessageStore
+	fs       *ReferService
+	ks       *KeyService
+	dd       *DedupService
+	ss       *StatusService
+	os       *OfflineService
+	ps       *PushService
+	sc       *ScheduleService
+	logger   *logger.Logger
 }
 
 func getOrDefaultRBSConfig(g *global.Config) (*global.RRSConfig, error) {
@@ -41,8 +57,17 @@ func getOrDefaultRBSConfig(g *global.Config) (*global.RRSConfig, error) {
 func NewRpcRouterServer(
 	registry registry.Registry,
 	g *global.Config,
-	us *UserService,
-	bcr *infra.BrokerClientResolver,
+	ds *DeliveryService,
+	rs *ReceiptService,
+	cs *ConvService,
+	ms *MessageStore,
+	fs *ReferService,
+	ks *KeyService,
+	dd *DedupService,
+	ss *StatusService,
+	os *OfflineService,
+	ps *PushService,
+	sc *ScheduleService,
 	lc fx.Lifecycle) (*RpcRouterServer, error) {
 
 	c, err := getOrDefaultRBSConfig(g)
@@ -53,7 +78,18 @@ func NewRpcRouterServer(
 	s := &RpcRouterServer{
 		cfg:      c,
 		registry: registry,
-		ds:       NewDeliveryService(us, bcr),
+		ds:       ds,
+		rs:       rs,
+		cs:       cs,
+		ms:       ms,
+		fs:       fs,
+		ks:       ks,
+		dd:       dd,
+		ss:       ss,
+		os:       os,
+		ps:       ps,
+		sc:       sc,
+		logger:   logger.Named("rrs"),
 	}
 
 	addr, _ := net.ResolveTCPAddr(c.Network, c.Addr)
@@ -62,60 +98,306 @@ func NewRpcRouterServer(
 		server.WithRegistry(registry),
 		server.WithServerBasicInfo(
 			&rpcinfo.EndpointBasicInfo{
-				ServiceName: "im.broker",
+				ServiceName: infra.RouterServiceName,
 			},
 		),
 	)
 
 	s.server = svr
 
-	//lc.Append(fx.Hook{
-	//	OnStart: func(ctx context.Context) error {
-	//		return s.Start(ctx)
-	//	},
-	//	OnStop: func(ctx context.Context) error {
-	//		return s.Stop(ctx)
-	//	},
-	//})
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return s.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
 	return s, nil
 }
 
-//func (s *RpcRouterServer) Start(ctx context.Context) error {
-//	go func() {
-//		err := s.server.Run()
-//		s.logger.SrvInfo("rpc server start", SrvLifecycle, err)
-//
-//	}()
-//	return nil
-//}
-//
-//func (s *RpcRouterServer) Stop(ctx context.Context) error {
-//	err := s.server.Stop()
-//	s.logger.SrvInfo("rpc server stop", SrvLifecycle, err)
-//
-//	return err
-//}
+func (s *RpcRouterServer) Start(ctx context.Context) error {
+	go func() {
+		if err := s.server.Run(); err != nil {
+			s.logger.Error("rpc server exited", zap.Error(err))
+		}
+	}()
+	s.logger.Info("rpc server started", zap.String("addr", s.cfg.Addr))
+	return nil
+}
+
+func (s *RpcRouterServer) Stop(ctx context.Context) error {
+	if err := s.server.Stop(); err != nil {
+		s.logger.Error("failed to stop rpc server", zap.Error(err))
+		return err
+	}
+	s.logger.Info("rpc server stopped")
+	return nil
+}
 
 func (s *RpcRouterServer) Route(ctx context.Context, m *api.Message) (res *api.RouteReply, err error) {
 
 	//TODO ..route
-	//TODO ..save
 	//TODO ..update conversation
 
-	if m.GetGroupId() == 0 {
-		//TODO ... 群组消息
+	err = m.Validate()
+	if err != nil {
+		return nil, errors.RouteErr.SetDetail(err.Error())
+	}
+
+	// 服务端时间和sequence以router为准，客户端重发的消息不再路由，返回首次发送的结果
+	m.STime = time.Now().UnixMilli()
+	res, err = s.dd.Claim(ctx, m)
+	if err != nil || res.Duplicate {
+		return res, err
+	}
+
+	if err := s.route(ctx, m); err != nil {
+		s.dd.Release(ctx, m)
+		s.ss.Rejected(ctx, m, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// RouteScheduled 路由到期的定时消息，只有消息本身无效时才向发送者返回失败状态，
+// 其他错误由定时服务重新发送
+func (s *RpcRouterServer) RouteScheduled(ctx context.Context, m *api.Message) error {
+	if err := m.Validate(); err != nil {
+		err = errors.ScheduleInvalid.SetDetail(err.Error())
+		s.ss.Rejected(ctx, m, err)
+		return err
+	}
+
+	m.STime = time.Now().UnixMilli()
+	res, err := s.dd.Claim(ctx, m)
+	if err != nil || res.Duplicate {
+		return err
+	}
+
+	if err := s.route(ctx, m); err != nil {
+		s.dd.Release(ctx, m)
+		if isRejected(err) {
+			s.ss.Rejected(ctx, m, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// isRejected 消息本身无效，重新发送也不会成功
+func isRejected(err error) bool {
+	switch errext.Format(err).Code {
+	case errors.ScheduleInvalid.Code, errors.MentionForbidden.Code, errors.ReferNotFound.Code, errors.ReferForbidden.Code:
+		return true
+	}
+	return false
+}
+
+func (s *RpcRouterServer) route(ctx context.Context, m *api.Message) error {
+	if err := s.fs.Resolve(ctx, m); err != nil {
+		return err
+	}
+
+	// @校验不通过的消息不保存，保存后才记录@标记
+	if err := s.cs.CheckMention(ctx, m); err != nil {
+		return err
+	}
+
+	if err := s.ms.Save(ctx, m); err != nil {
+		return errors.RouteErr.SetDetail(err.Error())
+	}
+
+	if m.IsToGroup() {
+		if err := s.cs.Mention(ctx, m); err != nil {
+			return err
+		}
+
+		if err := s.rs.Track(ctx, m); err != nil {
+			s.logger.Warn("failed to track receipt", zap.String("messageId", m.MessageId), zap.Error(err))
+		}
+
+		fails, err := s.ds.deliverToGroup(ctx, m)
+		s.offline(ctx, m, fails, err)
 	} else {
-		err := m.Validate()
-		if err != nil {
-			return nil, errors.RouteErr.SetDetail(err.Error())
+		fails, err := s.ds.deliverToUser(ctx, m)
+		s.offline(ctx, m, fails, err)
+	}
+
+	return nil
+}
+
+// offline 消息已保存，在线投递失败的部分转入离线，不影响发送结果
+func (s *RpcRouterServer) offline(ctx context.Context, m *api.Message, fails []vo.DeliverFail, err error) {
+	if err == nil {
+		return
+	}
+
+	if s.os.fallback(ctx, fails) > 0 {
+		s.ss.Offline(ctx, m)
+	}
+}
+
+func (s *RpcRouterServer) Read(ctx context.Context, req *api.ReadRequest) (*api.ReadReply, error) {
+	if req.AppId == "" || req.UserId == 0 || req.ConvId == "" {
+		return nil, errors.ReadErr.SetDetail("appId, userId and convId are required")
+	}
+
+	// 只有群消息有已读回执
+	if req.GroupId != 0 {
+		if _, err := s.rs.Read(ctx, req); err != nil {
+			return nil, err
 		}
+	}
+
+	if err := s.cs.ClearMention(ctx, req.AppId, req.UserId, req.ConvId, req.Sequence); err != nil {
+		return nil, errors.ReadErr.SetDetail(err.Error())
+	}
+
+	if err := s.ps.ClearBadge(ctx, req.AppId, req.UserId, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	mention, err := s.cs.GetMention(ctx, req.AppId, req.UserId, req.ConvId, req.GroupId)
+	if err != nil {
+		return nil, errors.ReadErr.SetDetail(err.Error())
+	}
+	return &api.ReadReply{Sequence: req.Sequence, Mention: mention}, nil
+}
+
+func (s *RpcRouterServer) QueryReceipt(ctx context.Context, req *api.ReceiptRequest) (*api.ReceiptReply, error) {
+	if req.AppId == "" || req.UserId == 0 || req.GroupId == 0 || req.MessageId == "" {
+		return nil, errors.ReceiptErr.SetDetail("appId, userId, groupId and messageId are required")
+	}
+
+	return s.rs.QueryReceipt(ctx, req)
+}
 
-		fail, err := s.ds.deliverToUser(ctx, m)
-		if err != nil {
-			//TODO... setToOffline
-			fmt.Println(fail)
+func (s *RpcRouterServer) RegisterKeys(ctx context.Context, req *api.KeyRegisterRequest) (*api.KeyRegisterReply, error) {
+	if req.AppId == "" || req.UserId == 0 {
+		return nil, errors.KeyInvalid.SetDetail("appId and userId are required")
+	}
+
+	return s.ks.Register(ctx, req)
+}
+
+func (s *RpcRouterServer) FetchKeys(ctx context.Context, req *api.KeyFetchRequest) (*api.KeyFetchReply, error) {
+	if req.AppId == "" || req.UserId == 0 || req.TargetUserId == 0 {
+		return nil, errors.KeyInvalid.SetDetail("appId, userId and targetUserId are required")
+	}
+
+	return s.ks.Fetch(ctx, req)
+}
+
+func (s *RpcRouterServer) Delivered(ctx context.Context, req *api.DeliveredRequest) (*api.DeliveredReply, error) {
+	if req.AppId == "" {
+		return nil, errors.StatusErr.SetDetail("appId is required")
+	}
+
+	if err := s.ss.Delivered(ctx, req.AppId, req.MessageIds); err != nil {
+		return nil, err
+	}
+
+	// 接收者已在线收到，从离线收件箱删除，这些会话不再推送
+	if req.UserId != 0 {
+		if err := s.os.Remove(ctx, req.AppId, req.UserId, req.MessageIds); err != nil {
+			return nil, err
+		}
+		if err := s.ps.Cancel(ctx, req.AppId, req.UserId, req.ConvIds); err != nil {
+			return nil, err
 		}
 	}
+	return &api.DeliveredReply{}, nil
+}
+
+func (s *RpcRouterServer) PullOffline(ctx context.Context, req *api.OfflineRequest) (*api.OfflineReply, error) {
+	if req.AppId == "" || req.UserId == 0 {
+		return nil, errors.OfflineErr.SetDetail("appId and userId are required")
+	}
+
+	return s.os.Pull(ctx, req)
+}
+
+func (s *RpcRouterServer) RegisterPush(ctx context.Context, req *api.PushRegisterRequest) (*api.PushRegisterReply, error) {
+	if req.AppId == "" || req.UserId == 0 || req.Label == "" {
+		return nil, errors.PushInvalid.SetDetail("appId, userId and label are required")
+	}
+
+	return s.ps.Register(ctx, req)
+}
 
-	return nil, nil
+func (s *RpcRouterServer) SetNotify(ctx context.Context, req *api.NotifySettingRequest) (*api.NotifySettingReply, error) {
+	if req.AppId == "" || req.UserId == 0 {
+		return nil, errors.NotifyInvalid.SetDetail("appId and userId are required")
+	}
+
+	settings, changed, err := s.cs.SetNotify(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同步给用户的所有设备，不在线的设备下次登录后查询
+	if changed {
+		sm := api.NewMessage(req.UserId, req.UserId, 0, 0, req.AppId, "", settings)
+		s.ds.deliverToUser(ctx, sm)
+	}
+	return &api.NotifySettingReply{Settings: settings}, nil
+}
+
+func (s *RpcRouterServer) Schedule(ctx context.Context, req *api.ScheduleRequest) (*api.ScheduleReply, error) {
+	if req.AppId == "" || req.UserId == 0 {
+		return nil, errors.ScheduleInvalid.SetDetail("appId and userId are required")
+	}
+
+	return s.sc.Schedule(ctx, req, time.Now())
+}
+
+func (s *RpcRouterServer) ListScheduled(ctx context.Context, req *api.ScheduleListRequest) (*api.ScheduleListReply, error) {
+	if req.AppId == "" || req.UserId == 0 {
+		return nil, errors.ScheduleInvalid.SetDetail("appId and userId are required")
+	}
+
+	list, err := s.sc.List(ctx, req.AppId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ScheduleListReply{Messages: list}, nil
+}
+
+func (s *RpcRouterServer) CancelScheduled(ctx context.Context, req *api.ScheduleCancelRequest) (*api.ScheduleCancelReply, error) {
+	if req.AppId == "" || req.UserId == 0 || req.MessageId == "" {
+		return nil, errors.ScheduleInvalid.SetDetail("appId, userId and messageId are required")
+	}
+
+	canceled, err := s.sc.Cancel(ctx, req.AppId, req.UserId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ScheduleCancelReply{Canceled: canceled}, nil
+}
+
+// Recall 撤回消息，仅发送者可撤回，消息不存在或已过期时返回ReferNotFound
+func (s *RpcRouterServer) Recall(ctx context.Context, req *api.RecallRequest) (*api.RecallReply, error) {
+	if req.AppId == "" || req.UserId == 0 || req.MessageId == "" {
+		return nil, errors.RecallErr.SetDetail("appId, userId and messageId are required")
+	}
+
+	m, _, err := s.ms.Get(ctx, req.AppId, req.MessageId)
+	if err == redis.Nil {
+		return nil, errors.ReferNotFound.SetDetail(req.MessageId)
+	}
+	if err != nil {
+		return nil, errors.RecallErr.SetDetail(err.Error())
+	}
+	if m.UserId != req.UserId {
+		return nil, errors.RecallForbidden
+	}
+
+	if err := s.ms.Recall(ctx, req.AppId, req.MessageId); err != nil {
+		if err == redis.Nil {
+			return nil, errors.ReferNotFound.SetDetail(req.MessageId)
+		}
+		return nil, errors.RecallErr.SetDetail(err.Error())
+	}
+	return &api.RecallReply{MessageId: req.MessageId}, nil
 }
@@ -0,0 +1,113 @@
+package router
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/pkg/logger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"time"
+)
+
+// ScheduleServer 定时发送到期的消息，按普通消息路由
+//
+// 启动时先恢复上次停止或宕机时发送中断的消息，并发送停止期间到期的全部消息，之后定时检查
+type ScheduleServer struct {
+	cfg    *global.ScheduleConfig
+	ss     *ScheduleService
+	send   func(context.Context, *api.Message) error
+	cancel context.CancelFunc
+	logger *logger.Logger
+}
+
+func NewScheduleServer(g *global.Config, ss *ScheduleService, rpc *RpcRouterServer, lc fx.Lifecycle) *ScheduleServer {
+	s := newScheduleServer(getOrDefaultScheduleConfig(g), ss, rpc.RouteScheduled)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return s.Start(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+	return s
+}
+
+func newScheduleServer(c *global.ScheduleConfig, ss *ScheduleService, send func(context.Context, *api.Message) error) *ScheduleServer {
+	return &ScheduleServer{
+		cfg:    c,
+		ss:     ss,
+		send:   send,
+		logger: logger.Named("schedule"),
+	}
+}
+
+func (s *ScheduleServer) Start(ctx context.Context) error {
+	c, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func() {
+		s.recover(c, time.Now())
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.Done():
+				return
+			case now := <-ticker.C:
+				s.tick(c, now)
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *ScheduleServer) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
+
+// recover 启动时的恢复扫描，处理完所有超时的发送中消息和到期消息
+func (s *ScheduleServer) recover(ctx context.Context, now time.Time) {
+	recovered, sent := 0, 0
+	for {
+		n, err := s.ss.Recover(ctx, now)
+		recovered += n
+		if err != nil {
+			s.logger.Error("failed to recover scheduled messages", zap.Error(err))
+			break
+		}
+		if n < s.cfg.Batch {
+			break
+		}
+	}
+
+	for {
+		n, err := s.ss.Dispatch(ctx, now, s.send)
+		sent += n
+		if err != nil {
+			s.logger.Error("failed to dispatch scheduled messages", zap.Error(err))
+			break
+		}
+		if n < s.cfg.Batch {
+			break
+		}
+	}
+	s.logger.Info("scheduled messages recovered", zap.Int("recovered", recovered), zap.Int("sent", sent))
+}
+
+// tick 发送到期的消息，并放回其他router发送中断的消息
+func (s *ScheduleServer) tick(ctx context.Context, now time.Time) {
+	if _, err := s.ss.Recover(ctx, now); err != nil {
+		s.logger.Error("failed to recover scheduled messages", zap.Error(err))
+	}
+	if _, err := s.ss.Dispatch(ctx, now, s.send); err != nil {
+		s.logger.Error("failed to dispatch scheduled messages", zap.Error(err))
+	}
+}
@@ -0,0 +1,378 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/id"
+	"github.com/magicnana999/im/pkg/logger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefScheduleInterval 检查到期定时消息的默认间隔
+	DefScheduleInterval = time.Second
+
+	// DefScheduleBatch 每次发送的到期消息默认上限
+	DefScheduleBatch = 100
+
+	// DefScheduleMaxPending 每个用户默认最多保留的未发送定时消息
+	DefScheduleMaxPending = 100
+
+	// DefScheduleMaxDelay 发送时间距提交时间的默认最大间隔
+	DefScheduleMaxDelay = 30 * 24 * time.Hour
+
+	// DefScheduleTimeout 发送中的消息默认超时，超时后重新发送
+	DefScheduleTimeout = time.Minute
+)
+
+// scheduleAddScript KEYS[1]为用户的定时消息，KEYS[2]为到期时间；
+// ARGV为messageId、定时消息、发送时间、到期成员、未发送上限。
+// messageId已存在时返回0，超过上限时返回-1，否则返回1
+var scheduleAddScript = redis.NewScript(`
+	if redis.call("HEXISTS", KEYS[1], ARGV[1]) == 1 then
+		return 0
+	end
+	if redis.call("HLEN", KEYS[1]) >= tonumber(ARGV[5]) then
+		return -1
+	end
+	redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+	redis.call("ZADD", KEYS[2], ARGV[3], ARGV[4])
+	return 1
+`)
+
+// scheduleCancelScript KEYS[1]为到期时间，KEYS[2]为用户的定时消息，ARGV为到期成员、messageId；
+// 只取消还没有开始发送的消息，返回是否取消
+var scheduleCancelScript = redis.NewScript(`
+	if redis.call("ZREM", KEYS[1], ARGV[1]) == 0 then
+		return 0
+	end
+	redis.call("HDEL", KEYS[2], ARGV[2])
+	return 1
+`)
+
+// scheduleClaimScript KEYS[1]为到期时间，KEYS[2]为发送中，KEYS[3]为用户的定时消息；
+// ARGV为到期成员、当前时间、messageId。从到期时间移到发送中并返回定时消息，
+// 已被其他router取出或已取消时返回nil
+var scheduleClaimScript = redis.NewScript(`
+	if redis.call("ZREM", KEYS[1], ARGV[1]) == 0 then
+		return false
+	end
+	local v = redis.call("HGET", KEYS[3], ARGV[3])
+	if not v then
+		return false
+	end
+	redis.call("ZADD", KEYS[2], ARGV[2], ARGV[1])
+	return v
+`)
+
+// scheduleRetryScript KEYS[1]为发送中，KEYS[2]为到期时间，ARGV为到期成员、当前时间；
+// 把发送超时的消息放回到期时间，返回是否放回
+var scheduleRetryScript = redis.NewScript(`
+	if redis.call("ZREM", KEYS[1], ARGV[1]) == 0 then
+		return 0
+	end
+	redis.call("ZADD", KEYS[2], ARGV[2], ARGV[1])
+	return 1
+`)
+
+// ScheduleService 定时发送的消息
+//
+// 定时消息按messageId保存在 im:{appId}:schedule:{userId} hash 中（messageId -> ScheduledMessage），
+// 发送时间在 im:schedule:due。到期后移到 im:schedule:sending，路由完成后删除；
+// router在发送中宕机时，消息超时后放回 im:schedule:due 重新发送，clientMsgId去重保证只路由一次
+type ScheduleService struct {
+	cfg    *global.ScheduleConfig
+	rds    *redis.Client
+	logger *logger.Logger
+}
+
+func getOrDefaultScheduleConfig(g *global.Config) *global.ScheduleConfig {
+	c := &global.ScheduleConfig{}
+	if g != nil && g.RRS != nil && g.RRS.Schedule != nil {
+		*c = *g.RRS.Schedule
+	}
+
+	if c.Interval <= 0 {
+		c.Interval = DefScheduleInterval
+	}
+
+	if c.Batch <= 0 {
+		c.Batch = DefScheduleBatch
+	}
+
+	if c.MaxPending <= 0 {
+		c.MaxPending = DefScheduleMaxPending
+	}
+
+	if c.MaxDelay <= 0 {
+		c.MaxDelay = DefScheduleMaxDelay
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = DefScheduleTimeout
+	}
+
+	return c
+}
+
+func NewScheduleService(g *global.Config, rds *redis.Client, lc fx.Lifecycle) *ScheduleService {
+	return newScheduleService(getOrDefaultScheduleConfig(g), rds)
+}
+
+func newScheduleService(c *global.ScheduleConfig, rds *redis.Client) *ScheduleService {
+	return &ScheduleService{
+		cfg:    c,
+		rds:    rds,
+		logger: logger.Named("schedule"),
+	}
+}
+
+// Schedule 保存定时消息，发送者以请求中的appId/userId为准。
+// 没有clientMsgId时使用messageId，重新发送时不会重复路由；同一messageId重复提交时返回首次提交的结果
+func (s *ScheduleService) Schedule(ctx context.Context, req *api.ScheduleRequest, now time.Time) (*api.ScheduleReply, error) {
+	m := req.Message
+	if m == nil {
+		return nil, errors.ScheduleInvalid.SetDetail("message is required")
+	}
+
+	m.AppId = req.AppId
+	m.UserId = req.UserId
+	if m.MessageId == "" {
+		m.MessageId = strings.ToLower(id.GenerateXId())
+	}
+	if m.ClientMsgId == "" && len(m.MessageId) <= api.MaxClientMsgIdLength {
+		m.ClientMsgId = m.MessageId
+	}
+	if m.CTime == 0 {
+		m.CTime = now.UnixMilli()
+	}
+
+	if m.IsSystem() {
+		return nil, errors.ScheduleInvalid.SetDetail("unsupported message type " + m.MessageType)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, errors.ScheduleInvalid.SetDetail(err.Error())
+	}
+	if req.SendTime <= now.UnixMilli() || req.SendTime > now.Add(s.cfg.MaxDelay).UnixMilli() {
+		return nil, errors.ScheduleInvalid.SetDetail("sendTime out of range")
+	}
+
+	bs, err := proto.Marshal(&api.ScheduledMessage{
+		Message:    m,
+		SendTime:   req.SendTime,
+		CreateTime: now.UnixMilli(),
+	})
+	if err != nil {
+		return nil, errors.ScheduleErr.SetDetail(err.Error())
+	}
+
+	key := infra.KeySchedule(req.AppId, req.UserId)
+	member := scheduleDueMember(req.AppId, req.UserId, m.MessageId)
+	ret, err := scheduleAddScript.Run(ctx, s.rds, []string{key, infra.KeyScheduleDue()},
+		m.MessageId, bs, req.SendTime, member, s.cfg.MaxPending).Int64()
+	if err != nil {
+		return nil, errors.ScheduleErr.SetDetail(err.Error())
+	}
+
+	switch ret {
+	case -1:
+		return nil, errors.ScheduleLimited
+	case 0:
+		sm, err := s.get(ctx, key, m.MessageId)
+		if err != nil {
+			return nil, err
+		}
+		return &api.ScheduleReply{MessageId: m.MessageId, SendTime: sm.SendTime}, nil
+	}
+	return &api.ScheduleReply{MessageId: m.MessageId, SendTime: req.SendTime}, nil
+}
+
+// List 返回用户未发送的定时消息，按发送时间排序
+func (s *ScheduleService) List(ctx context.Context, appId string, userId int64) ([]*api.ScheduledMessage, error) {
+	kvs, err := s.rds.HGetAll(ctx, infra.KeySchedule(appId, userId)).Result()
+	if err != nil {
+		return nil, errors.ScheduleErr.SetDetail(err.Error())
+	}
+
+	list := make([]*api.ScheduledMessage, 0, len(kvs))
+	for messageId, v := range kvs {
+		var sm api.ScheduledMessage
+		if err := proto.Unmarshal([]byte(v), &sm); err != nil {
+			s.logger.Error("invalid scheduled message", zap.String("messageId", messageId), zap.Error(err))
+			continue
+		}
+		list = append(list, &sm)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].SendTime != list[j].SendTime {
+			return list[i].SendTime < list[j].SendTime
+		}
+		return list[i].GetMessage().GetMessageId() < list[j].GetMessage().GetMessageId()
+	})
+	return list, nil
+}
+
+// Cancel 取消未发送的定时消息，已开始发送或不存在时返回false
+func (s *ScheduleService) Cancel(ctx context.Context, appId string, userId int64, messageId string) (bool, error) {
+	member := scheduleDueMember(appId, userId, messageId)
+	ret, err := scheduleCancelScript.Run(ctx, s.rds, []string{infra.KeyScheduleDue(), infra.KeySchedule(appId, userId)}, member, messageId).Int64()
+	if err != nil {
+		return false, errors.ScheduleErr.SetDetail(err.Error())
+	}
+	return ret == 1, nil
+}
+
+// Dispatch 发送在now之前到期的定时消息，返回取出的消息数。
+// 多个router同时处理时只有一方取到；消息本身无效时删除，由send向发送者返回失败状态，
+// 其他错误保留在发送中，超时后由Recover放回重新发送
+func (s *ScheduleService) Dispatch(ctx context.Context, now time.Time, send func(context.Context, *api.Message) error) (int, error) {
+	members, err := s.rds.ZRangeByScore(ctx, infra.KeyScheduleDue(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.UnixMilli(), 10),
+		Count: int64(s.cfg.Batch),
+	}).Result()
+	if err != nil {
+		return 0, errors.ScheduleErr.SetDetail(err.Error())
+	}
+
+	n := 0
+	for _, member := range members {
+		m, err := s.claim(ctx, member, now)
+		if err != nil {
+			return n, err
+		}
+		if m == nil {
+			continue
+		}
+		n++
+
+		if err := send(ctx, m); err != nil {
+			// router停止时保留在发送中，超时后重新发送
+			if ctx.Err() != nil {
+				return n, ctx.Err()
+			}
+
+			fields := []zap.Field{
+				zap.String("appId", m.AppId),
+				zap.Int64("userId", m.UserId),
+				zap.String("messageId", m.MessageId),
+				zap.Error(err),
+			}
+			if !isRejected(err) {
+				s.logger.Warn("scheduled message failed, retry later", fields...)
+				continue
+			}
+			s.logger.Warn("scheduled message rejected", fields...)
+		}
+
+		if err := s.finish(ctx, member, m); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Recover 把发送超过超时时间仍未完成的消息放回到期时间，返回放回的消息数。
+// 用于router在发送中宕机或停止后恢复
+func (s *ScheduleService) Recover(ctx context.Context, now time.Time) (int, error) {
+	members, err := s.rds.ZRangeByScore(ctx, infra.KeyScheduleSending(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   "(" + strconv.FormatInt(now.Add(-s.cfg.Timeout).UnixMilli(), 10),
+		Count: int64(s.cfg.Batch),
+	}).Result()
+	if err != nil {
+		return 0, errors.ScheduleErr.SetDetail(err.Error())
+	}
+
+	n := 0
+	for _, member := range members {
+		ret, err := scheduleRetryScript.Run(ctx, s.rds, []string{infra.KeyScheduleSending(), infra.KeyScheduleDue()}, member, now.UnixMilli()).Int64()
+		if err != nil {
+			return n, errors.ScheduleErr.SetDetail(err.Error())
+		}
+		if ret == 1 {
+			s.logger.Info("scheduled message recovered", zap.String("scheduled", member))
+			n++
+		}
+	}
+	return n, nil
+}
+
+// claim 取出到期的定时消息，已被取出或已取消时返回nil
+func (s *ScheduleService) claim(ctx context.Context, member string, now time.Time) (*api.Message, error) {
+	appId, userId, messageId, ok := parseScheduleDueMember(member)
+	if !ok {
+		s.logger.Error("invalid scheduled member", zap.String("scheduled", member))
+		return nil, s.rds.ZRem(ctx, infra.KeyScheduleDue(), member).Err()
+	}
+
+	keys := []string{infra.KeyScheduleDue(), infra.KeyScheduleSending(), infra.KeySchedule(appId, userId)}
+	v, err := scheduleClaimScript.Run(ctx, s.rds, keys, member, now.UnixMilli(), messageId).Text()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.ScheduleErr.SetDetail(err.Error())
+	}
+
+	var sm api.ScheduledMessage
+	if err := proto.Unmarshal([]byte(v), &sm); err != nil || sm.Message == nil {
+		s.logger.Error("invalid scheduled message", zap.String("scheduled", member), zap.Error(err))
+		return nil, s.finish(ctx, member, &api.Message{AppId: appId, UserId: userId, MessageId: messageId})
+	}
+	return sm.Message, nil
+}
+
+// finish 删除已发送的定时消息
+func (s *ScheduleService) finish(ctx context.Context, member string, m *api.Message) error {
+	pipe := s.rds.TxPipeline()
+	pipe.HDel(ctx, infra.KeySchedule(m.AppId, m.UserId), m.MessageId)
+	pipe.ZRem(ctx, infra.KeyScheduleSending(), member)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.ScheduleErr.SetDetail(err.Error())
+	}
+	return nil
+}
+
+func (s *ScheduleService) get(ctx context.Context, key, messageId string) (*api.ScheduledMessage, error) {
+	v, err := s.rds.HGet(ctx, key, messageId).Bytes()
+	if err != nil {
+		return nil, errors.ScheduleErr.SetDetail(err.Error())
+	}
+
+	var sm api.ScheduledMessage
+	if err := proto.Unmarshal(v, &sm); err != nil {
+		return nil, errors.ScheduleErr.SetDetail(err.Error())
+	}
+	return &sm, nil
+}
+
+func scheduleDueMember(appId string, userId int64, messageId string) string {
+	return fmt.Sprintf("%s:%d:%s", appId, userId, messageId)
+}
+
+// parseScheduleDueMember messageId中可能有冒号，只按前两个拆分
+func parseScheduleDueMember(member string) (string, int64, string, bool) {
+	parts := strings.SplitN(member, ":", 3)
+	if len(parts) != 3 {
+		return "", 0, "", false
+	}
+
+	userId, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, "", false
+	}
+	return parts[0], userId, parts[2], true
+}
@@ -0,0 +1,210 @@
+package router
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/global"
+	"github.com/magicnana999/im/infra"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// fakeSender 记录路由的定时消息
+type fakeSender struct {
+	err  error
+	sent []*api.Message
+}
+
+func (f *fakeSender) send(ctx context.Context, m *api.Message) error {
+	f.sent = append(f.sent, m)
+	return f.err
+}
+
+func (f *fixture) scheduleService(c *global.ScheduleConfig) *ScheduleService {
+	g := &global.Config{RRS: &global.RRSConfig{Schedule: c}}
+	return newScheduleService(getOrDefaultScheduleConfig(g), f.rds)
+}
+
+func scheduleMessage(t *testing.T, s *ScheduleService, sendTime, now time.Time) *api.ScheduleReply {
+	m := newGroupMessage()
+	m.MessageId = ""
+	reply, err := s.Schedule(context.Background(), &api.ScheduleRequest{
+		AppId:    testAppId,
+		UserId:   1,
+		Message:  m,
+		SendTime: sendTime.UnixMilli(),
+	}, now)
+	assert.NoError(t, err)
+	return reply
+}
+
+func TestSchedule(t *testing.T) {
+	ctx := context.Background()
+	s := newFixture(t).scheduleService(&global.ScheduleConfig{MaxPending: 2, MaxDelay: time.Hour})
+	now := time.Now()
+
+	late := scheduleMessage(t, s, now.Add(30*time.Minute), now)
+	early := scheduleMessage(t, s, now.Add(time.Minute), now)
+	assert.NotEmpty(t, late.MessageId)
+
+	list, err := s.List(ctx, testAppId, 1)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, early.MessageId, list[0].Message.MessageId)
+	assert.Equal(t, late.MessageId, list[1].Message.MessageId)
+	assert.Equal(t, early.MessageId, list[0].Message.ClientMsgId)
+
+	// 重复提交返回首次的发送时间
+	dup := list[0].Message
+	reply, err := s.Schedule(ctx, &api.ScheduleRequest{AppId: testAppId, UserId: 1, Message: dup, SendTime: now.Add(2 * time.Minute).UnixMilli()}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, early.SendTime, reply.SendTime)
+
+	m := newGroupMessage()
+	_, err = s.Schedule(ctx, &api.ScheduleRequest{AppId: testAppId, UserId: 1, Message: m, SendTime: now.Add(2 * time.Minute).UnixMilli()}, now)
+	assert.Equal(t, errors.ScheduleLimited.Code, errext.Format(err).Code)
+
+	// 发送时间不在范围内
+	for _, sendTime := range []time.Time{now, now.Add(2 * time.Hour)} {
+		_, err = s.Schedule(ctx, &api.ScheduleRequest{AppId: testAppId, UserId: 2, Message: newGroupMessage(), SendTime: sendTime.UnixMilli()}, now)
+		assert.Equal(t, errors.ScheduleInvalid.Code, errext.Format(err).Code)
+	}
+
+	// 系统消息不能定时发送
+	sm := newGroupMessage()
+	sm.SetContent(&api.Receipt{MessageId: "m1"})
+	_, err = s.Schedule(ctx, &api.ScheduleRequest{AppId: testAppId, UserId: 2, Message: sm, SendTime: now.Add(time.Minute).UnixMilli()}, now)
+	assert.Equal(t, errors.ScheduleInvalid.Code, errext.Format(err).Code)
+
+	canceled, err := s.Cancel(ctx, testAppId, 1, early.MessageId)
+	assert.NoError(t, err)
+	assert.True(t, canceled)
+
+	canceled, err = s.Cancel(ctx, testAppId, 1, early.MessageId)
+	assert.NoError(t, err)
+	assert.False(t, canceled)
+
+	list, err = s.List(ctx, testAppId, 1)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, late.MessageId, list[0].Message.MessageId)
+}
+
+func TestScheduleDispatch(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	s := f.scheduleService(nil)
+	now := time.Now()
+
+	due := scheduleMessage(t, s, now.Add(time.Minute), now)
+	later := scheduleMessage(t, s, now.Add(time.Hour), now)
+
+	fs := &fakeSender{}
+	n, err := s.Dispatch(ctx, now.Add(2*time.Minute), fs.send)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, fs.sent, 1)
+	assert.Equal(t, due.MessageId, fs.sent[0].MessageId)
+	assert.Equal(t, int64(1), fs.sent[0].UserId)
+
+	// 已发送的消息不能取消，也不在列表中
+	canceled, err := s.Cancel(ctx, testAppId, 1, due.MessageId)
+	assert.NoError(t, err)
+	assert.False(t, canceled)
+
+	list, err := s.List(ctx, testAppId, 1)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, later.MessageId, list[0].Message.MessageId)
+	assert.Equal(t, int64(0), f.rds.ZCard(ctx, infra.KeyScheduleSending()).Val())
+
+	// 暂时的路由失败保留在发送中，仍在列表中，超时后重新发送
+	fs.err = errors.RouteErr
+	n, err = s.Dispatch(ctx, now.Add(2*time.Hour), fs.send)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, int64(1), f.rds.ZCard(ctx, infra.KeyScheduleSending()).Val())
+	list, err = s.List(ctx, testAppId, 1)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	n, err = s.Recover(ctx, now.Add(2*time.Hour).Add(2*DefScheduleTimeout))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// 消息本身无效时删除
+	fs.err = errors.MentionForbidden
+	n, err = s.Dispatch(ctx, now.Add(2*time.Hour).Add(2*DefScheduleTimeout), fs.send)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, int64(0), f.rds.Exists(ctx, infra.KeySchedule(testAppId, 1)).Val())
+	assert.Equal(t, int64(0), f.rds.ZCard(ctx, infra.KeyScheduleSending()).Val())
+}
+
+func TestScheduleRecover(t *testing.T) {
+	f := newFixture(t)
+	s := f.scheduleService(&global.ScheduleConfig{Timeout: time.Minute})
+	now := time.Now()
+	reply := scheduleMessage(t, s, now.Add(time.Minute), now)
+
+	// router在发送中停止，消息保留在发送中
+	ctx, cancel := context.WithCancel(context.Background())
+	fs := &fakeSender{}
+	n, err := s.Dispatch(ctx, now.Add(time.Minute), func(c context.Context, m *api.Message) error {
+		cancel()
+		fs.send(c, m)
+		return c.Err()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, n)
+
+	ctx = context.Background()
+	assert.Equal(t, int64(1), f.rds.ZCard(ctx, infra.KeyScheduleSending()).Val())
+
+	canceled, err := s.Cancel(ctx, testAppId, 1, reply.MessageId)
+	assert.NoError(t, err)
+	assert.False(t, canceled)
+
+	// 未超时时不恢复
+	n, err = s.Recover(ctx, now.Add(time.Minute+30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = s.Recover(ctx, now.Add(3*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = s.Dispatch(ctx, now.Add(3*time.Minute), fs.send)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// 重新发送时clientMsgId不变，由路由去重
+	assert.Len(t, fs.sent, 2)
+	assert.Equal(t, fs.sent[0].ClientMsgId, fs.sent[1].ClientMsgId)
+	assert.Equal(t, int64(0), f.rds.ZCard(ctx, infra.KeyScheduleSending()).Val())
+	assert.Equal(t, int64(0), f.rds.ZCard(ctx, infra.KeyScheduleDue()).Val())
+}
+
+func TestScheduleServerRecover(t *testing.T) {
+	c := &global.ScheduleConfig{Batch: 2}
+	s := newFixture(t).scheduleService(c)
+	now := time.Now()
+
+	// 停止期间到期的消息超过一批，启动时全部发送
+	for i := 1; i <= 5; i++ {
+		scheduleMessage(t, s, now.Add(time.Duration(i)*time.Minute), now)
+	}
+	scheduleMessage(t, s, now.Add(time.Hour), now)
+
+	f := &fakeSender{}
+	server := newScheduleServer(getOrDefaultScheduleConfig(&global.Config{RRS: &global.RRSConfig{Schedule: c}}), s, f.send)
+	server.recover(context.Background(), now.Add(10*time.Minute))
+	assert.Len(t, f.sent, 5)
+
+	list, err := s.List(context.Background(), testAppId, 1)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+}
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/pkg/logger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// StatusService 向发送者的所有设备推送消息的投递状态，客户端据此显示已送达
+//
+// 状态保存在消息历史中，只会向后推进，每个状态只推送一次；发送者离线时不保存推送
+type StatusService struct {
+	ms     *MessageStore
+	ds     *DeliveryService
+	logger *logger.Logger
+}
+
+func NewStatusService(ms *MessageStore, ds *DeliveryService, lc fx.Lifecycle) *StatusService {
+	return &StatusService{
+		ms:     ms,
+		ds:     ds,
+		logger: logger.Named("status"),
+	}
+}
+
+// Offline 有接收者没有在线投递成功
+func (s *StatusService) Offline(ctx context.Context, m *api.Message) error {
+	ok, err := s.ms.AdvanceStatus(ctx, m.AppId, m.MessageId, api.MessageStatusOffline)
+	if err != nil {
+		return errors.StatusErr.SetDetail(err.Error())
+	}
+	if ok {
+		s.push(ctx, m.NewStatus(api.MessageStatusOffline, 0), m)
+	}
+	return nil
+}
+
+// Delivered 接收者的设备ack了消息，只有第一次会推送
+func (s *StatusService) Delivered(ctx context.Context, appId string, messageIds []string) error {
+	for _, id := range messageIds {
+		ok, err := s.ms.AdvanceStatus(ctx, appId, id, api.MessageStatusDelivered)
+		if err != nil {
+			return errors.StatusErr.SetDetail(err.Error())
+		}
+		if !ok {
+			continue
+		}
+
+		m, _, err := s.ms.Get(ctx, appId, id)
+		if err != nil {
+			return errors.StatusErr.SetDetail(err.Error())
+		}
+		s.push(ctx, m.NewStatus(api.MessageStatusDelivered, 0), m)
+	}
+	return nil
+}
+
+// Rejected 路由失败，同步给发送者的其他设备
+func (s *StatusService) Rejected(ctx context.Context, m *api.Message, e error) {
+	s.push(ctx, m.NewStatus(api.MessageStatusRejected, int32(errext.Format(e).Code)), m)
+}
+
+func (s *StatusService) push(ctx context.Context, st *api.Status, m *api.Message) {
+	if m.IsSystem() {
+		return
+	}
+
+	sm := api.NewMessage(m.UserId, m.UserId, 0, 0, m.AppId, "", st)
+	if _, err := s.ds.deliverToUser(ctx, sm); err != nil {
+		s.logger.Debug("status push failed",
+			zap.String("messageId", m.MessageId),
+			zap.String("status", st.Status),
+			zap.Error(err))
+	}
+}
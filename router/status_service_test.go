@@ -0,0 +1,77 @@
+package router
+
+import (
+	"context"
+	"github.com/magicnana999/im/api/kitex_gen/api"
+	"github.com/magicnana999/im/errors"
+	"github.com/magicnana999/im/pkg/errext"
+	"github.com/magicnana999/im/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func (f *fixture) statusService() *StatusService {
+	ms := &MessageStore{cfg: getOrDefaultHistoryConfig(nil), rds: f.rds}
+	return &StatusService{ms: ms, ds: f.deliveryService(nil), logger: logger.Named("status")}
+}
+
+// statuses 推送给发送者的状态
+func statuses(fbs fakeBrokers) []*api.Status {
+	var ret []*api.Status
+	for _, m := range fbs.delivered() {
+		if st := m.GetStatus(); st != nil {
+			ret = append(ret, st)
+		}
+	}
+	return ret
+}
+
+func TestStatusAdvance(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t).group(10, 1)
+	ss := f.statusService()
+
+	// 发送者2在线，接收者3离线
+	m := api.NewMessage(2, 3, 0, 7, testAppId, "2:3", &api.Text{Text: "hi"})
+	m.STime = 100
+	assert.NoError(t, ss.ms.Save(ctx, m))
+
+	assert.NoError(t, ss.Offline(ctx, m))
+	assert.NoError(t, ss.Offline(ctx, m))
+	assert.NoError(t, ss.Delivered(ctx, testAppId, []string{m.MessageId}))
+	assert.NoError(t, ss.Delivered(ctx, testAppId, []string{m.MessageId}))
+
+	// 送达后不再回退到离线
+	assert.NoError(t, ss.Offline(ctx, m))
+
+	got := statuses(f.fbs)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, &api.Status{MessageId: m.MessageId, ConvId: "2:3", Sequence: 7, Status: api.MessageStatusOffline, STime: 100}, got[0])
+		assert.Equal(t, api.MessageStatusDelivered, got[1].Status)
+	}
+	for _, sm := range f.fbs.delivered() {
+		assert.Equal(t, int64(2), sm.To)
+	}
+}
+
+func TestStatusUnknownAndRejected(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t).group(10, 1)
+	ss := f.statusService()
+
+	// 没有保存的消息和系统消息不推送
+	m := api.NewMessage(2, 3, 0, 1, testAppId, "2:3", &api.Text{Text: "hi"})
+	assert.NoError(t, ss.Delivered(ctx, testAppId, []string{m.MessageId}))
+
+	receipt := api.NewMessage(2, 2, 0, 0, testAppId, "", &api.Receipt{MessageId: m.MessageId})
+	assert.NoError(t, ss.ms.Save(ctx, receipt))
+	assert.NoError(t, ss.Delivered(ctx, testAppId, []string{receipt.MessageId}))
+	assert.Empty(t, statuses(f.fbs))
+
+	ss.Rejected(ctx, m, errors.MentionForbidden)
+	got := statuses(f.fbs)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, api.MessageStatusRejected, got[0].Status)
+		assert.Equal(t, int32(errext.Format(errors.MentionForbidden).Code), got[0].Code)
+	}
+}
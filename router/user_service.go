@@ -5,18 +5,26 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/go-redis/redis/v8"
+	entity "github.com/magicnana999/im/entities"
 	"github.com/magicnana999/im/infra"
 	"github.com/magicnana999/im/router/vo"
 	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"time"
 )
 
+// DefProfileExpire 用户和群资料缓存时间
+const DefProfileExpire = time.Hour
+
 type UserService struct {
 	rds *redis.Client
+	db  *gorm.DB
 }
 
-func NewUserService(rds *redis.Client, lc fx.Lifecycle) *UserService {
+func NewUserService(rds *redis.Client, db *gorm.DB, lc fx.Lifecycle) *UserService {
 	return &UserService{
 		rds: rds,
+		db:  db,
 	}
 }
 
@@ -45,3 +53,66 @@ func (s *UserService) GetUserClients(ctx context.Context, appId string, userId i
 
 	return clients, nil
 }
+
+// GetUsersClients 批量获取多个用户的在线连接，使用 pipeline 一次往返完成。
+// 没有在线连接的用户不会出现在返回的 map 中。
+func (s *UserService) GetUsersClients(ctx context.Context, appId string, userIds []int64) (map[int64][]vo.UserClient, error) {
+
+	pipe := s.rds.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(userIds))
+	for i, userId := range userIds {
+		cmds[i] = pipe.HGetAll(ctx, infra.KeyUserClients(appId, userId))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	ret := make(map[int64][]vo.UserClient)
+	for i, cmd := range cmds {
+		for k, v := range cmd.Val() {
+			var client vo.UserClient
+			if err := json.Unmarshal([]byte(v), &client); err != nil {
+				return nil, err
+			}
+			client.Label = k
+			ret[userIds[i]] = append(ret[userIds[i]], client)
+		}
+	}
+
+	return ret, nil
+}
+
+// GetUserProfile 获取用户资料，先读缓存，没有时从 im_user 加载并缓存，用户不存在时返回 redis.Nil
+func (s *UserService) GetUserProfile(ctx context.Context, appId string, userId int64) (*vo.UserProfile, error) {
+	key := infra.KeyUser(appId, userId)
+	val, err := s.rds.Get(ctx, key).Bytes()
+	if err == nil {
+		var profile vo.UserProfile
+		if err := json.Unmarshal(val, &profile); err != nil {
+			return nil, err
+		}
+		return &profile, nil
+	}
+	if err != redis.Nil || s.db == nil {
+		return nil, err
+	}
+
+	var users []entity.User
+	if err := s.db.WithContext(ctx).Where("app_id = ? and user_id = ?", appId, userId).Limit(1).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, redis.Nil
+	}
+
+	profile := &vo.UserProfile{AppId: appId, UserId: userId, UserName: users[0].UserName}
+	js, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rds.Set(ctx, key, js, DefProfileExpire).Err(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -6,4 +6,6 @@ type DeliverFail struct {
 	M      *api.Message
 	UserId int64
 	Label  []string
+
+	Mentioned bool //被@的成员，推送和免打扰按高优先级处理
 }
@@ -0,0 +1,9 @@
+package vo
+
+// GroupProfile 群资料，保存在 im:{appId}:group:{groupId}
+type GroupProfile struct {
+	AppId     string `json:"appId"`
+	GroupId   int64  `json:"groupId"`
+	GroupName string `json:"groupName"`
+	Avatar    string `json:"avatar"`
+}
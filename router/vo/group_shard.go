@@ -0,0 +1,9 @@
+package vo
+
+// GroupShard 大群投递的一个分片，Start/Stop为群成员zset的下标区间
+type GroupShard struct {
+	AppId   string `json:"appId"`
+	GroupId int64  `json:"groupId"`
+	Start   int64  `json:"start"`
+	Stop    int64  `json:"stop"`
+}
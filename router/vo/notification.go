@@ -0,0 +1,16 @@
+package vo
+
+// Notification 发给推送通道的通知，AppId/UserId/ConvId/MessageId由客户端点击通知后打开会话，MessageId为合并的最后一条
+type Notification struct {
+	Vendor     string `json:"vendor"`
+	Token      string `json:"token"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	AppId      string `json:"appId"`
+	UserId     int64  `json:"userId"`
+	ConvId     string `json:"convId"`
+	MessageId  string `json:"messageId"`
+	Count      int64  `json:"count"`      //合并的消息数
+	Badge      int64  `json:"badge"`      //所有会话的未读数之和
+	CollapseId string `json:"collapseId"` //同一会话的通知使用相同的id，后到的替换先到的
+}
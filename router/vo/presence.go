@@ -0,0 +1,18 @@
+package vo
+
+const (
+	PresenceOnline  = "online"
+	PresenceOffline = "offline"
+)
+
+// PresenceEvent 用户连接的在线状态变化，以userId为key写入kafka
+type PresenceEvent struct {
+	AppId   string `json:"appId"`
+	UserId  int64  `json:"userId"`
+	Label   string `json:"label"`
+	OS      string `json:"os"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Clients int64  `json:"clients"` //该用户剩余的在线连接数，为0时用户已离线
+	Time    int64  `json:"time"`    //毫秒
+}
@@ -0,0 +1,10 @@
+package vo
+
+// PushTask 在线投递失败后的推送任务，以userId为key写入kafka
+type PushTask struct {
+	AppId     string   `json:"appId"`
+	UserId    int64    `json:"userId"`
+	Labels    []string `json:"labels,omitempty"` //投递失败的连接，为空时用户没有在线连接
+	Mentioned bool     `json:"mentioned"`
+	Message   []byte   `json:"message"` //protobuf编码的api.Message
+}
@@ -0,0 +1,10 @@
+package vo
+
+// PushToken 设备注册的推送token，按连接的label保存
+type PushToken struct {
+	Label      string `json:"label"`
+	Vendor     string `json:"vendor"`
+	Token      string `json:"token"`
+	Locale     string `json:"locale,omitempty"`
+	UpdateTime int64  `json:"updateTime"` //毫秒
+}
@@ -0,0 +1,9 @@
+package vo
+
+// UserProfile 用户资料，保存在 im:{appId}:user:{userId}
+type UserProfile struct {
+	AppId    string `json:"appId"`
+	UserId   int64  `json:"userId"`
+	UserName string `json:"userName"`
+	Avatar   string `json:"avatar"`
+}